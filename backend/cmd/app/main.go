@@ -19,17 +19,24 @@ import (
 
 func main() {
 	cfg, err := configs.NewConfig(".env")
+	checkError(err)
 	// initMigration()
 
 	db, err := database.InitDatabase(cfg.PostgresConfig)
 	err = timezone.InitTimezone()
 	checkError(err)
-	publicRoutes := builder.BuildPublicRoutes(cfg, db)
-	privateRoutes := builder.BuildPrivateRoutes(cfg, db)
+	roleService := builder.BuildRoleService(db)
+	notificationService := builder.BuildNotificationService(db)
+	publicRoutes := builder.BuildPublicRoutes(cfg, db, roleService, notificationService)
+	privateRoutes := builder.BuildPrivateRoutes(cfg, db, roleService, notificationService)
+
+	jobs := builder.BuildScheduler(cfg, db, roleService, notificationService)
+	jobs.Start()
 
-	srv := server.NewServer(cfg, publicRoutes, privateRoutes)
+	srv := server.NewServer(cfg, roleService, publicRoutes, privateRoutes)
 	runServer(srv, cfg.PORT)
 	waitForShutdown(srv)
+	jobs.Stop()
 }
 
 // hidePassword masks the password in the database URL for logging
@@ -3,6 +3,7 @@ package configs
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/joho/godotenv"
@@ -10,23 +11,176 @@ import (
 )
 
 type Config struct {
-	ENV            string         `env:"ENV" envDefault:"development" mapstructure:"ENV"`
-	PORT           string         `env:"PORT" envDefault:"8003" mapstructure:"PORT"`
-	PostgresConfig PostgresConfig `envPrefix:"POSTGRES_" mapstructure:"POSTGRES"`
-	JWT            JWTConfig      `envPrefix:"JWT_" mapstructure:"JWT"`
-	MigrationPath  string         `env:"MIGRATION_PATH" envDefault:"db/migrations" mapstructure:"MIGRATION_PATH"`
+	ENV            string            `env:"ENV" envDefault:"development" mapstructure:"ENV"`
+	PORT           string            `env:"PORT" envDefault:"8003" mapstructure:"PORT"`
+	PostgresConfig PostgresConfig    `envPrefix:"POSTGRES_" mapstructure:"POSTGRES"`
+	JWT            JWTConfig         `envPrefix:"JWT_" mapstructure:"JWT"`
+	MigrationPath  string            `env:"MIGRATION_PATH" envDefault:"db/migrations" mapstructure:"MIGRATION_PATH"`
+	AppURL         string            `env:"APP_URL" envDefault:"http://localhost:3000" mapstructure:"APP_URL"`
+	TrustedProxies []string          `env:"TRUSTED_PROXIES" envSeparator:"," mapstructure:"TRUSTED_PROXIES"`
+	Mail           MailConfig        `envPrefix:"MAIL_" mapstructure:"MAIL"`
+	Login          LoginConfig       `envPrefix:"LOGIN_" mapstructure:"LOGIN"`
+	MFA            MFAConfig         `envPrefix:"MFA_" mapstructure:"MFA"`
+	Maintenance    MaintenanceConfig `envPrefix:"MAINTENANCE_" mapstructure:"MAINTENANCE"`
+	StoragePath    string            `env:"STORAGE_PATH" envDefault:"storage/blobs" mapstructure:"STORAGE_PATH"`
+	Documents      DocumentsConfig   `envPrefix:"DOCUMENTS_" mapstructure:"DOCUMENTS"`
+	Alerts         AlertsConfig      `envPrefix:"ALERTS_" mapstructure:"ALERTS"`
+	Webhooks       WebhooksConfig    `envPrefix:"WEBHOOKS_" mapstructure:"WEBHOOKS"`
+	Devices        DevicesConfig     `envPrefix:"DEVICES_" mapstructure:"DEVICES"`
+	Commands       CommandsConfig    `envPrefix:"COMMANDS_" mapstructure:"COMMANDS"`
+	Firmware       FirmwareConfig    `envPrefix:"FIRMWARE_" mapstructure:"FIRMWARE"`
+	Tracks         TracksConfig      `envPrefix:"TRACKS_" mapstructure:"TRACKS"`
+	Imports        ImportsConfig     `envPrefix:"IMPORTS_" mapstructure:"IMPORTS"`
+	Reports        ReportsConfig     `envPrefix:"REPORTS_" mapstructure:"REPORTS"`
+	POIs           POIsConfig        `envPrefix:"POIS_" mapstructure:"POIS"`
+}
+
+// POIsConfig controls points of interest. A POI created without a radius gets DefaultRadius meters and
+// none may exceed MaxRadius, which also bounds how far around a position POIs are looked up.
+// A CSV import holds at most MaxImportRows rows.
+type POIsConfig struct {
+	DefaultRadius float64 `env:"DEFAULT_RADIUS" envDefault:"100" mapstructure:"DEFAULT_RADIUS"`
+	MaxRadius     float64 `env:"MAX_RADIUS" envDefault:"5000" mapstructure:"MAX_RADIUS"`
+	MaxImportRows int     `env:"MAX_IMPORT_ROWS" envDefault:"10000" mapstructure:"MAX_IMPORT_ROWS"`
+}
+
+// ReportsConfig controls scheduled reports. Due reports are looked up every DispatchInterval,
+// at most BatchSize at a time; a violations report lists at most MaxRows events.
+type ReportsConfig struct {
+	DispatchInterval time.Duration `env:"DISPATCH_INTERVAL" envDefault:"1m" mapstructure:"DISPATCH_INTERVAL"`
+	BatchSize        int           `env:"BATCH_SIZE" envDefault:"20" mapstructure:"BATCH_SIZE"`
+	MaxRows          int           `env:"MAX_ROWS" envDefault:"5000" mapstructure:"MAX_ROWS"`
+}
+
+// ImportsConfig controls location history imports. The worker polls for uploaded files every PollInterval
+// and inserts rows in batches of BatchSize. A running import not updated for StaleAfter is taken over,
+// which is safe because rows already imported are skipped as duplicates.
+type ImportsConfig struct {
+	MaxFileSize  int64         `env:"MAX_FILE_SIZE" envDefault:"104857600" mapstructure:"MAX_FILE_SIZE"`
+	PollInterval time.Duration `env:"POLL_INTERVAL" envDefault:"10s" mapstructure:"POLL_INTERVAL"`
+	BatchSize    int           `env:"BATCH_SIZE" envDefault:"500" mapstructure:"BATCH_SIZE"`
+	MaxRowErrors int           `env:"MAX_ROW_ERRORS" envDefault:"100" mapstructure:"MAX_ROW_ERRORS"`
+	StaleAfter   time.Duration `env:"STALE_AFTER" envDefault:"10m" mapstructure:"STALE_AFTER"`
+}
+
+// TracksConfig controls GPS track exports and playback. A gap longer than SegmentGap between two positions
+// starts a new track segment; MaxRange bounds the period of a single export. Playback resamples a track
+// every PlaybackStep unless the client asks otherwise, into at most PlaybackMaxFrames frames. A vehicle
+// at or below StopSpeed km/h for StopMinDuration is stopped, and a gap during which it moved less than
+// StopRadius meters counts as a stop too. A position reached from the vehicle's previous one faster than
+// MaxSpeed km/h is flagged as suspect when recorded.
+type TracksConfig struct {
+	SegmentGap        time.Duration `env:"SEGMENT_GAP" envDefault:"10m" mapstructure:"SEGMENT_GAP"`
+	MaxRange          time.Duration `env:"MAX_RANGE" envDefault:"744h" mapstructure:"MAX_RANGE"`
+	PlaybackStep      time.Duration `env:"PLAYBACK_STEP" envDefault:"10s" mapstructure:"PLAYBACK_STEP"`
+	PlaybackMaxFrames int           `env:"PLAYBACK_MAX_FRAMES" envDefault:"20000" mapstructure:"PLAYBACK_MAX_FRAMES"`
+	StopSpeed         float64       `env:"STOP_SPEED" envDefault:"3" mapstructure:"STOP_SPEED"`
+	StopMinDuration   time.Duration `env:"STOP_MIN_DURATION" envDefault:"2m" mapstructure:"STOP_MIN_DURATION"`
+	StopRadius        float64       `env:"STOP_RADIUS" envDefault:"50" mapstructure:"STOP_RADIUS"`
+	MaxSpeed          float64       `env:"MAX_SPEED" envDefault:"250" mapstructure:"MAX_SPEED"`
+}
+
+// FirmwareConfig controls firmware uploads and the signed links devices download them from.
+// PublicURL is the address of this API as reachable by devices.
+type FirmwareConfig struct {
+	PublicURL          string        `env:"PUBLIC_URL" envDefault:"http://localhost:8003" mapstructure:"PUBLIC_URL"`
+	LinkTTL            time.Duration `env:"LINK_TTL" envDefault:"15m" mapstructure:"LINK_TTL"`
+	MaxFileSize        int64         `env:"MAX_FILE_SIZE" envDefault:"8388608" mapstructure:"MAX_FILE_SIZE"`
+	MaxInstallAttempts int           `env:"MAX_INSTALL_ATTEMPTS" envDefault:"3" mapstructure:"MAX_INSTALL_ATTEMPTS"`
+}
+
+// CommandsConfig controls how long queued device commands stay open and how many a device fetches at once
+type CommandsConfig struct {
+	DefaultTTL     time.Duration `env:"DEFAULT_TTL" envDefault:"1h" mapstructure:"DEFAULT_TTL"`
+	MaxTTL         time.Duration `env:"MAX_TTL" envDefault:"24h" mapstructure:"MAX_TTL"`
+	FetchLimit     int           `env:"FETCH_LIMIT" envDefault:"10" mapstructure:"FETCH_LIMIT"`
+	ExpiryInterval time.Duration `env:"EXPIRY_INTERVAL" envDefault:"1m" mapstructure:"EXPIRY_INTERVAL"`
+}
+
+// DevicesConfig controls how tracker connectivity states are derived from their last contact
+type DevicesConfig struct {
+	IdleAfter    time.Duration `env:"IDLE_AFTER" envDefault:"5m" mapstructure:"IDLE_AFTER"`
+	OfflineAfter time.Duration `env:"OFFLINE_AFTER" envDefault:"10m" mapstructure:"OFFLINE_AFTER"`
+}
+
+// WebhooksConfig controls the outgoing webhook delivery queue.
+// A failed delivery is retried after BaseBackoff, doubling up to MaxBackoff, until MaxAttempts is reached.
+type WebhooksConfig struct {
+	DispatchInterval time.Duration `env:"DISPATCH_INTERVAL" envDefault:"5s" mapstructure:"DISPATCH_INTERVAL"`
+	BatchSize        int           `env:"BATCH_SIZE" envDefault:"50" mapstructure:"BATCH_SIZE"`
+	Timeout          time.Duration `env:"TIMEOUT" envDefault:"10s" mapstructure:"TIMEOUT"`
+	MaxAttempts      int           `env:"MAX_ATTEMPTS" envDefault:"8" mapstructure:"MAX_ATTEMPTS"`
+	BaseBackoff      time.Duration `env:"BASE_BACKOFF" envDefault:"30s" mapstructure:"BASE_BACKOFF"`
+	MaxBackoff       time.Duration `env:"MAX_BACKOFF" envDefault:"6h" mapstructure:"MAX_BACKOFF"`
+}
+
+// AlertsConfig controls how often scheduled alert conditions are evaluated and how notifications are delivered
+type AlertsConfig struct {
+	EvaluationInterval time.Duration `env:"EVALUATION_INTERVAL" envDefault:"1m" mapstructure:"EVALUATION_INTERVAL"`
+	WebhookTimeout     time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s" mapstructure:"WEBHOOK_TIMEOUT"`
+}
+
+// DocumentsConfig controls vehicle document uploads and expiry tracking
+type DocumentsConfig struct {
+	ExpiryWindowDays int           `env:"EXPIRY_WINDOW_DAYS" envDefault:"30" mapstructure:"EXPIRY_WINDOW_DAYS"`
+	CheckInterval    time.Duration `env:"CHECK_INTERVAL" envDefault:"24h" mapstructure:"CHECK_INTERVAL"`
+	MaxFileSize      int64         `env:"MAX_FILE_SIZE" envDefault:"10485760" mapstructure:"MAX_FILE_SIZE"`
+}
+
+// MaintenanceConfig controls when maintenance items are reported as upcoming and how often they are checked
+type MaintenanceConfig struct {
+	CheckInterval       time.Duration `env:"CHECK_INTERVAL" envDefault:"1h" mapstructure:"CHECK_INTERVAL"`
+	UpcomingKm          float64       `env:"UPCOMING_KM" envDefault:"500" mapstructure:"UPCOMING_KM"`
+	UpcomingEngineHours float64       `env:"UPCOMING_ENGINE_HOURS" envDefault:"10" mapstructure:"UPCOMING_ENGINE_HOURS"`
+	UpcomingDays        int           `env:"UPCOMING_DAYS" envDefault:"14" mapstructure:"UPCOMING_DAYS"`
+}
+
+// MFAConfig controls TOTP two-factor authentication. RequireForAdmin applies to every role that can
+// manage users or roles.
+type MFAConfig struct {
+	Issuer          string        `env:"ISSUER" envDefault:"Cartrack" mapstructure:"ISSUER"`
+	RequireForAdmin bool          `env:"REQUIRE_FOR_ADMIN" envDefault:"false" mapstructure:"REQUIRE_FOR_ADMIN"`
+	ChallengeTTL    time.Duration `env:"CHALLENGE_TTL" envDefault:"5m" mapstructure:"CHALLENGE_TTL"`
+}
+
+// LoginConfig controls brute-force protection on the login endpoint
+type LoginConfig struct {
+	MaxAttempts     int           `env:"MAX_ATTEMPTS" envDefault:"5" mapstructure:"MAX_ATTEMPTS"`
+	LockoutDuration time.Duration `env:"LOCKOUT_DURATION" envDefault:"15m" mapstructure:"LOCKOUT_DURATION"`
+	IPMaxAttempts   int           `env:"IP_MAX_ATTEMPTS" envDefault:"20" mapstructure:"IP_MAX_ATTEMPTS"`
+	IPWindow        time.Duration `env:"IP_WINDOW" envDefault:"15m" mapstructure:"IP_WINDOW"`
+	DelayAfter      int           `env:"DELAY_AFTER" envDefault:"2" mapstructure:"DELAY_AFTER"`
+	BaseDelay       time.Duration `env:"BASE_DELAY" envDefault:"2s" mapstructure:"BASE_DELAY"`
+	// AttemptRetention is how long login attempts are kept; it never drops below IPWindow
+	AttemptRetention time.Duration `env:"ATTEMPT_RETENTION" envDefault:"720h" mapstructure:"ATTEMPT_RETENTION"`
+	PurgeInterval    time.Duration `env:"PURGE_INTERVAL" envDefault:"24h" mapstructure:"PURGE_INTERVAL"`
 }
 
 type JWTConfig struct {
 	SecretKey string `env:"SECRET_KEY" envDefault:"secret" mapstructure:"SECRET_KEY"`
 }
 
+// MailConfig selects and configures the outgoing mail transport.
+// Driver "smtp" sends real mail, "log" appends messages to LogPath for local development.
+type MailConfig struct {
+	Driver       string `env:"DRIVER" envDefault:"log" mapstructure:"DRIVER"`
+	From         string `env:"FROM" envDefault:"Cartrack <no-reply@cartrack.local>" mapstructure:"FROM"`
+	LogPath      string `env:"LOG_PATH" envDefault:"storage/mail.log" mapstructure:"LOG_PATH"`
+	SMTPHost     string `env:"SMTP_HOST" envDefault:"localhost" mapstructure:"SMTP_HOST"`
+	SMTPPort     string `env:"SMTP_PORT" envDefault:"587" mapstructure:"SMTP_PORT"`
+	SMTPUsername string `env:"SMTP_USERNAME" mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD" mapstructure:"SMTP_PASSWORD"`
+}
+
+// PostgresConfig holds the database connection. With PostGIS set, spatial queries use the geography columns
+// when the database has them; otherwise coordinates are filtered in Go.
 type PostgresConfig struct {
 	Host     string `env:"HOST" envDefault:"localhost" mapstructure:"HOST"`
 	Port     string `env:"PORT" envDefault:"5432" mapstructure:"PORT"`
 	User     string `env:"USER" envDefault:"postgres" mapstructure:"USER"`
 	Password string `env:"PASSWORD" envDefault:"postgres" mapstructure:"PASSWORD"`
 	Database string `env:"DATABASE" envDefault:"postgres" mapstructure:"DATABASE"`
+	PostGIS  bool   `env:"POSTGIS" envDefault:"true" mapstructure:"POSTGIS"`
 }
 
 // GetDatabaseURL returns the PostgreSQL connection string
@@ -46,6 +200,9 @@ func NewConfig(envPath string) (*Config, error) {
 	if err != nil {
 		return nil, errors.New("failed to parse env")
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
@@ -64,6 +221,18 @@ func NewConfigYaml(path string) (*Config, error) {
 	if err != nil {
 		return nil, errors.New("failed to parse config " + err.Error())
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
+
+// validate rejects settings that must not reach production. The log mail driver only writes
+// messages to a file, so password reset and verification links would never be delivered.
+func (c *Config) validate() error {
+	if c.ENV == "production" && c.Mail.Driver != "smtp" {
+		return fmt.Errorf("MAIL_DRIVER must be smtp when ENV is production, got %q", c.Mail.Driver)
+	}
+	return nil
+}
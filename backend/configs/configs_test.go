@@ -0,0 +1,26 @@
+package configs
+
+import "testing"
+
+func TestValidateMailDriver(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		driver  string
+		wantErr bool
+	}{
+		{name: "log driver in development", env: "development", driver: "log", wantErr: false},
+		{name: "log driver in production", env: "production", driver: "log", wantErr: true},
+		{name: "empty driver in production", env: "production", driver: "", wantErr: true},
+		{name: "smtp driver in production", env: "production", driver: "smtp", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ENV: tt.env, Mail: MailConfig{Driver: tt.driver}}
+			if err := cfg.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+ALTER TABLE api_keys DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE vehicles DROP COLUMN IF EXISTS organization_id;
+DROP TRIGGER IF EXISTS set_updated_at_organization_members ON organization_members;
+DROP TRIGGER IF EXISTS set_updated_at_organizations ON organizations;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
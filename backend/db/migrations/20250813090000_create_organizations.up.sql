@@ -0,0 +1,55 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'viewer', 'driver')),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+
+CREATE TRIGGER set_updated_at_organizations
+BEFORE UPDATE ON organizations
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER set_updated_at_organization_members
+BEFORE UPDATE ON organization_members
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE vehicles ADD COLUMN IF NOT EXISTS organization_id INT REFERENCES organizations(id);
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS organization_id INT REFERENCES organizations(id);
+
+-- Give every existing user a personal organization and move their vehicles and API keys into it
+ALTER TABLE organizations ADD COLUMN seed_user_id INT;
+
+INSERT INTO organizations (name, seed_user_id)
+SELECT u.name || '''s Fleet', u.id FROM users u;
+
+INSERT INTO organization_members (organization_id, user_id, role)
+SELECT o.id, o.seed_user_id, 'owner' FROM organizations o WHERE o.seed_user_id IS NOT NULL;
+
+UPDATE vehicles v SET organization_id = o.id
+FROM organizations o WHERE o.seed_user_id = v.user_id;
+
+UPDATE api_keys k SET organization_id = o.id
+FROM organizations o WHERE o.seed_user_id = k.user_id;
+
+ALTER TABLE organizations DROP COLUMN seed_user_id;
+
+ALTER TABLE vehicles ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE api_keys ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX idx_vehicles_organization_id ON vehicles(organization_id);
+CREATE INDEX idx_api_keys_organization_id ON api_keys(organization_id);
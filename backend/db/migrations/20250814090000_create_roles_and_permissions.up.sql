@@ -0,0 +1,61 @@
+CREATE TABLE IF NOT EXISTS roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL,
+    description TEXT,
+    is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id INT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TRIGGER set_updated_at_roles
+BEFORE UPDATE ON roles
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Built-in roles
+INSERT INTO roles (name, description, is_system) VALUES
+    ('admin', 'Full system access', TRUE),
+    ('user', 'Fleet user managing their own organizations', TRUE);
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES
+    ('profile:manage'), ('organizations:read'), ('organizations:write'),
+    ('vehicles:read'), ('vehicles:write'), ('vehicles:read_all'),
+    ('logs:read'), ('logs:write'), ('api_keys:manage'),
+    ('reports:read'), ('reports:read_all'),
+    ('users:manage'), ('roles:manage')
+) AS p(permission)
+WHERE r.name = 'admin';
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES
+    ('profile:manage'), ('organizations:read'), ('organizations:write'),
+    ('vehicles:read'), ('vehicles:write'),
+    ('logs:read'), ('logs:write'), ('api_keys:manage'),
+    ('reports:read')
+) AS p(permission)
+WHERE r.name = 'user';
+
+-- Normalize legacy role spellings
+UPDATE users SET role = 'admin' WHERE LOWER(role) IN ('admin', 'administrator');
+UPDATE users SET role = 'user' WHERE role IS NULL OR LOWER(role) = 'user';
+
+-- Keep any other legacy role as a role without permissions (it had no route access before)
+INSERT INTO roles (name, description)
+SELECT DISTINCT role, 'Migrated legacy role'
+FROM users
+WHERE role NOT IN ('admin', 'user')
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE users ALTER COLUMN role SET NOT NULL;
+ALTER TABLE users ADD CONSTRAINT fk_users_role FOREIGN KEY (role) REFERENCES roles(name) ON UPDATE CASCADE;
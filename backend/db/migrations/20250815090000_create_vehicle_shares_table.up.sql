@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS vehicle_shares (
+    id SERIAL PRIMARY KEY,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    created_by_user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grantee_user_id INT REFERENCES users(id) ON DELETE CASCADE,
+    label VARCHAR(100),
+    history_minutes INT NOT NULL DEFAULT 0 CHECK (history_minutes >= 0),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    last_accessed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_vehicle_shares_vehicle_id ON vehicle_shares(vehicle_id);
+CREATE INDEX idx_vehicle_shares_grantee_user_id ON vehicle_shares(grantee_user_id);
+
+CREATE TRIGGER set_updated_at_vehicle_shares
+BEFORE UPDATE ON vehicle_shares
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
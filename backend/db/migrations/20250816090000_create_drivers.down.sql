@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission IN ('drivers:read', 'drivers:write');
+
+DROP TABLE IF EXISTS driver_assignments;
+DROP TABLE IF EXISTS drivers;
@@ -0,0 +1,52 @@
+CREATE TABLE IF NOT EXISTS drivers (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    license_number VARCHAR(50) NOT NULL,
+    license_expiry_date DATE,
+    phone_number VARCHAR(20),
+    identification_tag VARCHAR(64),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS driver_assignments (
+    id SERIAL PRIMARY KEY,
+    driver_id INT NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    start_at TIMESTAMPTZ NOT NULL,
+    end_at TIMESTAMPTZ,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'device')),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (end_at IS NULL OR end_at >= start_at)
+);
+
+-- Indexes
+CREATE INDEX idx_drivers_organization_id ON drivers(organization_id);
+CREATE UNIQUE INDEX idx_drivers_organization_tag ON drivers(organization_id, identification_tag)
+    WHERE identification_tag IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX idx_driver_assignments_driver_id ON driver_assignments(driver_id);
+CREATE INDEX idx_driver_assignments_vehicle_period ON driver_assignments(vehicle_id, start_at, end_at);
+-- At most one active assignment per vehicle and per driver
+CREATE UNIQUE INDEX idx_driver_assignments_active_vehicle ON driver_assignments(vehicle_id) WHERE end_at IS NULL;
+CREATE UNIQUE INDEX idx_driver_assignments_active_driver ON driver_assignments(driver_id) WHERE end_at IS NULL;
+
+CREATE TRIGGER set_updated_at_drivers
+BEFORE UPDATE ON drivers
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER set_updated_at_driver_assignments
+BEFORE UPDATE ON driver_assignments
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Grant driver permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('drivers:read'), ('drivers:write')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
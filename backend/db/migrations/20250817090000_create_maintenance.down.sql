@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission IN ('maintenance:read', 'maintenance:write');
+
+DROP TABLE IF EXISTS service_records;
+DROP TABLE IF EXISTS maintenance_plans;
@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS maintenance_plans (
+    id SERIAL PRIMARY KEY,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    interval_km DOUBLE PRECISION CHECK (interval_km > 0),
+    interval_engine_hours DOUBLE PRECISION CHECK (interval_engine_hours > 0),
+    interval_days INT CHECK (interval_days > 0),
+    last_service_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    notified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    CHECK (interval_km IS NOT NULL OR interval_engine_hours IS NOT NULL OR interval_days IS NOT NULL)
+);
+
+CREATE TABLE IF NOT EXISTS service_records (
+    id SERIAL PRIMARY KEY,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    plan_id INT REFERENCES maintenance_plans(id) ON DELETE SET NULL,
+    created_by_user_id INT NOT NULL REFERENCES users(id),
+    description VARCHAR(255) NOT NULL,
+    serviced_at TIMESTAMPTZ NOT NULL,
+    odometer_km DOUBLE PRECISION,
+    engine_hours DOUBLE PRECISION,
+    cost DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (cost >= 0),
+    workshop VARCHAR(100),
+    notes TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+-- Indexes
+CREATE INDEX idx_maintenance_plans_vehicle_id ON maintenance_plans(vehicle_id);
+CREATE INDEX idx_maintenance_plans_deleted_at ON maintenance_plans(deleted_at);
+CREATE INDEX idx_service_records_vehicle_serviced_at ON service_records(vehicle_id, serviced_at DESC);
+CREATE INDEX idx_service_records_plan_id ON service_records(plan_id);
+CREATE INDEX idx_service_records_deleted_at ON service_records(deleted_at);
+
+CREATE TRIGGER set_updated_at_maintenance_plans
+BEFORE UPDATE ON maintenance_plans
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER set_updated_at_service_records
+BEFORE UPDATE ON service_records
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Grant maintenance permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('maintenance:read'), ('maintenance:write')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+DELETE FROM role_permissions WHERE permission IN ('documents:read', 'documents:write');
+
+DROP TABLE IF EXISTS vehicle_documents;
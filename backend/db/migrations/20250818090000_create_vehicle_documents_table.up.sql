@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS vehicle_documents (
+    id SERIAL PRIMARY KEY,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('registration', 'inspection', 'insurance', 'other')),
+    number VARCHAR(100) NOT NULL,
+    issuer VARCHAR(100),
+    issued_at DATE,
+    expires_at DATE NOT NULL,
+    notes TEXT,
+    file_key VARCHAR(255),
+    file_name VARCHAR(255),
+    file_content_type VARCHAR(100),
+    file_size BIGINT,
+    expiry_notified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+-- Indexes
+CREATE INDEX idx_vehicle_documents_vehicle_id ON vehicle_documents(vehicle_id);
+CREATE INDEX idx_vehicle_documents_expires_at ON vehicle_documents(expires_at) WHERE deleted_at IS NULL;
+CREATE INDEX idx_vehicle_documents_deleted_at ON vehicle_documents(deleted_at);
+
+CREATE TRIGGER set_updated_at_vehicle_documents
+BEFORE UPDATE ON vehicle_documents
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Grant document permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('documents:read'), ('documents:write')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,8 @@
+DELETE FROM role_permissions WHERE permission IN ('alerts:read', 'alerts:write');
+
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS alert_events;
+DROP TABLE IF EXISTS alert_states;
+DROP TABLE IF EXISTS alert_rules;
+
+ALTER TABLE location_logs DROP COLUMN IF EXISTS ignition;
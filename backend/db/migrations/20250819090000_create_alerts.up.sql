@@ -0,0 +1,90 @@
+-- Devices may report the ignition state; alerts fall back to speed when it is missing
+ALTER TABLE location_logs ADD COLUMN IF NOT EXISTS ignition BOOLEAN;
+
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    vehicle_id INT REFERENCES vehicles(id) ON DELETE CASCADE,
+    created_by_user_id INT NOT NULL REFERENCES users(id),
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(30) NOT NULL CHECK (type IN ('offline', 'low_fuel', 'overspeed', 'geofence_exit', 'after_hours_use')),
+    severity VARCHAR(10) NOT NULL DEFAULT 'warning' CHECK (severity IN ('info', 'warning', 'critical')),
+    threshold DOUBLE PRECISION,
+    geofence_latitude DECIMAL(10,6),
+    geofence_longitude DECIMAL(10,6),
+    geofence_radius_m DOUBLE PRECISION,
+    working_hours_start VARCHAR(5),
+    working_hours_end VARCHAR(5),
+    working_days VARCHAR(20),
+    channels VARCHAR(100) NOT NULL,
+    email_recipients TEXT,
+    webhook_url VARCHAR(500),
+    cooldown_minutes INT NOT NULL DEFAULT 15,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS alert_states (
+    id SERIAL PRIMARY KEY,
+    rule_id INT NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    active BOOLEAN NOT NULL DEFAULT FALSE,
+    triggered_at TIMESTAMPTZ,
+    resolved_at TIMESTAMPTZ,
+    last_notified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS alert_events (
+    id SERIAL PRIMARY KEY,
+    rule_id INT NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL,
+    severity VARCHAR(10) NOT NULL,
+    message TEXT NOT NULL,
+    value DOUBLE PRECISION,
+    triggered_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    vehicle_id INT REFERENCES vehicles(id) ON DELETE SET NULL,
+    alert_event_id INT REFERENCES alert_events(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    message TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_alert_rules_organization_id ON alert_rules(organization_id);
+CREATE INDEX idx_alert_rules_vehicle_id ON alert_rules(vehicle_id);
+CREATE INDEX idx_alert_rules_deleted_at ON alert_rules(deleted_at);
+CREATE UNIQUE INDEX idx_alert_states_rule_vehicle ON alert_states(rule_id, vehicle_id);
+CREATE INDEX idx_alert_events_organization_id ON alert_events(organization_id, triggered_at DESC);
+CREATE INDEX idx_alert_events_vehicle_id ON alert_events(vehicle_id);
+CREATE INDEX idx_alert_events_rule_id ON alert_events(rule_id);
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, created_at DESC);
+
+CREATE TRIGGER set_updated_at_alert_rules
+BEFORE UPDATE ON alert_rules
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER set_updated_at_alert_states
+BEFORE UPDATE ON alert_states
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Grant alert permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('alerts:read'), ('alerts:write')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
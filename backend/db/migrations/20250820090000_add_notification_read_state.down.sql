@@ -0,0 +1,6 @@
+DELETE FROM role_permissions WHERE permission IN ('notifications:read');
+
+DROP INDEX IF EXISTS idx_notifications_user_unread;
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS read_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS severity;
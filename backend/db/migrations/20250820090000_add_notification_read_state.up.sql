@@ -0,0 +1,13 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS severity VARCHAR(10) NOT NULL DEFAULT 'info' CHECK (severity IN ('info', 'warning', 'critical'));
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS read_at TIMESTAMPTZ;
+
+-- Indexes
+CREATE INDEX idx_notifications_user_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- Grant notification permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('notifications:read')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
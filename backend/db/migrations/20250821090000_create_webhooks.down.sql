@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission IN ('webhooks:manage');
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
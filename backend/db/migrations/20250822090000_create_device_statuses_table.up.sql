@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS device_statuses (
+    id SERIAL PRIMARY KEY,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+    last_ip VARCHAR(45),
+    firmware_version VARCHAR(50),
+    signal_strength INT,
+    last_location_at TIMESTAMPTZ,
+    last_moving_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Indexes
+CREATE UNIQUE INDEX idx_device_statuses_vehicle_id ON device_statuses(vehicle_id);
+
+CREATE TRIGGER set_updated_at_device_statuses
+BEFORE UPDATE ON device_statuses
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Seed statuses of vehicles that already reported locations
+INSERT INTO device_statuses (vehicle_id, last_seen_at, last_location_at, last_moving_at)
+SELECT vehicle_id, MAX(timestamp), MAX(timestamp), MAX(timestamp) FILTER (WHERE speed > 0)
+FROM location_logs
+WHERE deleted_at IS NULL
+GROUP BY vehicle_id
+ON CONFLICT DO NOTHING;
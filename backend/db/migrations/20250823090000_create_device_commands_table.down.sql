@@ -0,0 +1,3 @@
+DELETE FROM role_permissions WHERE permission IN ('commands:read', 'commands:write');
+
+DROP TABLE IF EXISTS device_commands;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS device_commands (
+    id SERIAL PRIMARY KEY,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    issued_by_user_id INT NOT NULL REFERENCES users(id),
+    type VARCHAR(30) NOT NULL,
+    payload JSONB,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'acked', 'failed', 'expired')),
+    expires_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    result JSONB,
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_device_commands_vehicle_id ON device_commands(vehicle_id, created_at DESC);
+CREATE INDEX idx_device_commands_open ON device_commands(expires_at) WHERE status IN ('pending', 'delivered');
+
+CREATE TRIGGER set_updated_at_device_commands
+BEFORE UPDATE ON device_commands
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Grant device command permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('commands:read'), ('commands:write')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DELETE FROM role_permissions WHERE permission IN ('firmware:read', 'firmware:manage');
+
+DROP TABLE IF EXISTS firmware_installs;
+DROP TABLE IF EXISTS firmware_rollouts;
+DROP TABLE IF EXISTS firmware_releases;
@@ -0,0 +1,66 @@
+CREATE TABLE IF NOT EXISTS firmware_releases (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    uploaded_by_user_id INT NOT NULL REFERENCES users(id),
+    version VARCHAR(50) NOT NULL,
+    board VARCHAR(50) NOT NULL,
+    file_key VARCHAR(255) NOT NULL,
+    file_size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS firmware_rollouts (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    release_id INT NOT NULL REFERENCES firmware_releases(id) ON DELETE CASCADE,
+    created_by_user_id INT NOT NULL REFERENCES users(id),
+    name VARCHAR(100) NOT NULL,
+    vehicle_ids TEXT NOT NULL DEFAULT '',
+    percentage INT CHECK (percentage BETWEEN 0 AND 100),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS firmware_installs (
+    id SERIAL PRIMARY KEY,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    release_id INT NOT NULL REFERENCES firmware_releases(id) ON DELETE CASCADE,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('installed', 'failed')),
+    previous_version VARCHAR(50),
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_firmware_releases_organization_id ON firmware_releases(organization_id);
+CREATE INDEX idx_firmware_releases_deleted_at ON firmware_releases(deleted_at);
+CREATE UNIQUE INDEX idx_firmware_releases_version ON firmware_releases(organization_id, board, version) WHERE deleted_at IS NULL;
+CREATE INDEX idx_firmware_rollouts_organization_id ON firmware_rollouts(organization_id);
+CREATE INDEX idx_firmware_rollouts_release_id ON firmware_rollouts(release_id);
+CREATE INDEX idx_firmware_rollouts_deleted_at ON firmware_rollouts(deleted_at);
+CREATE INDEX idx_firmware_installs_vehicle_id ON firmware_installs(vehicle_id);
+CREATE INDEX idx_firmware_installs_release_id ON firmware_installs(release_id, created_at DESC);
+
+CREATE TRIGGER set_updated_at_firmware_releases
+BEFORE UPDATE ON firmware_releases
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER set_updated_at_firmware_rollouts
+BEFORE UPDATE ON firmware_rollouts
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Grant firmware permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('firmware:read'), ('firmware:manage')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
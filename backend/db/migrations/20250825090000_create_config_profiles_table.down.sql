@@ -0,0 +1,10 @@
+DELETE FROM role_permissions WHERE permission IN ('device_config:read', 'device_config:manage');
+
+ALTER TABLE device_statuses
+    DROP COLUMN IF EXISTS config_applied_at,
+    DROP COLUMN IF EXISTS config_version,
+    DROP COLUMN IF EXISTS config_profile_id;
+
+DROP TABLE IF EXISTS vehicle_config_assignments;
+DROP TABLE IF EXISTS config_profile_versions;
+DROP TABLE IF EXISTS config_profiles;
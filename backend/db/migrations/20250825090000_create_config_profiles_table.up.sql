@@ -0,0 +1,63 @@
+CREATE TABLE IF NOT EXISTS config_profiles (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    created_by_user_id INT NOT NULL REFERENCES users(id),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    version INT NOT NULL DEFAULT 1,
+    settings JSONB NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS config_profile_versions (
+    id SERIAL PRIMARY KEY,
+    profile_id INT NOT NULL REFERENCES config_profiles(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    settings JSONB NOT NULL,
+    created_by_user_id INT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS vehicle_config_assignments (
+    id SERIAL PRIMARY KEY,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    profile_id INT NOT NULL REFERENCES config_profiles(id) ON DELETE CASCADE,
+    assigned_by_user_id INT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Track the configuration each device applied
+ALTER TABLE device_statuses
+    ADD COLUMN IF NOT EXISTS config_profile_id INT REFERENCES config_profiles(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS config_version INT,
+    ADD COLUMN IF NOT EXISTS config_applied_at TIMESTAMPTZ;
+
+-- Indexes
+CREATE INDEX idx_config_profiles_organization_id ON config_profiles(organization_id);
+CREATE INDEX idx_config_profiles_deleted_at ON config_profiles(deleted_at);
+CREATE UNIQUE INDEX idx_config_profiles_default ON config_profiles(organization_id) WHERE is_default AND deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_config_profile_versions_version ON config_profile_versions(profile_id, version);
+CREATE UNIQUE INDEX idx_vehicle_config_assignments_vehicle_id ON vehicle_config_assignments(vehicle_id);
+CREATE INDEX idx_vehicle_config_assignments_profile_id ON vehicle_config_assignments(profile_id);
+
+CREATE TRIGGER set_updated_at_config_profiles
+BEFORE UPDATE ON config_profiles
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER set_updated_at_vehicle_config_assignments
+BEFORE UPDATE ON vehicle_config_assignments
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Grant device configuration permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('device_config:read'), ('device_config:manage')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
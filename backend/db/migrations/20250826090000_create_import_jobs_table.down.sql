@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission IN ('imports:manage');
+
+DROP INDEX IF EXISTS idx_location_logs_vehicle_timestamp;
+DROP TABLE IF EXISTS import_jobs;
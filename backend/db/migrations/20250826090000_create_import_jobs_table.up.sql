@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    created_by_user_id INT NOT NULL REFERENCES users(id),
+    format VARCHAR(10) NOT NULL CHECK (format IN ('csv', 'gpx', 'geojson')),
+    file_name VARCHAR(255) NOT NULL,
+    file_key VARCHAR(255) NOT NULL,
+    file_size BIGINT NOT NULL,
+    column_mapping JSONB,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    progress INT NOT NULL DEFAULT 0,
+    processed_rows INT NOT NULL DEFAULT 0,
+    imported_rows INT NOT NULL DEFAULT 0,
+    duplicate_rows INT NOT NULL DEFAULT 0,
+    invalid_rows INT NOT NULL DEFAULT 0,
+    row_errors JSONB,
+    error TEXT,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_import_jobs_organization_id ON import_jobs(organization_id, created_at DESC);
+CREATE INDEX idx_import_jobs_open ON import_jobs(id) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_location_logs_vehicle_timestamp ON location_logs(vehicle_id, timestamp);
+
+CREATE TRIGGER set_updated_at_import_jobs
+BEFORE UPDATE ON import_jobs
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Grant import permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('imports:manage')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DELETE FROM role_permissions WHERE permission IN ('reports:manage');
+
+DROP INDEX IF EXISTS idx_fuel_logs_vehicle_timestamp;
+DROP TABLE IF EXISTS report_runs;
+DROP TABLE IF EXISTS report_definitions;
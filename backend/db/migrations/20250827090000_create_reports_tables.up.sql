@@ -0,0 +1,65 @@
+CREATE TABLE IF NOT EXISTS report_definitions (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    created_by_user_id INT NOT NULL REFERENCES users(id),
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(30) NOT NULL CHECK (type IN ('daily_activity', 'weekly_distance', 'fuel_usage', 'violations')),
+    format VARCHAR(10) NOT NULL CHECK (format IN ('pdf', 'csv')),
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+    hour INT NOT NULL DEFAULT 6 CHECK (hour BETWEEN 0 AND 23),
+    weekday INT NOT NULL DEFAULT 1 CHECK (weekday BETWEEN 1 AND 7),
+    day_of_month INT NOT NULL DEFAULT 1 CHECK (day_of_month BETWEEN 1 AND 28),
+    recipients TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS report_runs (
+    id SERIAL PRIMARY KEY,
+    definition_id INT NOT NULL REFERENCES report_definitions(id) ON DELETE CASCADE,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    triggered_by_user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'completed', 'failed')),
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    file_name VARCHAR(255),
+    file_key VARCHAR(255),
+    file_size BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    emailed_at TIMESTAMPTZ,
+    email_error TEXT,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_report_definitions_organization_id ON report_definitions(organization_id);
+CREATE INDEX idx_report_definitions_deleted_at ON report_definitions(deleted_at);
+CREATE INDEX idx_report_definitions_due ON report_definitions(next_run_at) WHERE enabled AND deleted_at IS NULL;
+CREATE INDEX idx_report_runs_definition_id ON report_runs(definition_id, created_at DESC);
+CREATE INDEX idx_report_runs_organization_id ON report_runs(organization_id);
+CREATE INDEX IF NOT EXISTS idx_fuel_logs_vehicle_timestamp ON fuel_logs(vehicle_id, timestamp);
+
+CREATE TRIGGER set_updated_at_report_definitions
+BEFORE UPDATE ON report_definitions
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER set_updated_at_report_runs
+BEFORE UPDATE ON report_runs
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Grant report permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('reports:manage')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
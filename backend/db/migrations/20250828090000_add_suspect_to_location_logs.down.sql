@@ -0,0 +1 @@
+ALTER TABLE location_logs DROP COLUMN IF EXISTS suspect;
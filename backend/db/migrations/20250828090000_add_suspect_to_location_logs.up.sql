@@ -0,0 +1 @@
+ALTER TABLE location_logs ADD COLUMN IF NOT EXISTS suspect BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+DELETE FROM role_permissions WHERE permission IN ('pois:read', 'pois:write');
+
+DROP TABLE IF EXISTS pois;
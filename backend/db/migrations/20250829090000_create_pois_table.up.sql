@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS pois (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(50),
+    address VARCHAR(255),
+    latitude DECIMAL(10,6) NOT NULL,
+    longitude DECIMAL(10,6) NOT NULL,
+    radius_m DOUBLE PRECISION NOT NULL DEFAULT 100 CHECK (radius_m > 0),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+-- Indexes
+CREATE INDEX idx_pois_deleted_at ON pois(deleted_at);
+-- Names identify POIs within an organization, so re-importing a file updates them
+CREATE UNIQUE INDEX idx_pois_organization_name ON pois(organization_id, name) WHERE deleted_at IS NULL;
+CREATE INDEX idx_pois_organization_position ON pois(organization_id, latitude, longitude);
+
+CREATE TRIGGER set_updated_at_pois
+BEFORE UPDATE ON pois
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- Grant POI permissions to the built-in roles
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('pois:read'), ('pois:write')) AS p(permission)
+WHERE r.name IN ('admin', 'user')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS set_geog_location_logs ON location_logs;
+DROP TRIGGER IF EXISTS set_geog_pois ON pois;
+DROP FUNCTION IF EXISTS update_geog_column();
+
+DROP INDEX IF EXISTS idx_location_logs_geog;
+DROP INDEX IF EXISTS idx_pois_geog;
+
+ALTER TABLE location_logs DROP COLUMN IF EXISTS geog;
+ALTER TABLE pois DROP COLUMN IF EXISTS geog;
@@ -0,0 +1,53 @@
+-- Optional PostGIS support. When the extension can be installed, location_logs and pois get a geography
+-- column kept in sync with latitude and longitude by trigger and indexed with GiST. Otherwise nothing changes
+-- and spatial queries fall back to bounding-box filtering on latitude and longitude.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'postgis') THEN
+        RAISE NOTICE 'PostGIS is not installed, skipping geography columns';
+        RETURN;
+    END IF;
+
+    BEGIN
+        CREATE EXTENSION IF NOT EXISTS postgis;
+    EXCEPTION WHEN insufficient_privilege THEN
+        RAISE NOTICE 'Not allowed to create the PostGIS extension, skipping geography columns';
+        RETURN;
+    END;
+
+    -- Function to keep geog in sync with latitude and longitude
+    EXECUTE $fn$
+        CREATE OR REPLACE FUNCTION update_geog_column()
+        RETURNS TRIGGER AS $body$
+        BEGIN
+            NEW.geog = ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326)::geography;
+            RETURN NEW;
+        END;
+        $body$ LANGUAGE plpgsql
+    $fn$;
+
+    EXECUTE 'ALTER TABLE location_logs ADD COLUMN IF NOT EXISTS geog geography(Point, 4326)';
+    EXECUTE 'ALTER TABLE pois ADD COLUMN IF NOT EXISTS geog geography(Point, 4326)';
+
+    -- Backfill without touching updated_at
+    EXECUTE 'ALTER TABLE location_logs DISABLE TRIGGER set_updated_at_location_logs';
+    EXECUTE 'UPDATE location_logs SET geog = ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography WHERE geog IS NULL';
+    EXECUTE 'ALTER TABLE location_logs ENABLE TRIGGER set_updated_at_location_logs';
+    EXECUTE 'ALTER TABLE pois DISABLE TRIGGER set_updated_at_pois';
+    EXECUTE 'UPDATE pois SET geog = ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography WHERE geog IS NULL';
+    EXECUTE 'ALTER TABLE pois ENABLE TRIGGER set_updated_at_pois';
+
+    -- Indexes
+    EXECUTE 'CREATE INDEX IF NOT EXISTS idx_location_logs_geog ON location_logs USING GIST (geog)';
+    EXECUTE 'CREATE INDEX IF NOT EXISTS idx_pois_geog ON pois USING GIST (geog)';
+
+    EXECUTE 'CREATE TRIGGER set_geog_location_logs
+        BEFORE INSERT OR UPDATE OF latitude, longitude ON location_logs
+        FOR EACH ROW
+        EXECUTE FUNCTION update_geog_column()';
+    EXECUTE 'CREATE TRIGGER set_geog_pois
+        BEFORE INSERT OR UPDATE OF latitude, longitude ON pois
+        FOR EACH ROW
+        EXECUTE FUNCTION update_geog_column()';
+END;
+$$;
@@ -0,0 +1,13 @@
+-- Members join an organization by accepting an invitation sent to their email address
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(100) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'viewer', 'driver')),
+    invited_by_id INT REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (organization_id, email)
+);
+
+CREATE INDEX idx_organization_invitations_email ON organization_invitations(email);
@@ -6,57 +6,142 @@ import (
 	"github.com/cartrack/backend/internal/http/router"
 	"github.com/cartrack/backend/internal/repository"
 	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/blobstore"
+	"github.com/cartrack/backend/pkg/mailer"
 	"github.com/cartrack/backend/pkg/route"
+	"github.com/cartrack/backend/pkg/scheduler"
 	"github.com/cartrack/backend/pkg/token"
 	"gorm.io/gorm"
 )
 
 // BuildPublicRoutes creates public routes that don't require authentication
-func BuildPublicRoutes(cfg *configs.Config, db *gorm.DB) []route.Route {
-	// Initialize token manager
+func BuildPublicRoutes(cfg *configs.Config, db *gorm.DB, roleService service.RoleService, notificationService service.NotificationService) []route.Route {
+	// Initialize token manager, mailer and blob store
 	tokenManager := token.NewTokenManager(cfg.JWT.SecretKey)
+	mail := mailer.NewMailer(cfg.Mail)
+	blobs := blobstore.NewLocalStore(cfg.StoragePath)
 
 	// Initialize repository layer
+	spatial := repository.NewSpatial(db, cfg.PostgresConfig.PostGIS)
 	userRepo := repository.NewUserRepository(db)
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	systemLogRepo := repository.NewSystemLogRepository(db)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(db)
+	organizationRepo := repository.NewOrganizationRepository(db)
 	vehicleRepo := repository.NewVehicleRepository(db)
-	locationLogRepo := repository.NewLocationLogRepository(db)
+	locationLogRepo := repository.NewLocationLogRepository(db, spatial)
 	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	vehicleShareRepo := repository.NewVehicleShareRepository(db)
+	driverRepo := repository.NewDriverRepository(db)
+	driverAssignmentRepo := repository.NewDriverAssignmentRepository(db)
+	deviceStatusRepo := repository.NewDeviceStatusRepository(db)
+	deviceCommandRepo := repository.NewDeviceCommandRepository(db)
+	firmwareReleaseRepo := repository.NewFirmwareReleaseRepository(db)
+	firmwareRolloutRepo := repository.NewFirmwareRolloutRepository(db)
+	firmwareInstallRepo := repository.NewFirmwareInstallRepository(db)
+	configProfileRepo := repository.NewConfigProfileRepository(db)
 
 	// Initialize service layer
-	userService := service.NewUserService(userRepo, tokenManager)
-	locationLogService := service.NewLocationLogService(locationLogRepo, vehicleRepo)
-	apiKeyService := service.NewAPIKeyService(apiKeyRepo, vehicleRepo)
-	vehicleService := service.NewVehicleService(vehicleRepo)
+	loginProtectionService := service.NewLoginProtectionService(cfg.Login, userRepo, loginAttemptRepo, systemLogRepo)
+	twoFactorService := service.NewTwoFactorService(cfg.MFA, userRepo, recoveryCodeRepo)
+	organizationService := service.NewOrganizationService(cfg, organizationRepo, userRepo, mail)
+	userService := service.NewUserService(cfg, userRepo, userTokenRepo, loginProtectionService, twoFactorService, roleService, tokenManager, mail)
+	driverService := service.NewDriverService(driverRepo, driverAssignmentRepo, vehicleRepo, organizationService)
+	webhookService := buildWebhookService(cfg, db, organizationService)
+	alertService := buildAlertService(cfg, db, organizationService, notificationService, webhookService, mail)
+	deviceStatusService := service.NewDeviceStatusService(cfg.Devices, deviceStatusRepo, vehicleRepo, organizationService)
+	deviceCommandService := service.NewDeviceCommandService(cfg.Commands, deviceCommandRepo, vehicleRepo, organizationService)
+	firmwareService := service.NewFirmwareService(cfg, firmwareReleaseRepo, firmwareRolloutRepo, firmwareInstallRepo, vehicleRepo, organizationService, blobs)
+	configProfileService := service.NewConfigProfileService(configProfileRepo, vehicleRepo, deviceStatusRepo, organizationService)
+	locationLogService := service.NewLocationLogService(cfg.Tracks, locationLogRepo, vehicleRepo, organizationService, driverService, alertService, webhookService, deviceStatusService)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, vehicleRepo, organizationService)
+	vehicleService := service.NewVehicleService(vehicleRepo, organizationService)
+	vehicleShareService := service.NewVehicleShareService(cfg, vehicleShareRepo, vehicleRepo, locationLogRepo, userRepo, organizationService)
 
 	// Initialize handler layer
 	userHandler := handler.NewUserHandler(userService, tokenManager)
 	locationLogHandler := handler.NewLocationLogHandler(locationLogService)
-	esp32Handler := handler.NewESP32Handler(apiKeyService, locationLogService, vehicleService)
+	esp32Handler := handler.NewESP32Handler(apiKeyService, locationLogService, vehicleService, driverService, deviceStatusService, deviceCommandService, firmwareService, configProfileService)
+	vehicleShareHandler := handler.NewVehicleShareHandler(vehicleShareService)
 
 	// Get routes from router
-	return router.PublicRoutes(userHandler, locationLogHandler, esp32Handler)
+	return router.PublicRoutes(userHandler, locationLogHandler, esp32Handler, vehicleShareHandler)
+}
+
+// BuildRoleService creates the role service shared by the permission middleware and the role API
+func BuildRoleService(db *gorm.DB) service.RoleService {
+	return service.NewRoleService(repository.NewRoleRepository(db), repository.NewUserRepository(db))
+}
+
+// BuildNotificationService creates the notification service shared by alert delivery and the notification API,
+// so notifications raised anywhere in the process reach the live streams
+func BuildNotificationService(db *gorm.DB) service.NotificationService {
+	return service.NewNotificationService(repository.NewNotificationRepository(db))
 }
 
 // BuildPrivateRoutes creates private routes that require authentication
-func BuildPrivateRoutes(cfg *configs.Config, db *gorm.DB) []route.Route {
-	// Initialize token manager
+func BuildPrivateRoutes(cfg *configs.Config, db *gorm.DB, roleService service.RoleService, notificationService service.NotificationService) []route.Route {
+	// Initialize token manager, mailer and blob store
 	tokenManager := token.NewTokenManager(cfg.JWT.SecretKey)
+	mail := mailer.NewMailer(cfg.Mail)
+	blobs := blobstore.NewLocalStore(cfg.StoragePath)
 
 	// Initialize repository layer
+	spatial := repository.NewSpatial(db, cfg.PostgresConfig.PostGIS)
 	userRepo := repository.NewUserRepository(db)
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	systemLogRepo := repository.NewSystemLogRepository(db)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(db)
+	organizationRepo := repository.NewOrganizationRepository(db)
 	vehicleRepo := repository.NewVehicleRepository(db)
-	locationLogRepo := repository.NewLocationLogRepository(db)
+	locationLogRepo := repository.NewLocationLogRepository(db, spatial)
 	fuelLogRepo := repository.NewFuelLogRepository(db)
 	apiKeyRepo := repository.NewAPIKeyRepository(db)
 	dashboardRepo := repository.NewDashboardRepository(db)
+	vehicleShareRepo := repository.NewVehicleShareRepository(db)
+	driverRepo := repository.NewDriverRepository(db)
+	driverAssignmentRepo := repository.NewDriverAssignmentRepository(db)
+	maintenancePlanRepo := repository.NewMaintenancePlanRepository(db)
+	serviceRecordRepo := repository.NewServiceRecordRepository(db)
+	vehicleDocumentRepo := repository.NewVehicleDocumentRepository(db)
+	deviceStatusRepo := repository.NewDeviceStatusRepository(db)
+	deviceCommandRepo := repository.NewDeviceCommandRepository(db)
+	firmwareReleaseRepo := repository.NewFirmwareReleaseRepository(db)
+	firmwareRolloutRepo := repository.NewFirmwareRolloutRepository(db)
+	firmwareInstallRepo := repository.NewFirmwareInstallRepository(db)
+	configProfileRepo := repository.NewConfigProfileRepository(db)
+	importJobRepo := repository.NewImportJobRepository(db)
+	alertRuleRepo := repository.NewAlertRuleRepository(db)
+	poiRepo := repository.NewPOIRepository(db, spatial)
 
 	// Initialize service layer
-	userService := service.NewUserService(userRepo, tokenManager)
-	vehicleService := service.NewVehicleService(vehicleRepo)
-	locationLogService := service.NewLocationLogService(locationLogRepo, vehicleRepo)
-	fuelLogService := service.NewFuelLogService(fuelLogRepo, vehicleRepo)
-	apiKeyService := service.NewAPIKeyService(apiKeyRepo, vehicleRepo)
+	loginProtectionService := service.NewLoginProtectionService(cfg.Login, userRepo, loginAttemptRepo, systemLogRepo)
+	twoFactorService := service.NewTwoFactorService(cfg.MFA, userRepo, recoveryCodeRepo)
+	organizationService := service.NewOrganizationService(cfg, organizationRepo, userRepo, mail)
+	userService := service.NewUserService(cfg, userRepo, userTokenRepo, loginProtectionService, twoFactorService, roleService, tokenManager, mail)
+	vehicleService := service.NewVehicleService(vehicleRepo, organizationService)
+	driverService := service.NewDriverService(driverRepo, driverAssignmentRepo, vehicleRepo, organizationService)
+	webhookService := buildWebhookService(cfg, db, organizationService)
+	alertService := buildAlertService(cfg, db, organizationService, notificationService, webhookService, mail)
+	deviceStatusService := service.NewDeviceStatusService(cfg.Devices, deviceStatusRepo, vehicleRepo, organizationService)
+	deviceCommandService := service.NewDeviceCommandService(cfg.Commands, deviceCommandRepo, vehicleRepo, organizationService)
+	firmwareService := service.NewFirmwareService(cfg, firmwareReleaseRepo, firmwareRolloutRepo, firmwareInstallRepo, vehicleRepo, organizationService, blobs)
+	configProfileService := service.NewConfigProfileService(configProfileRepo, vehicleRepo, deviceStatusRepo, organizationService)
+	poiService := service.NewPOIService(cfg.POIs, poiRepo, vehicleRepo, locationLogRepo, alertRuleRepo, organizationService)
+	trackService := service.NewTrackService(cfg.Tracks, locationLogRepo, vehicleRepo, poiService, organizationService)
+	stopService := service.NewStopService(cfg.Tracks, locationLogRepo, vehicleRepo, poiService, organizationService)
+	dispatchService := service.NewDispatchService(cfg.Devices, vehicleRepo, fuelLogRepo, deviceStatusRepo)
+	importService := service.NewImportService(cfg.Imports, importJobRepo, locationLogRepo, vehicleRepo, organizationService, blobs)
+	reportService := buildReportService(cfg, db, organizationService, blobs, mail)
+	locationLogService := service.NewLocationLogService(cfg.Tracks, locationLogRepo, vehicleRepo, organizationService, driverService, alertService, webhookService, deviceStatusService)
+	fuelLogService := service.NewFuelLogService(fuelLogRepo, vehicleRepo, organizationService, alertService, webhookService)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, vehicleRepo, organizationService)
 	dashboardService := service.NewDashboardService(dashboardRepo)
+	vehicleShareService := service.NewVehicleShareService(cfg, vehicleShareRepo, vehicleRepo, locationLogRepo, userRepo, organizationService)
+	maintenanceService := service.NewMaintenanceService(cfg.Maintenance, maintenancePlanRepo, serviceRecordRepo, vehicleRepo, locationLogRepo, systemLogRepo, organizationService)
+	vehicleDocumentService := service.NewVehicleDocumentService(cfg.Documents, vehicleDocumentRepo, vehicleRepo, systemLogRepo, organizationService, blobs)
 
 	// Initialize handler layer
 	userHandler := handler.NewUserHandler(userService, tokenManager)
@@ -65,7 +150,119 @@ func BuildPrivateRoutes(cfg *configs.Config, db *gorm.DB) []route.Route {
 	fuelLogHandler := handler.NewFuelLogHandler(fuelLogService)
 	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
 	dashboardHandler := handler.NewDashboardHandler(dashboardService)
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorService)
+	organizationHandler := handler.NewOrganizationHandler(organizationService)
+	roleHandler := handler.NewRoleHandler(roleService)
+	vehicleShareHandler := handler.NewVehicleShareHandler(vehicleShareService)
+	driverHandler := handler.NewDriverHandler(driverService)
+	maintenanceHandler := handler.NewMaintenanceHandler(maintenanceService)
+	vehicleDocumentHandler := handler.NewVehicleDocumentHandler(vehicleDocumentService)
+	alertHandler := handler.NewAlertHandler(alertService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	deviceStatusHandler := handler.NewDeviceStatusHandler(deviceStatusService)
+	deviceCommandHandler := handler.NewDeviceCommandHandler(deviceCommandService)
+	firmwareHandler := handler.NewFirmwareHandler(firmwareService)
+	configProfileHandler := handler.NewConfigProfileHandler(configProfileService)
+	trackHandler := handler.NewTrackHandler(trackService)
+	stopHandler := handler.NewStopHandler(stopService)
+	poiHandler := handler.NewPOIHandler(poiService)
+	dispatchHandler := handler.NewDispatchHandler(dispatchService)
+	importHandler := handler.NewImportHandler(importService)
+	reportHandler := handler.NewReportHandler(reportService)
 
 	// Get routes from router
-	return router.PrivateRoutes(userHandler, vehicleHandler, locationLogHandler, fuelLogHandler, apiKeyHandler, dashboardHandler)
+	return router.PrivateRoutes(userHandler, vehicleHandler, locationLogHandler, fuelLogHandler, apiKeyHandler, dashboardHandler, twoFactorHandler, organizationHandler, roleHandler, vehicleShareHandler, driverHandler, maintenanceHandler, vehicleDocumentHandler, alertHandler, notificationHandler, webhookHandler, deviceStatusHandler, deviceCommandHandler, firmwareHandler, configProfileHandler, trackHandler, importHandler, reportHandler, stopHandler, poiHandler, dispatchHandler)
+}
+
+// BuildScheduler creates the scheduler running background jobs
+func BuildScheduler(cfg *configs.Config, db *gorm.DB, roleService service.RoleService, notificationService service.NotificationService) *scheduler.Scheduler {
+	// Initialize repository layer
+	spatial := repository.NewSpatial(db, cfg.PostgresConfig.PostGIS)
+	userRepo := repository.NewUserRepository(db)
+	systemLogRepo := repository.NewSystemLogRepository(db)
+	organizationRepo := repository.NewOrganizationRepository(db)
+	vehicleRepo := repository.NewVehicleRepository(db)
+	locationLogRepo := repository.NewLocationLogRepository(db, spatial)
+	maintenancePlanRepo := repository.NewMaintenancePlanRepository(db)
+	serviceRecordRepo := repository.NewServiceRecordRepository(db)
+	vehicleDocumentRepo := repository.NewVehicleDocumentRepository(db)
+	deviceCommandRepo := repository.NewDeviceCommandRepository(db)
+	importJobRepo := repository.NewImportJobRepository(db)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(db)
+
+	// Initialize service layer
+	organizationService := service.NewOrganizationService(cfg, organizationRepo, userRepo, mailer.NewMailer(cfg.Mail))
+	maintenanceService := service.NewMaintenanceService(cfg.Maintenance, maintenancePlanRepo, serviceRecordRepo, vehicleRepo, locationLogRepo, systemLogRepo, organizationService)
+	vehicleDocumentService := service.NewVehicleDocumentService(cfg.Documents, vehicleDocumentRepo, vehicleRepo, systemLogRepo, organizationService, blobstore.NewLocalStore(cfg.StoragePath))
+	webhookService := buildWebhookService(cfg, db, organizationService)
+	alertService := buildAlertService(cfg, db, organizationService, notificationService, webhookService, mailer.NewMailer(cfg.Mail))
+	deviceCommandService := service.NewDeviceCommandService(cfg.Commands, deviceCommandRepo, vehicleRepo, organizationService)
+	importService := service.NewImportService(cfg.Imports, importJobRepo, locationLogRepo, vehicleRepo, organizationService, blobstore.NewLocalStore(cfg.StoragePath))
+	reportService := buildReportService(cfg, db, organizationService, blobstore.NewLocalStore(cfg.StoragePath), mailer.NewMailer(cfg.Mail))
+	loginProtectionService := service.NewLoginProtectionService(cfg.Login, userRepo, loginAttemptRepo, systemLogRepo)
+	twoFactorService := service.NewTwoFactorService(cfg.MFA, userRepo, recoveryCodeRepo)
+	userService := service.NewUserService(cfg, userRepo, userTokenRepo, loginProtectionService, twoFactorService, roleService, token.NewTokenManager(cfg.JWT.SecretKey), mailer.NewMailer(cfg.Mail))
+
+	// Register jobs
+	jobs := scheduler.New()
+	jobs.Every("maintenance-due", cfg.Maintenance.CheckInterval, maintenanceService.CheckDue)
+	jobs.Every("document-expiry", cfg.Documents.CheckInterval, vehicleDocumentService.CheckExpiring)
+	jobs.Every("alert-offline", cfg.Alerts.EvaluationInterval, alertService.EvaluateOffline)
+	jobs.Every("webhook-dispatch", cfg.Webhooks.DispatchInterval, webhookService.Dispatch)
+	jobs.Every("command-expiry", cfg.Commands.ExpiryInterval, deviceCommandService.ExpireDue)
+	jobs.Every("history-import", cfg.Imports.PollInterval, importService.ProcessPending)
+	jobs.Every("report-dispatch", cfg.Reports.DispatchInterval, reportService.DispatchDue)
+	jobs.Every("login-attempt-purge", cfg.Login.PurgeInterval, loginProtectionService.PurgeAttempts)
+	jobs.Every("user-token-purge", cfg.Login.PurgeInterval, userService.PurgeExpiredTokens)
+
+	return jobs
+}
+
+// buildAlertService creates the alert service with every notification channel
+func buildAlertService(cfg *configs.Config, db *gorm.DB, organizationService service.OrganizationService, notificationService service.NotificationService, webhookService service.WebhookService, mail mailer.Mailer) service.AlertService {
+	organizationRepo := repository.NewOrganizationRepository(db)
+
+	return service.NewAlertService(
+		repository.NewAlertRuleRepository(db),
+		repository.NewAlertStateRepository(db),
+		repository.NewAlertEventRepository(db),
+		repository.NewVehicleRepository(db),
+		repository.NewLocationLogRepository(db, repository.NewSpatial(db, cfg.PostgresConfig.PostGIS)),
+		repository.NewDeviceStatusRepository(db),
+		organizationService,
+		webhookService,
+		service.NewInAppNotifier(notificationService, organizationRepo),
+		service.NewEmailNotifier(mail, organizationRepo),
+		service.NewWebhookNotifier(cfg.Alerts.WebhookTimeout),
+	)
+}
+
+// buildReportService creates the report service that generates, stores and emails scheduled reports
+func buildReportService(cfg *configs.Config, db *gorm.DB, organizationService service.OrganizationService, blobs blobstore.Store, mail mailer.Mailer) service.ReportService {
+	return service.NewReportService(
+		cfg.Reports,
+		repository.NewReportDefinitionRepository(db),
+		repository.NewReportRunRepository(db),
+		repository.NewVehicleRepository(db),
+		repository.NewLocationLogRepository(db, repository.NewSpatial(db, cfg.PostgresConfig.PostGIS)),
+		repository.NewFuelLogRepository(db),
+		repository.NewAlertEventRepository(db),
+		repository.NewOrganizationRepository(db),
+		organizationService,
+		blobs,
+		mail,
+	)
+}
+
+// buildWebhookService creates the webhook service that queues and delivers outgoing events
+func buildWebhookService(cfg *configs.Config, db *gorm.DB, organizationService service.OrganizationService) service.WebhookService {
+	return service.NewWebhookService(
+		cfg.Webhooks,
+		repository.NewWebhookSubscriptionRepository(db),
+		repository.NewWebhookDeliveryRepository(db),
+		organizationService,
+	)
 }
@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AlertType is the condition an alert rule watches for
+type AlertType string
+
+const (
+	AlertTypeOffline       AlertType = "offline"         // no location for Threshold minutes
+	AlertTypeLowFuel       AlertType = "low_fuel"        // fuel level below Threshold percent
+	AlertTypeOverspeed     AlertType = "overspeed"       // speed above Threshold km/h
+	AlertTypeGeofenceExit  AlertType = "geofence_exit"   // position outside the rule's circular geofence
+	AlertTypeAfterHoursUse AlertType = "after_hours_use" // ignition on outside the rule's working hours
+)
+
+// IsValid checks if the alert type is known
+func (t AlertType) IsValid() bool {
+	switch t {
+	case AlertTypeOffline, AlertTypeLowFuel, AlertTypeOverspeed, AlertTypeGeofenceExit, AlertTypeAfterHoursUse:
+		return true
+	}
+	return false
+}
+
+// Severity ranks how urgent an alert or notification is
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Notification channels an alert rule can deliver through
+const (
+	ChannelInApp   = "in_app"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// AlertRule is a condition evaluated for one vehicle or, without VehicleID, for every vehicle of the organization
+type AlertRule struct {
+	ID                uint           `json:"id" gorm:"primarykey"`
+	OrganizationID    uint           `json:"organization_id" gorm:"not null;index"`
+	VehicleID         *uint          `json:"vehicle_id" gorm:"index"`
+	CreatedByUserID   uint           `json:"created_by_user_id" gorm:"not null"`
+	Name              string         `json:"name" gorm:"type:varchar(100);not null"`
+	Type              AlertType      `json:"type" gorm:"type:varchar(30);not null"`
+	Severity          Severity       `json:"severity" gorm:"type:varchar(10);not null;default:warning"`
+	Threshold         *float64       `json:"threshold"`
+	GeofenceLatitude  *float64       `json:"geofence_latitude" gorm:"type:decimal(10,6)"`
+	GeofenceLongitude *float64       `json:"geofence_longitude" gorm:"type:decimal(10,6)"`
+	GeofenceRadiusM   *float64       `json:"geofence_radius_m"`
+	WorkingHoursStart *string        `json:"working_hours_start" gorm:"type:varchar(5)"` // HH:MM local time
+	WorkingHoursEnd   *string        `json:"working_hours_end" gorm:"type:varchar(5)"`
+	WorkingDays       *string        `json:"working_days" gorm:"type:varchar(20)"` // ISO weekdays, e.g. "1,2,3,4,5"
+	Channels          string         `json:"channels" gorm:"type:varchar(100);not null"`
+	EmailRecipients   *string        `json:"email_recipients" gorm:"type:text"` // comma-separated, defaults to owners and managers
+	WebhookURL        *string        `json:"webhook_url" gorm:"type:varchar(500)"`
+	CooldownMinutes   int            `json:"cooldown_minutes" gorm:"not null;default:15"`
+	Enabled           bool           `json:"enabled" gorm:"not null;default:true"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName returns the table name for AlertRule entity
+func (AlertRule) TableName() string {
+	return "alert_rules"
+}
+
+// ChannelList returns the rule's notification channels
+func (r *AlertRule) ChannelList() []string {
+	return splitList(r.Channels)
+}
+
+// EmailList returns the rule's explicit email recipients
+func (r *AlertRule) EmailList() []string {
+	if r.EmailRecipients == nil {
+		return nil
+	}
+	return splitList(*r.EmailRecipients)
+}
+
+// AppliesTo checks if the rule watches the vehicle
+func (r *AlertRule) AppliesTo(vehicle *Vehicle) bool {
+	return vehicle.OrganizationID == r.OrganizationID && (r.VehicleID == nil || *r.VehicleID == vehicle.ID)
+}
+
+// AlertState tracks whether a rule's condition currently holds for a vehicle, so an ongoing
+// condition notifies once and the cooldown can be enforced across evaluations
+type AlertState struct {
+	ID             uint       `json:"id" gorm:"primarykey"`
+	RuleID         uint       `json:"rule_id" gorm:"not null;uniqueIndex:idx_alert_states_rule_vehicle"`
+	VehicleID      uint       `json:"vehicle_id" gorm:"not null;uniqueIndex:idx_alert_states_rule_vehicle"`
+	Active         bool       `json:"active" gorm:"not null;default:false"`
+	TriggeredAt    *time.Time `json:"triggered_at"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	LastNotifiedAt *time.Time `json:"last_notified_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for AlertState entity
+func (AlertState) TableName() string {
+	return "alert_states"
+}
+
+// AlertEvent is a notified occurrence of an alert rule's condition
+type AlertEvent struct {
+	ID             uint      `json:"id" gorm:"primarykey"`
+	RuleID         uint      `json:"rule_id" gorm:"not null;index"`
+	OrganizationID uint      `json:"organization_id" gorm:"not null;index"`
+	VehicleID      uint      `json:"vehicle_id" gorm:"not null;index"`
+	Type           AlertType `json:"type" gorm:"type:varchar(30);not null"`
+	Severity       Severity  `json:"severity" gorm:"type:varchar(10);not null"`
+	Message        string    `json:"message" gorm:"type:text;not null"`
+	Value          *float64  `json:"value"`
+	TriggeredAt    time.Time `json:"triggered_at" gorm:"not null"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	// Relationships
+	Rule    AlertRule `json:"-" gorm:"foreignKey:RuleID"`
+	Vehicle Vehicle   `json:"-" gorm:"foreignKey:VehicleID"`
+}
+
+// TableName returns the table name for AlertEvent entity
+func (AlertEvent) TableName() string {
+	return "alert_events"
+}
+
+// splitList splits a comma-separated list, dropping blanks
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,65 @@
+package entity
+
+import "time"
+
+// DeviceCommandType is an action a tracker can be asked to perform
+type DeviceCommandType string
+
+const (
+	DeviceCommandSetReportInterval DeviceCommandType = "set_report_interval" // payload {"interval_seconds": 30}
+	DeviceCommandReboot            DeviceCommandType = "reboot"
+	DeviceCommandEngineCut         DeviceCommandType = "engine_cut"     // open the engine relay
+	DeviceCommandEngineRestore     DeviceCommandType = "engine_restore" // close the engine relay
+	DeviceCommandCameraSnapshot    DeviceCommandType = "camera_snapshot"
+)
+
+// DeviceCommandTypes lists every command type a tracker understands
+var DeviceCommandTypes = []DeviceCommandType{
+	DeviceCommandSetReportInterval,
+	DeviceCommandReboot,
+	DeviceCommandEngineCut,
+	DeviceCommandEngineRestore,
+	DeviceCommandCameraSnapshot,
+}
+
+// DeviceCommandStatus is the lifecycle state of a device command
+type DeviceCommandStatus string
+
+const (
+	DeviceCommandPending   DeviceCommandStatus = "pending"   // queued, not yet fetched by the device
+	DeviceCommandDelivered DeviceCommandStatus = "delivered" // fetched by the device, awaiting its result
+	DeviceCommandAcked     DeviceCommandStatus = "acked"     // performed by the device
+	DeviceCommandFailed    DeviceCommandStatus = "failed"    // the device could not perform it
+	DeviceCommandExpired   DeviceCommandStatus = "expired"   // not completed before it expired
+)
+
+// DeviceCommand is a command queued for the tracker of a vehicle, kept as the vehicle's command history
+type DeviceCommand struct {
+	ID             uint                `json:"id" gorm:"primarykey"`
+	VehicleID      uint                `json:"vehicle_id" gorm:"not null;index"`
+	IssuedByUserID uint                `json:"issued_by_user_id" gorm:"not null"`
+	Type           DeviceCommandType   `json:"type" gorm:"type:varchar(30);not null"`
+	Payload        *string             `json:"-" gorm:"type:jsonb"`
+	Status         DeviceCommandStatus `json:"status" gorm:"type:varchar(10);not null;default:pending"`
+	ExpiresAt      time.Time           `json:"expires_at" gorm:"not null"`
+	DeliveredAt    *time.Time          `json:"delivered_at"`
+	CompletedAt    *time.Time          `json:"completed_at"`
+	Result         *string             `json:"-" gorm:"type:jsonb"`
+	Error          *string             `json:"error" gorm:"type:text"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+
+	// Relationships
+	Vehicle      Vehicle `json:"-" gorm:"foreignKey:VehicleID"`
+	IssuedByUser User    `json:"-" gorm:"foreignKey:IssuedByUserID"`
+}
+
+// TableName returns the table name for DeviceCommand entity
+func (DeviceCommand) TableName() string {
+	return "device_commands"
+}
+
+// IsOpen reports whether the command can still be delivered or completed
+func (c *DeviceCommand) IsOpen() bool {
+	return c.Status == DeviceCommandPending || c.Status == DeviceCommandDelivered
+}
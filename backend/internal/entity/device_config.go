@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ConfigProfile is a versioned set of tracker settings. Every change to the settings bumps Version
+// and keeps the previous settings as a ConfigProfileVersion.
+type ConfigProfile struct {
+	ID              uint           `json:"id" gorm:"primarykey"`
+	OrganizationID  uint           `json:"organization_id" gorm:"not null;index"`
+	CreatedByUserID uint           `json:"created_by_user_id" gorm:"not null"`
+	Name            string         `json:"name" gorm:"type:varchar(100);not null"`
+	Description     *string        `json:"description" gorm:"type:text"`
+	Version         int            `json:"version" gorm:"not null;default:1"`
+	Settings        string         `json:"-" gorm:"type:jsonb;not null"`
+	IsDefault       bool           `json:"is_default" gorm:"not null;default:false"` // applies to vehicles without an assigned profile
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName returns the table name for ConfigProfile entity
+func (ConfigProfile) TableName() string {
+	return "config_profiles"
+}
+
+// ETag identifies the profile's current settings. It changes with the profile and with the version.
+func (p *ConfigProfile) ETag() string {
+	return fmt.Sprintf(`"%d.%d"`, p.ID, p.Version)
+}
+
+// ConfigProfileVersion is the settings of a profile at one version
+type ConfigProfileVersion struct {
+	ID              uint      `json:"id" gorm:"primarykey"`
+	ProfileID       uint      `json:"profile_id" gorm:"not null;uniqueIndex:idx_config_profile_versions_version"`
+	Version         int       `json:"version" gorm:"not null;uniqueIndex:idx_config_profile_versions_version"`
+	Settings        string    `json:"-" gorm:"type:jsonb;not null"`
+	CreatedByUserID uint      `json:"created_by_user_id" gorm:"not null"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for ConfigProfileVersion entity
+func (ConfigProfileVersion) TableName() string {
+	return "config_profile_versions"
+}
+
+// VehicleConfigAssignment assigns a configuration profile to a vehicle, overriding the organization default
+type VehicleConfigAssignment struct {
+	ID               uint      `json:"id" gorm:"primarykey"`
+	VehicleID        uint      `json:"vehicle_id" gorm:"not null;uniqueIndex"`
+	ProfileID        uint      `json:"profile_id" gorm:"not null;index"`
+	AssignedByUserID uint      `json:"assigned_by_user_id" gorm:"not null"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Relationships
+	Profile ConfigProfile `json:"-" gorm:"foreignKey:ProfileID"`
+}
+
+// TableName returns the table name for VehicleConfigAssignment entity
+func (VehicleConfigAssignment) TableName() string {
+	return "vehicle_config_assignments"
+}
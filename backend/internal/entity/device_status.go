@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// DeviceState is the connectivity state of a vehicle's tracker
+type DeviceState string
+
+const (
+	DeviceStateOnline  DeviceState = "online"  // reporting and the vehicle moved recently
+	DeviceStateIdle    DeviceState = "idle"    // reporting but the vehicle is parked
+	DeviceStateOffline DeviceState = "offline" // not heard from within the offline threshold
+)
+
+// DeviceStatus is the latest contact with the tracker installed in a vehicle
+type DeviceStatus struct {
+	ID              uint       `json:"id" gorm:"primarykey"`
+	VehicleID       uint       `json:"vehicle_id" gorm:"not null;uniqueIndex"`
+	LastSeenAt      time.Time  `json:"last_seen_at" gorm:"not null"`
+	LastIP          *string    `json:"last_ip" gorm:"type:varchar(45)"`
+	FirmwareVersion *string    `json:"firmware_version" gorm:"type:varchar(50)"`
+	SignalStrength  *int       `json:"signal_strength"` // RSSI in dBm
+	LastLocationAt  *time.Time `json:"last_location_at"`
+	LastMovingAt    *time.Time `json:"last_moving_at"`
+	ConfigProfileID *uint      `json:"config_profile_id"` // configuration last applied by the device
+	ConfigVersion   *int       `json:"config_version"`
+	ConfigAppliedAt *time.Time `json:"config_applied_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for DeviceStatus entity
+func (DeviceStatus) TableName() string {
+	return "device_statuses"
+}
+
+// State derives the device state at now. A device is offline once it has not been seen for offlineAfter,
+// and idle while it reports but the vehicle has not moved for idleAfter.
+func (s *DeviceStatus) State(now time.Time, idleAfter, offlineAfter time.Duration) DeviceState {
+	if now.Sub(s.LastSeenAt) > offlineAfter {
+		return DeviceStateOffline
+	}
+	if s.LastMovingAt == nil || now.Sub(*s.LastMovingAt) > idleAfter {
+		return DeviceStateIdle
+	}
+	return DeviceStateOnline
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Driver represents a person who drives an organization's vehicles
+type Driver struct {
+	ID                uint           `json:"id" gorm:"primarykey"`
+	OrganizationID    uint           `json:"organization_id" gorm:"not null;index"`
+	Name              string         `json:"name" gorm:"type:varchar(100);not null"`
+	LicenseNumber     string         `json:"license_number" gorm:"type:varchar(50);not null"`
+	LicenseExpiryDate *time.Time     `json:"license_expiry_date" gorm:"type:date"`
+	PhoneNumber       *string        `json:"phone_number" gorm:"type:varchar(20)"`
+	IdentificationTag *string        `json:"identification_tag" gorm:"type:varchar(64)"` // RFID or iButton ID reported by devices
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName returns the table name for Driver entity
+func (Driver) TableName() string {
+	return "drivers"
+}
+
+// IsLicenseExpired checks if the driver's license has expired
+func (d *Driver) IsLicenseExpired(now time.Time) bool {
+	return d.LicenseExpiryDate != nil && now.After(d.LicenseExpiryDate.AddDate(0, 0, 1))
+}
+
+// AssignmentSource describes how a driver assignment was created
+type AssignmentSource string
+
+const (
+	AssignmentSourceManual AssignmentSource = "manual"
+	AssignmentSourceDevice AssignmentSource = "device"
+)
+
+// DriverAssignment records that a driver was on duty in a vehicle between StartAt and EndAt.
+// An assignment with no EndAt is still active.
+type DriverAssignment struct {
+	ID        uint             `json:"id" gorm:"primarykey"`
+	DriverID  uint             `json:"driver_id" gorm:"not null;index"`
+	VehicleID uint             `json:"vehicle_id" gorm:"not null;index"`
+	StartAt   time.Time        `json:"start_at" gorm:"not null"`
+	EndAt     *time.Time       `json:"end_at"`
+	Source    AssignmentSource `json:"source" gorm:"type:varchar(20);not null;default:manual"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+
+	// Relationships
+	Driver  Driver  `json:"driver" gorm:"foreignKey:DriverID"`
+	Vehicle Vehicle `json:"-" gorm:"foreignKey:VehicleID"`
+}
+
+// TableName returns the table name for DriverAssignment entity
+func (DriverAssignment) TableName() string {
+	return "driver_assignments"
+}
+
+// Covers checks if the assignment was active at the given time
+func (a *DriverAssignment) Covers(t time.Time) bool {
+	return !t.Before(a.StartAt) && (a.EndAt == nil || t.Before(*a.EndAt))
+}
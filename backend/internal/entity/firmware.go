@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FirmwareRelease is a firmware binary uploaded for one target board
+type FirmwareRelease struct {
+	ID               uint           `json:"id" gorm:"primarykey"`
+	OrganizationID   uint           `json:"organization_id" gorm:"not null;index"`
+	UploadedByUserID uint           `json:"uploaded_by_user_id" gorm:"not null"`
+	Version          string         `json:"version" gorm:"type:varchar(50);not null"`
+	Board            string         `json:"board" gorm:"type:varchar(50);not null"`
+	FileKey          string         `json:"-" gorm:"type:varchar(255);not null"`
+	FileSize         int64          `json:"file_size" gorm:"not null"`
+	SHA256           string         `json:"sha256" gorm:"column:sha256;type:char(64);not null"`
+	Notes            *string        `json:"notes" gorm:"type:text"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName returns the table name for FirmwareRelease entity
+func (FirmwareRelease) TableName() string {
+	return "firmware_releases"
+}
+
+// FirmwareRollout offers a release to a group of vehicles, either listed explicitly or a percentage of the fleet
+type FirmwareRollout struct {
+	ID              uint           `json:"id" gorm:"primarykey"`
+	OrganizationID  uint           `json:"organization_id" gorm:"not null;index"`
+	ReleaseID       uint           `json:"release_id" gorm:"not null;index"`
+	CreatedByUserID uint           `json:"created_by_user_id" gorm:"not null"`
+	Name            string         `json:"name" gorm:"type:varchar(100);not null"`
+	VehicleIDs      string         `json:"vehicle_ids" gorm:"type:text;not null;default:''"` // comma separated, empty for a percentage rollout
+	Percentage      *int           `json:"percentage"`
+	Enabled         bool           `json:"enabled" gorm:"not null;default:true"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	Release FirmwareRelease `json:"-" gorm:"foreignKey:ReleaseID"`
+}
+
+// TableName returns the table name for FirmwareRollout entity
+func (FirmwareRollout) TableName() string {
+	return "firmware_rollouts"
+}
+
+// VehicleIDList returns the vehicles listed in the rollout
+func (r *FirmwareRollout) VehicleIDList() []uint {
+	var ids []uint
+	for _, item := range splitList(r.VehicleIDs) {
+		if id, err := strconv.ParseUint(item, 10, 32); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
+
+// Targets reports whether the rollout offers its release to the vehicle. Vehicles of a percentage rollout are
+// picked by a stable hash, so raising the percentage only adds vehicles.
+func (r *FirmwareRollout) Targets(vehicleID uint) bool {
+	if r.Percentage != nil {
+		h := fnv.New32a()
+		h.Write([]byte(strconv.FormatUint(uint64(r.ID), 10) + ":" + strconv.FormatUint(uint64(vehicleID), 10)))
+		return int(h.Sum32()%100) < *r.Percentage
+	}
+	for _, id := range r.VehicleIDList() {
+		if id == vehicleID {
+			return true
+		}
+	}
+	return false
+}
+
+// FirmwareInstallStatus is the outcome of a firmware install reported by a device
+type FirmwareInstallStatus string
+
+const (
+	FirmwareInstallInstalled FirmwareInstallStatus = "installed"
+	FirmwareInstallFailed    FirmwareInstallStatus = "failed"
+)
+
+// FirmwareInstall is an install result reported by the tracker of a vehicle
+type FirmwareInstall struct {
+	ID              uint                  `json:"id" gorm:"primarykey"`
+	VehicleID       uint                  `json:"vehicle_id" gorm:"not null;index"`
+	ReleaseID       uint                  `json:"release_id" gorm:"not null;index"`
+	Status          FirmwareInstallStatus `json:"status" gorm:"type:varchar(10);not null"`
+	PreviousVersion *string               `json:"previous_version" gorm:"type:varchar(50)"`
+	Error           *string               `json:"error" gorm:"type:text"`
+	CreatedAt       time.Time             `json:"created_at"`
+
+	// Relationships
+	Release FirmwareRelease `json:"-" gorm:"foreignKey:ReleaseID"`
+}
+
+// TableName returns the table name for FirmwareInstall entity
+func (FirmwareInstall) TableName() string {
+	return "firmware_installs"
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// ImportJobStatus is the lifecycle state of a location history import
+type ImportJobStatus string
+
+const (
+	ImportJobPending   ImportJobStatus = "pending"   // uploaded, waiting for the import worker
+	ImportJobRunning   ImportJobStatus = "running"   // being imported
+	ImportJobCompleted ImportJobStatus = "completed" // every row was read; invalid rows are listed in its errors
+	ImportJobFailed    ImportJobStatus = "failed"    // the file could not be read to the end
+)
+
+// ImportJob is an uploaded file of historical positions imported into a vehicle's location logs in the background
+type ImportJob struct {
+	ID              uint            `json:"id" gorm:"primarykey"`
+	OrganizationID  uint            `json:"organization_id" gorm:"not null;index"`
+	VehicleID       uint            `json:"vehicle_id" gorm:"not null"`
+	CreatedByUserID uint            `json:"created_by_user_id" gorm:"not null"`
+	Format          string          `json:"format" gorm:"type:varchar(10);not null"`
+	FileName        string          `json:"file_name" gorm:"type:varchar(255);not null"`
+	FileKey         string          `json:"-" gorm:"type:varchar(255);not null"`
+	FileSize        int64           `json:"file_size" gorm:"not null"`
+	ColumnMapping   *string         `json:"-" gorm:"type:jsonb"`
+	Status          ImportJobStatus `json:"status" gorm:"type:varchar(10);not null;default:pending"`
+	Progress        int             `json:"progress" gorm:"not null;default:0"`
+	ProcessedRows   int             `json:"processed_rows" gorm:"not null;default:0"`
+	ImportedRows    int             `json:"imported_rows" gorm:"not null;default:0"`
+	DuplicateRows   int             `json:"duplicate_rows" gorm:"not null;default:0"`
+	InvalidRows     int             `json:"invalid_rows" gorm:"not null;default:0"`
+	RowErrors       *string         `json:"-" gorm:"type:jsonb"`
+	Error           *string         `json:"error" gorm:"type:text"`
+	StartedAt       *time.Time      `json:"started_at"`
+	CompletedAt     *time.Time      `json:"completed_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+
+	// Relationships
+	Vehicle Vehicle `json:"-" gorm:"foreignKey:VehicleID"`
+}
+
+// TableName returns the table name for ImportJob entity
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+// IsFinished reports whether the import has ended
+func (j *ImportJob) IsFinished() bool {
+	return j.Status == ImportJobCompleted || j.Status == ImportJobFailed
+}
@@ -14,6 +14,8 @@ type LocationLog struct {
 	Longitude float64        `json:"longitude" gorm:"type:decimal(10,6);not null"`
 	Speed     *float64       `json:"speed" gorm:"type:decimal(5,2)"`
 	Direction *int16         `json:"direction" gorm:"type:smallint"`
+	Ignition  *bool          `json:"ignition"`
+	Suspect   bool           `json:"suspect" gorm:"not null;default:false"`
 	Timestamp time.Time      `json:"timestamp" gorm:"default:now()"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
@@ -27,3 +29,11 @@ type LocationLog struct {
 func (LocationLog) TableName() string {
 	return "location_logs"
 }
+
+// AreaVisit summarizes the positions a vehicle reported inside an area during a period
+type AreaVisit struct {
+	VehicleID uint      `json:"vehicle_id"`
+	Positions int64     `json:"positions"`
+	FirstAt   time.Time `json:"first_at"`
+	LastAt    time.Time `json:"last_at"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+)
+
+// LoginAttempt represents a single login attempt used for brute-force protection
+type LoginAttempt struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	UserID    *uint     `json:"user_id"`
+	Email     string    `json:"email" gorm:"type:varchar(100);not null"`
+	IPAddress string    `json:"ip_address" gorm:"type:varchar(45);not null"`
+	Success   bool      `json:"success" gorm:"default:false"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for LoginAttempt entity
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
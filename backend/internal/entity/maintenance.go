@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaintenancePlan is a recurring service item of a vehicle (oil change, tyre rotation, inspection, ...).
+// It becomes due when any of its intervals has elapsed since the last service.
+type MaintenancePlan struct {
+	ID                  uint           `json:"id" gorm:"primarykey"`
+	VehicleID           uint           `json:"vehicle_id" gorm:"not null;index"`
+	Name                string         `json:"name" gorm:"type:varchar(100);not null"`
+	Description         *string        `json:"description" gorm:"type:text"`
+	IntervalKm          *float64       `json:"interval_km"`
+	IntervalEngineHours *float64       `json:"interval_engine_hours"`
+	IntervalDays        *int           `json:"interval_days"`
+	LastServiceAt       time.Time      `json:"last_service_at" gorm:"not null"` // start of the current service cycle
+	NotifiedAt          *time.Time     `json:"notified_at"`                     // when the current cycle was reported as due
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	Vehicle Vehicle `json:"-" gorm:"foreignKey:VehicleID"`
+}
+
+// TableName returns the table name for MaintenancePlan entity
+func (MaintenancePlan) TableName() string {
+	return "maintenance_plans"
+}
+
+// NextDueDate returns when the calendar interval elapses, if the plan has one
+func (p *MaintenancePlan) NextDueDate() *time.Time {
+	if p.IntervalDays == nil {
+		return nil
+	}
+	due := p.LastServiceAt.AddDate(0, 0, *p.IntervalDays)
+	return &due
+}
+
+// ServiceRecord is a maintenance job carried out on a vehicle
+type ServiceRecord struct {
+	ID              uint           `json:"id" gorm:"primarykey"`
+	VehicleID       uint           `json:"vehicle_id" gorm:"not null;index"`
+	PlanID          *uint          `json:"plan_id" gorm:"index"`
+	CreatedByUserID uint           `json:"created_by_user_id" gorm:"not null"`
+	Description     string         `json:"description" gorm:"type:varchar(255);not null"`
+	ServicedAt      time.Time      `json:"serviced_at" gorm:"not null"`
+	OdometerKm      *float64       `json:"odometer_km"`
+	EngineHours     *float64       `json:"engine_hours"`
+	Cost            float64        `json:"cost" gorm:"type:decimal(12,2);not null;default:0"`
+	Workshop        *string        `json:"workshop" gorm:"type:varchar(100)"`
+	Notes           *string        `json:"notes" gorm:"type:text"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	Plan *MaintenancePlan `json:"plan,omitempty" gorm:"foreignKey:PlanID"`
+}
+
+// TableName returns the table name for ServiceRecord entity
+func (ServiceRecord) TableName() string {
+	return "service_records"
+}
+
+// VehicleUsage is how far and how long a vehicle was driven in a period, derived from location logs
+type VehicleUsage struct {
+	DistanceKm  float64 `json:"distance_km"`
+	EngineHours float64 `json:"engine_hours"`
+}
+
+// MaintenanceStatus describes how close a maintenance plan is to being due
+type MaintenanceStatus string
+
+const (
+	MaintenanceStatusOK       MaintenanceStatus = "ok"
+	MaintenanceStatusUpcoming MaintenanceStatus = "upcoming"
+	MaintenanceStatusOverdue  MaintenanceStatus = "overdue"
+)
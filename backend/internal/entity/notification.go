@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Notification is a message delivered to a user's in-app inbox
+type Notification struct {
+	ID           uint       `json:"id" gorm:"primarykey"`
+	UserID       uint       `json:"user_id" gorm:"not null;index"`
+	VehicleID    *uint      `json:"vehicle_id"`
+	AlertEventID *uint      `json:"alert_event_id"`
+	Severity     Severity   `json:"severity" gorm:"type:varchar(10);not null;default:info"`
+	Title        string     `json:"title" gorm:"type:varchar(200);not null"`
+	Message      string     `json:"message" gorm:"type:text;not null"`
+	ReadAt       *time.Time `json:"read_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+
+	// Relationships
+	Vehicle *Vehicle `json:"-" gorm:"foreignKey:VehicleID"`
+}
+
+// TableName returns the table name for Notification entity
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// IsRead checks if the user has read the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OrganizationRole represents a member's role within an organization
+type OrganizationRole string
+
+const (
+	OrgRoleOwner   OrganizationRole = "owner"
+	OrgRoleManager OrganizationRole = "manager"
+	OrgRoleViewer  OrganizationRole = "viewer"
+	OrgRoleDriver  OrganizationRole = "driver"
+)
+
+// OrgAction represents an action that can be performed inside an organization
+type OrgAction string
+
+const (
+	OrgActionRead               OrgAction = "read"
+	OrgActionLogTelemetry       OrgAction = "log_telemetry"
+	OrgActionManageVehicles     OrgAction = "manage_vehicles"
+	OrgActionManageMembers      OrgAction = "manage_members"
+	OrgActionManageOrganization OrgAction = "manage_organization"
+)
+
+// orgRoleActions maps each organization role to the actions it allows
+var orgRoleActions = map[OrganizationRole][]OrgAction{
+	OrgRoleOwner:   {OrgActionRead, OrgActionLogTelemetry, OrgActionManageVehicles, OrgActionManageMembers, OrgActionManageOrganization},
+	OrgRoleManager: {OrgActionRead, OrgActionLogTelemetry, OrgActionManageVehicles, OrgActionManageMembers},
+	OrgRoleDriver:  {OrgActionRead, OrgActionLogTelemetry},
+	OrgRoleViewer:  {OrgActionRead},
+}
+
+// IsValid checks if role is a known organization role
+func (r OrganizationRole) IsValid() bool {
+	_, ok := orgRoleActions[r]
+	return ok
+}
+
+// Can checks if role allows the given action
+func (r OrganizationRole) Can(action OrgAction) bool {
+	for _, allowed := range orgRoleActions[r] {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Organization represents a fleet owned and operated by one or more users
+type Organization struct {
+	ID        uint           `json:"id" gorm:"primarykey"`
+	Name      string         `json:"name" gorm:"type:varchar(100);not null"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	Members []OrganizationMember `json:"members,omitempty" gorm:"foreignKey:OrganizationID"`
+}
+
+// TableName returns the table name for Organization entity
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// OrganizationMember represents a user's membership and role in an organization
+type OrganizationMember struct {
+	ID             uint             `json:"id" gorm:"primarykey"`
+	OrganizationID uint             `json:"organization_id" gorm:"not null"`
+	UserID         uint             `json:"user_id" gorm:"not null"`
+	Role           OrganizationRole `json:"role" gorm:"type:varchar(20);not null"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+
+	// Relationships
+	Organization Organization `json:"organization" gorm:"foreignKey:OrganizationID"`
+	User         User         `json:"user" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for OrganizationMember entity
+func (OrganizationMember) TableName() string {
+	return "organization_members"
+}
+
+// OrganizationInvitation offers the owner of an email address a role in an organization.
+// It becomes a membership only when a user registered with that email accepts it.
+type OrganizationInvitation struct {
+	ID             uint             `json:"id" gorm:"primarykey"`
+	OrganizationID uint             `json:"organization_id" gorm:"not null"`
+	Email          string           `json:"email" gorm:"type:varchar(100);not null"`
+	Role           OrganizationRole `json:"role" gorm:"type:varchar(20);not null"`
+	InvitedByID    *uint            `json:"invited_by_id"`
+	ExpiresAt      time.Time        `json:"expires_at" gorm:"not null"`
+	CreatedAt      time.Time        `json:"created_at"`
+
+	// Relationships
+	Organization Organization `json:"organization" gorm:"foreignKey:OrganizationID"`
+}
+
+// TableName returns the table name for OrganizationInvitation entity
+func (OrganizationInvitation) TableName() string {
+	return "organization_invitations"
+}
+
+// IsPending checks if the invitation can still be accepted
+func (i *OrganizationInvitation) IsPending(now time.Time) bool {
+	return now.Before(i.ExpiresAt)
+}
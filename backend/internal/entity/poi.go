@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// POI is a named place of an organization, such as a customer delivery point. A position within RadiusM
+// meters of the coordinates is at the POI.
+type POI struct {
+	ID             uint           `json:"id" gorm:"primarykey"`
+	OrganizationID uint           `json:"organization_id" gorm:"not null;index"`
+	Name           string         `json:"name" gorm:"type:varchar(100);not null"`
+	Category       *string        `json:"category" gorm:"type:varchar(50)"`
+	Address        *string        `json:"address" gorm:"type:varchar(255)"`
+	Latitude       float64        `json:"latitude" gorm:"type:decimal(10,6);not null"`
+	Longitude      float64        `json:"longitude" gorm:"type:decimal(10,6);not null"`
+	RadiusM        float64        `json:"radius_m" gorm:"not null;default:100"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName returns the table name for POI entity
+func (POI) TableName() string {
+	return "pois"
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+)
+
+// RecoveryCode represents a single-use two-factor recovery code.
+// Only the SHA-256 hash of the code is stored.
+type RecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	UserID    uint       `json:"user_id" gorm:"not null"`
+	CodeHash  string     `json:"-" gorm:"type:varchar(64);not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName returns the table name for RecoveryCode entity
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
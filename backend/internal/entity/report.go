@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReportType is the content of a scheduled report
+type ReportType string
+
+const (
+	ReportTypeDailyActivity  ReportType = "daily_activity"  // positions, first and last movement, distance, driving time and top speed per vehicle
+	ReportTypeWeeklyDistance ReportType = "weekly_distance" // distance per vehicle for each day of the period
+	ReportTypeFuelUsage      ReportType = "fuel_usage"      // fuel level consumed and refuelled per vehicle
+	ReportTypeViolations     ReportType = "violations"      // overspeed, geofence exit and after-hours alert events
+)
+
+// IsValid checks if the report type is known
+func (t ReportType) IsValid() bool {
+	switch t {
+	case ReportTypeDailyActivity, ReportTypeWeeklyDistance, ReportTypeFuelUsage, ReportTypeViolations:
+		return true
+	}
+	return false
+}
+
+// Report file formats
+const (
+	ReportFormatPDF = "pdf"
+	ReportFormatCSV = "csv"
+)
+
+// ReportFrequency is how often a report is generated. It also sets the period the report covers:
+// the previous day, the previous seven days or the previous calendar month.
+type ReportFrequency string
+
+const (
+	ReportFrequencyDaily   ReportFrequency = "daily"
+	ReportFrequencyWeekly  ReportFrequency = "weekly"
+	ReportFrequencyMonthly ReportFrequency = "monthly"
+)
+
+// ReportDefinition is a report of an organization's fleet generated on a schedule and emailed to its recipients.
+// Schedules are in local (Asia/Jakarta) time.
+type ReportDefinition struct {
+	ID              uint            `json:"id" gorm:"primarykey"`
+	OrganizationID  uint            `json:"organization_id" gorm:"not null;index"`
+	CreatedByUserID uint            `json:"created_by_user_id" gorm:"not null"`
+	Name            string          `json:"name" gorm:"type:varchar(100);not null"`
+	Type            ReportType      `json:"type" gorm:"type:varchar(30);not null"`
+	Format          string          `json:"format" gorm:"type:varchar(10);not null"`
+	Frequency       ReportFrequency `json:"frequency" gorm:"type:varchar(10);not null"`
+	Hour            int             `json:"hour" gorm:"not null;default:6"`    // local hour the report is generated at
+	Weekday         int             `json:"weekday" gorm:"not null;default:1"` // ISO weekday of weekly reports
+	DayOfMonth      int             `json:"day_of_month" gorm:"not null;default:1"`
+	Recipients      *string         `json:"recipients" gorm:"type:text"` // comma-separated, defaults to owners and managers
+	Enabled         bool            `json:"enabled" gorm:"not null;default:true"`
+	NextRunAt       time.Time       `json:"next_run_at" gorm:"not null"`
+	LastRunAt       *time.Time      `json:"last_run_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt  `json:"deleted_at" gorm:"index"`
+}
+
+// TableName returns the table name for ReportDefinition entity
+func (ReportDefinition) TableName() string {
+	return "report_definitions"
+}
+
+// RecipientList returns the definition's explicit email recipients
+func (d *ReportDefinition) RecipientList() []string {
+	if d.Recipients == nil {
+		return nil
+	}
+	return splitList(*d.Recipients)
+}
+
+// NextRunAfter returns the first scheduled time strictly after the given time
+func (d *ReportDefinition) NextRunAfter(after time.Time, loc *time.Location) time.Time {
+	local := after.In(loc)
+
+	switch d.Frequency {
+	case ReportFrequencyWeekly:
+		next := time.Date(local.Year(), local.Month(), local.Day(), d.Hour, 0, 0, 0, loc)
+		days := (d.Weekday%7 - int(next.Weekday()) + 7) % 7
+		next = next.AddDate(0, 0, days)
+		if !next.After(after) {
+			next = next.AddDate(0, 0, 7)
+		}
+		return next
+	case ReportFrequencyMonthly:
+		next := time.Date(local.Year(), local.Month(), d.DayOfMonth, d.Hour, 0, 0, 0, loc)
+		if !next.After(after) {
+			next = time.Date(local.Year(), local.Month()+1, d.DayOfMonth, d.Hour, 0, 0, 0, loc)
+		}
+		return next
+	default:
+		next := time.Date(local.Year(), local.Month(), local.Day(), d.Hour, 0, 0, 0, loc)
+		if !next.After(after) {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next
+	}
+}
+
+// Period returns the time range a report generated at runAt covers. It ends at the local midnight
+// starting the day of runAt, so a report never includes the day it is generated on.
+func (d *ReportDefinition) Period(runAt time.Time, loc *time.Location) (time.Time, time.Time) {
+	local := runAt.In(loc)
+	end := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	switch d.Frequency {
+	case ReportFrequencyWeekly:
+		return end.AddDate(0, 0, -7), end
+	case ReportFrequencyMonthly:
+		end = time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+		return end.AddDate(0, -1, 0), end
+	default:
+		return end.AddDate(0, 0, -1), end
+	}
+}
+
+// ReportRunStatus is the state of a report generation
+type ReportRunStatus string
+
+const (
+	ReportRunRunning   ReportRunStatus = "running"
+	ReportRunCompleted ReportRunStatus = "completed"
+	ReportRunFailed    ReportRunStatus = "failed"
+)
+
+// ReportRun is one generation of a report definition, keeping the file for download.
+// A completed run whose email could not be sent records the error in EmailError.
+type ReportRun struct {
+	ID                uint            `json:"id" gorm:"primarykey"`
+	DefinitionID      uint            `json:"definition_id" gorm:"not null;index"`
+	OrganizationID    uint            `json:"organization_id" gorm:"not null;index"`
+	TriggeredByUserID *uint           `json:"triggered_by_user_id"` // nil when run by the schedule
+	Status            ReportRunStatus `json:"status" gorm:"type:varchar(10);not null;default:running"`
+	PeriodStart       time.Time       `json:"period_start" gorm:"not null"`
+	PeriodEnd         time.Time       `json:"period_end" gorm:"not null"`
+	Format            string          `json:"format" gorm:"type:varchar(10);not null"`
+	FileName          *string         `json:"file_name" gorm:"type:varchar(255)"`
+	FileKey           *string         `json:"-" gorm:"type:varchar(255)"`
+	FileSize          int64           `json:"file_size" gorm:"not null;default:0"`
+	Error             *string         `json:"error" gorm:"type:text"`
+	EmailedAt         *time.Time      `json:"emailed_at"`
+	EmailError        *string         `json:"email_error" gorm:"type:text"`
+	CompletedAt       *time.Time      `json:"completed_at"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+// TableName returns the table name for ReportRun entity
+func (ReportRun) TableName() string {
+	return "report_runs"
+}
+
+// VehicleActivity summarises the positions a vehicle reported in a period
+type VehicleActivity struct {
+	Positions int64      `json:"positions"`
+	FirstAt   *time.Time `json:"first_at"`
+	LastAt    *time.Time `json:"last_at"`
+	MaxSpeed  float64    `json:"max_speed"`
+}
+
+// FuelUsage is how the fuel level of a vehicle changed in a period, in percent of the tank.
+// Only a rise larger than sensor noise between two readings counts as a refuel, and consumption
+// is the level lost between the first and last reading plus what was refuelled.
+type FuelUsage struct {
+	Readings   int64    `json:"readings"`
+	StartLevel *float64 `json:"start_level"`
+	EndLevel   *float64 `json:"end_level"`
+	Consumed   float64  `json:"consumed"`
+	Refuelled  float64  `json:"refuelled"`
+	Refuels    int64    `json:"refuels"`
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+)
+
+// Built-in role names that cannot be deleted
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Role represents a named set of permissions assigned to users
+type Role struct {
+	ID          uint             `json:"id" gorm:"primarykey"`
+	Name        string           `json:"name" gorm:"type:varchar(50);uniqueIndex;not null"`
+	Description *string          `json:"description" gorm:"type:text"`
+	IsSystem    bool             `json:"is_system" gorm:"default:false"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	Permissions []RolePermission `json:"permissions,omitempty" gorm:"foreignKey:RoleID"`
+}
+
+// TableName returns the table name for Role entity
+func (Role) TableName() string {
+	return "roles"
+}
+
+// PermissionNames returns the names of the permissions granted by the role
+func (r *Role) PermissionNames() []string {
+	names := make([]string, len(r.Permissions))
+	for i, p := range r.Permissions {
+		names[i] = p.Permission
+	}
+	return names
+}
+
+// RolePermission grants a single permission to a role
+type RolePermission struct {
+	RoleID     uint   `json:"role_id" gorm:"primaryKey"`
+	Permission string `json:"permission" gorm:"type:varchar(50);primaryKey"`
+}
+
+// TableName returns the table name for RolePermission entity
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
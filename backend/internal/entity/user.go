@@ -8,15 +8,22 @@ import (
 
 // User represents user entity in the system
 type User struct {
-	ID           uint           `json:"id" gorm:"primarykey"`
-	Name         string         `json:"name" gorm:"type:varchar(100);not null"`
-	Email        string         `json:"email" gorm:"type:varchar(100);uniqueIndex;not null"`
-	PasswordHash string         `json:"-" gorm:"column:password_hash;not null"`
-	PhoneNumber  *string        `json:"phone_number" gorm:"type:varchar(20)"`
-	Role         string         `json:"role" gorm:"type:varchar(50);default:user"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	ID                  uint           `json:"id" gorm:"primarykey"`
+	Name                string         `json:"name" gorm:"type:varchar(100);not null"`
+	Email               string         `json:"email" gorm:"type:varchar(100);uniqueIndex;not null"`
+	PasswordHash        string         `json:"-" gorm:"column:password_hash;not null"`
+	PhoneNumber         *string        `json:"phone_number" gorm:"type:varchar(20)"`
+	Role                string         `json:"role" gorm:"type:varchar(50);not null;default:user"`
+	EmailVerifiedAt     *time.Time     `json:"email_verified_at"`
+	FailedLoginAttempts int            `json:"-" gorm:"default:0"`
+	LastFailedLoginAt   *time.Time     `json:"-"`
+	LockedUntil         *time.Time     `json:"locked_until"`
+	TOTPSecret          *string        `json:"-" gorm:"column:totp_secret;type:varchar(64)"`
+	TwoFactorEnabled    bool           `json:"two_factor_enabled" gorm:"default:false"`
+	TOTPLastStep        *int64         `json:"-" gorm:"column:totp_last_step"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
 // TableName returns the table name for User entity
@@ -26,10 +33,20 @@ func (User) TableName() string {
 
 // IsAdmin checks if user has admin role
 func (u *User) IsAdmin() bool {
-	return u.Role == "admin" || u.Role == "Administrator"
+	return u.Role == RoleAdmin
 }
 
 // IsUser checks if user has user role
 func (u *User) IsUser() bool {
-	return u.Role == "user" || u.Role == "User"
+	return u.Role == RoleUser
+}
+
+// IsEmailVerified checks if user has verified their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsLocked checks if user account is temporarily locked
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
 }
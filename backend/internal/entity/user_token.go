@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+)
+
+// TokenPurpose represents what a user token can be used for
+type TokenPurpose string
+
+const (
+	TokenPurposePasswordReset     TokenPurpose = "password_reset"
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+)
+
+// UserToken represents a single-use token sent to the user by email.
+// Only the SHA-256 hash of the token is stored.
+type UserToken struct {
+	ID        uint         `json:"id" gorm:"primarykey"`
+	UserID    uint         `json:"user_id" gorm:"not null"`
+	Purpose   TokenPurpose `json:"purpose" gorm:"type:varchar(30);not null"`
+	TokenHash string       `json:"-" gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time    `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time   `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+
+	// Relationships
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for UserToken entity
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
+
+// IsUsable checks if token is unused and not expired
+func (t *UserToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -8,14 +8,15 @@ import (
 
 // Vehicle represents vehicle entity in the system
 type Vehicle struct {
-	ID          uint           `json:"id" gorm:"primarykey"`
-	UserID      uint           `json:"user_id" gorm:"not null"`
-	PlateNumber string         `json:"plate_number" gorm:"type:varchar(20);not null"`
-	Model       *string        `json:"model" gorm:"type:varchar(100)"`
-	IMEI        *string        `json:"imei" gorm:"type:varchar(50);unique"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	ID             uint           `json:"id" gorm:"primarykey"`
+	UserID         uint           `json:"user_id" gorm:"not null"`
+	OrganizationID uint           `json:"organization_id" gorm:"not null;index"`
+	PlateNumber    string         `json:"plate_number" gorm:"type:varchar(20);not null"`
+	Model          *string        `json:"model" gorm:"type:varchar(100)"`
+	IMEI           *string        `json:"imei" gorm:"type:varchar(50);unique"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 
 	// Relationships
 	User         User          `json:"user" gorm:"foreignKey:UserID"`
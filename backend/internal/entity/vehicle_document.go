@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DocumentType is the kind of compliance document attached to a vehicle
+type DocumentType string
+
+const (
+	DocumentTypeRegistration DocumentType = "registration" // STNK
+	DocumentTypeInspection   DocumentType = "inspection"   // KIR
+	DocumentTypeInsurance    DocumentType = "insurance"
+	DocumentTypeOther        DocumentType = "other"
+)
+
+// IsValid checks if the document type is known
+func (t DocumentType) IsValid() bool {
+	switch t {
+	case DocumentTypeRegistration, DocumentTypeInspection, DocumentTypeInsurance, DocumentTypeOther:
+		return true
+	}
+	return false
+}
+
+// VehicleDocument is a registration, inspection or insurance document of a vehicle with its expiry date
+type VehicleDocument struct {
+	ID               uint           `json:"id" gorm:"primarykey"`
+	VehicleID        uint           `json:"vehicle_id" gorm:"not null;index"`
+	Type             DocumentType   `json:"type" gorm:"type:varchar(20);not null"`
+	Number           string         `json:"number" gorm:"type:varchar(100);not null"`
+	Issuer           *string        `json:"issuer" gorm:"type:varchar(100)"` // issuing office or insurer
+	IssuedAt         *time.Time     `json:"issued_at" gorm:"type:date"`
+	ExpiresAt        time.Time      `json:"expires_at" gorm:"type:date;not null"`
+	Notes            *string        `json:"notes" gorm:"type:text"`
+	FileKey          *string        `json:"-" gorm:"type:varchar(255)"`
+	FileName         *string        `json:"file_name" gorm:"type:varchar(255)"`
+	FileContentType  *string        `json:"file_content_type" gorm:"type:varchar(100)"`
+	FileSize         *int64         `json:"file_size"`
+	ExpiryNotifiedAt *time.Time     `json:"expiry_notified_at"` // when the daily job flagged the document as expiring
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	Vehicle Vehicle `json:"-" gorm:"foreignKey:VehicleID"`
+}
+
+// TableName returns the table name for VehicleDocument entity
+func (VehicleDocument) TableName() string {
+	return "vehicle_documents"
+}
+
+// DaysUntilExpiry returns whole days from now until the document expires; negative once expired
+func (d *VehicleDocument) DaysUntilExpiry(now time.Time) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	expiry := time.Date(d.ExpiresAt.Year(), d.ExpiresAt.Month(), d.ExpiresAt.Day(), 0, 0, 0, 0, time.UTC)
+	return int(expiry.Sub(today).Hours() / 24)
+}
+
+// HasFile checks if a file is attached to the document
+func (d *VehicleDocument) HasFile() bool {
+	return d.FileKey != nil
+}
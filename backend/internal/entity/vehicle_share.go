@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+)
+
+// VehicleShare grants read access to a vehicle's live location until it expires or is revoked.
+// A share targets either a registered user (GranteeUserID) or anyone holding its signed link.
+type VehicleShare struct {
+	ID              uint       `json:"id" gorm:"primarykey"`
+	VehicleID       uint       `json:"vehicle_id" gorm:"not null;index"`
+	CreatedByUserID uint       `json:"created_by_user_id" gorm:"not null"`
+	GranteeUserID   *uint      `json:"grantee_user_id" gorm:"index"`
+	Label           *string    `json:"label" gorm:"type:varchar(100)"`
+	HistoryMinutes  int        `json:"history_minutes" gorm:"default:0"`
+	ExpiresAt       time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt       *time.Time `json:"revoked_at"`
+	LastAccessedAt  *time.Time `json:"last_accessed_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relationships
+	Vehicle     Vehicle `json:"-" gorm:"foreignKey:VehicleID"`
+	GranteeUser *User   `json:"grantee_user,omitempty" gorm:"foreignKey:GranteeUserID"`
+}
+
+// TableName returns the table name for VehicleShare entity
+func (VehicleShare) TableName() string {
+	return "vehicle_shares"
+}
+
+// IsLink checks if the share is an anonymous signed link rather than a user grant
+func (s *VehicleShare) IsLink() bool {
+	return s.GranteeUserID == nil
+}
+
+// IsActive checks if the share can still be used
+func (s *VehicleShare) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook event types a subscription can filter on
+const (
+	WebhookEventLocationCreated = "location.created"
+	WebhookEventFuelCreated     = "fuel.created"
+	WebhookEventGeofenceExit    = "geofence.exit"
+	WebhookEventGeofenceEnter   = "geofence.enter"
+	WebhookEventAlertTriggered  = "alert.triggered"
+)
+
+// WebhookEventTypes lists every event type that can be subscribed to
+var WebhookEventTypes = []string{
+	WebhookEventLocationCreated,
+	WebhookEventFuelCreated,
+	WebhookEventGeofenceExit,
+	WebhookEventGeofenceEnter,
+	WebhookEventAlertTriggered,
+}
+
+// WebhookSubscription posts an organization's events of the selected types to an external URL
+type WebhookSubscription struct {
+	ID              uint           `json:"id" gorm:"primarykey"`
+	OrganizationID  uint           `json:"organization_id" gorm:"not null;index"`
+	CreatedByUserID uint           `json:"created_by_user_id" gorm:"not null"`
+	Name            string         `json:"name" gorm:"type:varchar(100);not null"`
+	URL             string         `json:"url" gorm:"type:varchar(500);not null"`
+	Secret          string         `json:"-" gorm:"type:varchar(64);not null"` // HMAC-SHA256 signing key
+	EventTypes      string         `json:"event_types" gorm:"type:varchar(255);not null"`
+	Enabled         bool           `json:"enabled" gorm:"not null;default:true"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// TableName returns the table name for WebhookSubscription entity
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// EventTypeList returns the subscribed event types
+func (s *WebhookSubscription) EventTypeList() []string {
+	return splitList(s.EventTypes)
+}
+
+// WebhookDeliveryStatus is the state of a queued webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // gave up after the last attempt
+)
+
+// WebhookDelivery is one event queued for one subscription, kept as the delivery log
+type WebhookDelivery struct {
+	ID             uint                  `json:"id" gorm:"primarykey"`
+	SubscriptionID uint                  `json:"subscription_id" gorm:"not null;index"`
+	EventID        string                `json:"event_id" gorm:"type:varchar(32);not null"`
+	EventType      string                `json:"event_type" gorm:"type:varchar(50);not null"`
+	Payload        string                `json:"-" gorm:"type:jsonb;not null"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"type:varchar(10);not null;default:pending"`
+	Attempts       int                   `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" gorm:"not null"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at"`
+	ResponseStatus *int                  `json:"response_status"`
+	LastError      *string               `json:"last_error" gorm:"type:text"`
+	DeliveredAt    *time.Time            `json:"delivered_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+
+	// Relationships
+	Subscription WebhookSubscription `json:"-" gorm:"foreignKey:SubscriptionID"`
+}
+
+// TableName returns the table name for WebhookDelivery entity
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,83 @@
+package dto
+
+import "time"
+
+// CreateAlertRuleRequest represents create alert rule request.
+// Without vehicle_id the rule watches every vehicle of the organization.
+// Threshold is minutes for offline, percent for low_fuel and km/h for overspeed;
+// geofence_exit needs the geofence fields and after_hours_use the working hours.
+type CreateAlertRuleRequest struct {
+	OrganizationID    *uint    `json:"organization_id,omitempty"`
+	VehicleID         *uint    `json:"vehicle_id,omitempty"`
+	Name              string   `json:"name" validate:"required,min=2,max=100"`
+	Type              string   `json:"type" validate:"required,oneof=offline low_fuel overspeed geofence_exit after_hours_use"`
+	Severity          string   `json:"severity,omitempty" validate:"omitempty,oneof=info warning critical"`
+	Threshold         *float64 `json:"threshold,omitempty" validate:"omitempty,gt=0"`
+	GeofenceLatitude  *float64 `json:"geofence_latitude,omitempty" validate:"omitempty,min=-90,max=90"`
+	GeofenceLongitude *float64 `json:"geofence_longitude,omitempty" validate:"omitempty,min=-180,max=180"`
+	GeofenceRadiusM   *float64 `json:"geofence_radius_m,omitempty" validate:"omitempty,gt=0"`
+	WorkingHoursStart string   `json:"working_hours_start,omitempty" validate:"omitempty,datetime=15:04"`
+	WorkingHoursEnd   string   `json:"working_hours_end,omitempty" validate:"omitempty,datetime=15:04"`
+	WorkingDays       []int    `json:"working_days,omitempty" validate:"omitempty,dive,min=1,max=7"` // ISO weekdays, defaults to Monday-Friday
+	Channels          []string `json:"channels" validate:"required,min=1,dive,oneof=in_app email webhook"`
+	EmailRecipients   []string `json:"email_recipients,omitempty" validate:"omitempty,dive,email"` // defaults to owners and managers
+	WebhookURL        string   `json:"webhook_url,omitempty" validate:"omitempty,url,max=500"`
+	CooldownMinutes   *int     `json:"cooldown_minutes,omitempty" validate:"omitempty,min=0"`
+	Enabled           *bool    `json:"enabled,omitempty"`
+}
+
+// UpdateAlertRuleRequest represents update alert rule request. The rule type and scope cannot change.
+type UpdateAlertRuleRequest struct {
+	Name              string   `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Severity          string   `json:"severity,omitempty" validate:"omitempty,oneof=info warning critical"`
+	Threshold         *float64 `json:"threshold,omitempty" validate:"omitempty,gt=0"`
+	GeofenceLatitude  *float64 `json:"geofence_latitude,omitempty" validate:"omitempty,min=-90,max=90"`
+	GeofenceLongitude *float64 `json:"geofence_longitude,omitempty" validate:"omitempty,min=-180,max=180"`
+	GeofenceRadiusM   *float64 `json:"geofence_radius_m,omitempty" validate:"omitempty,gt=0"`
+	WorkingHoursStart string   `json:"working_hours_start,omitempty" validate:"omitempty,datetime=15:04"`
+	WorkingHoursEnd   string   `json:"working_hours_end,omitempty" validate:"omitempty,datetime=15:04"`
+	WorkingDays       []int    `json:"working_days,omitempty" validate:"omitempty,dive,min=1,max=7"`
+	Channels          []string `json:"channels,omitempty" validate:"omitempty,min=1,dive,oneof=in_app email webhook"`
+	EmailRecipients   []string `json:"email_recipients,omitempty" validate:"omitempty,dive,email"`
+	WebhookURL        *string  `json:"webhook_url,omitempty" validate:"omitempty,max=500"`
+	CooldownMinutes   *int     `json:"cooldown_minutes,omitempty" validate:"omitempty,min=0"`
+	Enabled           *bool    `json:"enabled,omitempty"`
+}
+
+// AlertRuleResponse represents alert rule data in response
+type AlertRuleResponse struct {
+	ID                uint      `json:"id"`
+	OrganizationID    uint      `json:"organization_id"`
+	VehicleID         *uint     `json:"vehicle_id"`
+	Name              string    `json:"name"`
+	Type              string    `json:"type"`
+	Severity          string    `json:"severity"`
+	Threshold         *float64  `json:"threshold"`
+	GeofenceLatitude  *float64  `json:"geofence_latitude"`
+	GeofenceLongitude *float64  `json:"geofence_longitude"`
+	GeofenceRadiusM   *float64  `json:"geofence_radius_m"`
+	WorkingHoursStart *string   `json:"working_hours_start"`
+	WorkingHoursEnd   *string   `json:"working_hours_end"`
+	WorkingDays       []int     `json:"working_days"`
+	Channels          []string  `json:"channels"`
+	EmailRecipients   []string  `json:"email_recipients"`
+	WebhookURL        *string   `json:"webhook_url"`
+	CooldownMinutes   int       `json:"cooldown_minutes"`
+	Enabled           bool      `json:"enabled"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// AlertEventResponse represents a triggered alert in response
+type AlertEventResponse struct {
+	ID          uint      `json:"id"`
+	RuleID      uint      `json:"rule_id"`
+	RuleName    string    `json:"rule_name"`
+	VehicleID   uint      `json:"vehicle_id"`
+	PlateNumber string    `json:"plate_number"`
+	Type        string    `json:"type"`
+	Severity    string    `json:"severity"`
+	Message     string    `json:"message"`
+	Value       *float64  `json:"value"`
+	TriggeredAt time.Time `json:"triggered_at"`
+}
@@ -0,0 +1,90 @@
+package dto
+
+import "time"
+
+// DeviceSettings are the tracker settings a configuration profile carries
+type DeviceSettings struct {
+	ReportIntervalSeconds    int          `json:"report_interval_seconds" validate:"required,min=5,max=86400"`
+	HeartbeatIntervalSeconds int          `json:"heartbeat_interval_seconds,omitempty" validate:"omitempty,min=10,max=86400"`
+	SpeedThresholdKmh        float64      `json:"speed_threshold_kmh,omitempty" validate:"omitempty,min=0,max=300"` // below this the vehicle counts as stopped
+	APN                      *APNSettings `json:"apn,omitempty"`
+}
+
+// APNSettings are the cellular access point settings of a tracker
+type APNSettings struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	User     string `json:"user,omitempty" validate:"omitempty,max=100"`
+	Password string `json:"password,omitempty" validate:"omitempty,max=100"`
+}
+
+// CreateConfigProfileRequest represents create configuration profile request
+type CreateConfigProfileRequest struct {
+	OrganizationID *uint          `json:"organization_id,omitempty"`
+	Name           string         `json:"name" validate:"required,min=2,max=100"`
+	Description    string         `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Settings       DeviceSettings `json:"settings" validate:"required"`
+	IsDefault      bool           `json:"is_default,omitempty"`
+}
+
+// UpdateConfigProfileRequest represents update configuration profile request. New settings create a new version.
+type UpdateConfigProfileRequest struct {
+	Name        string          `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Description *string         `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Settings    *DeviceSettings `json:"settings,omitempty"`
+	IsDefault   *bool           `json:"is_default,omitempty"`
+}
+
+// AssignConfigProfileRequest represents the vehicles a configuration profile is assigned to
+type AssignConfigProfileRequest struct {
+	VehicleIDs []uint `json:"vehicle_ids" validate:"required,min=1"`
+}
+
+// ConfigProfileResponse represents configuration profile data in response
+type ConfigProfileResponse struct {
+	ID                 uint           `json:"id"`
+	OrganizationID     uint           `json:"organization_id"`
+	Name               string         `json:"name"`
+	Description        *string        `json:"description"`
+	Version            int            `json:"version"`
+	Settings           DeviceSettings `json:"settings"`
+	IsDefault          bool           `json:"is_default"`
+	AssignedVehicleIDs []uint         `json:"assigned_vehicle_ids,omitempty"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+}
+
+// ConfigProfileVersionResponse represents a configuration profile version in response
+type ConfigProfileVersionResponse struct {
+	Version         int            `json:"version"`
+	Settings        DeviceSettings `json:"settings"`
+	CreatedByUserID uint           `json:"created_by_user_id"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+// VehicleConfigResponse represents the effective configuration of a vehicle and what its device applied
+type VehicleConfigResponse struct {
+	VehicleID      uint            `json:"vehicle_id"`
+	ProfileID      *uint           `json:"profile_id"`
+	ProfileName    string          `json:"profile_name,omitempty"`
+	Source         string          `json:"source"` // assigned, default or none
+	Version        *int            `json:"version"`
+	Settings       *DeviceSettings `json:"settings,omitempty"`
+	AppliedProfile *uint           `json:"applied_profile_id"`
+	AppliedVersion *int            `json:"applied_version"`
+	AppliedAt      *time.Time      `json:"applied_at"`
+	InSync         bool            `json:"in_sync"`
+}
+
+// ESP32ConfigResponse represents the effective configuration handed to a tracker
+type ESP32ConfigResponse struct {
+	ProfileID uint           `json:"profile_id"`
+	Version   int            `json:"version"`
+	Settings  DeviceSettings `json:"settings"`
+}
+
+// ESP32ConfigAppliedRequest represents a tracker's confirmation that it applied a configuration
+type ESP32ConfigAppliedRequest struct {
+	VehicleID uint `json:"vehicle_id" validate:"required"`
+	ProfileID uint `json:"profile_id" validate:"required"`
+	Version   int  `json:"version" validate:"required,min=1"`
+}
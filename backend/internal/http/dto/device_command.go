@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateDeviceCommandRequest represents a command queued for a vehicle's tracker
+type CreateDeviceCommandRequest struct {
+	Type       string          `json:"type" validate:"required,oneof=set_report_interval reboot engine_cut engine_restore camera_snapshot"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	TTLSeconds int             `json:"ttl_seconds,omitempty" validate:"omitempty,min=10"` // defaults to COMMANDS_DEFAULT_TTL
+}
+
+// DeviceCommandResponse represents a device command in response
+type DeviceCommandResponse struct {
+	ID             uint            `json:"id"`
+	VehicleID      uint            `json:"vehicle_id"`
+	IssuedByUserID uint            `json:"issued_by_user_id"`
+	IssuedByName   string          `json:"issued_by_name,omitempty"`
+	Type           string          `json:"type"`
+	Payload        json.RawMessage `json:"payload,omitempty"`
+	Status         string          `json:"status"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CompletedAt    *time.Time      `json:"completed_at"`
+	Result         json.RawMessage `json:"result,omitempty"`
+	Error          *string         `json:"error"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// ESP32CommandResponse represents a command handed to a tracker
+type ESP32CommandResponse struct {
+	ID        uint            `json:"id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+// ESP32CommandResultRequest represents the outcome of a command reported by a tracker
+type ESP32CommandResultRequest struct {
+	Status string          `json:"status" validate:"required,oneof=acked failed"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty" validate:"omitempty,max=1000"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// ESP32HeartbeatRequest represents a device heartbeat
+type ESP32HeartbeatRequest struct {
+	VehicleID       uint   `json:"vehicle_id" validate:"required"`
+	FirmwareVersion string `json:"firmware_version,omitempty" validate:"omitempty,max=50"`
+	SignalStrength  *int   `json:"signal_strength,omitempty" validate:"omitempty,min=-150,max=0"` // RSSI in dBm
+}
+
+// ESP32HeartbeatResponse represents the answer to a device heartbeat
+type ESP32HeartbeatResponse struct {
+	ServerTime time.Time `json:"server_time"`
+}
+
+// VehicleStatusResponse represents the device status of a vehicle in response
+type VehicleStatusResponse struct {
+	VehicleID       uint       `json:"vehicle_id"`
+	PlateNumber     string     `json:"plate_number"`
+	Status          string     `json:"status"` // online, idle or offline
+	LastSeenAt      *time.Time `json:"last_seen_at"`
+	LastIP          *string    `json:"last_ip"`
+	FirmwareVersion *string    `json:"firmware_version"`
+	SignalStrength  *int       `json:"signal_strength"`
+	LastLocationAt  *time.Time `json:"last_location_at"`
+	LastMovingAt    *time.Time `json:"last_moving_at"`
+	ConfigProfileID *uint      `json:"config_profile_id"` // configuration last applied by the device
+	ConfigVersion   *int       `json:"config_version"`
+}
+
+// FleetStatusResponse represents the device status of every vehicle of the user's fleet
+type FleetStatusResponse struct {
+	Online   int                     `json:"online"`
+	Idle     int                     `json:"idle"`
+	Offline  int                     `json:"offline"`
+	Vehicles []VehicleStatusResponse `json:"vehicles"`
+}
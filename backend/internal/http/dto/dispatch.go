@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// NearestVehiclesRequest represents a search for the vehicles closest to a coordinate, such as a pickup.
+// MaxAgeSeconds leaves out vehicles whose latest position is older; MinFuelLevel those whose latest
+// fuel reading is lower or missing.
+type NearestVehiclesRequest struct {
+	Latitude      *float64 `query:"latitude" validate:"required,min=-90,max=90"`
+	Longitude     *float64 `query:"longitude" validate:"required,min=-180,max=180"`
+	Model         string   `query:"model" validate:"max=100"`
+	MinFuelLevel  *float64 `query:"min_fuel" validate:"omitempty,min=0,max=100"`
+	MaxAgeSeconds int      `query:"max_age" validate:"omitempty,min=1,max=2592000"`
+	Limit         int      `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// DispatchVehicleResponse represents a vehicle ranked by the distance of its latest position from a coordinate
+type DispatchVehicleResponse struct {
+	Rank               int        `json:"rank"`
+	VehicleID          uint       `json:"vehicle_id"`
+	PlateNumber        string     `json:"plate_number"`
+	Model              *string    `json:"model"`
+	DistanceMeters     float64    `json:"distance_m"`
+	Latitude           float64    `json:"latitude"`
+	Longitude          float64    `json:"longitude"`
+	Speed              *float64   `json:"speed"`
+	Ignition           *bool      `json:"ignition"`
+	PositionAt         time.Time  `json:"position_at"`
+	PositionAgeSeconds float64    `json:"position_age_seconds"`
+	Status             string     `json:"status"` // online, idle or offline
+	LastSeenAt         *time.Time `json:"last_seen_at"`
+	FuelLevel          *float64   `json:"fuel_level"`
+	FuelAt             *time.Time `json:"fuel_at"`
+}
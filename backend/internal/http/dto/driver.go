@@ -0,0 +1,59 @@
+package dto
+
+import "time"
+
+// CreateDriverRequest represents create driver request
+type CreateDriverRequest struct {
+	OrganizationID    *uint  `json:"organization_id,omitempty"`
+	Name              string `json:"name" validate:"required,min=2,max=100"`
+	LicenseNumber     string `json:"license_number" validate:"required,max=50"`
+	LicenseExpiryDate string `json:"license_expiry_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	PhoneNumber       string `json:"phone_number,omitempty" validate:"omitempty,max=20"`
+	IdentificationTag string `json:"identification_tag,omitempty" validate:"omitempty,max=64"`
+}
+
+// UpdateDriverRequest represents update driver request
+type UpdateDriverRequest struct {
+	Name              string  `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	LicenseNumber     string  `json:"license_number,omitempty" validate:"omitempty,max=50"`
+	LicenseExpiryDate string  `json:"license_expiry_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	PhoneNumber       *string `json:"phone_number,omitempty" validate:"omitempty,max=20"`
+	IdentificationTag *string `json:"identification_tag,omitempty" validate:"omitempty,max=64"`
+}
+
+// DriverResponse represents driver data in response
+type DriverResponse struct {
+	ID                uint       `json:"id"`
+	OrganizationID    uint       `json:"organization_id"`
+	Name              string     `json:"name"`
+	LicenseNumber     string     `json:"license_number"`
+	LicenseExpiryDate *time.Time `json:"license_expiry_date"`
+	LicenseExpired    bool       `json:"license_expired"`
+	PhoneNumber       *string    `json:"phone_number"`
+	IdentificationTag *string    `json:"identification_tag"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// DriverSummaryResponse represents the driver attributed to a record
+type DriverSummaryResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// AssignDriverRequest represents assign driver to vehicle request
+type AssignDriverRequest struct {
+	VehicleID uint       `json:"vehicle_id" validate:"required"`
+	StartAt   *time.Time `json:"start_at,omitempty"`
+}
+
+// DriverAssignmentResponse represents driver assignment data in response
+type DriverAssignmentResponse struct {
+	ID        uint                   `json:"id"`
+	DriverID  uint                   `json:"driver_id"`
+	VehicleID uint                   `json:"vehicle_id"`
+	StartAt   time.Time              `json:"start_at"`
+	EndAt     *time.Time             `json:"end_at"`
+	Source    string                 `json:"source"`
+	Driver    *DriverSummaryResponse `json:"driver,omitempty"`
+}
@@ -0,0 +1,100 @@
+package dto
+
+import "time"
+
+// CreateFirmwareReleaseRequest represents the form fields sent with a firmware upload
+type CreateFirmwareReleaseRequest struct {
+	OrganizationID *uint  `form:"organization_id"`
+	Version        string `form:"version" validate:"required,max=50"`
+	Board          string `form:"board" validate:"required,max=50"`
+	SHA256         string `form:"sha256" validate:"required,len=64,hexadecimal"`
+	Notes          string `form:"notes" validate:"omitempty,max=2000"`
+}
+
+// FirmwareReleaseResponse represents firmware release data in response
+type FirmwareReleaseResponse struct {
+	ID               uint      `json:"id"`
+	OrganizationID   uint      `json:"organization_id"`
+	Version          string    `json:"version"`
+	Board            string    `json:"board"`
+	FileSize         int64     `json:"file_size"`
+	SHA256           string    `json:"sha256"`
+	Notes            *string   `json:"notes"`
+	UploadedByUserID uint      `json:"uploaded_by_user_id"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// CreateFirmwareRolloutRequest represents create firmware rollout request.
+// Exactly one of VehicleIDs and Percentage selects the vehicles.
+type CreateFirmwareRolloutRequest struct {
+	ReleaseID  uint   `json:"release_id" validate:"required"`
+	Name       string `json:"name" validate:"required,min=2,max=100"`
+	VehicleIDs []uint `json:"vehicle_ids,omitempty" validate:"omitempty,min=1"`
+	Percentage *int   `json:"percentage,omitempty" validate:"omitempty,min=0,max=100"`
+	Enabled    *bool  `json:"enabled,omitempty"`
+}
+
+// UpdateFirmwareRolloutRequest represents update firmware rollout request.
+// Setting VehicleIDs turns the rollout into a vehicle list, setting Percentage into a percentage rollout.
+type UpdateFirmwareRolloutRequest struct {
+	Name       string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	VehicleIDs []uint `json:"vehicle_ids,omitempty" validate:"omitempty,min=1"`
+	Percentage *int   `json:"percentage,omitempty" validate:"omitempty,min=0,max=100"`
+	Enabled    *bool  `json:"enabled,omitempty"`
+}
+
+// FirmwareRolloutStats summarizes install reports of the vehicles a rollout targets
+type FirmwareRolloutStats struct {
+	Targeted  int `json:"targeted"`
+	Installed int `json:"installed"`
+	Failed    int `json:"failed"`  // reported only failures
+	Pending   int `json:"pending"` // not reported yet
+}
+
+// FirmwareRolloutResponse represents firmware rollout data in response
+type FirmwareRolloutResponse struct {
+	ID             uint                  `json:"id"`
+	OrganizationID uint                  `json:"organization_id"`
+	ReleaseID      uint                  `json:"release_id"`
+	Version        string                `json:"version"`
+	Board          string                `json:"board"`
+	Name           string                `json:"name"`
+	VehicleIDs     []uint                `json:"vehicle_ids,omitempty"`
+	Percentage     *int                  `json:"percentage,omitempty"`
+	Enabled        bool                  `json:"enabled"`
+	Stats          *FirmwareRolloutStats `json:"stats,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// FirmwareInstallResponse represents an install report in response
+type FirmwareInstallResponse struct {
+	ID              uint      `json:"id"`
+	VehicleID       uint      `json:"vehicle_id"`
+	ReleaseID       uint      `json:"release_id"`
+	Version         string    `json:"version"`
+	Status          string    `json:"status"`
+	PreviousVersion *string   `json:"previous_version"`
+	Error           *string   `json:"error"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ESP32FirmwareCheckResponse tells a tracker whether to update and where to download the release
+type ESP32FirmwareCheckResponse struct {
+	UpdateAvailable bool       `json:"update_available"`
+	ReleaseID       uint       `json:"release_id,omitempty"`
+	Version         string     `json:"version,omitempty"`
+	Size            int64      `json:"size,omitempty"`
+	SHA256          string     `json:"sha256,omitempty"`
+	URL             string     `json:"url,omitempty"`
+	URLExpiresAt    *time.Time `json:"url_expires_at,omitempty"`
+}
+
+// ESP32FirmwareReportRequest represents the install result reported by a tracker
+type ESP32FirmwareReportRequest struct {
+	VehicleID       uint   `json:"vehicle_id" validate:"required"`
+	ReleaseID       uint   `json:"release_id" validate:"required"`
+	Status          string `json:"status" validate:"required,oneof=installed failed"`
+	PreviousVersion string `json:"previous_version,omitempty" validate:"omitempty,max=50"`
+	Error           string `json:"error,omitempty" validate:"omitempty,max=1000"`
+}
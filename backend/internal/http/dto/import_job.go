@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// CreateImportJobRequest represents the form fields sent with a location history upload.
+// Format defaults to the file extension. ColumnMapping is a JSON object naming the CSV columns, e.g.
+// {"timestamp": "Time", "latitude": "Lat", "longitude": "Lng", "time_format": "unix", "speed_unit": "mps"}.
+type CreateImportJobRequest struct {
+	VehicleID     uint   `form:"vehicle_id" validate:"required"`
+	Format        string `form:"format" validate:"omitempty,oneof=csv gpx geojson json"`
+	ColumnMapping string `form:"column_mapping" validate:"omitempty,max=2000"`
+}
+
+// ImportRowError represents a row of an import file that was not imported
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportJobResponse represents import job data in response. Row errors are only listed on a single job.
+type ImportJobResponse struct {
+	ID              uint             `json:"id"`
+	OrganizationID  uint             `json:"organization_id"`
+	VehicleID       uint             `json:"vehicle_id"`
+	CreatedByUserID uint             `json:"created_by_user_id"`
+	Format          string           `json:"format"`
+	FileName        string           `json:"file_name"`
+	FileSize        int64            `json:"file_size"`
+	Status          string           `json:"status"`
+	Progress        int              `json:"progress"`
+	ProcessedRows   int              `json:"processed_rows"`
+	ImportedRows    int              `json:"imported_rows"`
+	DuplicateRows   int              `json:"duplicate_rows"`
+	InvalidRows     int              `json:"invalid_rows"`
+	RowErrors       []ImportRowError `json:"row_errors,omitempty"`
+	Error           *string          `json:"error"`
+	StartedAt       *time.Time       `json:"started_at"`
+	CompletedAt     *time.Time       `json:"completed_at"`
+	CreatedAt       time.Time        `json:"created_at"`
+}
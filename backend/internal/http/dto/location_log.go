@@ -2,13 +2,16 @@ package dto
 
 import "time"
 
-// CreateLocationLogRequest represents create location log request
+// CreateLocationLogRequest represents create location log request. Timestamp is when the device took the fix;
+// without it, or when it lies in the future, the time the position is received is used.
 type CreateLocationLogRequest struct {
-	VehicleID uint     `json:"vehicle_id" validate:"required"`
-	Latitude  float64  `json:"latitude" validate:"required,min=-90,max=90"`
-	Longitude float64  `json:"longitude" validate:"required,min=-180,max=180"`
-	Speed     *float64 `json:"speed,omitempty" validate:"omitempty,min=0"`
-	Direction *int16   `json:"direction,omitempty" validate:"omitempty,min=0,max=359"`
+	VehicleID uint       `json:"vehicle_id" validate:"required"`
+	Latitude  float64    `json:"latitude" validate:"required,min=-90,max=90"`
+	Longitude float64    `json:"longitude" validate:"required,min=-180,max=180"`
+	Speed     *float64   `json:"speed,omitempty" validate:"omitempty,min=0"`
+	Direction *int16     `json:"direction,omitempty" validate:"omitempty,min=0,max=359"`
+	Ignition  *bool      `json:"ignition,omitempty"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
 }
 
 // UpdateLocationLogRequest represents update location log request
@@ -21,16 +24,19 @@ type UpdateLocationLogRequest struct {
 
 // LocationLogResponse represents location log data in response
 type LocationLogResponse struct {
-	ID        uint             `json:"id"`
-	VehicleID uint             `json:"vehicle_id"`
-	Latitude  float64          `json:"latitude"`
-	Longitude float64          `json:"longitude"`
-	Speed     *float64         `json:"speed"`
-	Direction *int16           `json:"direction"`
-	Timestamp time.Time        `json:"timestamp"`
-	CreatedAt time.Time        `json:"created_at"`
-	UpdatedAt time.Time        `json:"updated_at"`
-	Vehicle   *VehicleResponse `json:"vehicle,omitempty"`
+	ID        uint                   `json:"id"`
+	VehicleID uint                   `json:"vehicle_id"`
+	Latitude  float64                `json:"latitude"`
+	Longitude float64                `json:"longitude"`
+	Speed     *float64               `json:"speed"`
+	Direction *int16                 `json:"direction"`
+	Ignition  *bool                  `json:"ignition"`
+	Suspect   bool                   `json:"suspect"`
+	Timestamp time.Time              `json:"timestamp"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	Vehicle   *VehicleResponse       `json:"vehicle,omitempty"`
+	Driver    *DriverSummaryResponse `json:"driver,omitempty"`
 }
 
 // LocationTrackingRequest represents real-time location tracking request
@@ -50,3 +56,39 @@ type LocationHistoryQuery struct {
 	Limit     int       `query:"limit" validate:"min=1,max=1000"`
 	Offset    int       `query:"offset" validate:"min=0"`
 }
+
+// AreaVisitsRequest represents a search for vehicles that reported positions inside an area during a period.
+// The area is exactly one of bounds, a center with radius_m, or a polygon of at least three vertices.
+// Without vehicle_ids every vehicle of the user's organizations is searched.
+type AreaVisitsRequest struct {
+	StartDate  time.Time   `json:"start_date" validate:"required"`
+	EndDate    time.Time   `json:"end_date" validate:"required"`
+	VehicleIDs []uint      `json:"vehicle_ids,omitempty"`
+	Bounds     *AreaBounds `json:"bounds,omitempty"`
+	Center     *AreaPoint  `json:"center,omitempty"`
+	RadiusM    *float64    `json:"radius_m,omitempty" validate:"omitempty,gt=0,max=100000"`
+	Polygon    []AreaPoint `json:"polygon,omitempty" validate:"omitempty,min=3,max=500,dive"`
+}
+
+// AreaBounds represents a bounding box in degrees
+type AreaBounds struct {
+	MinLatitude  float64 `json:"min_latitude" validate:"min=-90,max=90"`
+	MaxLatitude  float64 `json:"max_latitude" validate:"min=-90,max=90,gtefield=MinLatitude"`
+	MinLongitude float64 `json:"min_longitude" validate:"min=-180,max=180"`
+	MaxLongitude float64 `json:"max_longitude" validate:"min=-180,max=180,gtefield=MinLongitude"`
+}
+
+// AreaPoint represents a coordinate in degrees
+type AreaPoint struct {
+	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
+}
+
+// AreaVisitResponse represents the positions a vehicle reported inside an area
+type AreaVisitResponse struct {
+	VehicleID   uint      `json:"vehicle_id"`
+	PlateNumber string    `json:"plate_number"`
+	Positions   int64     `json:"positions"`
+	FirstAt     time.Time `json:"first_at"`
+	LastAt      time.Time `json:"last_at"`
+}
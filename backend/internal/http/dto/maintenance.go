@@ -0,0 +1,90 @@
+package dto
+
+import "time"
+
+// CreateMaintenancePlanRequest represents create maintenance plan request.
+// At least one of the intervals is required.
+type CreateMaintenancePlanRequest struct {
+	Name                string     `json:"name" validate:"required,min=2,max=100"`
+	Description         string     `json:"description,omitempty"`
+	IntervalKm          *float64   `json:"interval_km,omitempty" validate:"omitempty,gt=0"`
+	IntervalEngineHours *float64   `json:"interval_engine_hours,omitempty" validate:"omitempty,gt=0"`
+	IntervalDays        *int       `json:"interval_days,omitempty" validate:"omitempty,min=1"`
+	LastServiceAt       *time.Time `json:"last_service_at,omitempty"` // defaults to now
+}
+
+// UpdateMaintenancePlanRequest represents update maintenance plan request.
+// An interval of 0 removes that trigger.
+type UpdateMaintenancePlanRequest struct {
+	Name                string     `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Description         *string    `json:"description,omitempty"`
+	IntervalKm          *float64   `json:"interval_km,omitempty" validate:"omitempty,min=0"`
+	IntervalEngineHours *float64   `json:"interval_engine_hours,omitempty" validate:"omitempty,min=0"`
+	IntervalDays        *int       `json:"interval_days,omitempty" validate:"omitempty,min=0"`
+	LastServiceAt       *time.Time `json:"last_service_at,omitempty"`
+}
+
+// MaintenancePlanResponse represents maintenance plan data in response
+type MaintenancePlanResponse struct {
+	ID                  uint       `json:"id"`
+	VehicleID           uint       `json:"vehicle_id"`
+	Name                string     `json:"name"`
+	Description         *string    `json:"description"`
+	IntervalKm          *float64   `json:"interval_km"`
+	IntervalEngineHours *float64   `json:"interval_engine_hours"`
+	IntervalDays        *int       `json:"interval_days"`
+	LastServiceAt       time.Time  `json:"last_service_at"`
+	NextDueDate         *time.Time `json:"next_due_date"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// MaintenanceStatusResponse represents how far a vehicle is from the next service of a plan
+type MaintenanceStatusResponse struct {
+	PlanID                  uint       `json:"plan_id"`
+	VehicleID               uint       `json:"vehicle_id"`
+	PlateNumber             string     `json:"plate_number,omitempty"`
+	Name                    string     `json:"name"`
+	Status                  string     `json:"status"` // ok, upcoming or overdue
+	LastServiceAt           time.Time  `json:"last_service_at"`
+	DistanceSinceServiceKm  float64    `json:"distance_since_service_km"`
+	EngineHoursSinceService float64    `json:"engine_hours_since_service"`
+	RemainingKm             *float64   `json:"remaining_km"`
+	RemainingEngineHours    *float64   `json:"remaining_engine_hours"`
+	DueDate                 *time.Time `json:"due_date"`
+}
+
+// CreateServiceRecordRequest represents create service record request
+type CreateServiceRecordRequest struct {
+	PlanID      *uint      `json:"plan_id,omitempty"` // completes the plan and starts its next cycle
+	Description string     `json:"description" validate:"required,max=255"`
+	ServicedAt  *time.Time `json:"serviced_at,omitempty"`  // defaults to now
+	OdometerKm  *float64   `json:"odometer_km,omitempty"`  // defaults to the location-derived odometer
+	EngineHours *float64   `json:"engine_hours,omitempty"` // defaults to the location-derived engine hours
+	Cost        float64    `json:"cost" validate:"min=0"`
+	Workshop    string     `json:"workshop,omitempty" validate:"omitempty,max=100"`
+	Notes       string     `json:"notes,omitempty"`
+}
+
+// ServiceRecordResponse represents service record data in response
+type ServiceRecordResponse struct {
+	ID          uint      `json:"id"`
+	VehicleID   uint      `json:"vehicle_id"`
+	PlanID      *uint     `json:"plan_id"`
+	PlanName    *string   `json:"plan_name,omitempty"`
+	Description string    `json:"description"`
+	ServicedAt  time.Time `json:"serviced_at"`
+	OdometerKm  *float64  `json:"odometer_km"`
+	EngineHours *float64  `json:"engine_hours"`
+	Cost        float64   `json:"cost"`
+	Workshop    *string   `json:"workshop"`
+	Notes       *string   `json:"notes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// VehicleUsageResponse represents the location-derived odometer and engine hours of a vehicle
+type VehicleUsageResponse struct {
+	VehicleID   uint    `json:"vehicle_id"`
+	DistanceKm  float64 `json:"distance_km"`
+	EngineHours float64 `json:"engine_hours"`
+}
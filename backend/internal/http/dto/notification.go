@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// NotificationResponse represents notification data in response
+type NotificationResponse struct {
+	ID           uint       `json:"id"`
+	VehicleID    *uint      `json:"vehicle_id"`
+	PlateNumber  string     `json:"plate_number,omitempty"`
+	AlertEventID *uint      `json:"alert_event_id"`
+	Severity     string     `json:"severity"`
+	Title        string     `json:"title"`
+	Message      string     `json:"message"`
+	Read         bool       `json:"read"`
+	ReadAt       *time.Time `json:"read_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// UnreadCountResponse represents the number of unread notifications
+type UnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// MarkAllReadResponse represents how many notifications were marked read
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+// Notification stream event types
+const (
+	NotificationEventCreated     = "notification"
+	NotificationEventUnreadCount = "unread_count"
+)
+
+// NotificationEvent is pushed to a user's live notification stream.
+// Notification is set for new notifications; UnreadCount is always the user's current count.
+type NotificationEvent struct {
+	Type         string                `json:"type"`
+	Notification *NotificationResponse `json:"notification,omitempty"`
+	UnreadCount  int64                 `json:"unread_count"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+// CreateOrganizationRequest represents create organization request
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+// UpdateOrganizationRequest represents update organization request
+type UpdateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+// InviteOrganizationMemberRequest represents invite member request
+type InviteOrganizationMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=owner manager viewer driver"`
+}
+
+// UpdateOrganizationMemberRequest represents change member role request
+type UpdateOrganizationMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner manager viewer driver"`
+}
+
+// OrganizationResponse represents organization data in response
+type OrganizationResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	MyRole    string    `json:"my_role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrganizationMemberResponse represents organization member data in response
+type OrganizationMemberResponse struct {
+	ID             uint          `json:"id"`
+	OrganizationID uint          `json:"organization_id"`
+	UserID         uint          `json:"user_id"`
+	Role           string        `json:"role"`
+	CreatedAt      time.Time     `json:"created_at"`
+	User           *UserResponse `json:"user,omitempty"`
+}
+
+// OrganizationInvitationResponse represents organization invitation data in response
+type OrganizationInvitationResponse struct {
+	ID               uint      `json:"id"`
+	OrganizationID   uint      `json:"organization_id"`
+	OrganizationName string    `json:"organization_name,omitempty"`
+	Email            string    `json:"email"`
+	Role             string    `json:"role"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	CreatedAt        time.Time `json:"created_at"`
+}
@@ -0,0 +1,71 @@
+package dto
+
+import "time"
+
+// CreatePOIRequest represents create point of interest request. Without a radius the configured default is used.
+type CreatePOIRequest struct {
+	OrganizationID *uint    `json:"organization_id,omitempty"`
+	Name           string   `json:"name" validate:"required,min=1,max=100"`
+	Category       string   `json:"category,omitempty" validate:"omitempty,max=50"`
+	Address        string   `json:"address,omitempty" validate:"omitempty,max=255"`
+	Latitude       *float64 `json:"latitude" validate:"required,min=-90,max=90"`
+	Longitude      *float64 `json:"longitude" validate:"required,min=-180,max=180"`
+	RadiusM        *float64 `json:"radius_m,omitempty" validate:"omitempty,gt=0"`
+}
+
+// UpdatePOIRequest represents update point of interest request. An empty category or address clears it.
+type UpdatePOIRequest struct {
+	Name      string   `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Category  *string  `json:"category,omitempty" validate:"omitempty,max=50"`
+	Address   *string  `json:"address,omitempty" validate:"omitempty,max=255"`
+	Latitude  *float64 `json:"latitude,omitempty" validate:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude,omitempty" validate:"omitempty,min=-180,max=180"`
+	RadiusM   *float64 `json:"radius_m,omitempty" validate:"omitempty,gt=0"`
+}
+
+// ImportPOIsRequest represents the form fields sent with a POI CSV upload
+type ImportPOIsRequest struct {
+	OrganizationID *uint `form:"organization_id"`
+}
+
+// POIResponse represents point of interest data in response
+type POIResponse struct {
+	ID             uint      `json:"id"`
+	OrganizationID uint      `json:"organization_id"`
+	Name           string    `json:"name"`
+	Category       *string   `json:"category"`
+	Address        *string   `json:"address"`
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	RadiusM        float64   `json:"radius_m"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// POIImportResponse represents the outcome of a POI CSV import. Rows are matched to existing POIs by name.
+type POIImportResponse struct {
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+// NearbyPOIResponse represents a point of interest near a position. Inside tells whether the position
+// lies within the POI's radius.
+type NearbyPOIResponse struct {
+	POIResponse
+	DistanceMeters float64 `json:"distance_m"`
+	Inside         bool    `json:"inside"`
+}
+
+// NearbyVehicleResponse represents a vehicle ranked by the distance of its latest trusted position
+type NearbyVehicleResponse struct {
+	VehicleID      uint      `json:"vehicle_id"`
+	PlateNumber    string    `json:"plate_number"`
+	Model          *string   `json:"model"`
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	Speed          *float64  `json:"speed"`
+	Ignition       *bool     `json:"ignition"`
+	Timestamp      time.Time `json:"timestamp"`
+	DistanceMeters float64   `json:"distance_m"`
+}
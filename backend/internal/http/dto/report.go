@@ -0,0 +1,69 @@
+package dto
+
+import "time"
+
+// CreateReportRequest represents create report definition request.
+// Hour is the local hour the report is generated at; weekly reports also need weekday (ISO, 1 = Monday)
+// and monthly reports day_of_month. Without recipients the report is emailed to owners and managers.
+type CreateReportRequest struct {
+	OrganizationID *uint    `json:"organization_id,omitempty"`
+	Name           string   `json:"name" validate:"required,min=2,max=100"`
+	Type           string   `json:"type" validate:"required,oneof=daily_activity weekly_distance fuel_usage violations"`
+	Format         string   `json:"format" validate:"required,oneof=pdf csv"`
+	Frequency      string   `json:"frequency" validate:"required,oneof=daily weekly monthly"`
+	Hour           *int     `json:"hour,omitempty" validate:"omitempty,min=0,max=23"`
+	Weekday        *int     `json:"weekday,omitempty" validate:"omitempty,min=1,max=7"`
+	DayOfMonth     *int     `json:"day_of_month,omitempty" validate:"omitempty,min=1,max=28"`
+	Recipients     []string `json:"recipients,omitempty" validate:"omitempty,dive,email"`
+	Enabled        *bool    `json:"enabled,omitempty"`
+}
+
+// UpdateReportRequest represents update report definition request. The organization cannot change.
+type UpdateReportRequest struct {
+	Name       string   `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Type       string   `json:"type,omitempty" validate:"omitempty,oneof=daily_activity weekly_distance fuel_usage violations"`
+	Format     string   `json:"format,omitempty" validate:"omitempty,oneof=pdf csv"`
+	Frequency  string   `json:"frequency,omitempty" validate:"omitempty,oneof=daily weekly monthly"`
+	Hour       *int     `json:"hour,omitempty" validate:"omitempty,min=0,max=23"`
+	Weekday    *int     `json:"weekday,omitempty" validate:"omitempty,min=1,max=7"`
+	DayOfMonth *int     `json:"day_of_month,omitempty" validate:"omitempty,min=1,max=28"`
+	Recipients []string `json:"recipients,omitempty" validate:"omitempty,dive,email"`
+	Enabled    *bool    `json:"enabled,omitempty"`
+}
+
+// ReportResponse represents report definition data in response
+type ReportResponse struct {
+	ID             uint       `json:"id"`
+	OrganizationID uint       `json:"organization_id"`
+	Name           string     `json:"name"`
+	Type           string     `json:"type"`
+	Format         string     `json:"format"`
+	Frequency      string     `json:"frequency"`
+	Hour           int        `json:"hour"`
+	Weekday        int        `json:"weekday"`
+	DayOfMonth     int        `json:"day_of_month"`
+	Recipients     []string   `json:"recipients"`
+	Enabled        bool       `json:"enabled"`
+	NextRunAt      time.Time  `json:"next_run_at"`
+	LastRunAt      *time.Time `json:"last_run_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ReportRunResponse represents a generated report in response
+type ReportRunResponse struct {
+	ID                uint       `json:"id"`
+	DefinitionID      uint       `json:"definition_id"`
+	TriggeredByUserID *uint      `json:"triggered_by_user_id"`
+	Status            string     `json:"status"`
+	PeriodStart       time.Time  `json:"period_start"`
+	PeriodEnd         time.Time  `json:"period_end"`
+	Format            string     `json:"format"`
+	FileName          *string    `json:"file_name"`
+	FileSize          int64      `json:"file_size"`
+	Error             *string    `json:"error"`
+	EmailedAt         *time.Time `json:"emailed_at"`
+	EmailError        *string    `json:"email_error"`
+	CompletedAt       *time.Time `json:"completed_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// CreateRoleRequest represents create role request
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=50"`
+	Description *string  `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateRoleRequest represents update role request.
+// Permissions replaces the role's permissions when present.
+type UpdateRoleRequest struct {
+	Description *string  `json:"description,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// AssignRoleRequest represents change user role request
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+// RoleResponse represents role data in response
+type RoleResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	IsSystem    bool      `json:"is_system"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// VehicleStopsResponse represents the stops of a vehicle during a period with their totals
+type VehicleStopsResponse struct {
+	VehicleID         uint                  `json:"vehicle_id"`
+	PeriodStart       time.Time             `json:"period_start"`
+	PeriodEnd         time.Time             `json:"period_end"`
+	RadiusMeters      float64               `json:"radius_m"`
+	MinDurationSecs   float64               `json:"min_duration_seconds"`
+	TotalStops        int                   `json:"total_stops"`
+	TotalDwellSeconds float64               `json:"total_dwell_seconds"`
+	TotalIdleSeconds  float64               `json:"total_idle_seconds"`
+	Stops             []VehicleStopResponse `json:"stops"`
+}
+
+// VehicleStopResponse represents a stretch of time a vehicle stayed in one place.
+// Kind is parked, idling or unknown when the tracker reports no ignition state.
+type VehicleStopResponse struct {
+	Start           time.Time          `json:"start"`
+	End             time.Time          `json:"end"`
+	DurationSeconds float64            `json:"duration_seconds"`
+	IdleSeconds     float64            `json:"idle_seconds"`
+	Kind            string             `json:"kind"`
+	Latitude        float64            `json:"latitude"`
+	Longitude       float64            `json:"longitude"`
+	Positions       int                `json:"positions"`
+	Place           *StopPlaceResponse `json:"place,omitempty"`
+}
+
+// StopPlaceResponse represents a known place a stop lies in, such as a geofence
+type StopPlaceResponse struct {
+	Type           string  `json:"type"`
+	ID             uint    `json:"id"`
+	Name           string  `json:"name"`
+	DistanceMeters float64 `json:"distance_m"`
+}
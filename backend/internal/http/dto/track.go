@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// TrackPlaybackResponse represents a vehicle track resampled to a fixed time step for animated playback.
+// Frames run from the first to the last position of the period.
+type TrackPlaybackResponse struct {
+	VehicleID   uint                 `json:"vehicle_id"`
+	PeriodStart time.Time            `json:"period_start"`
+	PeriodEnd   time.Time            `json:"period_end"`
+	StepSeconds float64              `json:"step_seconds"`
+	DistanceKm  float64              `json:"distance_km"`
+	Frames      []TrackPlaybackFrame `json:"frames"`
+	Stops       []TrackPlaybackSpan  `json:"stops"`
+	Gaps        []TrackPlaybackSpan  `json:"gaps"`
+}
+
+// TrackPlaybackFrame represents the interpolated state of a vehicle at one time step.
+// State is moving, stopped or gap; distance_km is cumulative from the first frame.
+type TrackPlaybackFrame struct {
+	Time       time.Time `json:"time"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	Speed      float64   `json:"speed"`
+	Heading    float64   `json:"heading"`
+	State      string    `json:"state"`
+	DistanceKm float64   `json:"distance_km"`
+	Ignition   *bool     `json:"ignition"`
+}
+
+// TrackPlaybackSpan represents a stop or a gap in a track, with the position the vehicle was last seen at.
+// Stops within a POI or geofence name it as their place.
+type TrackPlaybackSpan struct {
+	Start           time.Time          `json:"start"`
+	End             time.Time          `json:"end"`
+	DurationSeconds float64            `json:"duration_seconds"`
+	Latitude        float64            `json:"latitude"`
+	Longitude       float64            `json:"longitude"`
+	Place           *StopPlaceResponse `json:"place,omitempty"`
+}
@@ -16,24 +16,38 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
-// LoginResponse represents login response with tokens
+// LoginResponse represents login response with tokens.
+// When MFARequired is set only MFAToken is returned and must be exchanged via auth/login/mfa.
 type LoginResponse struct {
-	User         UserResponse `json:"user"`
-	AccessToken  string       `json:"access_token"`
-	RefreshToken string       `json:"refresh_token"`
-	TokenType    string       `json:"token_type"`
-	ExpiresIn    int          `json:"expires_in"`
+	User                  *UserResponse `json:"user,omitempty"`
+	AccessToken           string        `json:"access_token,omitempty"`
+	RefreshToken          string        `json:"refresh_token,omitempty"`
+	TokenType             string        `json:"token_type"`
+	ExpiresIn             int           `json:"expires_in"`
+	MFARequired           bool          `json:"mfa_required,omitempty"`
+	MFAToken              string        `json:"mfa_token,omitempty"`
+	MFAEnrollmentRequired bool          `json:"mfa_enrollment_required,omitempty"`
+}
+
+// MFALoginRequest represents the second step of a two-factor login
+type MFALoginRequest struct {
+	MFAToken     string `json:"mfa_token" validate:"required"`
+	Code         string `json:"code,omitempty" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code,omitempty" validate:"required_without=Code"`
 }
 
 // UserResponse represents user data in response
 type UserResponse struct {
-	ID          uint      `json:"id"`
-	Name        string    `json:"name"`
-	Email       string    `json:"email"`
-	PhoneNumber *string   `json:"phone_number"`
-	Role        string    `json:"role"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID               uint       `json:"id"`
+	Name             string     `json:"name"`
+	Email            string     `json:"email"`
+	PhoneNumber      *string    `json:"phone_number"`
+	Role             string     `json:"role"`
+	EmailVerifiedAt  *time.Time `json:"email_verified_at"`
+	LockedUntil      *time.Time `json:"locked_until,omitempty"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 // UpdateUserRequest represents user update request
@@ -59,3 +73,19 @@ type RefreshTokenResponse struct {
 	TokenType   string `json:"token_type"`
 	ExpiresIn   int    `json:"expires_in"`
 }
+
+// ForgotPasswordRequest represents forgot password request
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest represents reset password request
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6"`
+}
+
+// VerifyEmailRequest represents email verification request
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
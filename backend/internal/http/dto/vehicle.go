@@ -4,9 +4,10 @@ import "time"
 
 // CreateVehicleRequest represents create vehicle request
 type CreateVehicleRequest struct {
-	PlateNumber string `json:"plate_number" validate:"required,min=1,max=20"`
-	Model       string `json:"model,omitempty" validate:"omitempty,max=100"`
-	IMEI        string `json:"imei,omitempty" validate:"omitempty,max=50"`
+	OrganizationID *uint  `json:"organization_id,omitempty"`
+	PlateNumber    string `json:"plate_number" validate:"required,min=1,max=20"`
+	Model          string `json:"model,omitempty" validate:"omitempty,max=100"`
+	IMEI           string `json:"imei,omitempty" validate:"omitempty,max=50"`
 }
 
 // UpdateVehicleRequest represents update vehicle request
@@ -18,20 +19,22 @@ type UpdateVehicleRequest struct {
 
 // VehicleResponse represents vehicle data in response
 type VehicleResponse struct {
-	ID          uint          `json:"id"`
-	UserID      uint          `json:"user_id"`
-	PlateNumber string        `json:"plate_number"`
-	Model       *string       `json:"model"`
-	IMEI        *string       `json:"imei"`
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
-	User        *UserResponse `json:"user,omitempty"`
+	ID             uint          `json:"id"`
+	UserID         uint          `json:"user_id"`
+	OrganizationID uint          `json:"organization_id"`
+	PlateNumber    string        `json:"plate_number"`
+	Model          *string       `json:"model"`
+	IMEI           *string       `json:"imei"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+	User           *UserResponse `json:"user,omitempty"`
 }
 
 // VehicleWithLocationResponse represents vehicle with latest location
 type VehicleWithLocationResponse struct {
 	ID             uint                 `json:"id"`
 	UserID         uint                 `json:"user_id"`
+	OrganizationID uint                 `json:"organization_id"`
 	PlateNumber    string               `json:"plate_number"`
 	Model          *string              `json:"model"`
 	IMEI           *string              `json:"imei"`
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// CreateVehicleDocumentRequest represents create vehicle document request
+type CreateVehicleDocumentRequest struct {
+	Type      string `json:"type" validate:"required,oneof=registration inspection insurance other"`
+	Number    string `json:"number" validate:"required,max=100"`
+	Issuer    string `json:"issuer,omitempty" validate:"omitempty,max=100"`
+	IssuedAt  string `json:"issued_at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	ExpiresAt string `json:"expires_at" validate:"required,datetime=2006-01-02"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+// UpdateVehicleDocumentRequest represents update vehicle document request
+type UpdateVehicleDocumentRequest struct {
+	Type      string  `json:"type,omitempty" validate:"omitempty,oneof=registration inspection insurance other"`
+	Number    string  `json:"number,omitempty" validate:"omitempty,max=100"`
+	Issuer    *string `json:"issuer,omitempty" validate:"omitempty,max=100"`
+	IssuedAt  string  `json:"issued_at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	ExpiresAt string  `json:"expires_at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Notes     *string `json:"notes,omitempty"`
+}
+
+// VehicleDocumentResponse represents vehicle document data in response
+type VehicleDocumentResponse struct {
+	ID              uint       `json:"id"`
+	VehicleID       uint       `json:"vehicle_id"`
+	PlateNumber     string     `json:"plate_number,omitempty"`
+	Type            string     `json:"type"`
+	Number          string     `json:"number"`
+	Issuer          *string    `json:"issuer"`
+	IssuedAt        *time.Time `json:"issued_at"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	DaysUntilExpiry int        `json:"days_until_expiry"`
+	Status          string     `json:"status"` // valid, expiring or expired
+	Notes           *string    `json:"notes"`
+	HasFile         bool       `json:"has_file"`
+	FileName        *string    `json:"file_name"`
+	FileContentType *string    `json:"file_content_type"`
+	FileSize        *int64     `json:"file_size"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+// CreateVehicleShareRequest represents create vehicle share request.
+// Leave GranteeEmail empty to create an anonymous signed link.
+// Either ExpiresAt or ExpiresInMinutes must be set.
+type CreateVehicleShareRequest struct {
+	GranteeEmail     string     `json:"grantee_email,omitempty" validate:"omitempty,email"`
+	Label            string     `json:"label,omitempty" validate:"omitempty,max=100"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	ExpiresInMinutes int        `json:"expires_in_minutes,omitempty" validate:"omitempty,min=1"`
+	HistoryMinutes   int        `json:"history_minutes,omitempty" validate:"omitempty,min=0,max=10080"`
+}
+
+// VehicleShareResponse represents vehicle share data in response
+type VehicleShareResponse struct {
+	ID             uint       `json:"id"`
+	VehicleID      uint       `json:"vehicle_id"`
+	Type           string     `json:"type"`
+	GranteeUserID  *uint      `json:"grantee_user_id,omitempty"`
+	GranteeEmail   *string    `json:"grantee_email,omitempty"`
+	Label          *string    `json:"label"`
+	HistoryMinutes int        `json:"history_minutes"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	LastAccessedAt *time.Time `json:"last_accessed_at"`
+	Active         bool       `json:"active"`
+	Token          string     `json:"token,omitempty"`
+	URL            string     `json:"url,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// SharedVehicleResponse represents a share granted to the current user
+type SharedVehicleResponse struct {
+	ShareID        uint      `json:"share_id"`
+	Label          *string   `json:"label"`
+	HistoryMinutes int       `json:"history_minutes"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// SharedPositionResponse represents a position visible through a share
+type SharedPositionResponse struct {
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	Speed     *float64  `json:"speed"`
+	Direction *int16    `json:"direction"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SharedLocationResponse represents the location data exposed by a share
+type SharedLocationResponse struct {
+	Label     *string                  `json:"label"`
+	ExpiresAt time.Time                `json:"expires_at"`
+	Latest    *SharedPositionResponse  `json:"latest"`
+	History   []SharedPositionResponse `json:"history,omitempty"`
+}
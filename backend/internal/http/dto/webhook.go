@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateWebhookRequest represents create webhook subscription request
+type CreateWebhookRequest struct {
+	OrganizationID *uint    `json:"organization_id,omitempty"`
+	Name           string   `json:"name" validate:"required,min=2,max=100"`
+	URL            string   `json:"url" validate:"required,url,max=500"`
+	EventTypes     []string `json:"event_types" validate:"required,min=1,dive,oneof=location.created fuel.created geofence.exit geofence.enter alert.triggered"`
+	Enabled        *bool    `json:"enabled,omitempty"`
+}
+
+// UpdateWebhookRequest represents update webhook subscription request
+type UpdateWebhookRequest struct {
+	Name       string   `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	URL        string   `json:"url,omitempty" validate:"omitempty,url,max=500"`
+	EventTypes []string `json:"event_types,omitempty" validate:"omitempty,min=1,dive,oneof=location.created fuel.created geofence.exit geofence.enter alert.triggered"`
+	Enabled    *bool    `json:"enabled,omitempty"`
+}
+
+// WebhookResponse represents webhook subscription data in response.
+// Secret is only returned when the subscription is created or its secret is rotated.
+type WebhookResponse struct {
+	ID             uint      `json:"id"`
+	OrganizationID uint      `json:"organization_id"`
+	Name           string    `json:"name"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"event_types"`
+	Enabled        bool      `json:"enabled"`
+	Secret         string    `json:"secret,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// WebhookDeliveryResponse represents a queued or attempted webhook delivery in response
+type WebhookDeliveryResponse struct {
+	ID             uint            `json:"id"`
+	SubscriptionID uint            `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at"`
+	ResponseStatus *int            `json:"response_status"`
+	LastError      *string         `json:"last_error"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Payload        json.RawMessage `json:"payload,omitempty"`
+}
+
+// WebhookEvent is the JSON body posted to webhook subscribers
+type WebhookEvent struct {
+	ID             string      `json:"id"`
+	Type           string      `json:"type"`
+	OrganizationID uint        `json:"organization_id"`
+	CreatedAt      time.Time   `json:"created_at"`
+	Data           interface{} `json:"data"`
+}
+
+// GeofenceCrossingEvent is the data of geofence.exit and geofence.enter webhook events
+type GeofenceCrossingEvent struct {
+	RuleID      uint      `json:"rule_id"`
+	RuleName    string    `json:"rule_name"`
+	VehicleID   uint      `json:"vehicle_id"`
+	PlateNumber string    `json:"plate_number"`
+	Latitude    float64   `json:"geofence_latitude"`
+	Longitude   float64   `json:"geofence_longitude"`
+	RadiusM     float64   `json:"geofence_radius_m"`
+	DistanceM   *float64  `json:"distance_m"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// AlertHandler defines alert handler interface
+type AlertHandler interface {
+	CreateRule(c echo.Context) error
+	GetRules(c echo.Context) error
+	GetRule(c echo.Context) error
+	UpdateRule(c echo.Context) error
+	DeleteRule(c echo.Context) error
+	GetEvents(c echo.Context) error
+	GetGeofenceVehicles(c echo.Context) error
+}
+
+// alertHandler implements AlertHandler interface
+type alertHandler struct {
+	alertService service.AlertService
+}
+
+// NewAlertHandler creates new alert handler instance
+func NewAlertHandler(alertService service.AlertService) AlertHandler {
+	return &alertHandler{
+		alertService: alertService,
+	}
+}
+
+// CreateRule creates an alert rule
+func (h *alertHandler) CreateRule(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.CreateAlertRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	rule, err := h.alertService.CreateRule(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Alert rule created successfully", rule)
+}
+
+// GetRules gets alert rules of the user's organizations
+func (h *alertHandler) GetRules(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	rules, err := h.alertService.GetRules(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get alert rules", nil)
+	}
+
+	return response.Success(c, "Alert rules retrieved successfully", rules)
+}
+
+// GetRule gets an alert rule by ID
+func (h *alertHandler) GetRule(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid alert rule ID", nil)
+	}
+
+	rule, err := h.alertService.GetRule(userID, uint(ruleID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Alert rule retrieved successfully", rule)
+}
+
+// GetGeofenceVehicles gets the vehicles currently inside the geofence of an alert rule
+func (h *alertHandler) GetGeofenceVehicles(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid alert rule ID", nil)
+	}
+
+	vehicles, err := h.alertService.GetGeofenceVehicles(userID, uint(ruleID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Vehicles inside geofence retrieved successfully", vehicles)
+}
+
+// UpdateRule updates an alert rule
+func (h *alertHandler) UpdateRule(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid alert rule ID", nil)
+	}
+
+	var req dto.UpdateAlertRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	rule, err := h.alertService.UpdateRule(userID, uint(ruleID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Alert rule updated successfully", rule)
+}
+
+// DeleteRule deletes an alert rule
+func (h *alertHandler) DeleteRule(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid alert rule ID", nil)
+	}
+
+	if err := h.alertService.DeleteRule(userID, uint(ruleID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Alert rule deleted successfully", nil)
+}
+
+// GetEvents gets triggered alerts, optionally filtered by vehicle_id and start_date
+func (h *alertHandler) GetEvents(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var vehicleID *uint
+	if vehicleIDStr := c.QueryParam("vehicle_id"); vehicleIDStr != "" {
+		id, err := strconv.ParseUint(vehicleIDStr, 10, 32)
+		if err != nil {
+			return response.BadRequest(c, "Invalid vehicle ID", nil)
+		}
+		value := uint(id)
+		vehicleID = &value
+	}
+
+	var since *time.Time
+	if startDateStr := c.QueryParam("start_date"); startDateStr != "" {
+		startDate, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			return response.BadRequest(c, "Invalid start_date format. Use YYYY-MM-DD", nil)
+		}
+		since = &startDate
+	}
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	events, total, err := h.alertService.GetEvents(userID, vehicleID, since, limit, offset)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("Alerts retrieved successfully", events, page, perPage, total))
+}
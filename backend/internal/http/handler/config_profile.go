@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// ConfigProfileHandler defines configuration profile handler interface
+type ConfigProfileHandler interface {
+	Create(c echo.Context) error
+	GetMyProfiles(c echo.Context) error
+	GetByID(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	GetVersions(c echo.Context) error
+	AssignVehicles(c echo.Context) error
+	GetVehicleConfig(c echo.Context) error
+	UnassignVehicle(c echo.Context) error
+}
+
+// configProfileHandler implements ConfigProfileHandler interface
+type configProfileHandler struct {
+	configProfileService service.ConfigProfileService
+}
+
+// NewConfigProfileHandler creates new configuration profile handler instance
+func NewConfigProfileHandler(configProfileService service.ConfigProfileService) ConfigProfileHandler {
+	return &configProfileHandler{
+		configProfileService: configProfileService,
+	}
+}
+
+// Create creates a configuration profile
+func (h *configProfileHandler) Create(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.CreateConfigProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	profile, err := h.configProfileService.Create(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Configuration profile created successfully", profile)
+}
+
+// GetMyProfiles gets configuration profiles of the user's organizations
+func (h *configProfileHandler) GetMyProfiles(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	profiles, err := h.configProfileService.GetMyProfiles(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get configuration profiles", nil)
+	}
+
+	return response.Success(c, "Configuration profiles retrieved successfully", profiles)
+}
+
+// GetByID gets a configuration profile by ID
+func (h *configProfileHandler) GetByID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	profileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid profile ID", nil)
+	}
+
+	profile, err := h.configProfileService.GetByID(userID, uint(profileID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Configuration profile retrieved successfully", profile)
+}
+
+// Update updates a configuration profile
+func (h *configProfileHandler) Update(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	profileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid profile ID", nil)
+	}
+
+	var req dto.UpdateConfigProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	profile, err := h.configProfileService.Update(userID, uint(profileID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Configuration profile updated successfully", profile)
+}
+
+// Delete deletes a configuration profile
+func (h *configProfileHandler) Delete(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	profileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid profile ID", nil)
+	}
+
+	if err := h.configProfileService.Delete(userID, uint(profileID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Configuration profile deleted successfully", nil)
+}
+
+// GetVersions gets the version history of a configuration profile
+func (h *configProfileHandler) GetVersions(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	profileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid profile ID", nil)
+	}
+
+	versions, err := h.configProfileService.GetVersions(userID, uint(profileID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Configuration profile versions retrieved successfully", versions)
+}
+
+// AssignVehicles assigns a configuration profile to each of a list of vehicles
+func (h *configProfileHandler) AssignVehicles(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	profileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid profile ID", nil)
+	}
+
+	var req dto.AssignConfigProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	profile, err := h.configProfileService.AssignVehicles(userID, uint(profileID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Configuration profile assigned successfully", profile)
+}
+
+// GetVehicleConfig gets the effective configuration of a vehicle and the version its device applied
+func (h *configProfileHandler) GetVehicleConfig(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	config, err := h.configProfileService.GetVehicleConfig(userID, uint(vehicleID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Vehicle configuration retrieved successfully", config)
+}
+
+// UnassignVehicle removes the configuration profile assigned to a vehicle
+func (h *configProfileHandler) UnassignVehicle(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	if err := h.configProfileService.UnassignVehicle(userID, uint(vehicleID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Configuration profile unassigned successfully", nil)
+}
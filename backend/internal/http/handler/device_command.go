@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// DeviceCommandHandler defines device command handler interface
+type DeviceCommandHandler interface {
+	Create(c echo.Context) error
+	GetByVehicleID(c echo.Context) error
+	GetByID(c echo.Context) error
+}
+
+// deviceCommandHandler implements DeviceCommandHandler interface
+type deviceCommandHandler struct {
+	deviceCommandService service.DeviceCommandService
+}
+
+// NewDeviceCommandHandler creates new device command handler instance
+func NewDeviceCommandHandler(deviceCommandService service.DeviceCommandService) DeviceCommandHandler {
+	return &deviceCommandHandler{
+		deviceCommandService: deviceCommandService,
+	}
+}
+
+// Create queues a command for a vehicle's tracker
+func (h *deviceCommandHandler) Create(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	var req dto.CreateDeviceCommandRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	command, err := h.deviceCommandService.Enqueue(userID, uint(vehicleID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Device command queued successfully", command)
+}
+
+// GetByVehicleID gets the command history of a vehicle
+func (h *deviceCommandHandler) GetByVehicleID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	status := c.QueryParam("status")
+	if status != "" && status != "pending" && status != "delivered" && status != "acked" && status != "failed" && status != "expired" {
+		return response.BadRequest(c, "Invalid status. Use pending, delivered, acked, failed or expired", nil)
+	}
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	commands, total, err := h.deviceCommandService.GetByVehicleID(userID, uint(vehicleID), status, limit, offset)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("Device commands retrieved successfully", commands, page, perPage, total))
+}
+
+// GetByID gets a command of a vehicle
+func (h *deviceCommandHandler) GetByID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	commandID, err := strconv.ParseUint(c.Param("commandId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid command ID", nil)
+	}
+
+	command, err := h.deviceCommandService.GetByID(userID, uint(vehicleID), uint(commandID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Device command retrieved successfully", command)
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// DeviceStatusHandler defines device status handler interface
+type DeviceStatusHandler interface {
+	GetFleetStatus(c echo.Context) error
+	GetVehicleStatus(c echo.Context) error
+}
+
+// deviceStatusHandler implements DeviceStatusHandler interface
+type deviceStatusHandler struct {
+	deviceStatusService service.DeviceStatusService
+}
+
+// NewDeviceStatusHandler creates new device status handler instance
+func NewDeviceStatusHandler(deviceStatusService service.DeviceStatusService) DeviceStatusHandler {
+	return &deviceStatusHandler{
+		deviceStatusService: deviceStatusService,
+	}
+}
+
+// GetFleetStatus gets the online, idle and offline vehicles of the user's fleet
+func (h *deviceStatusHandler) GetFleetStatus(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	fleet, err := h.deviceStatusService.GetFleetStatus(userID, c.QueryParam("status"))
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Vehicle statuses retrieved successfully", fleet)
+}
+
+// GetVehicleStatus gets the device status of a vehicle
+func (h *deviceStatusHandler) GetVehicleStatus(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	status, err := h.deviceStatusService.GetVehicleStatus(userID, uint(vehicleID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Vehicle status retrieved successfully", status)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// DispatchHandler defines dispatch handler interface
+type DispatchHandler interface {
+	GetNearestVehicles(c echo.Context) error
+}
+
+// dispatchHandler implements DispatchHandler interface
+type dispatchHandler struct {
+	dispatchService service.DispatchService
+}
+
+// NewDispatchHandler creates new dispatch handler instance
+func NewDispatchHandler(dispatchService service.DispatchService) DispatchHandler {
+	return &dispatchHandler{
+		dispatchService: dispatchService,
+	}
+}
+
+// GetNearestVehicles gets the user's vehicles ranked by the distance of their latest position from a coordinate
+func (h *dispatchHandler) GetNearestVehicles(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.NearestVehiclesRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid query parameters", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	vehicles, err := h.dispatchService.GetNearestVehicles(userID, &req)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get nearest vehicles", nil)
+	}
+
+	return response.Success(c, "Nearest vehicles retrieved successfully", vehicles)
+}
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// DriverHandler defines driver handler interface
+type DriverHandler interface {
+	Create(c echo.Context) error
+	GetMyDrivers(c echo.Context) error
+	GetByID(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	Assign(c echo.Context) error
+	GetAssignmentsByDriverID(c echo.Context) error
+	GetCurrentDriver(c echo.Context) error
+	Unassign(c echo.Context) error
+	GetAssignmentsByVehicleID(c echo.Context) error
+}
+
+// driverHandler implements DriverHandler interface
+type driverHandler struct {
+	driverService service.DriverService
+}
+
+// NewDriverHandler creates new driver handler instance
+func NewDriverHandler(driverService service.DriverService) DriverHandler {
+	return &driverHandler{
+		driverService: driverService,
+	}
+}
+
+// Create creates a new driver
+func (h *driverHandler) Create(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.CreateDriverRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	driver, err := h.driverService.Create(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Driver created successfully", driver)
+}
+
+// GetMyDrivers gets drivers of the current user's organizations
+func (h *driverHandler) GetMyDrivers(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	drivers, total, err := h.driverService.GetMyDrivers(userID, limit, offset)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get drivers", nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("Drivers retrieved successfully", drivers, page, perPage, total))
+}
+
+// GetByID gets driver by ID
+func (h *driverHandler) GetByID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	driverID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid driver ID", nil)
+	}
+
+	driver, err := h.driverService.GetByID(userID, uint(driverID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Driver retrieved successfully", driver)
+}
+
+// Update updates driver data
+func (h *driverHandler) Update(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	driverID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid driver ID", nil)
+	}
+
+	var req dto.UpdateDriverRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	driver, err := h.driverService.Update(userID, uint(driverID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Driver updated successfully", driver)
+}
+
+// Delete deletes a driver
+func (h *driverHandler) Delete(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	driverID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid driver ID", nil)
+	}
+
+	if err := h.driverService.Delete(userID, uint(driverID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Driver deleted successfully", nil)
+}
+
+// Assign puts a driver on duty in a vehicle
+func (h *driverHandler) Assign(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	driverID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid driver ID", nil)
+	}
+
+	var req dto.AssignDriverRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	assignment, err := h.driverService.Assign(userID, uint(driverID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Driver assigned successfully", assignment)
+}
+
+// GetAssignmentsByDriverID gets assignment history of a driver
+func (h *driverHandler) GetAssignmentsByDriverID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	driverID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid driver ID", nil)
+	}
+
+	limit, offset := assignmentPagination(c)
+
+	assignments, err := h.driverService.GetAssignmentsByDriverID(userID, uint(driverID), limit, offset)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Driver assignments retrieved successfully", assignments)
+}
+
+// GetCurrentDriver gets the driver currently assigned to a vehicle
+func (h *driverHandler) GetCurrentDriver(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	assignment, err := h.driverService.GetCurrentDriver(userID, uint(vehicleID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Current driver retrieved successfully", assignment)
+}
+
+// Unassign ends the current driver assignment of a vehicle
+func (h *driverHandler) Unassign(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	if err := h.driverService.Unassign(userID, uint(vehicleID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Driver unassigned successfully", nil)
+}
+
+// GetAssignmentsByVehicleID gets driver assignment history of a vehicle
+func (h *driverHandler) GetAssignmentsByVehicleID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	limit, offset := assignmentPagination(c)
+
+	assignments, err := h.driverService.GetAssignmentsByVehicleID(userID, uint(vehicleID), limit, offset)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Driver assignments retrieved successfully", assignments)
+}
+
+// assignmentPagination reads limit and offset for assignment history queries
+func assignmentPagination(c echo.Context) (int, int) {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return limit, offset
+}
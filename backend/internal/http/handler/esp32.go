@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cartrack/backend/internal/entity"
 	"github.com/cartrack/backend/internal/http/dto"
 	"github.com/cartrack/backend/internal/service"
 	"github.com/cartrack/backend/pkg/response"
@@ -17,21 +21,39 @@ type ESP32Handler interface {
 	SendLocationLog(c echo.Context) error
 	GetVehicleInfo(c echo.Context) error
 	GetUserVehicles(c echo.Context) error
+	Heartbeat(c echo.Context) error
+	FetchCommands(c echo.Context) error
+	ReportCommandResult(c echo.Context) error
+	CheckFirmware(c echo.Context) error
+	DownloadFirmware(c echo.Context) error
+	ReportFirmwareInstall(c echo.Context) error
+	GetConfig(c echo.Context) error
+	ReportConfigApplied(c echo.Context) error
 }
 
 // esp32Handler implements ESP32Handler interface
 type esp32Handler struct {
-	apiKeyService      service.APIKeyService
-	locationLogService service.LocationLogService
-	vehicleService     service.VehicleService
+	apiKeyService        service.APIKeyService
+	locationLogService   service.LocationLogService
+	vehicleService       service.VehicleService
+	driverService        service.DriverService
+	deviceStatusService  service.DeviceStatusService
+	deviceCommandService service.DeviceCommandService
+	firmwareService      service.FirmwareService
+	configProfileService service.ConfigProfileService
 }
 
 // NewESP32Handler creates new ESP32 handler instance
-func NewESP32Handler(apiKeyService service.APIKeyService, locationLogService service.LocationLogService, vehicleService service.VehicleService) ESP32Handler {
+func NewESP32Handler(apiKeyService service.APIKeyService, locationLogService service.LocationLogService, vehicleService service.VehicleService, driverService service.DriverService, deviceStatusService service.DeviceStatusService, deviceCommandService service.DeviceCommandService, firmwareService service.FirmwareService, configProfileService service.ConfigProfileService) ESP32Handler {
 	return &esp32Handler{
-		apiKeyService:      apiKeyService,
-		locationLogService: locationLogService,
-		vehicleService:     vehicleService,
+		apiKeyService:        apiKeyService,
+		locationLogService:   locationLogService,
+		vehicleService:       vehicleService,
+		driverService:        driverService,
+		deviceStatusService:  deviceStatusService,
+		deviceCommandService: deviceCommandService,
+		firmwareService:      firmwareService,
+		configProfileService: configProfileService,
 	}
 }
 
@@ -39,43 +61,66 @@ func NewESP32Handler(apiKeyService service.APIKeyService, locationLogService ser
 func getAPIKeyFromHeader(c echo.Context) (string, error) {
 	authHeader := c.Request().Header.Get("Authorization")
 	if authHeader == "" {
-		return "", echo.NewHTTPError(http.StatusUnauthorized, "API key required")
+		return "", errors.New("API key required")
 	}
 
-	// Debug logging
-	fmt.Printf("DEBUG: Authorization header: '%s'\n", authHeader)
-
 	// Check if it's Bearer token format
 	if strings.HasPrefix(authHeader, "Bearer ") {
-		apiKey := strings.TrimPrefix(authHeader, "Bearer ")
-		fmt.Printf("DEBUG: Extracted API key from Bearer format: '%s'\n", apiKey)
-		return apiKey, nil
+		return strings.TrimPrefix(authHeader, "Bearer "), nil
 	}
 
 	// Check if it's API key format
 	if strings.HasPrefix(authHeader, "ApiKey ") {
-		apiKey := strings.TrimPrefix(authHeader, "ApiKey ")
-		fmt.Printf("DEBUG: Extracted API key from ApiKey format: '%s'\n", apiKey)
-		return apiKey, nil
+		return strings.TrimPrefix(authHeader, "ApiKey "), nil
 	}
 
 	// Assume it's just the API key
-	fmt.Printf("DEBUG: Using authorization header as-is as API key: '%s'\n", authHeader)
 	return authHeader, nil
 }
 
-// SendLocationLog handles ESP32 location log submission
-func (h *esp32Handler) SendLocationLog(c echo.Context) error {
-	// Get API key from header
+// authenticateDevice returns the API key the device sent in the Authorization header after validating it
+func (h *esp32Handler) authenticateDevice(c echo.Context) (*entity.APIKey, error) {
 	apiKeyStr, err := getAPIKeyFromHeader(c)
 	if err != nil {
-		return response.Unauthorized(c, err.Error(), nil)
+		return nil, err
 	}
 
-	// Validate API key
 	apiKey, err := h.apiKeyService.ValidateAPIKey(apiKeyStr)
 	if err != nil {
-		return response.Unauthorized(c, "Invalid API key", nil)
+		return nil, errors.New("Invalid API key")
+	}
+	return apiKey, nil
+}
+
+// recordContact records an authenticated request of a vehicle's device. Devices may report their firmware
+// version and signal strength on any request through the X-Firmware-Version and X-Signal-Strength headers.
+func (h *esp32Handler) recordContact(c echo.Context, vehicleID uint, firmwareVersion string, signalStrength *int) {
+	if firmwareVersion == "" {
+		firmwareVersion = c.Request().Header.Get("X-Firmware-Version")
+	}
+	if signalStrength == nil {
+		if value, err := strconv.Atoi(c.Request().Header.Get("X-Signal-Strength")); err == nil {
+			signalStrength = &value
+		}
+	}
+
+	var firmware *string
+	if firmwareVersion != "" {
+		firmware = &firmwareVersion
+	}
+	ip := c.RealIP()
+
+	if err := h.deviceStatusService.RecordContact(vehicleID, &ip, firmware, signalStrength); err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to record device contact: %v", err)
+	}
+}
+
+// SendLocationLog handles ESP32 location log submission
+func (h *esp32Handler) SendLocationLog(c echo.Context) error {
+	apiKey, err := h.authenticateDevice(c)
+	if err != nil {
+		return response.Unauthorized(c, err.Error(), nil)
 	}
 
 	// Parse request
@@ -88,11 +133,20 @@ func (h *esp32Handler) SendLocationLog(c echo.Context) error {
 		return response.BadRequest(c, err.Error(), nil)
 	}
 
-	// Verify that the vehicle belongs to the API key's user
-	_, err = h.vehicleService.GetByID(apiKey.UserID, req.VehicleID)
+	// Verify that the vehicle belongs to the API key's organization
+	_, err = h.vehicleService.GetByOrganization(apiKey.OrganizationID, req.VehicleID)
 	if err != nil {
 		return response.BadRequest(c, "Vehicle not found or not accessible with this API key", nil)
 	}
+	h.recordContact(c, req.VehicleID, "", nil)
+
+	// Switch the on-duty driver when the device reports a driver ID card
+	if req.DriverTag != "" {
+		if err := h.driverService.AssignFromDevice(apiKey.OrganizationID, req.VehicleID, req.DriverTag, time.Now()); err != nil {
+			// Log error but don't reject the telemetry
+			log.Printf("Failed to assign driver from device tag: %v", err)
+		}
+	}
 
 	// Create location log request
 	locationLogReq := &dto.CreateLocationLogRequest{
@@ -101,31 +155,26 @@ func (h *esp32Handler) SendLocationLog(c echo.Context) error {
 		Longitude: req.Longitude,
 		Speed:     req.Speed,
 		Direction: req.Direction,
+		Ignition:  req.Ignition,
+		Timestamp: req.Timestamp,
 	}
 
-	// Create location log using the API key's user ID
-	log, err := h.locationLogService.Create(apiKey.UserID, locationLogReq)
+	// Create location log on behalf of the API key's organization
+	locationLog, err := h.locationLogService.CreateForOrganization(apiKey.OrganizationID, locationLogReq)
 	if err != nil {
 		return response.BadRequest(c, err.Error(), nil)
 	}
 
-	return response.Created(c, "Location log sent successfully", log)
+	return response.Created(c, "Location log sent successfully", locationLog)
 }
 
 // GetVehicleInfo handles ESP32 vehicle info request
 func (h *esp32Handler) GetVehicleInfo(c echo.Context) error {
-	// Get API key from header
-	apiKeyStr, err := getAPIKeyFromHeader(c)
+	apiKey, err := h.authenticateDevice(c)
 	if err != nil {
 		return response.Unauthorized(c, err.Error(), nil)
 	}
 
-	// Validate API key
-	apiKey, err := h.apiKeyService.ValidateAPIKey(apiKeyStr)
-	if err != nil {
-		return response.Unauthorized(c, "Invalid API key", nil)
-	}
-
 	// Get vehicle ID from query parameter or path parameter
 	vehicleIDStr := c.QueryParam("vehicle_id")
 	if vehicleIDStr == "" {
@@ -141,11 +190,12 @@ func (h *esp32Handler) GetVehicleInfo(c echo.Context) error {
 		return response.BadRequest(c, "Invalid vehicle ID", nil)
 	}
 
-	// Get vehicle info using the API key's user ID
-	vehicle, err := h.vehicleService.GetByID(apiKey.UserID, uint(vehicleID))
+	// Get vehicle info scoped to the API key's organization
+	vehicle, err := h.vehicleService.GetByOrganization(apiKey.OrganizationID, uint(vehicleID))
 	if err != nil {
 		return response.NotFound(c, "Vehicle not found or not accessible with this API key", nil)
 	}
+	h.recordContact(c, vehicle.ID, "", nil)
 
 	// Convert to ESP32 response format
 	esp32Vehicle := &dto.ESP32VehicleResponse{
@@ -158,22 +208,15 @@ func (h *esp32Handler) GetVehicleInfo(c echo.Context) error {
 	return response.Success(c, "Vehicle info retrieved successfully", esp32Vehicle)
 }
 
-// GetUserVehicles handles ESP32 request to get all vehicles for the API key user
+// GetUserVehicles handles ESP32 request to get all vehicles of the API key's organization
 func (h *esp32Handler) GetUserVehicles(c echo.Context) error {
-	// Get API key from header
-	apiKeyStr, err := getAPIKeyFromHeader(c)
+	apiKey, err := h.authenticateDevice(c)
 	if err != nil {
 		return response.Unauthorized(c, err.Error(), nil)
 	}
 
-	// Validate API key
-	apiKey, err := h.apiKeyService.ValidateAPIKey(apiKeyStr)
-	if err != nil {
-		return response.Unauthorized(c, "Invalid API key", nil)
-	}
-
-	// Get all vehicles for the user (using a large limit to get all vehicles)
-	vehicles, err := h.vehicleService.GetByUserID(apiKey.UserID, 1000, 0)
+	// Get all vehicles of the organization (using a large limit to get all vehicles)
+	vehicles, err := h.vehicleService.GetByOrganizationID(apiKey.OrganizationID, 1000, 0)
 	if err != nil {
 		return response.InternalServerError(c, "Failed to get vehicles", nil)
 	}
@@ -191,3 +234,248 @@ func (h *esp32Handler) GetUserVehicles(c echo.Context) error {
 
 	return response.Success(c, "Vehicles retrieved successfully", esp32Vehicles)
 }
+
+// Heartbeat handles ESP32 keep-alive, reporting the device's firmware version and signal strength
+func (h *esp32Handler) Heartbeat(c echo.Context) error {
+	apiKey, err := h.authenticateDevice(c)
+	if err != nil {
+		return response.Unauthorized(c, err.Error(), nil)
+	}
+
+	// Parse request
+	var req dto.ESP32HeartbeatRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	// Verify that the vehicle belongs to the API key's organization
+	if _, err := h.vehicleService.GetByOrganization(apiKey.OrganizationID, req.VehicleID); err != nil {
+		return response.BadRequest(c, "Vehicle not found or not accessible with this API key", nil)
+	}
+	h.recordContact(c, req.VehicleID, req.FirmwareVersion, req.SignalStrength)
+
+	return response.Success(c, "Heartbeat received", &dto.ESP32HeartbeatResponse{ServerTime: time.Now()})
+}
+
+// FetchCommands handles ESP32 request for the pending commands of a vehicle. Returned commands are marked
+// delivered and the device must report their result.
+func (h *esp32Handler) FetchCommands(c echo.Context) error {
+	apiKey, err := h.authenticateDevice(c)
+	if err != nil {
+		return response.Unauthorized(c, err.Error(), nil)
+	}
+
+	vehicleID, err := strconv.ParseUint(c.QueryParam("vehicle_id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	commands, err := h.deviceCommandService.FetchForDevice(apiKey.OrganizationID, uint(vehicleID))
+	if err != nil {
+		return response.NotFound(c, "Vehicle not found or not accessible with this API key", nil)
+	}
+	h.recordContact(c, uint(vehicleID), "", nil)
+
+	return response.Success(c, "Commands retrieved successfully", commands)
+}
+
+// ReportCommandResult handles ESP32 report of the outcome of a delivered command
+func (h *esp32Handler) ReportCommandResult(c echo.Context) error {
+	apiKey, err := h.authenticateDevice(c)
+	if err != nil {
+		return response.Unauthorized(c, err.Error(), nil)
+	}
+
+	commandID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid command ID", nil)
+	}
+
+	// Parse request
+	var req dto.ESP32CommandResultRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	command, err := h.deviceCommandService.ReportResult(apiKey.OrganizationID, uint(commandID), &req)
+	if err != nil {
+		if errors.Is(err, service.ErrDeviceCommandNotFound) {
+			return response.NotFound(c, err.Error(), nil)
+		}
+		return response.BadRequest(c, err.Error(), nil)
+	}
+	h.recordContact(c, command.VehicleID, "", nil)
+
+	return response.Success(c, "Command result recorded successfully", command)
+}
+
+// CheckFirmware handles ESP32 check for a firmware update. The device sends its vehicle ID, board and current
+// version and receives a signed download URL when a rollout offers it another release.
+func (h *esp32Handler) CheckFirmware(c echo.Context) error {
+	apiKey, err := h.authenticateDevice(c)
+	if err != nil {
+		return response.Unauthorized(c, err.Error(), nil)
+	}
+
+	vehicleID, err := strconv.ParseUint(c.QueryParam("vehicle_id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	board := c.QueryParam("board")
+	version := c.QueryParam("version")
+	if board == "" || version == "" {
+		return response.BadRequest(c, "board and version are required", nil)
+	}
+
+	check, err := h.firmwareService.CheckForDevice(apiKey.OrganizationID, uint(vehicleID), board, version)
+	if err != nil {
+		return response.NotFound(c, "Vehicle not found or not accessible with this API key", nil)
+	}
+	h.recordContact(c, uint(vehicleID), version, nil)
+
+	return response.Success(c, "Firmware check completed", check)
+}
+
+// DownloadFirmware streams the firmware binary of a signed download link. The link itself authorizes the download.
+func (h *esp32Handler) DownloadFirmware(c echo.Context) error {
+	file, err := h.firmwareService.OpenDownload(c.Param("token"))
+	if err != nil {
+		return response.NotFound(c, "Firmware not found or link expired", nil)
+	}
+	defer file.Content.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", file.Name))
+	c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(file.Size, 10))
+	c.Response().Header().Set("X-Firmware-SHA256", file.SHA256)
+
+	return c.Stream(http.StatusOK, echo.MIMEOctetStream, file.Content)
+}
+
+// ReportFirmwareInstall handles ESP32 report of a firmware install result
+func (h *esp32Handler) ReportFirmwareInstall(c echo.Context) error {
+	apiKey, err := h.authenticateDevice(c)
+	if err != nil {
+		return response.Unauthorized(c, err.Error(), nil)
+	}
+
+	// Parse request
+	var req dto.ESP32FirmwareReportRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	install, err := h.firmwareService.ReportInstall(apiKey.OrganizationID, &req)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	firmwareVersion := req.PreviousVersion
+	if install.Status == "installed" {
+		firmwareVersion = install.Version
+	}
+	h.recordContact(c, req.VehicleID, firmwareVersion, nil)
+
+	return response.Created(c, "Firmware install reported successfully", install)
+}
+
+// GetConfig handles ESP32 request for the configuration of a vehicle. The response carries an ETag so devices
+// can poll with If-None-Match and receive 304 Not Modified until the profile changes.
+func (h *esp32Handler) GetConfig(c echo.Context) error {
+	apiKey, err := h.authenticateDevice(c)
+	if err != nil {
+		return response.Unauthorized(c, err.Error(), nil)
+	}
+
+	vehicleID, err := strconv.ParseUint(c.QueryParam("vehicle_id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	config, etag, err := h.configProfileService.GetForDevice(apiKey.OrganizationID, uint(vehicleID))
+	if err != nil {
+		if errors.Is(err, service.ErrNoConfigProfile) {
+			h.recordContact(c, uint(vehicleID), "", nil)
+			return response.NotFound(c, err.Error(), nil)
+		}
+		return response.NotFound(c, "Vehicle not found or not accessible with this API key", nil)
+	}
+	h.recordContact(c, uint(vehicleID), "", nil)
+
+	c.Response().Header().Set("ETag", etag)
+	if noneMatchFails(c.Request().Header.Values("If-None-Match"), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return response.Success(c, "Configuration retrieved successfully", config)
+}
+
+// noneMatchFails evaluates If-None-Match field values against the current ETag as RFC 9110 section 13.1.2
+// describes: the condition fails for "*" or when any listed entity tag matches using the weak comparison,
+// which ignores a "W/" prefix. Parsing stops at the first malformed entity tag of a field value.
+func noneMatchFails(values []string, etag string) bool {
+	current := strings.TrimPrefix(etag, "W/")
+	for _, value := range values {
+		rest := value
+		for {
+			rest = strings.TrimLeft(rest, " \t,")
+			if rest == "" {
+				break
+			}
+			if strings.HasPrefix(rest, "*") {
+				return true
+			}
+			// An opaque tag is quoted and may itself contain commas
+			tag := strings.TrimPrefix(rest, "W/")
+			if !strings.HasPrefix(tag, `"`) {
+				break
+			}
+			end := strings.IndexByte(tag[1:], '"')
+			if end < 0 {
+				break
+			}
+			if tag[:end+2] == current {
+				return true
+			}
+			rest = tag[end+2:]
+		}
+	}
+	return false
+}
+
+// ReportConfigApplied handles ESP32 report that a configuration version was applied
+func (h *esp32Handler) ReportConfigApplied(c echo.Context) error {
+	apiKey, err := h.authenticateDevice(c)
+	if err != nil {
+		return response.Unauthorized(c, err.Error(), nil)
+	}
+
+	// Parse request
+	var req dto.ESP32ConfigAppliedRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	if err := h.configProfileService.RecordApplied(apiKey.OrganizationID, &req); err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+	h.recordContact(c, req.VehicleID, "", nil)
+
+	return response.Success(c, "Applied configuration recorded successfully", nil)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import "testing"
+
+func TestNoneMatchFails(t *testing.T) {
+	const etag = `"12.3"`
+
+	tests := []struct {
+		name   string
+		values []string
+		want   bool
+	}{
+		{"no header", nil, false},
+		{"empty header", []string{""}, false},
+		{"same tag", []string{`"12.3"`}, true},
+		{"other tag", []string{`"12.2"`}, false},
+		{"weak tag", []string{`W/"12.3"`}, true},
+		{"list containing the tag", []string{`"12.1", W/"12.2", "12.3"`}, true},
+		{"list without the tag", []string{`"12.1","12.2"`}, false},
+		{"tag in a second header line", []string{`"12.1"`, `"12.3"`}, true},
+		{"any tag", []string{"*"}, true},
+		{"comma inside a tag", []string{`"12.3,4", "9"`}, false},
+		{"unquoted tag", []string{"12.3"}, false},
+		{"unterminated tag", []string{`"12.3`}, false},
+		{"tag after a malformed one", []string{`bogus, "12.3"`}, false},
+		{"prefix of the tag", []string{`"12."`}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := noneMatchFails(tt.values, etag); got != tt.want {
+				t.Errorf("noneMatchFails(%q) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
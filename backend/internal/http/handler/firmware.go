@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// FirmwareHandler defines firmware handler interface
+type FirmwareHandler interface {
+	CreateRelease(c echo.Context) error
+	GetReleases(c echo.Context) error
+	GetRelease(c echo.Context) error
+	DeleteRelease(c echo.Context) error
+	GetInstalls(c echo.Context) error
+	CreateRollout(c echo.Context) error
+	GetRollouts(c echo.Context) error
+	GetRollout(c echo.Context) error
+	UpdateRollout(c echo.Context) error
+	DeleteRollout(c echo.Context) error
+}
+
+// firmwareHandler implements FirmwareHandler interface
+type firmwareHandler struct {
+	firmwareService service.FirmwareService
+}
+
+// NewFirmwareHandler creates new firmware handler instance
+func NewFirmwareHandler(firmwareService service.FirmwareService) FirmwareHandler {
+	return &firmwareHandler{
+		firmwareService: firmwareService,
+	}
+}
+
+// CreateRelease uploads the multipart "file" field as a firmware release
+func (h *firmwareHandler) CreateRelease(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.CreateFirmwareReleaseRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return response.BadRequest(c, "A file is required in the 'file' form field", nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return response.BadRequest(c, "Failed to read uploaded file", nil)
+	}
+	defer file.Close()
+
+	release, err := h.firmwareService.CreateRelease(userID, &req, fileHeader.Size, file)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Firmware release uploaded successfully", release)
+}
+
+// GetReleases gets firmware releases of the user's organizations
+func (h *firmwareHandler) GetReleases(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	releases, err := h.firmwareService.GetReleases(userID, c.QueryParam("board"))
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get firmware releases", nil)
+	}
+
+	return response.Success(c, "Firmware releases retrieved successfully", releases)
+}
+
+// GetRelease gets a firmware release by ID
+func (h *firmwareHandler) GetRelease(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	releaseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid release ID", nil)
+	}
+
+	release, err := h.firmwareService.GetRelease(userID, uint(releaseID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Firmware release retrieved successfully", release)
+}
+
+// DeleteRelease deletes a firmware release
+func (h *firmwareHandler) DeleteRelease(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	releaseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid release ID", nil)
+	}
+
+	if err := h.firmwareService.DeleteRelease(userID, uint(releaseID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Firmware release deleted successfully", nil)
+}
+
+// GetInstalls gets install reports of a firmware release
+func (h *firmwareHandler) GetInstalls(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	releaseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid release ID", nil)
+	}
+
+	status := c.QueryParam("status")
+	if status != "" && status != "installed" && status != "failed" {
+		return response.BadRequest(c, "Invalid status. Use installed or failed", nil)
+	}
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	installs, total, err := h.firmwareService.GetInstalls(userID, uint(releaseID), status, limit, offset)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("Firmware installs retrieved successfully", installs, page, perPage, total))
+}
+
+// CreateRollout creates a firmware rollout
+func (h *firmwareHandler) CreateRollout(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.CreateFirmwareRolloutRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	rollout, err := h.firmwareService.CreateRollout(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Firmware rollout created successfully", rollout)
+}
+
+// GetRollouts gets firmware rollouts of the user's organizations
+func (h *firmwareHandler) GetRollouts(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	rollouts, err := h.firmwareService.GetRollouts(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get firmware rollouts", nil)
+	}
+
+	return response.Success(c, "Firmware rollouts retrieved successfully", rollouts)
+}
+
+// GetRollout gets a firmware rollout with its install progress
+func (h *firmwareHandler) GetRollout(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	rolloutID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid rollout ID", nil)
+	}
+
+	rollout, err := h.firmwareService.GetRollout(userID, uint(rolloutID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Firmware rollout retrieved successfully", rollout)
+}
+
+// UpdateRollout updates a firmware rollout
+func (h *firmwareHandler) UpdateRollout(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	rolloutID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid rollout ID", nil)
+	}
+
+	var req dto.UpdateFirmwareRolloutRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	rollout, err := h.firmwareService.UpdateRollout(userID, uint(rolloutID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Firmware rollout updated successfully", rollout)
+}
+
+// DeleteRollout deletes a firmware rollout
+func (h *firmwareHandler) DeleteRollout(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	rolloutID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid rollout ID", nil)
+	}
+
+	if err := h.firmwareService.DeleteRollout(userID, uint(rolloutID)); err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Firmware rollout deleted successfully", nil)
+}
@@ -1,7 +1,13 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/cartrack/backend/pkg/token"
+	"github.com/cartrack/backend/pkg/trackfilter"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 )
@@ -16,3 +22,76 @@ func getUserIDFromContext(c echo.Context) uint {
 	claims := user.Claims.(*token.Claims)
 	return uint(claims.UserID)
 }
+
+// parseDateTimeRange parses the required start_date and end_date (YYYY-MM-DD) query parameters with the optional
+// start_time and end_time (HH:MM). Without times the range covers both days entirely.
+func parseDateTimeRange(c echo.Context) (time.Time, time.Time, error) {
+	startDateStr := c.QueryParam("start_date")
+	endDateStr := c.QueryParam("end_date")
+	if startDateStr == "" || endDateStr == "" {
+		return time.Time{}, time.Time{}, errors.New("start_date and end_date parameters are required")
+	}
+
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("Invalid start_date format. Use YYYY-MM-DD")
+	}
+
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("Invalid end_date format. Use YYYY-MM-DD")
+	}
+
+	// Default to start of the first day and end of the last day
+	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+	endDate = time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 23, 59, 59, 999999999, endDate.Location())
+
+	if startTimeStr := c.QueryParam("start_time"); startTimeStr != "" {
+		startTime, err := time.Parse("15:04", startTimeStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("Invalid start_time format. Use HH:MM (24-hour format)")
+		}
+		startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(),
+			startTime.Hour(), startTime.Minute(), 0, 0, startDate.Location())
+	}
+
+	if endTimeStr := c.QueryParam("end_time"); endTimeStr != "" {
+		endTime, err := time.Parse("15:04", endTimeStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("Invalid end_time format. Use HH:MM (24-hour format)")
+		}
+		endDate = time.Date(endDate.Year(), endDate.Month(), endDate.Day(),
+			endTime.Hour(), endTime.Minute(), 59, 999999999, endDate.Location())
+	}
+
+	return startDate, endDate, nil
+}
+
+// parseTrackFilter parses the optional track cleaning query parameters: max_speed in km/h, jitter_radius and
+// tolerance in meters. Missing parameters leave their step disabled.
+func parseTrackFilter(c echo.Context) (trackfilter.Options, error) {
+	var filter trackfilter.Options
+	params := []struct {
+		name  string
+		value *float64
+		max   float64
+	}{
+		{"max_speed", &filter.MaxSpeed, 2000},
+		{"jitter_radius", &filter.JitterRadius, 1000},
+		{"tolerance", &filter.Tolerance, 1000},
+	}
+
+	for _, param := range params {
+		str := c.QueryParam(param.name)
+		if str == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(str, 64)
+		if err != nil || value <= 0 || value > param.max {
+			return trackfilter.Options{}, fmt.Errorf("Invalid %s. Use a positive number up to %g", param.name, param.max)
+		}
+		*param.value = value
+	}
+
+	return filter, nil
+}
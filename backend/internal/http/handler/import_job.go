@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// ImportHandler defines location history import handler interface
+type ImportHandler interface {
+	Create(c echo.Context) error
+	GetMyJobs(c echo.Context) error
+	GetByID(c echo.Context) error
+}
+
+// importHandler implements ImportHandler interface
+type importHandler struct {
+	importService service.ImportService
+}
+
+// NewImportHandler creates new import handler instance
+func NewImportHandler(importService service.ImportService) ImportHandler {
+	return &importHandler{
+		importService: importService,
+	}
+}
+
+// Create uploads the multipart "file" field and queues its import
+func (h *importHandler) Create(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.CreateImportJobRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return response.BadRequest(c, "A file is required in the 'file' form field", nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return response.BadRequest(c, "Failed to read uploaded file", nil)
+	}
+	defer file.Close()
+
+	job, err := h.importService.Create(userID, &req, fileHeader.Filename, fileHeader.Size, file)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Import queued successfully", job)
+}
+
+// GetMyJobs gets import jobs of the user's organizations
+func (h *importHandler) GetMyJobs(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	status := c.QueryParam("status")
+	if status != "" && status != "pending" && status != "running" && status != "completed" && status != "failed" {
+		return response.BadRequest(c, "Invalid status. Use pending, running, completed or failed", nil)
+	}
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	jobs, total, err := h.importService.GetMyJobs(userID, status, limit, offset)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get import jobs", nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("Import jobs retrieved successfully", jobs, page, perPage, total))
+}
+
+// GetByID gets an import job with its progress and error report
+func (h *importHandler) GetByID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid import job ID", nil)
+	}
+
+	job, err := h.importService.GetByID(userID, uint(jobID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Import job retrieved successfully", job)
+}
@@ -17,7 +17,9 @@ type LocationLogHandler interface {
 	GetByVehicleID(c echo.Context) error
 	GetMyLocationLogs(c echo.Context) error
 	GetLatestByVehicleID(c echo.Context) error
+	GetByDriverID(c echo.Context) error
 	RealTimeTracking(c echo.Context) error
+	GetAreaVisits(c echo.Context) error
 	GetAll(c echo.Context) error // Admin only
 }
 
@@ -86,6 +88,12 @@ func (h *locationLogHandler) GetByVehicleID(c echo.Context) error {
 	startTimeStr := c.QueryParam("start_time")
 	endTimeStr := c.QueryParam("end_time")
 
+	// Track cleaning applies to date range queries
+	filter, err := parseTrackFilter(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
 	var logs []dto.LocationLogResponse
 	var total int64
 
@@ -127,7 +135,7 @@ func (h *locationLogHandler) GetByVehicleID(c echo.Context) error {
 				23, 59, 59, 999999999, endDate.Location())
 		}
 
-		logs, total, err = h.locationLogService.GetByDateRangeWithPagination(userID, uint(vehicleID), startDate, endDate, limit, offset)
+		logs, total, err = h.locationLogService.GetByDateRangeWithPagination(userID, uint(vehicleID), startDate, endDate, limit, offset, filter)
 		if err != nil {
 			return response.BadRequest(c, err.Error(), nil)
 		}
@@ -243,6 +251,38 @@ func (h *locationLogHandler) GetLatestByVehicleID(c echo.Context) error {
 	return response.Success(c, "Latest location retrieved successfully", log)
 }
 
+// GetByDriverID gets location logs recorded while a driver was on duty
+func (h *locationLogHandler) GetByDriverID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	driverID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid driver ID", nil)
+	}
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	logs, total, err := h.locationLogService.GetByDriverID(userID, uint(driverID), limit, offset)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("Location logs retrieved successfully", logs, page, perPage, total))
+}
+
 // RealTimeTracking handles real-time location tracking (same as Create for now)
 func (h *locationLogHandler) RealTimeTracking(c echo.Context) error {
 	return h.Create(c)
@@ -272,3 +312,24 @@ func (h *locationLogHandler) GetAll(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("All location logs retrieved successfully", logs, page, perPage, total))
 }
+
+// GetAreaVisits gets the vehicles that reported positions inside an area during a period
+func (h *locationLogHandler) GetAreaVisits(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.AreaVisitsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	visits, err := h.locationLogService.GetAreaVisits(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Area visits retrieved successfully", visits)
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// MaintenanceHandler defines maintenance handler interface
+type MaintenanceHandler interface {
+	CreatePlan(c echo.Context) error
+	GetPlans(c echo.Context) error
+	UpdatePlan(c echo.Context) error
+	DeletePlan(c echo.Context) error
+	GetVehicleStatus(c echo.Context) error
+	GetDueItems(c echo.Context) error
+	CreateServiceRecord(c echo.Context) error
+	GetServiceRecords(c echo.Context) error
+	DeleteServiceRecord(c echo.Context) error
+	GetUsage(c echo.Context) error
+}
+
+// maintenanceHandler implements MaintenanceHandler interface
+type maintenanceHandler struct {
+	maintenanceService service.MaintenanceService
+}
+
+// NewMaintenanceHandler creates new maintenance handler instance
+func NewMaintenanceHandler(maintenanceService service.MaintenanceService) MaintenanceHandler {
+	return &maintenanceHandler{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// CreatePlan creates a maintenance plan for a vehicle
+func (h *maintenanceHandler) CreatePlan(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	var req dto.CreateMaintenancePlanRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	plan, err := h.maintenanceService.CreatePlan(userID, uint(vehicleID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Maintenance plan created successfully", plan)
+}
+
+// GetPlans gets maintenance plans of a vehicle
+func (h *maintenanceHandler) GetPlans(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	plans, err := h.maintenanceService.GetPlans(userID, uint(vehicleID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Maintenance plans retrieved successfully", plans)
+}
+
+// UpdatePlan updates a maintenance plan
+func (h *maintenanceHandler) UpdatePlan(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	planID, err := strconv.ParseUint(c.Param("planId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid maintenance plan ID", nil)
+	}
+
+	var req dto.UpdateMaintenancePlanRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	plan, err := h.maintenanceService.UpdatePlan(userID, uint(vehicleID), uint(planID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Maintenance plan updated successfully", plan)
+}
+
+// DeletePlan deletes a maintenance plan
+func (h *maintenanceHandler) DeletePlan(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	planID, err := strconv.ParseUint(c.Param("planId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid maintenance plan ID", nil)
+	}
+
+	if err := h.maintenanceService.DeletePlan(userID, uint(vehicleID), uint(planID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Maintenance plan deleted successfully", nil)
+}
+
+// GetVehicleStatus gets upcoming and overdue maintenance of a vehicle
+func (h *maintenanceHandler) GetVehicleStatus(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	statuses, err := h.maintenanceService.GetVehicleStatus(userID, uint(vehicleID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Maintenance status retrieved successfully", statuses)
+}
+
+// GetDueItems gets upcoming and overdue maintenance across the user's fleet
+func (h *maintenanceHandler) GetDueItems(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	items, err := h.maintenanceService.GetDueItems(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get due maintenance", nil)
+	}
+
+	return response.Success(c, "Due maintenance retrieved successfully", items)
+}
+
+// CreateServiceRecord records a service carried out on a vehicle
+func (h *maintenanceHandler) CreateServiceRecord(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	var req dto.CreateServiceRecordRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	record, err := h.maintenanceService.CreateServiceRecord(userID, uint(vehicleID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Service record created successfully", record)
+}
+
+// GetServiceRecords gets service history of a vehicle
+func (h *maintenanceHandler) GetServiceRecords(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	records, total, err := h.maintenanceService.GetServiceRecords(userID, uint(vehicleID), limit, offset)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("Service records retrieved successfully", records, page, perPage, total))
+}
+
+// DeleteServiceRecord deletes a service record
+func (h *maintenanceHandler) DeleteServiceRecord(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	recordID, err := strconv.ParseUint(c.Param("recordId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid service record ID", nil)
+	}
+
+	if err := h.maintenanceService.DeleteServiceRecord(userID, uint(vehicleID), uint(recordID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Service record deleted successfully", nil)
+}
+
+// GetUsage gets the location-derived odometer and engine hours of a vehicle
+func (h *maintenanceHandler) GetUsage(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	usage, err := h.maintenanceService.GetUsage(userID, uint(vehicleID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Vehicle usage retrieved successfully", usage)
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// streamKeepAlive is how often an idle notification stream sends a comment so proxies keep it open
+const streamKeepAlive = 25 * time.Second
+
+// NotificationHandler defines notification handler interface
+type NotificationHandler interface {
+	GetMyNotifications(c echo.Context) error
+	GetUnreadCount(c echo.Context) error
+	MarkRead(c echo.Context) error
+	MarkAllRead(c echo.Context) error
+	Stream(c echo.Context) error
+}
+
+// notificationHandler implements NotificationHandler interface
+type notificationHandler struct {
+	notificationService service.NotificationService
+}
+
+// NewNotificationHandler creates new notification handler instance
+func NewNotificationHandler(notificationService service.NotificationService) NotificationHandler {
+	return &notificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetMyNotifications gets the current user's notifications, only unread ones with ?unread=true
+func (h *notificationHandler) GetMyNotifications(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	unreadOnly, _ := strconv.ParseBool(c.QueryParam("unread"))
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	notifications, total, err := h.notificationService.GetByUserID(userID, unreadOnly, limit, offset)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get notifications", nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("Notifications retrieved successfully", notifications, page, perPage, total))
+}
+
+// GetUnreadCount gets the number of unread notifications of the current user
+func (h *notificationHandler) GetUnreadCount(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	count, err := h.notificationService.CountUnread(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to count unread notifications", nil)
+	}
+
+	return response.Success(c, "Unread count retrieved successfully", dto.UnreadCountResponse{UnreadCount: count})
+}
+
+// MarkRead marks a notification read
+func (h *notificationHandler) MarkRead(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	notificationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid notification ID", nil)
+	}
+
+	notification, err := h.notificationService.MarkRead(userID, uint(notificationID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Notification marked as read", notification)
+}
+
+// MarkAllRead marks every notification of the current user read
+func (h *notificationHandler) MarkAllRead(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	updated, err := h.notificationService.MarkAllRead(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to mark notifications as read", nil)
+	}
+
+	return response.Success(c, "Notifications marked as read", dto.MarkAllReadResponse{Updated: updated})
+}
+
+// Stream pushes the current user's notification events as server-sent events.
+// The current unread count is sent first, then every new notification and count change.
+func (h *notificationHandler) Stream(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	// Subscribe before counting so no notification falls between the two
+	events, unsubscribe := h.notificationService.Subscribe(userID)
+	defer unsubscribe()
+
+	count, err := h.notificationService.CountUnread(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to count unread notifications", nil)
+	}
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeServerSentEvent(w, dto.NotificationEvent{Type: dto.NotificationEventUnreadCount, UnreadCount: count}); err != nil {
+		return nil
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeServerSentEvent(w, event); err != nil {
+				return nil
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			w.Flush()
+		}
+	}
+}
+
+// writeServerSentEvent writes one notification event in text/event-stream format
+func writeServerSentEvent(w *echo.Response, event dto.NotificationEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+		return err
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// OrganizationHandler defines organization handler interface
+type OrganizationHandler interface {
+	Create(c echo.Context) error
+	GetMyOrganizations(c echo.Context) error
+	GetByID(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	GetMembers(c echo.Context) error
+	UpdateMember(c echo.Context) error
+	RemoveMember(c echo.Context) error
+	InviteMember(c echo.Context) error
+	GetInvitations(c echo.Context) error
+	RevokeInvitation(c echo.Context) error
+	GetMyInvitations(c echo.Context) error
+	AcceptInvitation(c echo.Context) error
+	DeclineInvitation(c echo.Context) error
+}
+
+// organizationHandler implements OrganizationHandler interface
+type organizationHandler struct {
+	organizationService service.OrganizationService
+}
+
+// NewOrganizationHandler creates new organization handler instance
+func NewOrganizationHandler(organizationService service.OrganizationService) OrganizationHandler {
+	return &organizationHandler{
+		organizationService: organizationService,
+	}
+}
+
+// Create creates a new organization owned by the current user
+func (h *organizationHandler) Create(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.CreateOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	organization, err := h.organizationService.Create(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Organization created successfully", organization)
+}
+
+// GetMyOrganizations gets organizations the current user belongs to
+func (h *organizationHandler) GetMyOrganizations(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	organizations, err := h.organizationService.GetMyOrganizations(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get organizations", nil)
+	}
+
+	return response.Success(c, "Organizations retrieved successfully", organizations)
+}
+
+// GetByID gets organization by ID
+func (h *organizationHandler) GetByID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid organization ID", nil)
+	}
+
+	organization, err := h.organizationService.GetByID(userID, uint(organizationID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Organization retrieved successfully", organization)
+}
+
+// Update updates organization details
+func (h *organizationHandler) Update(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid organization ID", nil)
+	}
+
+	var req dto.UpdateOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	organization, err := h.organizationService.Update(userID, uint(organizationID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Organization updated successfully", organization)
+}
+
+// Delete deletes an organization
+func (h *organizationHandler) Delete(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid organization ID", nil)
+	}
+
+	if err := h.organizationService.Delete(userID, uint(organizationID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Organization deleted successfully", nil)
+}
+
+// GetMembers gets members of an organization
+func (h *organizationHandler) GetMembers(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid organization ID", nil)
+	}
+
+	members, err := h.organizationService.GetMembers(userID, uint(organizationID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Members retrieved successfully", members)
+}
+
+// UpdateMember changes the role of a member
+func (h *organizationHandler) UpdateMember(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid organization ID", nil)
+	}
+
+	memberUserID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid user ID", nil)
+	}
+
+	var req dto.UpdateOrganizationMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	member, err := h.organizationService.UpdateMember(userID, uint(organizationID), uint(memberUserID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Member updated successfully", member)
+}
+
+// RemoveMember removes a member from an organization
+func (h *organizationHandler) RemoveMember(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid organization ID", nil)
+	}
+
+	memberUserID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid user ID", nil)
+	}
+
+	if err := h.organizationService.RemoveMember(userID, uint(organizationID), uint(memberUserID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Member removed successfully", nil)
+}
+
+// InviteMember invites an email address to join an organization
+func (h *organizationHandler) InviteMember(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid organization ID", nil)
+	}
+
+	var req dto.InviteOrganizationMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	invitation, err := h.organizationService.InviteMember(userID, uint(organizationID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Invitation sent successfully", invitation)
+}
+
+// GetInvitations gets pending invitations of an organization
+func (h *organizationHandler) GetInvitations(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid organization ID", nil)
+	}
+
+	invitations, err := h.organizationService.GetInvitations(userID, uint(organizationID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Invitations retrieved successfully", invitations)
+}
+
+// RevokeInvitation withdraws an invitation of an organization
+func (h *organizationHandler) RevokeInvitation(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	organizationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid organization ID", nil)
+	}
+
+	invitationID, err := strconv.ParseUint(c.Param("invitationId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid invitation ID", nil)
+	}
+
+	if err := h.organizationService.RevokeInvitation(userID, uint(organizationID), uint(invitationID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Invitation revoked successfully", nil)
+}
+
+// GetMyInvitations gets pending invitations sent to the current user
+func (h *organizationHandler) GetMyInvitations(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	invitations, err := h.organizationService.GetMyInvitations(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get invitations", nil)
+	}
+
+	return response.Success(c, "Invitations retrieved successfully", invitations)
+}
+
+// AcceptInvitation joins the organization of an invitation sent to the current user
+func (h *organizationHandler) AcceptInvitation(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	invitationID, err := strconv.ParseUint(c.Param("invitationId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid invitation ID", nil)
+	}
+
+	organization, err := h.organizationService.AcceptInvitation(userID, uint(invitationID))
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Invitation accepted successfully", organization)
+}
+
+// DeclineInvitation declines an invitation sent to the current user
+func (h *organizationHandler) DeclineInvitation(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	invitationID, err := strconv.ParseUint(c.Param("invitationId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid invitation ID", nil)
+	}
+
+	if err := h.organizationService.DeclineInvitation(userID, uint(invitationID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Invitation declined successfully", nil)
+}
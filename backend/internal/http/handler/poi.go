@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// POIHandler defines point of interest handler interface
+type POIHandler interface {
+	Create(c echo.Context) error
+	GetMyPOIs(c echo.Context) error
+	GetByID(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	Import(c echo.Context) error
+	GetNearestVehicles(c echo.Context) error
+	GetNearbyPOIs(c echo.Context) error
+}
+
+// poiHandler implements POIHandler interface
+type poiHandler struct {
+	poiService service.POIService
+}
+
+// NewPOIHandler creates new point of interest handler instance
+func NewPOIHandler(poiService service.POIService) POIHandler {
+	return &poiHandler{
+		poiService: poiService,
+	}
+}
+
+// Create creates a new point of interest
+func (h *poiHandler) Create(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.CreatePOIRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	poi, err := h.poiService.Create(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "POI created successfully", poi)
+}
+
+// GetMyPOIs gets points of interest of the user's organizations, optionally of one category
+func (h *poiHandler) GetMyPOIs(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	pois, total, err := h.poiService.GetMyPOIs(userID, c.QueryParam("category"), limit, offset)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get POIs", nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("POIs retrieved successfully", pois, page, perPage, total))
+}
+
+// GetByID gets point of interest by ID
+func (h *poiHandler) GetByID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	poiID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid POI ID", nil)
+	}
+
+	poi, err := h.poiService.GetByID(userID, uint(poiID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "POI retrieved successfully", poi)
+}
+
+// Update updates point of interest data
+func (h *poiHandler) Update(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	poiID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid POI ID", nil)
+	}
+
+	var req dto.UpdatePOIRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	poi, err := h.poiService.Update(userID, uint(poiID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "POI updated successfully", poi)
+}
+
+// Delete deletes a point of interest
+func (h *poiHandler) Delete(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	poiID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid POI ID", nil)
+	}
+
+	if err := h.poiService.Delete(userID, uint(poiID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "POI deleted successfully", nil)
+}
+
+// Import creates or updates points of interest from the CSV in the multipart "file" field
+func (h *poiHandler) Import(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.ImportPOIsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return response.BadRequest(c, "A file is required in the 'file' form field", nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return response.BadRequest(c, "Failed to read uploaded file", nil)
+	}
+	defer file.Close()
+
+	result, err := h.poiService.Import(userID, &req, file)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "POIs imported successfully", result)
+}
+
+// GetNearestVehicles gets the vehicles closest to a point of interest, optionally only those within radius meters
+func (h *poiHandler) GetNearestVehicles(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	poiID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid POI ID", nil)
+	}
+
+	var radius float64
+	if radiusStr := c.QueryParam("radius"); radiusStr != "" {
+		radius, err = strconv.ParseFloat(radiusStr, 64)
+		if err != nil || radius <= 0 || radius > 100000 {
+			return response.BadRequest(c, "Invalid radius. Use meters up to 100000", nil)
+		}
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	vehicles, err := h.poiService.GetNearestVehicles(userID, uint(poiID), radius, limit)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Nearest vehicles retrieved successfully", vehicles)
+}
+
+// GetNearbyPOIs gets the points of interest within radius meters of a vehicle's latest position
+func (h *poiHandler) GetNearbyPOIs(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	radius := 5000.0
+	if radiusStr := c.QueryParam("radius"); radiusStr != "" {
+		radius, err = strconv.ParseFloat(radiusStr, 64)
+		if err != nil || radius <= 0 || radius > 100000 {
+			return response.BadRequest(c, "Invalid radius. Use meters up to 100000", nil)
+		}
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	pois, err := h.poiService.GetNearbyPOIs(userID, uint(vehicleID), radius, limit)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Nearby POIs retrieved successfully", pois)
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// ReportHandler defines scheduled report handler interface
+type ReportHandler interface {
+	Create(c echo.Context) error
+	GetMyReports(c echo.Context) error
+	GetByID(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	RunNow(c echo.Context) error
+	GetRuns(c echo.Context) error
+	DownloadRun(c echo.Context) error
+}
+
+// reportHandler implements ReportHandler interface
+type reportHandler struct {
+	reportService service.ReportService
+}
+
+// NewReportHandler creates new report handler instance
+func NewReportHandler(reportService service.ReportService) ReportHandler {
+	return &reportHandler{
+		reportService: reportService,
+	}
+}
+
+// Create creates a scheduled report
+func (h *reportHandler) Create(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.CreateReportRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	report, err := h.reportService.Create(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Report created successfully", report)
+}
+
+// GetMyReports gets scheduled reports of the user's organizations
+func (h *reportHandler) GetMyReports(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	reports, err := h.reportService.GetMyReports(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get reports", nil)
+	}
+
+	return response.Success(c, "Reports retrieved successfully", reports)
+}
+
+// GetByID gets a scheduled report by ID
+func (h *reportHandler) GetByID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	reportID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID", nil)
+	}
+
+	report, err := h.reportService.GetByID(userID, uint(reportID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Report retrieved successfully", report)
+}
+
+// Update updates a scheduled report
+func (h *reportHandler) Update(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	reportID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID", nil)
+	}
+
+	var req dto.UpdateReportRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	report, err := h.reportService.Update(userID, uint(reportID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Report updated successfully", report)
+}
+
+// Delete deletes a scheduled report
+func (h *reportHandler) Delete(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	reportID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID", nil)
+	}
+
+	if err := h.reportService.Delete(userID, uint(reportID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Report deleted successfully", nil)
+}
+
+// RunNow generates and emails a report for its latest complete period
+func (h *reportHandler) RunNow(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	reportID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID", nil)
+	}
+
+	run, err := h.reportService.RunNow(userID, uint(reportID))
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Report generated", run)
+}
+
+// GetRuns gets past runs of a scheduled report
+func (h *reportHandler) GetRuns(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	reportID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID", nil)
+	}
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	runs, total, err := h.reportService.GetRuns(userID, uint(reportID), limit, offset)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("Report runs retrieved successfully", runs, page, perPage, total))
+}
+
+// DownloadRun streams the file of a report run
+func (h *reportHandler) DownloadRun(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	runID, err := strconv.ParseUint(c.Param("runId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report run ID", nil)
+	}
+
+	file, err := h.reportService.OpenRunFile(userID, uint(runID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+	defer file.Content.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", file.Name))
+	if file.Size > 0 {
+		c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(file.Size, 10))
+	}
+
+	return c.Stream(http.StatusOK, file.ContentType, file.Content)
+}
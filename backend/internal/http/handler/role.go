@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// RoleHandler defines role handler interface
+type RoleHandler interface {
+	GetAll(c echo.Context) error
+	GetByID(c echo.Context) error
+	Create(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	GetPermissions(c echo.Context) error
+	AssignToUser(c echo.Context) error
+}
+
+// roleHandler implements RoleHandler interface
+type roleHandler struct {
+	roleService service.RoleService
+}
+
+// NewRoleHandler creates new role handler instance
+func NewRoleHandler(roleService service.RoleService) RoleHandler {
+	return &roleHandler{
+		roleService: roleService,
+	}
+}
+
+// GetAll gets all roles with their permissions
+func (h *roleHandler) GetAll(c echo.Context) error {
+	roles, err := h.roleService.GetAll()
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get roles", nil)
+	}
+
+	return response.Success(c, "Roles retrieved successfully", roles)
+}
+
+// GetByID gets role by ID
+func (h *roleHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid role ID", nil)
+	}
+
+	role, err := h.roleService.GetByID(uint(id))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Role retrieved successfully", role)
+}
+
+// Create creates a new role
+func (h *roleHandler) Create(c echo.Context) error {
+	var req dto.CreateRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	role, err := h.roleService.Create(&req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Role created successfully", role)
+}
+
+// Update updates role description and permissions
+func (h *roleHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid role ID", nil)
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	role, err := h.roleService.Update(uint(id), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Role updated successfully", role)
+}
+
+// Delete deletes a role
+func (h *roleHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid role ID", nil)
+	}
+
+	if err := h.roleService.Delete(uint(id)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Role deleted successfully", nil)
+}
+
+// GetPermissions lists every permission that can be granted to a role
+func (h *roleHandler) GetPermissions(c echo.Context) error {
+	return response.Success(c, "Permissions retrieved successfully", h.roleService.GetPermissions())
+}
+
+// AssignToUser changes the role of a user
+func (h *roleHandler) AssignToUser(c echo.Context) error {
+	actorID := getUserIDFromContext(c)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid user ID", nil)
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	if err := h.roleService.AssignToUser(actorID, uint(id), &req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "User role updated successfully", nil)
+}
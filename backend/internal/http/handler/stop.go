@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// StopHandler defines vehicle stop handler interface
+type StopHandler interface {
+	GetStops(c echo.Context) error
+	Export(c echo.Context) error
+}
+
+// stopHandler implements StopHandler interface
+type stopHandler struct {
+	stopService service.StopService
+}
+
+// NewStopHandler creates new stop handler instance
+func NewStopHandler(stopService service.StopService) StopHandler {
+	return &stopHandler{
+		stopService: stopService,
+	}
+}
+
+// GetStops gets where a vehicle stopped during a date range, for how long and whether it idled
+func (h *stopHandler) GetStops(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	startDate, endDate, err := parseDateTimeRange(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	radius, minDuration, err := parseStopThresholds(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	stops, err := h.stopService.GetStops(userID, uint(vehicleID), startDate, endDate, radius, minDuration)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Vehicle stops retrieved successfully", stops)
+}
+
+// Export downloads the stops of a vehicle during a date range as CSV or GeoJSON
+func (h *stopHandler) Export(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	startDate, endDate, err := parseDateTimeRange(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	radius, minDuration, err := parseStopThresholds(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "csv"
+	}
+
+	export, err := h.stopService.Export(userID, uint(vehicleID), startDate, endDate, radius, minDuration, format)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, export.ContentType)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", export.FileName))
+	c.Response().WriteHeader(http.StatusOK)
+
+	return export.WriteTrack(c.Response())
+}
+
+// parseStopThresholds parses the optional radius (meters) and min_duration (seconds) query parameters.
+// Missing parameters are returned as zero so the configured thresholds apply.
+func parseStopThresholds(c echo.Context) (float64, time.Duration, error) {
+	var radius float64
+	if radiusStr := c.QueryParam("radius"); radiusStr != "" {
+		value, err := strconv.ParseFloat(radiusStr, 64)
+		if err != nil || value < 5 || value > 1000 {
+			return 0, 0, errors.New("Invalid radius. Use meters between 5 and 1000")
+		}
+		radius = value
+	}
+
+	var minDuration time.Duration
+	if durationStr := c.QueryParam("min_duration"); durationStr != "" {
+		seconds, err := strconv.Atoi(durationStr)
+		if err != nil || seconds < 30 || seconds > 86400 {
+			return 0, 0, errors.New("Invalid min_duration. Use whole seconds between 30 and 86400")
+		}
+		minDuration = time.Duration(seconds) * time.Second
+	}
+
+	return radius, minDuration, nil
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// TrackHandler defines vehicle track handler interface
+type TrackHandler interface {
+	Export(c echo.Context) error
+	Playback(c echo.Context) error
+}
+
+// trackHandler implements TrackHandler interface
+type trackHandler struct {
+	trackService service.TrackService
+}
+
+// NewTrackHandler creates new track handler instance
+func NewTrackHandler(trackService service.TrackService) TrackHandler {
+	return &trackHandler{
+		trackService: trackService,
+	}
+}
+
+// Export streams the track of a vehicle for a date range as GPX, KML, GeoJSON or CSV
+func (h *trackHandler) Export(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	startDate, endDate, err := parseDateTimeRange(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "gpx"
+	}
+
+	filter, err := parseTrackFilter(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	export, err := h.trackService.Export(userID, uint(vehicleID), startDate, endDate, format, filter)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, export.ContentType)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", export.FileName))
+	c.Response().WriteHeader(http.StatusOK)
+
+	// The status is already sent, so a failure can only cut the file short
+	return export.WriteTrack(c.Response())
+}
+
+// Playback gets the track of a vehicle for a date range resampled every step seconds for animated replay
+func (h *trackHandler) Playback(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	startDate, endDate, err := parseDateTimeRange(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	var step time.Duration
+	if stepStr := c.QueryParam("step"); stepStr != "" {
+		seconds, err := strconv.Atoi(stepStr)
+		if err != nil || seconds < 1 || seconds > 3600 {
+			return response.BadRequest(c, "Invalid step. Use whole seconds between 1 and 3600", nil)
+		}
+		step = time.Duration(seconds) * time.Second
+	}
+
+	playback, err := h.trackService.Playback(userID, uint(vehicleID), startDate, endDate, step)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Track playback retrieved successfully", playback)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// TwoFactorHandler defines two-factor handler interface
+type TwoFactorHandler interface {
+	Setup(c echo.Context) error
+	Enable(c echo.Context) error
+	Disable(c echo.Context) error
+	RegenerateRecoveryCodes(c echo.Context) error
+	GetStatus(c echo.Context) error
+}
+
+// twoFactorHandler implements TwoFactorHandler interface
+type twoFactorHandler struct {
+	twoFactorService service.TwoFactorService
+}
+
+// NewTwoFactorHandler creates new two-factor handler instance
+func NewTwoFactorHandler(twoFactorService service.TwoFactorService) TwoFactorHandler {
+	return &twoFactorHandler{
+		twoFactorService: twoFactorService,
+	}
+}
+
+// Setup starts TOTP enrollment and returns the secret and otpauth URI
+func (h *twoFactorHandler) Setup(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	setup, err := h.twoFactorService.Setup(userID)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Scan the QR code with your authenticator app, then confirm with a code", setup)
+}
+
+// Enable confirms TOTP enrollment and returns recovery codes
+func (h *twoFactorHandler) Enable(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	codes, err := h.twoFactorService.Enable(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Two-factor authentication enabled. Store the recovery codes safely, they are shown only once", codes)
+}
+
+// Disable turns off two-factor authentication
+func (h *twoFactorHandler) Disable(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.TwoFactorDisableRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	if err := h.twoFactorService.Disable(userID, &req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Two-factor authentication disabled", nil)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes
+func (h *twoFactorHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Recovery codes regenerated successfully", codes)
+}
+
+// GetStatus gets two-factor status of the current user
+func (h *twoFactorHandler) GetStatus(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	status, err := h.twoFactorService.GetStatus(userID)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Two-factor status retrieved successfully", status)
+}
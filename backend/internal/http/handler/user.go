@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/cartrack/backend/internal/http/dto"
@@ -14,6 +15,7 @@ import (
 type UserHandler interface {
 	Register(c echo.Context) error
 	Login(c echo.Context) error
+	LoginMFA(c echo.Context) error
 	GetProfile(c echo.Context) error
 	UpdateProfile(c echo.Context) error
 	ChangePassword(c echo.Context) error
@@ -21,6 +23,11 @@ type UserHandler interface {
 	GetAllUsers(c echo.Context) error
 	GetUserByID(c echo.Context) error
 	DeleteUser(c echo.Context) error
+	UnlockUser(c echo.Context) error
+	ForgotPassword(c echo.Context) error
+	ResetPassword(c echo.Context) error
+	VerifyEmail(c echo.Context) error
+	ResendVerification(c echo.Context) error
 }
 
 // userHandler implements UserHandler interface
@@ -67,14 +74,39 @@ func (h *userHandler) Login(c echo.Context) error {
 		return response.BadRequest(c, "Validation failed", err.Error())
 	}
 
-	loginResponse, err := h.userService.Login(&req)
+	loginResponse, err := h.userService.Login(&req, c.RealIP())
 	if err != nil {
+		if errors.Is(err, service.ErrTooManyLoginAttempts) {
+			return response.TooManyRequests(c, err.Error(), nil)
+		}
 		return response.BadRequest(c, err.Error(), nil)
 	}
 
 	return response.Success(c, "Login successful", loginResponse)
 }
 
+// LoginMFA handles the second step of a two-factor login
+func (h *userHandler) LoginMFA(c echo.Context) error {
+	var req dto.MFALoginRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request format", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, "Validation failed", err.Error())
+	}
+
+	loginResponse, err := h.userService.CompleteMFALogin(&req, c.RealIP())
+	if err != nil {
+		if errors.Is(err, service.ErrTooManyLoginAttempts) {
+			return response.TooManyRequests(c, err.Error(), nil)
+		}
+		return response.Unauthorized(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Login successful", loginResponse)
+}
+
 // GetProfile handles get user profile
 func (h *userHandler) GetProfile(c echo.Context) error {
 	userID := h.getUserIDFromContext(c)
@@ -208,6 +240,93 @@ func (h *userHandler) DeleteUser(c echo.Context) error {
 	return response.Success(c, "User deleted successfully", nil)
 }
 
+// UnlockUser handles clearing a login lockout (admin only)
+func (h *userHandler) UnlockUser(c echo.Context) error {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid user ID", nil)
+	}
+
+	if err := h.userService.UnlockUser(uint(id)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "User unlocked successfully", nil)
+}
+
+// ForgotPassword handles password reset link request
+func (h *userHandler) ForgotPassword(c echo.Context) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request format", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, "Validation failed", err.Error())
+	}
+
+	if err := h.userService.ForgotPassword(&req, c.RealIP()); err != nil {
+		if errors.Is(err, service.ErrTooManyLoginAttempts) {
+			return response.TooManyRequests(c, err.Error(), nil)
+		}
+		return response.InternalServerError(c, "Failed to process password reset request", nil)
+	}
+
+	return response.Success(c, "If the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPassword handles password reset using a reset token
+func (h *userHandler) ResetPassword(c echo.Context) error {
+	var req dto.ResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request format", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, "Validation failed", err.Error())
+	}
+
+	if err := h.userService.ResetPassword(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Password reset successfully", nil)
+}
+
+// VerifyEmail handles email verification using a verification token
+func (h *userHandler) VerifyEmail(c echo.Context) error {
+	var req dto.VerifyEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request format", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, "Validation failed", err.Error())
+	}
+
+	user, err := h.userService.VerifyEmail(&req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Email verified successfully", user)
+}
+
+// ResendVerification handles resending the email verification link
+func (h *userHandler) ResendVerification(c echo.Context) error {
+	userID := h.getUserIDFromContext(c)
+	if userID == 0 {
+		return response.Unauthorized(c, "Invalid token", nil)
+	}
+
+	if err := h.userService.ResendVerification(userID); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Verification email sent successfully", nil)
+}
+
 // getUserIDFromContext extracts user ID from JWT token in context
 func (h *userHandler) getUserIDFromContext(c echo.Context) uint {
 	authHeader := c.Request().Header.Get("Authorization")
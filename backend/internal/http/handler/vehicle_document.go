@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// VehicleDocumentHandler defines vehicle document handler interface
+type VehicleDocumentHandler interface {
+	Create(c echo.Context) error
+	GetByVehicleID(c echo.Context) error
+	GetByID(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	UploadFile(c echo.Context) error
+	DownloadFile(c echo.Context) error
+	DeleteFile(c echo.Context) error
+	GetExpiring(c echo.Context) error
+}
+
+// vehicleDocumentHandler implements VehicleDocumentHandler interface
+type vehicleDocumentHandler struct {
+	documentService service.VehicleDocumentService
+}
+
+// NewVehicleDocumentHandler creates new vehicle document handler instance
+func NewVehicleDocumentHandler(documentService service.VehicleDocumentService) VehicleDocumentHandler {
+	return &vehicleDocumentHandler{
+		documentService: documentService,
+	}
+}
+
+// Create creates a document for a vehicle
+func (h *vehicleDocumentHandler) Create(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	var req dto.CreateVehicleDocumentRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	document, err := h.documentService.Create(userID, uint(vehicleID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Document created successfully", document)
+}
+
+// GetByVehicleID gets documents of a vehicle
+func (h *vehicleDocumentHandler) GetByVehicleID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	documents, err := h.documentService.GetByVehicleID(userID, uint(vehicleID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Documents retrieved successfully", documents)
+}
+
+// GetByID gets a document of a vehicle
+func (h *vehicleDocumentHandler) GetByID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, documentID, err := parseDocumentParams(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	document, err := h.documentService.GetByID(userID, vehicleID, documentID)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Document retrieved successfully", document)
+}
+
+// Update updates a document
+func (h *vehicleDocumentHandler) Update(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, documentID, err := parseDocumentParams(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	var req dto.UpdateVehicleDocumentRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	document, err := h.documentService.Update(userID, vehicleID, documentID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Document updated successfully", document)
+}
+
+// Delete deletes a document
+func (h *vehicleDocumentHandler) Delete(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, documentID, err := parseDocumentParams(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	if err := h.documentService.Delete(userID, vehicleID, documentID); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Document deleted successfully", nil)
+}
+
+// UploadFile attaches the multipart "file" field to a document
+func (h *vehicleDocumentHandler) UploadFile(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, documentID, err := parseDocumentParams(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return response.BadRequest(c, "A file is required in the 'file' form field", nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return response.BadRequest(c, "Failed to read uploaded file", nil)
+	}
+	defer file.Close()
+
+	document, err := h.documentService.UploadFile(userID, vehicleID, documentID, fileHeader.Filename, fileHeader.Header.Get("Content-Type"), fileHeader.Size, file)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "File uploaded successfully", document)
+}
+
+// DownloadFile streams the attachment of a document
+func (h *vehicleDocumentHandler) DownloadFile(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, documentID, err := parseDocumentParams(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	file, err := h.documentService.OpenFile(userID, vehicleID, documentID)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+	defer file.Content.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", file.Name))
+	if file.Size > 0 {
+		c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(file.Size, 10))
+	}
+
+	return c.Stream(http.StatusOK, file.ContentType, file.Content)
+}
+
+// DeleteFile removes the attachment of a document
+func (h *vehicleDocumentHandler) DeleteFile(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, documentID, err := parseDocumentParams(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	if err := h.documentService.DeleteFile(userID, vehicleID, documentID); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "File deleted successfully", nil)
+}
+
+// GetExpiring gets expired and soon-to-expire documents across the user's fleet
+func (h *vehicleDocumentHandler) GetExpiring(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	days, _ := strconv.Atoi(c.QueryParam("days"))
+
+	documents, err := h.documentService.GetExpiring(userID, days)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get expiring documents", nil)
+	}
+
+	return response.Success(c, "Expiring documents retrieved successfully", documents)
+}
+
+// parseDocumentParams reads the vehicle and document IDs from the path
+func parseDocumentParams(c echo.Context) (uint, uint, error) {
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.New("Invalid vehicle ID")
+	}
+
+	documentID, err := strconv.ParseUint(c.Param("documentId"), 10, 32)
+	if err != nil {
+		return 0, 0, errors.New("Invalid document ID")
+	}
+
+	return uint(vehicleID), uint(documentID), nil
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// VehicleShareHandler defines vehicle share handler interface
+type VehicleShareHandler interface {
+	Create(c echo.Context) error
+	GetByVehicleID(c echo.Context) error
+	Revoke(c echo.Context) error
+	GetSharedWithMe(c echo.Context) error
+	GetSharedLocation(c echo.Context) error
+	GetPublicLocation(c echo.Context) error
+}
+
+// vehicleShareHandler implements VehicleShareHandler interface
+type vehicleShareHandler struct {
+	vehicleShareService service.VehicleShareService
+}
+
+// NewVehicleShareHandler creates new vehicle share handler instance
+func NewVehicleShareHandler(vehicleShareService service.VehicleShareService) VehicleShareHandler {
+	return &vehicleShareHandler{
+		vehicleShareService: vehicleShareService,
+	}
+}
+
+// Create shares a vehicle's location with a user or through a signed link
+func (h *vehicleShareHandler) Create(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	var req dto.CreateVehicleShareRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	share, err := h.vehicleShareService.Create(userID, uint(vehicleID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Vehicle shared successfully", share)
+}
+
+// GetByVehicleID lists shares of a vehicle
+func (h *vehicleShareHandler) GetByVehicleID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	shares, err := h.vehicleShareService.GetByVehicleID(userID, uint(vehicleID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Vehicle shares retrieved successfully", shares)
+}
+
+// Revoke revokes a share of a vehicle
+func (h *vehicleShareHandler) Revoke(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	vehicleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid vehicle ID", nil)
+	}
+
+	shareID, err := strconv.ParseUint(c.Param("shareId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid share ID", nil)
+	}
+
+	if err := h.vehicleShareService.Revoke(userID, uint(vehicleID), uint(shareID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Vehicle share revoked successfully", nil)
+}
+
+// GetSharedWithMe lists active shares granted to the current user
+func (h *vehicleShareHandler) GetSharedWithMe(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	shares, err := h.vehicleShareService.GetSharedWithMe(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get shared vehicles", nil)
+	}
+
+	return response.Success(c, "Shared vehicles retrieved successfully", shares)
+}
+
+// GetSharedLocation gets the location of a vehicle shared with the current user
+func (h *vehicleShareHandler) GetSharedLocation(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	shareID, err := strconv.ParseUint(c.Param("shareId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid share ID", nil)
+	}
+
+	location, err := h.vehicleShareService.GetSharedLocation(userID, uint(shareID))
+	if err != nil {
+		if errors.Is(err, service.ErrShareNotFound) {
+			return response.NotFound(c, err.Error(), nil)
+		}
+		return response.InternalServerError(c, "Failed to get shared location", nil)
+	}
+
+	return response.Success(c, "Shared location retrieved successfully", location)
+}
+
+// GetPublicLocation gets the location exposed by an anonymous share link
+func (h *vehicleShareHandler) GetPublicLocation(c echo.Context) error {
+	location, err := h.vehicleShareService.GetPublicLocation(c.Param("token"))
+	if err != nil {
+		if errors.Is(err, service.ErrShareNotFound) {
+			return response.NotFound(c, err.Error(), nil)
+		}
+		return response.InternalServerError(c, "Failed to get shared location", nil)
+	}
+
+	return response.Success(c, "Shared location retrieved successfully", location)
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/service"
+	"github.com/cartrack/backend/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// WebhookHandler defines webhook handler interface
+type WebhookHandler interface {
+	Create(c echo.Context) error
+	GetMyWebhooks(c echo.Context) error
+	GetByID(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	RotateSecret(c echo.Context) error
+	GetDeliveries(c echo.Context) error
+	Redeliver(c echo.Context) error
+}
+
+// webhookHandler implements WebhookHandler interface
+type webhookHandler struct {
+	webhookService service.WebhookService
+}
+
+// NewWebhookHandler creates new webhook handler instance
+func NewWebhookHandler(webhookService service.WebhookService) WebhookHandler {
+	return &webhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// Create creates a webhook subscription
+func (h *webhookHandler) Create(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	var req dto.CreateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	webhook, err := h.webhookService.Create(userID, &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Webhook created successfully", webhook)
+}
+
+// GetMyWebhooks gets webhook subscriptions of the user's organizations
+func (h *webhookHandler) GetMyWebhooks(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	webhooks, err := h.webhookService.GetMyWebhooks(userID)
+	if err != nil {
+		return response.InternalServerError(c, "Failed to get webhooks", nil)
+	}
+
+	return response.Success(c, "Webhooks retrieved successfully", webhooks)
+}
+
+// GetByID gets a webhook subscription by ID
+func (h *webhookHandler) GetByID(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid webhook ID", nil)
+	}
+
+	webhook, err := h.webhookService.GetByID(userID, uint(webhookID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Webhook retrieved successfully", webhook)
+}
+
+// Update updates a webhook subscription
+func (h *webhookHandler) Update(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid webhook ID", nil)
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body", nil)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	webhook, err := h.webhookService.Update(userID, uint(webhookID), &req)
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Webhook updated successfully", webhook)
+}
+
+// Delete deletes a webhook subscription
+func (h *webhookHandler) Delete(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid webhook ID", nil)
+	}
+
+	if err := h.webhookService.Delete(userID, uint(webhookID)); err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Webhook deleted successfully", nil)
+}
+
+// RotateSecret replaces the signing secret of a webhook subscription
+func (h *webhookHandler) RotateSecret(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid webhook ID", nil)
+	}
+
+	webhook, err := h.webhookService.RotateSecret(userID, uint(webhookID))
+	if err != nil {
+		return response.BadRequest(c, err.Error(), nil)
+	}
+
+	return response.Success(c, "Webhook secret rotated successfully", webhook)
+}
+
+// GetDeliveries gets the delivery log of a webhook subscription, optionally filtered by status
+func (h *webhookHandler) GetDeliveries(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid webhook ID", nil)
+	}
+
+	status := c.QueryParam("status")
+	if status != "" && status != "pending" && status != "succeeded" && status != "failed" {
+		return response.BadRequest(c, "Invalid status. Use pending, succeeded or failed", nil)
+	}
+
+	// Get pagination parameters
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	deliveries, total, err := h.webhookService.GetDeliveries(userID, uint(webhookID), status, limit, offset)
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	// Calculate pagination info
+	page := int64(offset/limit + 1)
+	perPage := int64(limit)
+
+	return c.JSON(http.StatusOK, response.SuccessResponseWithPagination("Webhook deliveries retrieved successfully", deliveries, page, perPage, total))
+}
+
+// Redeliver queues a logged delivery again
+func (h *webhookHandler) Redeliver(c echo.Context) error {
+	userID := getUserIDFromContext(c)
+
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid webhook ID", nil)
+	}
+
+	deliveryID, err := strconv.ParseUint(c.Param("deliveryId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid webhook delivery ID", nil)
+	}
+
+	delivery, err := h.webhookService.Redeliver(userID, uint(webhookID), uint(deliveryID))
+	if err != nil {
+		return response.NotFound(c, err.Error(), nil)
+	}
+
+	return response.Created(c, "Webhook delivery queued successfully", delivery)
+}
@@ -4,19 +4,15 @@ import (
 	"net/http"
 
 	"github.com/cartrack/backend/internal/http/handler"
+	"github.com/cartrack/backend/pkg/permission"
 	"github.com/cartrack/backend/pkg/route"
 )
 
-var (
-	adminOnly = []string{"admin"}
-	userOnly  = []string{"user"}
-	allRoles  = []string{"admin", "user"}
-)
-
 func PublicRoutes(
 	userHandler handler.UserHandler,
 	locationLogHandler handler.LocationLogHandler,
 	esp32Handler handler.ESP32Handler,
+	vehicleShareHandler handler.VehicleShareHandler,
 ) []route.Route {
 	return []route.Route{
 		// Auth routes
@@ -30,17 +26,43 @@ func PublicRoutes(
 			Path:    "auth/login",
 			Handler: userHandler.Login,
 		},
+		{
+			Method:  http.MethodPost,
+			Path:    "auth/login/mfa",
+			Handler: userHandler.LoginMFA,
+		},
 		{
 			Method:  http.MethodPost,
 			Path:    "auth/refresh",
 			Handler: userHandler.RefreshToken,
 		},
+		{
+			Method:  http.MethodPost,
+			Path:    "auth/forgot-password",
+			Handler: userHandler.ForgotPassword,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "auth/reset-password",
+			Handler: userHandler.ResetPassword,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "auth/verify-email",
+			Handler: userHandler.VerifyEmail,
+		},
 		// Public location tracking route
 		{
 			Method:  http.MethodPost,
 			Path:    "location-logs",
 			Handler: locationLogHandler.Create,
 		},
+		// Public vehicle share link
+		{
+			Method:  http.MethodGet,
+			Path:    "shares/:token",
+			Handler: vehicleShareHandler.GetPublicLocation,
+		},
 		// ESP32 routes
 		{
 			Method:  http.MethodPost,
@@ -62,6 +84,46 @@ func PublicRoutes(
 			Path:    "esp32/vehicles",
 			Handler: esp32Handler.GetUserVehicles,
 		},
+		{
+			Method:  http.MethodPost,
+			Path:    "esp32/heartbeat",
+			Handler: esp32Handler.Heartbeat,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "esp32/commands",
+			Handler: esp32Handler.FetchCommands,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "esp32/commands/:id/result",
+			Handler: esp32Handler.ReportCommandResult,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "esp32/firmware/check",
+			Handler: esp32Handler.CheckFirmware,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "esp32/firmware/download/:token",
+			Handler: esp32Handler.DownloadFirmware,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "esp32/firmware/report",
+			Handler: esp32Handler.ReportFirmwareInstall,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "esp32/config",
+			Handler: esp32Handler.GetConfig,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "esp32/config/applied",
+			Handler: esp32Handler.ReportConfigApplied,
+		},
 	}
 }
 
@@ -72,193 +134,1031 @@ func PrivateRoutes(
 	fuelLogHandler handler.FuelLogHandler,
 	apiKeyHandler handler.APIKeyHandler,
 	dashboardHandler handler.DashboardHandler,
+	twoFactorHandler handler.TwoFactorHandler,
+	organizationHandler handler.OrganizationHandler,
+	roleHandler handler.RoleHandler,
+	vehicleShareHandler handler.VehicleShareHandler,
+	driverHandler handler.DriverHandler,
+	maintenanceHandler handler.MaintenanceHandler,
+	vehicleDocumentHandler handler.VehicleDocumentHandler,
+	alertHandler handler.AlertHandler,
+	notificationHandler handler.NotificationHandler,
+	webhookHandler handler.WebhookHandler,
+	deviceStatusHandler handler.DeviceStatusHandler,
+	deviceCommandHandler handler.DeviceCommandHandler,
+	firmwareHandler handler.FirmwareHandler,
+	configProfileHandler handler.ConfigProfileHandler,
+	trackHandler handler.TrackHandler,
+	importHandler handler.ImportHandler,
+	reportHandler handler.ReportHandler,
+	stopHandler handler.StopHandler,
+	poiHandler handler.POIHandler,
+	dispatchHandler handler.DispatchHandler,
 ) []route.Route {
 	return []route.Route{
 		// User profile routes
 		{
-			Method:  http.MethodGet,
-			Path:    "user/profile",
-			Handler: userHandler.GetProfile,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "user/profile",
+			Handler:     userHandler.GetProfile,
+			Permissions: []string{permission.ProfileManage},
 		},
 
 		{
-			Method:  http.MethodPut,
-			Path:    "user/profile",
-			Handler: userHandler.UpdateProfile,
-			Roles:   allRoles,
+			Method:      http.MethodPut,
+			Path:        "user/profile",
+			Handler:     userHandler.UpdateProfile,
+			Permissions: []string{permission.ProfileManage},
 		},
 		{
-			Method:  http.MethodPost,
-			Path:    "user/change-password",
-			Handler: userHandler.ChangePassword,
-			Roles:   allRoles,
+			Method:      http.MethodPost,
+			Path:        "user/change-password",
+			Handler:     userHandler.ChangePassword,
+			Permissions: []string{permission.ProfileManage},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "user/resend-verification",
+			Handler:     userHandler.ResendVerification,
+			Permissions: []string{permission.ProfileManage},
+		},
+
+		// Two-factor authentication routes
+		{
+			Method:      http.MethodGet,
+			Path:        "user/2fa",
+			Handler:     twoFactorHandler.GetStatus,
+			Permissions: []string{permission.ProfileManage},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "user/2fa/setup",
+			Handler:     twoFactorHandler.Setup,
+			Permissions: []string{permission.ProfileManage},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "user/2fa/enable",
+			Handler:     twoFactorHandler.Enable,
+			Permissions: []string{permission.ProfileManage},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "user/2fa/disable",
+			Handler:     twoFactorHandler.Disable,
+			Permissions: []string{permission.ProfileManage},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "user/2fa/recovery-codes",
+			Handler:     twoFactorHandler.RegenerateRecoveryCodes,
+			Permissions: []string{permission.ProfileManage},
+		},
+
+		// Organization routes
+		{
+			Method:      http.MethodPost,
+			Path:        "organizations",
+			Handler:     organizationHandler.Create,
+			Permissions: []string{permission.OrganizationsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "organizations",
+			Handler:     organizationHandler.GetMyOrganizations,
+			Permissions: []string{permission.OrganizationsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "organizations/:id",
+			Handler:     organizationHandler.GetByID,
+			Permissions: []string{permission.OrganizationsRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "organizations/:id",
+			Handler:     organizationHandler.Update,
+			Permissions: []string{permission.OrganizationsWrite},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "organizations/:id",
+			Handler:     organizationHandler.Delete,
+			Permissions: []string{permission.OrganizationsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "organizations/:id/members",
+			Handler:     organizationHandler.GetMembers,
+			Permissions: []string{permission.OrganizationsRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "organizations/:id/members/:userId",
+			Handler:     organizationHandler.UpdateMember,
+			Permissions: []string{permission.OrganizationsWrite},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "organizations/:id/members/:userId",
+			Handler:     organizationHandler.RemoveMember,
+			Permissions: []string{permission.OrganizationsWrite},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "organizations/:id/invitations",
+			Handler:     organizationHandler.InviteMember,
+			Permissions: []string{permission.OrganizationsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "organizations/:id/invitations",
+			Handler:     organizationHandler.GetInvitations,
+			Permissions: []string{permission.OrganizationsWrite},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "organizations/:id/invitations/:invitationId",
+			Handler:     organizationHandler.RevokeInvitation,
+			Permissions: []string{permission.OrganizationsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "organizations/invitations",
+			Handler:     organizationHandler.GetMyInvitations,
+			Permissions: []string{permission.OrganizationsRead},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "organizations/invitations/:invitationId/accept",
+			Handler:     organizationHandler.AcceptInvitation,
+			Permissions: []string{permission.OrganizationsRead},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "organizations/invitations/:invitationId",
+			Handler:     organizationHandler.DeclineInvitation,
+			Permissions: []string{permission.OrganizationsRead},
 		},
 
 		// Vehicle routes
 		{
-			Method:  http.MethodPost,
-			Path:    "vehicles",
-			Handler: vehicleHandler.Create,
-			Roles:   allRoles,
+			Method:      http.MethodPost,
+			Path:        "vehicles",
+			Handler:     vehicleHandler.Create,
+			Permissions: []string{permission.VehiclesWrite},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "vehicles",
-			Handler: vehicleHandler.GetMyVehicles,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "vehicles",
+			Handler:     vehicleHandler.GetMyVehicles,
+			Permissions: []string{permission.VehiclesRead},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "vehicles/:id",
-			Handler: vehicleHandler.GetByID,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id",
+			Handler:     vehicleHandler.GetByID,
+			Permissions: []string{permission.VehiclesRead},
 		},
 		{
-			Method:  http.MethodPut,
-			Path:    "vehicles/:id",
-			Handler: vehicleHandler.Update,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "vehicles/status",
+			Handler:     deviceStatusHandler.GetFleetStatus,
+			Permissions: []string{permission.VehiclesRead},
 		},
 		{
-			Method:  http.MethodDelete,
-			Path:    "vehicles/:id",
-			Handler: vehicleHandler.Delete,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "vehicles/nearest",
+			Handler:     dispatchHandler.GetNearestVehicles,
+			Permissions: []string{permission.VehiclesRead, permission.LogsRead},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "vehicles/:id/latest-location",
-			Handler: locationLogHandler.GetLatestByVehicleID,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/status",
+			Handler:     deviceStatusHandler.GetVehicleStatus,
+			Permissions: []string{permission.VehiclesRead},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "vehicles/:id/current-fuel",
-			Handler: fuelLogHandler.GetCurrentFuelLevel,
-			Roles:   allRoles,
+			Method:      http.MethodPost,
+			Path:        "vehicles/:id/commands",
+			Handler:     deviceCommandHandler.Create,
+			Permissions: []string{permission.CommandsWrite},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "vehicles/:id/fuel-stats",
-			Handler: fuelLogHandler.GetFuelStatistics,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/commands",
+			Handler:     deviceCommandHandler.GetByVehicleID,
+			Permissions: []string{permission.CommandsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/commands/:commandId",
+			Handler:     deviceCommandHandler.GetByID,
+			Permissions: []string{permission.CommandsRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "vehicles/:id",
+			Handler:     vehicleHandler.Update,
+			Permissions: []string{permission.VehiclesWrite},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "vehicles/:id",
+			Handler:     vehicleHandler.Delete,
+			Permissions: []string{permission.VehiclesWrite},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "vehicles/:id/shares",
+			Handler:     vehicleShareHandler.Create,
+			Permissions: []string{permission.VehiclesWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/shares",
+			Handler:     vehicleShareHandler.GetByVehicleID,
+			Permissions: []string{permission.VehiclesWrite},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "vehicles/:id/shares/:shareId",
+			Handler:     vehicleShareHandler.Revoke,
+			Permissions: []string{permission.VehiclesWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "shared/vehicles",
+			Handler:     vehicleShareHandler.GetSharedWithMe,
+			Permissions: []string{permission.VehiclesRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "shared/vehicles/:shareId/location",
+			Handler:     vehicleShareHandler.GetSharedLocation,
+			Permissions: []string{permission.VehiclesRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/driver",
+			Handler:     driverHandler.GetCurrentDriver,
+			Permissions: []string{permission.DriversRead},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "vehicles/:id/driver",
+			Handler:     driverHandler.Unassign,
+			Permissions: []string{permission.DriversWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/driver-assignments",
+			Handler:     driverHandler.GetAssignmentsByVehicleID,
+			Permissions: []string{permission.DriversRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/latest-location",
+			Handler:     locationLogHandler.GetLatestByVehicleID,
+			Permissions: []string{permission.LogsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/current-fuel",
+			Handler:     fuelLogHandler.GetCurrentFuelLevel,
+			Permissions: []string{permission.LogsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/fuel-stats",
+			Handler:     fuelLogHandler.GetFuelStatistics,
+			Permissions: []string{permission.LogsRead},
+		},
+
+		// Driver routes
+		{
+			Method:      http.MethodPost,
+			Path:        "drivers",
+			Handler:     driverHandler.Create,
+			Permissions: []string{permission.DriversWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "drivers",
+			Handler:     driverHandler.GetMyDrivers,
+			Permissions: []string{permission.DriversRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "drivers/:id",
+			Handler:     driverHandler.GetByID,
+			Permissions: []string{permission.DriversRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "drivers/:id",
+			Handler:     driverHandler.Update,
+			Permissions: []string{permission.DriversWrite},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "drivers/:id",
+			Handler:     driverHandler.Delete,
+			Permissions: []string{permission.DriversWrite},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "drivers/:id/assignments",
+			Handler:     driverHandler.Assign,
+			Permissions: []string{permission.DriversWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "drivers/:id/assignments",
+			Handler:     driverHandler.GetAssignmentsByDriverID,
+			Permissions: []string{permission.DriversRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "drivers/:id/location-logs",
+			Handler:     locationLogHandler.GetByDriverID,
+			Permissions: []string{permission.DriversRead, permission.LogsRead},
+		},
+
+		// Point of interest routes
+		{
+			Method:      http.MethodPost,
+			Path:        "pois",
+			Handler:     poiHandler.Create,
+			Permissions: []string{permission.POIsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "pois",
+			Handler:     poiHandler.GetMyPOIs,
+			Permissions: []string{permission.POIsRead},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "pois/import",
+			Handler:     poiHandler.Import,
+			Permissions: []string{permission.POIsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "pois/:id",
+			Handler:     poiHandler.GetByID,
+			Permissions: []string{permission.POIsRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "pois/:id",
+			Handler:     poiHandler.Update,
+			Permissions: []string{permission.POIsWrite},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "pois/:id",
+			Handler:     poiHandler.Delete,
+			Permissions: []string{permission.POIsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "pois/:id/nearest-vehicles",
+			Handler:     poiHandler.GetNearestVehicles,
+			Permissions: []string{permission.POIsRead, permission.LogsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/nearby-pois",
+			Handler:     poiHandler.GetNearbyPOIs,
+			Permissions: []string{permission.POIsRead, permission.LogsRead},
+		},
+
+		// Maintenance routes
+		{
+			Method:      http.MethodPost,
+			Path:        "vehicles/:id/maintenance-plans",
+			Handler:     maintenanceHandler.CreatePlan,
+			Permissions: []string{permission.MaintenanceWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/maintenance-plans",
+			Handler:     maintenanceHandler.GetPlans,
+			Permissions: []string{permission.MaintenanceRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "vehicles/:id/maintenance-plans/:planId",
+			Handler:     maintenanceHandler.UpdatePlan,
+			Permissions: []string{permission.MaintenanceWrite},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "vehicles/:id/maintenance-plans/:planId",
+			Handler:     maintenanceHandler.DeletePlan,
+			Permissions: []string{permission.MaintenanceWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/maintenance",
+			Handler:     maintenanceHandler.GetVehicleStatus,
+			Permissions: []string{permission.MaintenanceRead},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "vehicles/:id/service-records",
+			Handler:     maintenanceHandler.CreateServiceRecord,
+			Permissions: []string{permission.MaintenanceWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/service-records",
+			Handler:     maintenanceHandler.GetServiceRecords,
+			Permissions: []string{permission.MaintenanceRead},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "vehicles/:id/service-records/:recordId",
+			Handler:     maintenanceHandler.DeleteServiceRecord,
+			Permissions: []string{permission.MaintenanceWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/usage",
+			Handler:     maintenanceHandler.GetUsage,
+			Permissions: []string{permission.MaintenanceRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "maintenance/due",
+			Handler:     maintenanceHandler.GetDueItems,
+			Permissions: []string{permission.MaintenanceRead},
+		},
+
+		// Vehicle document routes
+		{
+			Method:      http.MethodPost,
+			Path:        "vehicles/:id/documents",
+			Handler:     vehicleDocumentHandler.Create,
+			Permissions: []string{permission.DocumentsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/documents",
+			Handler:     vehicleDocumentHandler.GetByVehicleID,
+			Permissions: []string{permission.DocumentsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/documents/:documentId",
+			Handler:     vehicleDocumentHandler.GetByID,
+			Permissions: []string{permission.DocumentsRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "vehicles/:id/documents/:documentId",
+			Handler:     vehicleDocumentHandler.Update,
+			Permissions: []string{permission.DocumentsWrite},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "vehicles/:id/documents/:documentId",
+			Handler:     vehicleDocumentHandler.Delete,
+			Permissions: []string{permission.DocumentsWrite},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "vehicles/:id/documents/:documentId/file",
+			Handler:     vehicleDocumentHandler.UploadFile,
+			Permissions: []string{permission.DocumentsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/documents/:documentId/file",
+			Handler:     vehicleDocumentHandler.DownloadFile,
+			Permissions: []string{permission.DocumentsRead},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "vehicles/:id/documents/:documentId/file",
+			Handler:     vehicleDocumentHandler.DeleteFile,
+			Permissions: []string{permission.DocumentsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "documents/expiring",
+			Handler:     vehicleDocumentHandler.GetExpiring,
+			Permissions: []string{permission.DocumentsRead},
+		},
+
+		// Alert routes
+		{
+			Method:      http.MethodPost,
+			Path:        "alert-rules",
+			Handler:     alertHandler.CreateRule,
+			Permissions: []string{permission.AlertsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "alert-rules",
+			Handler:     alertHandler.GetRules,
+			Permissions: []string{permission.AlertsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "alert-rules/:id",
+			Handler:     alertHandler.GetRule,
+			Permissions: []string{permission.AlertsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "alert-rules/:id/vehicles",
+			Handler:     alertHandler.GetGeofenceVehicles,
+			Permissions: []string{permission.AlertsRead, permission.LogsRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "alert-rules/:id",
+			Handler:     alertHandler.UpdateRule,
+			Permissions: []string{permission.AlertsWrite},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "alert-rules/:id",
+			Handler:     alertHandler.DeleteRule,
+			Permissions: []string{permission.AlertsWrite},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "alerts",
+			Handler:     alertHandler.GetEvents,
+			Permissions: []string{permission.AlertsRead},
+		},
+
+		// Firmware routes
+		{
+			Method:      http.MethodPost,
+			Path:        "firmware/releases",
+			Handler:     firmwareHandler.CreateRelease,
+			Permissions: []string{permission.FirmwareManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "firmware/releases",
+			Handler:     firmwareHandler.GetReleases,
+			Permissions: []string{permission.FirmwareRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "firmware/releases/:id",
+			Handler:     firmwareHandler.GetRelease,
+			Permissions: []string{permission.FirmwareRead},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "firmware/releases/:id",
+			Handler:     firmwareHandler.DeleteRelease,
+			Permissions: []string{permission.FirmwareManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "firmware/releases/:id/installs",
+			Handler:     firmwareHandler.GetInstalls,
+			Permissions: []string{permission.FirmwareRead},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "firmware/rollouts",
+			Handler:     firmwareHandler.CreateRollout,
+			Permissions: []string{permission.FirmwareManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "firmware/rollouts",
+			Handler:     firmwareHandler.GetRollouts,
+			Permissions: []string{permission.FirmwareRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "firmware/rollouts/:id",
+			Handler:     firmwareHandler.GetRollout,
+			Permissions: []string{permission.FirmwareRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "firmware/rollouts/:id",
+			Handler:     firmwareHandler.UpdateRollout,
+			Permissions: []string{permission.FirmwareManage},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "firmware/rollouts/:id",
+			Handler:     firmwareHandler.DeleteRollout,
+			Permissions: []string{permission.FirmwareManage},
+		},
+
+		// Device configuration routes
+		{
+			Method:      http.MethodPost,
+			Path:        "config-profiles",
+			Handler:     configProfileHandler.Create,
+			Permissions: []string{permission.DeviceConfigManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "config-profiles",
+			Handler:     configProfileHandler.GetMyProfiles,
+			Permissions: []string{permission.DeviceConfigRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "config-profiles/:id",
+			Handler:     configProfileHandler.GetByID,
+			Permissions: []string{permission.DeviceConfigRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "config-profiles/:id",
+			Handler:     configProfileHandler.Update,
+			Permissions: []string{permission.DeviceConfigManage},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "config-profiles/:id",
+			Handler:     configProfileHandler.Delete,
+			Permissions: []string{permission.DeviceConfigManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "config-profiles/:id/versions",
+			Handler:     configProfileHandler.GetVersions,
+			Permissions: []string{permission.DeviceConfigRead},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "config-profiles/:id/vehicles",
+			Handler:     configProfileHandler.AssignVehicles,
+			Permissions: []string{permission.DeviceConfigManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/config",
+			Handler:     configProfileHandler.GetVehicleConfig,
+			Permissions: []string{permission.DeviceConfigRead},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "vehicles/:id/config-profile",
+			Handler:     configProfileHandler.UnassignVehicle,
+			Permissions: []string{permission.DeviceConfigManage},
+		},
+
+		// Webhook routes
+		{
+			Method:      http.MethodPost,
+			Path:        "webhooks",
+			Handler:     webhookHandler.Create,
+			Permissions: []string{permission.WebhooksManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "webhooks",
+			Handler:     webhookHandler.GetMyWebhooks,
+			Permissions: []string{permission.WebhooksManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "webhooks/:id",
+			Handler:     webhookHandler.GetByID,
+			Permissions: []string{permission.WebhooksManage},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "webhooks/:id",
+			Handler:     webhookHandler.Update,
+			Permissions: []string{permission.WebhooksManage},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "webhooks/:id",
+			Handler:     webhookHandler.Delete,
+			Permissions: []string{permission.WebhooksManage},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "webhooks/:id/rotate-secret",
+			Handler:     webhookHandler.RotateSecret,
+			Permissions: []string{permission.WebhooksManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "webhooks/:id/deliveries",
+			Handler:     webhookHandler.GetDeliveries,
+			Permissions: []string{permission.WebhooksManage},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "webhooks/:id/deliveries/:deliveryId/redeliver",
+			Handler:     webhookHandler.Redeliver,
+			Permissions: []string{permission.WebhooksManage},
+		},
+
+		// Notification routes
+		{
+			Method:      http.MethodGet,
+			Path:        "notifications",
+			Handler:     notificationHandler.GetMyNotifications,
+			Permissions: []string{permission.NotificationsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "notifications/unread-count",
+			Handler:     notificationHandler.GetUnreadCount,
+			Permissions: []string{permission.NotificationsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "notifications/stream",
+			Handler:     notificationHandler.Stream,
+			Permissions: []string{permission.NotificationsRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "notifications/read-all",
+			Handler:     notificationHandler.MarkAllRead,
+			Permissions: []string{permission.NotificationsRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "notifications/:id/read",
+			Handler:     notificationHandler.MarkRead,
+			Permissions: []string{permission.NotificationsRead},
 		},
 
 		// Location tracking routes
 		{
-			Method:  http.MethodPost,
-			Path:    "location-logs",
-			Handler: locationLogHandler.Create,
-			Roles:   allRoles,
+			Method:      http.MethodPost,
+			Path:        "location-logs",
+			Handler:     locationLogHandler.Create,
+			Permissions: []string{permission.LogsWrite},
 		},
 		{
-			Method:  http.MethodPost,
-			Path:    "tracking/location",
-			Handler: locationLogHandler.RealTimeTracking,
-			Roles:   allRoles,
+			Method:      http.MethodPost,
+			Path:        "tracking/location",
+			Handler:     locationLogHandler.RealTimeTracking,
+			Permissions: []string{permission.LogsWrite},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "location-logs",
-			Handler: locationLogHandler.GetMyLocationLogs,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "location-logs",
+			Handler:     locationLogHandler.GetMyLocationLogs,
+			Permissions: []string{permission.LogsRead},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "location-logs/vehicle",
-			Handler: locationLogHandler.GetByVehicleID,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "location-logs/vehicle",
+			Handler:     locationLogHandler.GetByVehicleID,
+			Permissions: []string{permission.LogsRead},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "location-logs/area-visits",
+			Handler:     locationLogHandler.GetAreaVisits,
+			Permissions: []string{permission.LogsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/track/export",
+			Handler:     trackHandler.Export,
+			Permissions: []string{permission.LogsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/track/playback",
+			Handler:     trackHandler.Playback,
+			Permissions: []string{permission.LogsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/stops",
+			Handler:     stopHandler.GetStops,
+			Permissions: []string{permission.LogsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "vehicles/:id/stops/export",
+			Handler:     stopHandler.Export,
+			Permissions: []string{permission.LogsRead},
+		},
+
+		// Location history import routes
+		{
+			Method:      http.MethodPost,
+			Path:        "imports",
+			Handler:     importHandler.Create,
+			Permissions: []string{permission.ImportsManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "imports",
+			Handler:     importHandler.GetMyJobs,
+			Permissions: []string{permission.ImportsManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "imports/:id",
+			Handler:     importHandler.GetByID,
+			Permissions: []string{permission.ImportsManage},
+		},
+
+		// Scheduled report routes
+		{
+			Method:      http.MethodPost,
+			Path:        "reports",
+			Handler:     reportHandler.Create,
+			Permissions: []string{permission.ReportsManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "reports",
+			Handler:     reportHandler.GetMyReports,
+			Permissions: []string{permission.ReportsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "reports/:id",
+			Handler:     reportHandler.GetByID,
+			Permissions: []string{permission.ReportsRead},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "reports/:id",
+			Handler:     reportHandler.Update,
+			Permissions: []string{permission.ReportsManage},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "reports/:id",
+			Handler:     reportHandler.Delete,
+			Permissions: []string{permission.ReportsManage},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "reports/:id/run",
+			Handler:     reportHandler.RunNow,
+			Permissions: []string{permission.ReportsManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "reports/:id/runs",
+			Handler:     reportHandler.GetRuns,
+			Permissions: []string{permission.ReportsRead},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "reports/runs/:runId/download",
+			Handler:     reportHandler.DownloadRun,
+			Permissions: []string{permission.ReportsRead},
 		},
 
 		// Fuel management routes
 		{
-			Method:  http.MethodPost,
-			Path:    "fuel-logs",
-			Handler: fuelLogHandler.Create,
-			Roles:   allRoles,
+			Method:      http.MethodPost,
+			Path:        "fuel-logs",
+			Handler:     fuelLogHandler.Create,
+			Permissions: []string{permission.LogsWrite},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "fuel-logs",
-			Handler: fuelLogHandler.GetByVehicleID,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "fuel-logs",
+			Handler:     fuelLogHandler.GetByVehicleID,
+			Permissions: []string{permission.LogsRead},
 		},
 
 		// API Key management routes
 		{
-			Method:  http.MethodPost,
-			Path:    "api-keys",
-			Handler: apiKeyHandler.Create,
-			Roles:   allRoles,
+			Method:      http.MethodPost,
+			Path:        "api-keys",
+			Handler:     apiKeyHandler.Create,
+			Permissions: []string{permission.APIKeysManage},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "api-keys",
-			Handler: apiKeyHandler.GetAll,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "api-keys",
+			Handler:     apiKeyHandler.GetAll,
+			Permissions: []string{permission.APIKeysManage},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "api-keys/:id",
-			Handler: apiKeyHandler.GetByID,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "api-keys/:id",
+			Handler:     apiKeyHandler.GetByID,
+			Permissions: []string{permission.APIKeysManage},
 		},
 		{
-			Method:  http.MethodPut,
-			Path:    "api-keys/:id",
-			Handler: apiKeyHandler.Update,
-			Roles:   allRoles,
+			Method:      http.MethodPut,
+			Path:        "api-keys/:id",
+			Handler:     apiKeyHandler.Update,
+			Permissions: []string{permission.APIKeysManage},
 		},
 		{
-			Method:  http.MethodDelete,
-			Path:    "api-keys/:id",
-			Handler: apiKeyHandler.Delete,
-			Roles:   allRoles,
+			Method:      http.MethodDelete,
+			Path:        "api-keys/:id",
+			Handler:     apiKeyHandler.Delete,
+			Permissions: []string{permission.APIKeysManage},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "user/api-keys",
-			Handler: apiKeyHandler.GetByUserID,
-			Roles:   allRoles,
+			Method:      http.MethodGet,
+			Path:        "user/api-keys",
+			Handler:     apiKeyHandler.GetByUserID,
+			Permissions: []string{permission.APIKeysManage},
 		},
 
 		// Admin routes
 		{
-			Method:  http.MethodGet,
-			Path:    "admin/users",
-			Handler: userHandler.GetAllUsers,
-			Roles:   adminOnly,
+			Method:      http.MethodGet,
+			Path:        "admin/users",
+			Handler:     userHandler.GetAllUsers,
+			Permissions: []string{permission.UsersManage},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "admin/users/:id",
-			Handler: userHandler.GetUserByID,
-			Roles:   adminOnly,
+			Method:      http.MethodGet,
+			Path:        "admin/users/:id",
+			Handler:     userHandler.GetUserByID,
+			Permissions: []string{permission.UsersManage},
 		},
 		{
-			Method:  http.MethodDelete,
-			Path:    "admin/users/:id",
-			Handler: userHandler.DeleteUser,
-			Roles:   adminOnly,
+			Method:      http.MethodDelete,
+			Path:        "admin/users/:id",
+			Handler:     userHandler.DeleteUser,
+			Permissions: []string{permission.UsersManage},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "admin/vehicles",
-			Handler: vehicleHandler.GetAll,
-			Roles:   adminOnly,
+			Method:      http.MethodPost,
+			Path:        "admin/users/:id/unlock",
+			Handler:     userHandler.UnlockUser,
+			Permissions: []string{permission.UsersManage},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "admin/dashboard",
-			Handler: dashboardHandler.GetTotalDashboardByAdmin,
-			Roles:   adminOnly,
+			Method:      http.MethodPut,
+			Path:        "admin/users/:id/role",
+			Handler:     roleHandler.AssignToUser,
+			Permissions: []string{permission.UsersManage},
 		},
 		{
-			Method:  http.MethodGet,
-			Path:    "user/dashboard",
-			Handler: dashboardHandler.GetTotalDashboard,
-			Roles:   userOnly,
+			Method:      http.MethodGet,
+			Path:        "admin/roles",
+			Handler:     roleHandler.GetAll,
+			Permissions: []string{permission.RolesManage},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "admin/roles",
+			Handler:     roleHandler.Create,
+			Permissions: []string{permission.RolesManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "admin/roles/:id",
+			Handler:     roleHandler.GetByID,
+			Permissions: []string{permission.RolesManage},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "admin/roles/:id",
+			Handler:     roleHandler.Update,
+			Permissions: []string{permission.RolesManage},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "admin/roles/:id",
+			Handler:     roleHandler.Delete,
+			Permissions: []string{permission.RolesManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "admin/permissions",
+			Handler:     roleHandler.GetPermissions,
+			Permissions: []string{permission.RolesManage},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "admin/vehicles",
+			Handler:     vehicleHandler.GetAll,
+			Permissions: []string{permission.VehiclesReadAll},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "admin/dashboard",
+			Handler:     dashboardHandler.GetTotalDashboardByAdmin,
+			Permissions: []string{permission.ReportsReadAll},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "user/dashboard",
+			Handler:     dashboardHandler.GetTotalDashboard,
+			Permissions: []string{permission.ReportsRead},
 		},
 	}
 }
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// AlertRuleRepository defines alert rule repository interface
+type AlertRuleRepository interface {
+	Create(rule *entity.AlertRule) error
+	GetByID(id uint) (*entity.AlertRule, error)
+	GetByOrganizationIDs(organizationIDs []uint) ([]entity.AlertRule, error)
+	GetEnabledForVehicle(vehicle *entity.Vehicle, types ...entity.AlertType) ([]entity.AlertRule, error)
+	GetEnabledByType(alertType entity.AlertType) ([]entity.AlertRule, error)
+	Update(rule *entity.AlertRule) error
+	Delete(id uint) error
+}
+
+// alertRuleRepository implements AlertRuleRepository interface
+type alertRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewAlertRuleRepository creates new alert rule repository instance
+func NewAlertRuleRepository(db *gorm.DB) AlertRuleRepository {
+	return &alertRuleRepository{db: db}
+}
+
+// Create creates a new alert rule
+func (r *alertRuleRepository) Create(rule *entity.AlertRule) error {
+	return r.db.Create(rule).Error
+}
+
+// GetByID gets alert rule by ID
+func (r *alertRuleRepository) GetByID(id uint) (*entity.AlertRule, error) {
+	var rule entity.AlertRule
+	err := r.db.First(&rule, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// GetByOrganizationIDs gets alert rules of the organizations
+func (r *alertRuleRepository) GetByOrganizationIDs(organizationIDs []uint) ([]entity.AlertRule, error) {
+	var rules []entity.AlertRule
+	if len(organizationIDs) == 0 {
+		return rules, nil
+	}
+	err := r.db.Where("organization_id IN ?", organizationIDs).
+		Order("id ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// GetEnabledForVehicle gets enabled rules of the given types watching the vehicle,
+// both the vehicle's own rules and its organization's fleet-wide rules
+func (r *alertRuleRepository) GetEnabledForVehicle(vehicle *entity.Vehicle, types ...entity.AlertType) ([]entity.AlertRule, error) {
+	var rules []entity.AlertRule
+	err := r.db.Where("enabled = ? AND organization_id = ? AND (vehicle_id IS NULL OR vehicle_id = ?) AND type IN ?",
+		true, vehicle.OrganizationID, vehicle.ID, types).
+		Order("id ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// GetEnabledByType gets every enabled rule of a type
+func (r *alertRuleRepository) GetEnabledByType(alertType entity.AlertType) ([]entity.AlertRule, error) {
+	var rules []entity.AlertRule
+	err := r.db.Where("enabled = ? AND type = ?", true, alertType).
+		Order("id ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// Update updates alert rule data
+func (r *alertRuleRepository) Update(rule *entity.AlertRule) error {
+	return r.db.Save(rule).Error
+}
+
+// Delete soft deletes alert rule by ID
+func (r *alertRuleRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.AlertRule{}, id).Error
+}
+
+// AlertStateRepository defines alert state repository interface
+type AlertStateRepository interface {
+	Activate(ruleID, vehicleID uint, now time.Time, cooldown time.Duration) (activated, notify bool, err error)
+	Resolve(ruleID, vehicleID uint, now time.Time) (bool, error)
+	DeleteByRuleID(ruleID uint) error
+}
+
+// alertStateRepository implements AlertStateRepository interface
+type alertStateRepository struct {
+	db *gorm.DB
+}
+
+// NewAlertStateRepository creates new alert state repository instance
+func NewAlertStateRepository(db *gorm.DB) AlertStateRepository {
+	return &alertStateRepository{db: db}
+}
+
+// Activate marks the state of a rule for a vehicle active unless it already is. The check and the update are one
+// statement, so of several concurrent activations only one reports activated. notify is true when the last
+// notification was sent before the cooldown, in which case now is recorded as the new notification time.
+func (r *alertStateRepository) Activate(ruleID, vehicleID uint, now time.Time, cooldown time.Duration) (bool, bool, error) {
+	var notified []bool
+	err := r.db.Raw(`
+		INSERT INTO alert_states (rule_id, vehicle_id, active, triggered_at, last_notified_at, created_at, updated_at)
+		VALUES (?, ?, TRUE, ?, ?, ?, ?)
+		ON CONFLICT (rule_id, vehicle_id) DO UPDATE SET
+			active = TRUE,
+			triggered_at = EXCLUDED.triggered_at,
+			resolved_at = NULL,
+			last_notified_at = CASE
+				WHEN alert_states.last_notified_at IS NULL OR alert_states.last_notified_at <= ? THEN EXCLUDED.last_notified_at
+				ELSE alert_states.last_notified_at
+			END,
+			updated_at = EXCLUDED.updated_at
+		WHERE alert_states.active = FALSE
+		RETURNING last_notified_at = triggered_at`,
+		ruleID, vehicleID, now, now, now, now, now.Add(-cooldown)).
+		Scan(&notified).Error
+	if err != nil || len(notified) == 0 {
+		return false, false, err
+	}
+	return true, notified[0], nil
+}
+
+// Resolve marks the active state of a rule for a vehicle inactive. It returns false when the state was not active,
+// so of several concurrent resolutions only one reports the change.
+func (r *alertStateRepository) Resolve(ruleID, vehicleID uint, now time.Time) (bool, error) {
+	result := r.db.Model(&entity.AlertState{}).
+		Where("rule_id = ? AND vehicle_id = ? AND active = TRUE", ruleID, vehicleID).
+		Updates(map[string]interface{}{
+			"active":      false,
+			"resolved_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteByRuleID deletes every state of a rule, so changed conditions start from scratch
+func (r *alertStateRepository) DeleteByRuleID(ruleID uint) error {
+	return r.db.Where("rule_id = ?", ruleID).Delete(&entity.AlertState{}).Error
+}
+
+// AlertEventRepository defines alert event repository interface
+type AlertEventRepository interface {
+	Create(event *entity.AlertEvent) error
+	GetByOrganizationIDsWithPagination(organizationIDs []uint, vehicleID *uint, since *time.Time, limit, offset int) ([]entity.AlertEvent, int64, error)
+	GetByOrganizationIDBetween(organizationID uint, types []entity.AlertType, since, until time.Time, limit int) ([]entity.AlertEvent, error)
+}
+
+// alertEventRepository implements AlertEventRepository interface
+type alertEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAlertEventRepository creates new alert event repository instance
+func NewAlertEventRepository(db *gorm.DB) AlertEventRepository {
+	return &alertEventRepository{db: db}
+}
+
+// Create creates a new alert event
+func (r *alertEventRepository) Create(event *entity.AlertEvent) error {
+	return r.db.Omit("Rule", "Vehicle").Create(event).Error
+}
+
+// GetByOrganizationIDsWithPagination gets alert events of the organizations, newest first,
+// optionally narrowed to a vehicle and a start time
+func (r *alertEventRepository) GetByOrganizationIDsWithPagination(organizationIDs []uint, vehicleID *uint, since *time.Time, limit, offset int) ([]entity.AlertEvent, int64, error) {
+	var events []entity.AlertEvent
+	var total int64
+	if len(organizationIDs) == 0 {
+		return events, 0, nil
+	}
+
+	query := r.db.Model(&entity.AlertEvent{}).Where("organization_id IN ?", organizationIDs)
+	if vehicleID != nil {
+		query = query.Where("vehicle_id = ?", *vehicleID)
+	}
+	if since != nil {
+		query = query.Where("triggered_at >= ?", *since)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Rule", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Vehicle", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order("triggered_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&events).Error
+	return events, total, err
+}
+
+// GetByOrganizationIDBetween gets alert events of the given types triggered in an organization
+// between since and until (exclusive), oldest first, with their vehicles
+func (r *alertEventRepository) GetByOrganizationIDBetween(organizationID uint, types []entity.AlertType, since, until time.Time, limit int) ([]entity.AlertEvent, error) {
+	var events []entity.AlertEvent
+	err := r.db.Where("organization_id = ? AND type IN ? AND triggered_at >= ? AND triggered_at < ?", organizationID, types, since, until).
+		Preload("Vehicle", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order("triggered_at ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
@@ -13,6 +13,8 @@ type APIKeyRepository interface {
 	GetByID(id uint) (*entity.APIKey, error)
 	GetByKey(key string) (*entity.APIKey, error)
 	GetByUserID(userID uint) ([]entity.APIKey, error)
+	GetByOrganizationIDs(organizationIDs []uint) ([]entity.APIKey, error)
+	GetByOrganizationIDsWithPagination(organizationIDs []uint, limit, offset int) ([]entity.APIKey, int64, error)
 	Update(apiKey *entity.APIKey) error
 	Delete(id uint) error
 	UpdateLastUsed(id uint) error
@@ -62,6 +64,42 @@ func (r *apiKeyRepository) GetByUserID(userID uint) ([]entity.APIKey, error) {
 	return apiKeys, err
 }
 
+// GetByOrganizationIDs gets API keys belonging to any of the given organizations
+func (r *apiKeyRepository) GetByOrganizationIDs(organizationIDs []uint) ([]entity.APIKey, error) {
+	var apiKeys []entity.APIKey
+	if len(organizationIDs) == 0 {
+		return apiKeys, nil
+	}
+	err := r.db.Preload("User").Where("organization_id IN ?", organizationIDs).Find(&apiKeys).Error
+	return apiKeys, err
+}
+
+// GetByOrganizationIDsWithPagination gets API keys of the given organizations with pagination info
+func (r *apiKeyRepository) GetByOrganizationIDsWithPagination(organizationIDs []uint, limit, offset int) ([]entity.APIKey, int64, error) {
+	var apiKeys []entity.APIKey
+	var total int64
+
+	if len(organizationIDs) == 0 {
+		return apiKeys, 0, nil
+	}
+
+	query := r.db.Model(&entity.APIKey{}).Where("organization_id IN ?", organizationIDs)
+
+	// Get total count
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated data
+	err := r.db.Preload("User").
+		Where("organization_id IN ?", organizationIDs).
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&apiKeys).Error
+
+	return apiKeys, total, err
+}
+
 // Update updates API key data
 func (r *apiKeyRepository) Update(apiKey *entity.APIKey) error {
 	return r.db.Save(apiKey).Error
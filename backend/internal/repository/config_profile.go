@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ConfigProfileRepository defines configuration profile repository interface
+type ConfigProfileRepository interface {
+	Create(profile *entity.ConfigProfile) error
+	GetByID(id uint) (*entity.ConfigProfile, error)
+	GetByOrganizationIDs(organizationIDs []uint) ([]entity.ConfigProfile, error)
+	GetDefault(organizationID uint) (*entity.ConfigProfile, error)
+	Update(profile *entity.ConfigProfile, version *entity.ConfigProfileVersion) error
+	Delete(id uint) error
+	GetVersions(profileID uint) ([]entity.ConfigProfileVersion, error)
+	AssignVehicles(profileID uint, vehicleIDs []uint, assignedByUserID uint) error
+	UnassignVehicle(vehicleID uint) (bool, error)
+	GetAssignedVehicleIDs(profileID uint) ([]uint, error)
+	GetAssignedToVehicle(vehicleID uint) (*entity.ConfigProfile, error)
+}
+
+// configProfileRepository implements ConfigProfileRepository interface
+type configProfileRepository struct {
+	db *gorm.DB
+}
+
+// NewConfigProfileRepository creates new configuration profile repository instance
+func NewConfigProfileRepository(db *gorm.DB) ConfigProfileRepository {
+	return &configProfileRepository{db: db}
+}
+
+// Create creates a configuration profile together with its first version.
+// A default profile replaces the organization's previous default.
+func (r *configProfileRepository) Create(profile *entity.ConfigProfile) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if profile.IsDefault {
+			if err := clearDefaultProfile(tx, profile.OrganizationID, 0); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(profile).Error; err != nil {
+			return err
+		}
+		return tx.Create(&entity.ConfigProfileVersion{
+			ProfileID:       profile.ID,
+			Version:         profile.Version,
+			Settings:        profile.Settings,
+			CreatedByUserID: profile.CreatedByUserID,
+		}).Error
+	})
+}
+
+// GetByID gets configuration profile by ID
+func (r *configProfileRepository) GetByID(id uint) (*entity.ConfigProfile, error) {
+	var profile entity.ConfigProfile
+	err := r.db.First(&profile, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// GetByOrganizationIDs gets configuration profiles of the organizations
+func (r *configProfileRepository) GetByOrganizationIDs(organizationIDs []uint) ([]entity.ConfigProfile, error) {
+	var profiles []entity.ConfigProfile
+	if len(organizationIDs) == 0 {
+		return profiles, nil
+	}
+	err := r.db.Where("organization_id IN ?", organizationIDs).
+		Order("name ASC").
+		Find(&profiles).Error
+	return profiles, err
+}
+
+// GetDefault gets the default configuration profile of the organization
+func (r *configProfileRepository) GetDefault(organizationID uint) (*entity.ConfigProfile, error) {
+	var profile entity.ConfigProfile
+	err := r.db.Where("organization_id = ? AND is_default = ?", organizationID, true).First(&profile).Error
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// Update updates a configuration profile and, when the settings changed, records the new version
+func (r *configProfileRepository) Update(profile *entity.ConfigProfile, version *entity.ConfigProfileVersion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if profile.IsDefault {
+			if err := clearDefaultProfile(tx, profile.OrganizationID, profile.ID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(profile).Error; err != nil {
+			return err
+		}
+		if version == nil {
+			return nil
+		}
+		return tx.Create(version).Error
+	})
+}
+
+// Delete soft deletes configuration profile by ID and removes its vehicle assignments
+func (r *configProfileRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("profile_id = ?", id).Delete(&entity.VehicleConfigAssignment{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.ConfigProfile{}, id).Error
+	})
+}
+
+// GetVersions gets every version of a configuration profile, newest first
+func (r *configProfileRepository) GetVersions(profileID uint) ([]entity.ConfigProfileVersion, error) {
+	var versions []entity.ConfigProfileVersion
+	err := r.db.Where("profile_id = ?", profileID).
+		Order("version DESC").
+		Find(&versions).Error
+	return versions, err
+}
+
+// AssignVehicles assigns the profile to the vehicles, replacing their previous assignments
+func (r *configProfileRepository) AssignVehicles(profileID uint, vehicleIDs []uint, assignedByUserID uint) error {
+	if len(vehicleIDs) == 0 {
+		return nil
+	}
+	assignments := make([]entity.VehicleConfigAssignment, len(vehicleIDs))
+	for i, vehicleID := range vehicleIDs {
+		assignments[i] = entity.VehicleConfigAssignment{
+			VehicleID:        vehicleID,
+			ProfileID:        profileID,
+			AssignedByUserID: assignedByUserID,
+		}
+	}
+	return r.db.Omit("Profile").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "vehicle_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"profile_id", "assigned_by_user_id"}),
+	}).Create(&assignments).Error
+}
+
+// UnassignVehicle removes the vehicle's profile assignment. It reports false when none existed.
+func (r *configProfileRepository) UnassignVehicle(vehicleID uint) (bool, error) {
+	tx := r.db.Where("vehicle_id = ?", vehicleID).Delete(&entity.VehicleConfigAssignment{})
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// GetAssignedVehicleIDs gets the vehicles the profile is assigned to
+func (r *configProfileRepository) GetAssignedVehicleIDs(profileID uint) ([]uint, error) {
+	var vehicleIDs []uint
+	err := r.db.Model(&entity.VehicleConfigAssignment{}).
+		Where("profile_id = ?", profileID).
+		Order("vehicle_id ASC").
+		Pluck("vehicle_id", &vehicleIDs).Error
+	return vehicleIDs, err
+}
+
+// GetAssignedToVehicle gets the profile assigned to the vehicle
+func (r *configProfileRepository) GetAssignedToVehicle(vehicleID uint) (*entity.ConfigProfile, error) {
+	var profile entity.ConfigProfile
+	err := r.db.Joins("JOIN vehicle_config_assignments ON vehicle_config_assignments.profile_id = config_profiles.id").
+		Where("vehicle_config_assignments.vehicle_id = ?", vehicleID).
+		First(&profile).Error
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// clearDefaultProfile unsets the default flag of the organization's profiles other than exceptID
+func clearDefaultProfile(tx *gorm.DB, organizationID, exceptID uint) error {
+	return tx.Model(&entity.ConfigProfile{}).
+		Where("organization_id = ? AND is_default = ? AND id <> ?", organizationID, true, exceptID).
+		Update("is_default", false).Error
+}
@@ -73,13 +73,13 @@ func (r *dashboardRepository) GetTotalCustomers() (int, error) {
 
 func (r *dashboardRepository) GetTotalVehiclesByUser(userID uint) (int, error) {
 	var total int64
-	err := r.db.Model(&entity.Vehicle{}).Where("user_id = ?", userID).Count(&total).Error
+	err := r.db.Model(&entity.Vehicle{}).Where("organization_id IN ("+memberOrganizationIDs+")", userID).Count(&total).Error
 	return int(total), err
 }
 
 func (r *dashboardRepository) GetTotalAPIKeysByUser(userID uint) (int, error) {
 	var total int64
-	err := r.db.Model(&entity.APIKey{}).Where("user_id = ?", userID).Count(&total).Error
+	err := r.db.Model(&entity.APIKey{}).Where("organization_id IN ("+memberOrganizationIDs+")", userID).Count(&total).Error
 	return int(total), err
 }
 
@@ -87,7 +87,7 @@ func (r *dashboardRepository) GetTotalFuelLogsByUser(userID uint) (int, error) {
 	var total int64
 	err := r.db.Model(&entity.FuelLog{}).
 		Joins("JOIN vehicles ON fuel_logs.vehicle_id = vehicles.id").
-		Where("vehicles.user_id = ?", userID).
+		Where("vehicles.organization_id IN ("+memberOrganizationIDs+")", userID).
 		Count(&total).Error
 	return int(total), err
 }
@@ -96,7 +96,7 @@ func (r *dashboardRepository) GetTotalLocationLogsByUser(userID uint) (int, erro
 	var total int64
 	err := r.db.Model(&entity.LocationLog{}).
 		Joins("JOIN vehicles ON location_logs.vehicle_id = vehicles.id").
-		Where("vehicles.user_id = ?", userID).
+		Where("vehicles.organization_id IN ("+memberOrganizationIDs+")", userID).
 		Count(&total).Error
 	return int(total), err
 }
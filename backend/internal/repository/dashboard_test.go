@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newDryRunDB opens a database handle that builds SQL without connecting, and returns the
+// statements built so far
+func newDryRunDB(t *testing.T) (*gorm.DB, func() []string) {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=dryrun"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+
+	var statements []string
+	record := func(tx *gorm.DB) { statements = append(statements, tx.Statement.SQL.String()) }
+	if err := db.Callback().Query().After("gorm:query").Register("test:record", record); err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("test:record", record); err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	return db, func() []string { return statements }
+}
+
+func TestUserScopedQueriesSkipDeletedOrganizations(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	dashboards := NewDashboardRepository(db)
+	locationLogs := NewLocationLogRepository(db, NewSpatial(db, false))
+
+	queries := map[string]func() error{
+		"GetTotalVehiclesByUser": func() error { _, err := dashboards.GetTotalVehiclesByUser(7); return err },
+		"GetTotalAPIKeysByUser":  func() error { _, err := dashboards.GetTotalAPIKeysByUser(7); return err },
+		"GetTotalFuelLogsByUser": func() error { _, err := dashboards.GetTotalFuelLogsByUser(7); return err },
+		"GetByUserIDWithPagination": func() error {
+			_, _, err := locationLogs.GetByUserIDWithPagination(7, 10, 0)
+			return err
+		},
+	}
+
+	for name, query := range queries {
+		before := len(statements())
+		if err := query(); err != nil {
+			t.Fatalf("%s() error = %v", name, err)
+		}
+		built := statements()[before:]
+		if len(built) == 0 {
+			t.Fatalf("%s() built no statement", name)
+		}
+		for _, sql := range built {
+			if !strings.Contains(sql, "organizations.deleted_at IS NULL") {
+				t.Errorf("%s() does not exclude deleted organizations:\n%s", name, sql)
+			}
+		}
+	}
+}
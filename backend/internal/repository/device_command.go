@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// DeviceCommandRepository defines device command repository interface
+type DeviceCommandRepository interface {
+	Create(command *entity.DeviceCommand) error
+	GetByID(id uint) (*entity.DeviceCommand, error)
+	GetByVehicleIDWithPagination(vehicleID uint, status string, limit, offset int) ([]entity.DeviceCommand, int64, error)
+	ClaimPending(vehicleID uint, now time.Time, limit int) ([]entity.DeviceCommand, error)
+	Complete(id uint, status entity.DeviceCommandStatus, result, errorMessage *string, now time.Time) (bool, error)
+	ExpireDue(now time.Time) (int64, error)
+}
+
+// deviceCommandRepository implements DeviceCommandRepository interface
+type deviceCommandRepository struct {
+	db *gorm.DB
+}
+
+// NewDeviceCommandRepository creates new device command repository instance
+func NewDeviceCommandRepository(db *gorm.DB) DeviceCommandRepository {
+	return &deviceCommandRepository{db: db}
+}
+
+// Create creates a new device command
+func (r *deviceCommandRepository) Create(command *entity.DeviceCommand) error {
+	return r.db.Create(command).Error
+}
+
+// GetByID gets device command by ID with its vehicle and issuer
+func (r *deviceCommandRepository) GetByID(id uint) (*entity.DeviceCommand, error) {
+	var command entity.DeviceCommand
+	err := r.db.Preload("Vehicle").Preload("IssuedByUser").First(&command, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &command, nil
+}
+
+// GetByVehicleIDWithPagination gets the command history of a vehicle, newest first, optionally filtered by status
+func (r *deviceCommandRepository) GetByVehicleIDWithPagination(vehicleID uint, status string, limit, offset int) ([]entity.DeviceCommand, int64, error) {
+	var commands []entity.DeviceCommand
+	var total int64
+
+	query := r.db.Model(&entity.DeviceCommand{}).Where("vehicle_id = ?", vehicleID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("IssuedByUser").
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&commands).Error
+	return commands, total, err
+}
+
+// ClaimPending marks the oldest pending, unexpired commands of a vehicle delivered and returns them in queue order.
+// Each command is handed out once, so concurrent fetches of the same device never receive the same command.
+func (r *deviceCommandRepository) ClaimPending(vehicleID uint, now time.Time, limit int) ([]entity.DeviceCommand, error) {
+	var commands []entity.DeviceCommand
+	err := r.db.Raw(`
+		WITH claimed AS (
+			UPDATE device_commands SET status = ?, delivered_at = ?
+			WHERE id IN (
+				SELECT id FROM device_commands
+				WHERE vehicle_id = ? AND status = ? AND expires_at > ?
+				ORDER BY id
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT * FROM claimed ORDER BY id`,
+		entity.DeviceCommandDelivered, now, vehicleID, entity.DeviceCommandPending, now, limit).
+		Scan(&commands).Error
+	return commands, err
+}
+
+// Complete records the result reported by the device for a delivered command. A result arriving after the
+// command expired is still recorded, as the device did act on it. It reports false when the command was
+// never delivered or already has a result.
+func (r *deviceCommandRepository) Complete(id uint, status entity.DeviceCommandStatus, result, errorMessage *string, now time.Time) (bool, error) {
+	tx := r.db.Model(&entity.DeviceCommand{}).
+		Where("id = ? AND delivered_at IS NOT NULL AND status IN ?", id,
+			[]entity.DeviceCommandStatus{entity.DeviceCommandDelivered, entity.DeviceCommandExpired}).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"result":       result,
+			"error":        errorMessage,
+			"completed_at": now,
+		})
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// ExpireDue expires open commands whose expiry has passed and returns how many were expired
+func (r *deviceCommandRepository) ExpireDue(now time.Time) (int64, error) {
+	tx := r.db.Model(&entity.DeviceCommand{}).
+		Where("status IN ? AND expires_at <= ?",
+			[]entity.DeviceCommandStatus{entity.DeviceCommandPending, entity.DeviceCommandDelivered}, now).
+		Update("status", entity.DeviceCommandExpired)
+	return tx.RowsAffected, tx.Error
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// DeviceStatusRepository defines device status repository interface
+type DeviceStatusRepository interface {
+	RecordContact(vehicleID uint, at time.Time, ip, firmwareVersion *string, signalStrength *int) error
+	RecordLocation(vehicleID uint, at time.Time, moving bool) error
+	RecordConfigApplied(vehicleID, profileID uint, version int, at time.Time) error
+	GetByVehicleID(vehicleID uint) (*entity.DeviceStatus, error)
+	GetByVehicleIDs(vehicleIDs []uint) ([]entity.DeviceStatus, error)
+}
+
+// deviceStatusRepository implements DeviceStatusRepository interface
+type deviceStatusRepository struct {
+	db *gorm.DB
+}
+
+// NewDeviceStatusRepository creates new device status repository instance
+func NewDeviceStatusRepository(db *gorm.DB) DeviceStatusRepository {
+	return &deviceStatusRepository{db: db}
+}
+
+// RecordContact records a request from the vehicle's device. Timestamps never move backwards
+// and device details that were not reported keep their previous value.
+func (r *deviceStatusRepository) RecordContact(vehicleID uint, at time.Time, ip, firmwareVersion *string, signalStrength *int) error {
+	return r.db.Exec(`
+		INSERT INTO device_statuses (vehicle_id, last_seen_at, last_ip, firmware_version, signal_strength)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (vehicle_id) DO UPDATE SET
+			last_seen_at = GREATEST(device_statuses.last_seen_at, EXCLUDED.last_seen_at),
+			last_ip = COALESCE(EXCLUDED.last_ip, device_statuses.last_ip),
+			firmware_version = COALESCE(EXCLUDED.firmware_version, device_statuses.firmware_version),
+			signal_strength = COALESCE(EXCLUDED.signal_strength, device_statuses.signal_strength)`,
+		vehicleID, at, ip, firmwareVersion, signalStrength).Error
+}
+
+// RecordLocation records a location of the vehicle, and its movement when moving
+func (r *deviceStatusRepository) RecordLocation(vehicleID uint, at time.Time, moving bool) error {
+	var movingAt *time.Time
+	if moving {
+		movingAt = &at
+	}
+	return r.db.Exec(`
+		INSERT INTO device_statuses (vehicle_id, last_seen_at, last_location_at, last_moving_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (vehicle_id) DO UPDATE SET
+			last_seen_at = GREATEST(device_statuses.last_seen_at, EXCLUDED.last_seen_at),
+			last_location_at = GREATEST(device_statuses.last_location_at, EXCLUDED.last_location_at),
+			last_moving_at = GREATEST(device_statuses.last_moving_at, EXCLUDED.last_moving_at)`,
+		vehicleID, at, at, movingAt).Error
+}
+
+// RecordConfigApplied records the configuration profile version the vehicle's device applied
+func (r *deviceStatusRepository) RecordConfigApplied(vehicleID, profileID uint, version int, at time.Time) error {
+	return r.db.Exec(`
+		INSERT INTO device_statuses (vehicle_id, last_seen_at, config_profile_id, config_version, config_applied_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (vehicle_id) DO UPDATE SET
+			last_seen_at = GREATEST(device_statuses.last_seen_at, EXCLUDED.last_seen_at),
+			config_profile_id = EXCLUDED.config_profile_id,
+			config_version = EXCLUDED.config_version,
+			config_applied_at = EXCLUDED.config_applied_at`,
+		vehicleID, at, profileID, version, at).Error
+}
+
+// GetByVehicleID gets the device status of a vehicle
+func (r *deviceStatusRepository) GetByVehicleID(vehicleID uint) (*entity.DeviceStatus, error) {
+	var status entity.DeviceStatus
+	err := r.db.Where("vehicle_id = ?", vehicleID).First(&status).Error
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetByVehicleIDs gets device statuses of the vehicles. Vehicles whose device never reported are absent.
+func (r *deviceStatusRepository) GetByVehicleIDs(vehicleIDs []uint) ([]entity.DeviceStatus, error) {
+	var statuses []entity.DeviceStatus
+	if len(vehicleIDs) == 0 {
+		return statuses, nil
+	}
+	err := r.db.Where("vehicle_id IN ?", vehicleIDs).Find(&statuses).Error
+	return statuses, err
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// DriverRepository defines driver repository interface
+type DriverRepository interface {
+	Create(driver *entity.Driver) error
+	GetByID(id uint) (*entity.Driver, error)
+	GetByOrganizationIDs(organizationIDs []uint, limit, offset int) ([]entity.Driver, int64, error)
+	GetByIdentificationTag(organizationID uint, tag string) (*entity.Driver, error)
+	Update(driver *entity.Driver) error
+	Delete(id uint) error
+}
+
+// driverRepository implements DriverRepository interface
+type driverRepository struct {
+	db *gorm.DB
+}
+
+// NewDriverRepository creates new driver repository instance
+func NewDriverRepository(db *gorm.DB) DriverRepository {
+	return &driverRepository{db: db}
+}
+
+// Create creates a new driver
+func (r *driverRepository) Create(driver *entity.Driver) error {
+	return r.db.Create(driver).Error
+}
+
+// GetByID gets driver by ID
+func (r *driverRepository) GetByID(id uint) (*entity.Driver, error) {
+	var driver entity.Driver
+	err := r.db.First(&driver, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &driver, nil
+}
+
+// GetByOrganizationIDs gets drivers of the given organizations with pagination info
+func (r *driverRepository) GetByOrganizationIDs(organizationIDs []uint, limit, offset int) ([]entity.Driver, int64, error) {
+	var drivers []entity.Driver
+	var total int64
+
+	if len(organizationIDs) == 0 {
+		return drivers, 0, nil
+	}
+
+	// Get total count
+	err := r.db.Model(&entity.Driver{}).Where("organization_id IN ?", organizationIDs).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated data
+	err = r.db.Where("organization_id IN ?", organizationIDs).
+		Order("name ASC").
+		Limit(limit).Offset(offset).
+		Find(&drivers).Error
+
+	return drivers, total, err
+}
+
+// GetByIdentificationTag gets the driver of an organization carrying the RFID/iButton tag
+func (r *driverRepository) GetByIdentificationTag(organizationID uint, tag string) (*entity.Driver, error) {
+	var driver entity.Driver
+	err := r.db.Where("organization_id = ? AND identification_tag = ?", organizationID, tag).First(&driver).Error
+	if err != nil {
+		return nil, err
+	}
+	return &driver, nil
+}
+
+// Update updates driver data
+func (r *driverRepository) Update(driver *entity.Driver) error {
+	return r.db.Save(driver).Error
+}
+
+// Delete soft deletes driver by ID
+func (r *driverRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.Driver{}, id).Error
+}
+
+// DriverAssignmentRepository defines driver assignment repository interface
+type DriverAssignmentRepository interface {
+	Create(assignment *entity.DriverAssignment) error
+	GetByID(id uint) (*entity.DriverAssignment, error)
+	GetActiveByVehicleID(vehicleID uint) (*entity.DriverAssignment, error)
+	GetActiveByDriverID(driverID uint) (*entity.DriverAssignment, error)
+	GetByVehicleID(vehicleID uint, limit, offset int) ([]entity.DriverAssignment, error)
+	GetByDriverID(driverID uint, limit, offset int) ([]entity.DriverAssignment, error)
+	GetOverlapping(vehicleIDs []uint, start, end time.Time) ([]entity.DriverAssignment, error)
+	End(id uint, at time.Time) error
+	Reassign(assignment *entity.DriverAssignment) error
+}
+
+// driverAssignmentRepository implements DriverAssignmentRepository interface
+type driverAssignmentRepository struct {
+	db *gorm.DB
+}
+
+// NewDriverAssignmentRepository creates new driver assignment repository instance
+func NewDriverAssignmentRepository(db *gorm.DB) DriverAssignmentRepository {
+	return &driverAssignmentRepository{db: db}
+}
+
+// Create creates a new driver assignment
+func (r *driverAssignmentRepository) Create(assignment *entity.DriverAssignment) error {
+	return r.db.Omit("Driver", "Vehicle").Create(assignment).Error
+}
+
+// GetByID gets driver assignment by ID
+func (r *driverAssignmentRepository) GetByID(id uint) (*entity.DriverAssignment, error) {
+	var assignment entity.DriverAssignment
+	err := r.db.Preload("Driver").First(&assignment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// GetActiveByVehicleID gets the open assignment of a vehicle
+func (r *driverAssignmentRepository) GetActiveByVehicleID(vehicleID uint) (*entity.DriverAssignment, error) {
+	var assignment entity.DriverAssignment
+	err := r.db.Preload("Driver").Where("vehicle_id = ? AND end_at IS NULL", vehicleID).First(&assignment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// GetActiveByDriverID gets the open assignment of a driver
+func (r *driverAssignmentRepository) GetActiveByDriverID(driverID uint) (*entity.DriverAssignment, error) {
+	var assignment entity.DriverAssignment
+	err := r.db.Preload("Driver").Where("driver_id = ? AND end_at IS NULL", driverID).First(&assignment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// GetByVehicleID gets assignment history of a vehicle, newest first
+func (r *driverAssignmentRepository) GetByVehicleID(vehicleID uint, limit, offset int) ([]entity.DriverAssignment, error) {
+	var assignments []entity.DriverAssignment
+	err := r.db.Preload("Driver").
+		Where("vehicle_id = ?", vehicleID).
+		Order("start_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// GetByDriverID gets assignment history of a driver, newest first
+func (r *driverAssignmentRepository) GetByDriverID(driverID uint, limit, offset int) ([]entity.DriverAssignment, error) {
+	var assignments []entity.DriverAssignment
+	err := r.db.Preload("Driver").
+		Where("driver_id = ?", driverID).
+		Order("start_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// GetOverlapping gets assignments of the vehicles that were active at any point between start and end
+func (r *driverAssignmentRepository) GetOverlapping(vehicleIDs []uint, start, end time.Time) ([]entity.DriverAssignment, error) {
+	var assignments []entity.DriverAssignment
+	if len(vehicleIDs) == 0 {
+		return assignments, nil
+	}
+	err := r.db.Preload("Driver").
+		Where("vehicle_id IN ? AND start_at <= ? AND (end_at IS NULL OR end_at > ?)", vehicleIDs, end, start).
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// End closes an open assignment
+func (r *driverAssignmentRepository) End(id uint, at time.Time) error {
+	return r.db.Model(&entity.DriverAssignment{}).
+		Where("id = ? AND end_at IS NULL", id).
+		Update("end_at", at).Error
+}
+
+// Reassign closes any open assignment of the new assignment's vehicle and driver and creates it, atomically
+func (r *driverAssignmentRepository) Reassign(assignment *entity.DriverAssignment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.DriverAssignment{}).
+			Where("(vehicle_id = ? OR driver_id = ?) AND end_at IS NULL", assignment.VehicleID, assignment.DriverID).
+			Update("end_at", gorm.Expr("GREATEST(start_at, ?::timestamptz)", assignment.StartAt)).Error
+		if err != nil {
+			return err
+		}
+		return tx.Omit("Driver", "Vehicle").Create(assignment).Error
+	})
+}
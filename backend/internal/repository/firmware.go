@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// FirmwareReleaseRepository defines firmware release repository interface
+type FirmwareReleaseRepository interface {
+	Create(release *entity.FirmwareRelease) error
+	GetByID(id uint) (*entity.FirmwareRelease, error)
+	GetByOrganizationIDs(organizationIDs []uint, board string) ([]entity.FirmwareRelease, error)
+	ExistsVersion(organizationID uint, board, version string) (bool, error)
+	Delete(id uint) error
+}
+
+// firmwareReleaseRepository implements FirmwareReleaseRepository interface
+type firmwareReleaseRepository struct {
+	db *gorm.DB
+}
+
+// NewFirmwareReleaseRepository creates new firmware release repository instance
+func NewFirmwareReleaseRepository(db *gorm.DB) FirmwareReleaseRepository {
+	return &firmwareReleaseRepository{db: db}
+}
+
+// Create creates a new firmware release
+func (r *firmwareReleaseRepository) Create(release *entity.FirmwareRelease) error {
+	return r.db.Create(release).Error
+}
+
+// GetByID gets firmware release by ID
+func (r *firmwareReleaseRepository) GetByID(id uint) (*entity.FirmwareRelease, error) {
+	var release entity.FirmwareRelease
+	err := r.db.First(&release, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// GetByOrganizationIDs gets firmware releases of the organizations, newest first, optionally for one board
+func (r *firmwareReleaseRepository) GetByOrganizationIDs(organizationIDs []uint, board string) ([]entity.FirmwareRelease, error) {
+	var releases []entity.FirmwareRelease
+	if len(organizationIDs) == 0 {
+		return releases, nil
+	}
+	query := r.db.Where("organization_id IN ?", organizationIDs)
+	if board != "" {
+		query = query.Where("board = ?", board)
+	}
+	err := query.Order("created_at DESC, id DESC").Find(&releases).Error
+	return releases, err
+}
+
+// ExistsVersion checks if the organization already has the version for the board
+func (r *firmwareReleaseRepository) ExistsVersion(organizationID uint, board, version string) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.FirmwareRelease{}).
+		Where("organization_id = ? AND board = ? AND version = ?", organizationID, board, version).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Delete soft deletes firmware release by ID
+func (r *firmwareReleaseRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.FirmwareRelease{}, id).Error
+}
+
+// FirmwareRolloutRepository defines firmware rollout repository interface
+type FirmwareRolloutRepository interface {
+	Create(rollout *entity.FirmwareRollout) error
+	GetByID(id uint) (*entity.FirmwareRollout, error)
+	GetByOrganizationIDs(organizationIDs []uint) ([]entity.FirmwareRollout, error)
+	GetEnabledForBoard(organizationID uint, board string) ([]entity.FirmwareRollout, error)
+	CountEnabledByReleaseID(releaseID uint) (int64, error)
+	Update(rollout *entity.FirmwareRollout) error
+	Delete(id uint) error
+	DeleteByReleaseID(releaseID uint) error
+}
+
+// firmwareRolloutRepository implements FirmwareRolloutRepository interface
+type firmwareRolloutRepository struct {
+	db *gorm.DB
+}
+
+// NewFirmwareRolloutRepository creates new firmware rollout repository instance
+func NewFirmwareRolloutRepository(db *gorm.DB) FirmwareRolloutRepository {
+	return &firmwareRolloutRepository{db: db}
+}
+
+// Create creates a new firmware rollout
+func (r *firmwareRolloutRepository) Create(rollout *entity.FirmwareRollout) error {
+	return r.db.Omit("Release").Create(rollout).Error
+}
+
+// GetByID gets firmware rollout by ID with its release
+func (r *firmwareRolloutRepository) GetByID(id uint) (*entity.FirmwareRollout, error) {
+	var rollout entity.FirmwareRollout
+	err := r.db.Preload("Release").First(&rollout, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rollout, nil
+}
+
+// GetByOrganizationIDs gets firmware rollouts of the organizations with their releases, newest first
+func (r *firmwareRolloutRepository) GetByOrganizationIDs(organizationIDs []uint) ([]entity.FirmwareRollout, error) {
+	var rollouts []entity.FirmwareRollout
+	if len(organizationIDs) == 0 {
+		return rollouts, nil
+	}
+	err := r.db.Preload("Release").
+		Where("organization_id IN ?", organizationIDs).
+		Order("id DESC").
+		Find(&rollouts).Error
+	return rollouts, err
+}
+
+// GetEnabledForBoard gets the organization's enabled rollouts of releases for the board, newest first
+func (r *firmwareRolloutRepository) GetEnabledForBoard(organizationID uint, board string) ([]entity.FirmwareRollout, error) {
+	var rollouts []entity.FirmwareRollout
+	err := r.db.Joins("Release").
+		Where("firmware_rollouts.organization_id = ? AND firmware_rollouts.enabled = ? AND \"Release\".board = ?", organizationID, true, board).
+		Order("firmware_rollouts.id DESC").
+		Find(&rollouts).Error
+	return rollouts, err
+}
+
+// CountEnabledByReleaseID counts enabled rollouts of a release
+func (r *firmwareRolloutRepository) CountEnabledByReleaseID(releaseID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.FirmwareRollout{}).
+		Where("release_id = ? AND enabled = ?", releaseID, true).
+		Count(&count).Error
+	return count, err
+}
+
+// Update updates firmware rollout data
+func (r *firmwareRolloutRepository) Update(rollout *entity.FirmwareRollout) error {
+	return r.db.Omit("Release").Save(rollout).Error
+}
+
+// Delete soft deletes firmware rollout by ID
+func (r *firmwareRolloutRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.FirmwareRollout{}, id).Error
+}
+
+// DeleteByReleaseID soft deletes every rollout of a release
+func (r *firmwareRolloutRepository) DeleteByReleaseID(releaseID uint) error {
+	return r.db.Where("release_id = ?", releaseID).Delete(&entity.FirmwareRollout{}).Error
+}
+
+// FirmwareInstallRepository defines firmware install repository interface
+type FirmwareInstallRepository interface {
+	Create(install *entity.FirmwareInstall) error
+	CountFailed(vehicleID, releaseID uint) (int64, error)
+	GetByReleaseIDWithPagination(releaseID uint, status string, limit, offset int) ([]entity.FirmwareInstall, int64, error)
+	GetOutcomes(releaseID uint) (map[uint]bool, error)
+}
+
+// firmwareInstallRepository implements FirmwareInstallRepository interface
+type firmwareInstallRepository struct {
+	db *gorm.DB
+}
+
+// NewFirmwareInstallRepository creates new firmware install repository instance
+func NewFirmwareInstallRepository(db *gorm.DB) FirmwareInstallRepository {
+	return &firmwareInstallRepository{db: db}
+}
+
+// Create creates a new firmware install report
+func (r *firmwareInstallRepository) Create(install *entity.FirmwareInstall) error {
+	return r.db.Omit("Release").Create(install).Error
+}
+
+// CountFailed counts failed installs of a release reported by a vehicle
+func (r *firmwareInstallRepository) CountFailed(vehicleID, releaseID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.FirmwareInstall{}).
+		Where("vehicle_id = ? AND release_id = ? AND status = ?", vehicleID, releaseID, entity.FirmwareInstallFailed).
+		Count(&count).Error
+	return count, err
+}
+
+// GetByReleaseIDWithPagination gets install reports of a release, newest first, optionally filtered by status
+func (r *firmwareInstallRepository) GetByReleaseIDWithPagination(releaseID uint, status string, limit, offset int) ([]entity.FirmwareInstall, int64, error) {
+	var installs []entity.FirmwareInstall
+	var total int64
+
+	query := r.db.Model(&entity.FirmwareInstall{}).Where("release_id = ?", releaseID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&installs).Error
+	return installs, total, err
+}
+
+// GetOutcomes gets, for every vehicle that reported on the release, whether any of its installs succeeded
+func (r *firmwareInstallRepository) GetOutcomes(releaseID uint) (map[uint]bool, error) {
+	var rows []struct {
+		VehicleID uint
+		Installed bool
+	}
+	err := r.db.Model(&entity.FirmwareInstall{}).
+		Select("vehicle_id, BOOL_OR(status = ?) AS installed", entity.FirmwareInstallInstalled).
+		Where("release_id = ?", releaseID).
+		Group("vehicle_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make(map[uint]bool, len(rows))
+	for _, row := range rows {
+		outcomes[row.VehicleID] = row.Installed
+	}
+	return outcomes, nil
+}
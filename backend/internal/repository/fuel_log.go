@@ -14,12 +14,14 @@ type FuelLogRepository interface {
 	GetByVehicleID(vehicleID uint, limit, offset int) ([]entity.FuelLog, error)
 	GetByVehicleIDAndDateRange(vehicleID uint, startDate, endDate time.Time, limit, offset int) ([]entity.FuelLog, error)
 	GetLatestByVehicleID(vehicleID uint) (*entity.FuelLog, error)
+	GetLatestByVehicleIDs(vehicleIDs []uint) ([]entity.FuelLog, error)
 	Update(fuelLog *entity.FuelLog) error
 	Delete(id uint) error
 	GetAll(limit, offset int) ([]entity.FuelLog, error)
 	CountByVehicleID(vehicleID uint) (int64, error)
 	Count() (int64, error)
 	GetFuelStatistics(vehicleID uint, startDate, endDate time.Time) (map[string]interface{}, error)
+	GetUsage(vehicleID uint, since, until time.Time) (*entity.FuelUsage, error)
 }
 
 // fuelLogRepository implements FuelLogRepository interface
@@ -82,6 +84,23 @@ func (r *fuelLogRepository) GetLatestByVehicleID(vehicleID uint) (*entity.FuelLo
 	return &fuelLog, nil
 }
 
+// GetLatestByVehicleIDs gets the latest fuel log of each vehicle. Vehicles without one are left out.
+func (r *fuelLogRepository) GetLatestByVehicleIDs(vehicleIDs []uint) ([]entity.FuelLog, error) {
+	var fuelLogs []entity.FuelLog
+	if len(vehicleIDs) == 0 {
+		return fuelLogs, nil
+	}
+
+	err := r.db.Raw(`
+		SELECT DISTINCT ON (vehicle_id) *
+		FROM fuel_logs
+		WHERE vehicle_id IN ? AND deleted_at IS NULL
+		ORDER BY vehicle_id, timestamp DESC`,
+		vehicleIDs).
+		Scan(&fuelLogs).Error
+	return fuelLogs, err
+}
+
 // Update updates fuel log data
 func (r *fuelLogRepository) Update(fuelLog *entity.FuelLog) error {
 	return r.db.Save(fuelLog).Error
@@ -149,3 +168,39 @@ func (r *fuelLogRepository) GetFuelStatistics(vehicleID uint, startDate, endDate
 		"total_entries":      result.Count,
 	}, nil
 }
+
+// refuelMinRise is the smallest rise of the fuel level between two readings counted as a refuel, in percent.
+// Smaller rises are sensor noise, e.g. fuel sloshing while the vehicle moves.
+const refuelMinRise = 5.0
+
+// GetUsage derives fuel consumed and refuelled by a vehicle between since and until (exclusive) from its fuel readings
+func (r *fuelLogRepository) GetUsage(vehicleID uint, since, until time.Time) (*entity.FuelUsage, error) {
+	var usage entity.FuelUsage
+	err := r.db.Raw(`
+		WITH ordered AS (
+			SELECT fuel_level, timestamp,
+				fuel_level - LAG(fuel_level) OVER w AS rise,
+				FIRST_VALUE(fuel_level) OVER w AS start_level,
+				LAST_VALUE(fuel_level) OVER (w ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) AS end_level
+			FROM fuel_logs
+			WHERE vehicle_id = ? AND timestamp >= ? AND timestamp < ? AND deleted_at IS NULL
+			WINDOW w AS (ORDER BY timestamp)
+		)
+		SELECT
+			COUNT(*) AS readings,
+			MIN(start_level) AS start_level,
+			MIN(end_level) AS end_level,
+			COALESCE(SUM(rise) FILTER (WHERE rise >= ?), 0) AS refuelled,
+			COUNT(*) FILTER (WHERE rise >= ?) AS refuels
+		FROM ordered`,
+		vehicleID, since, until, refuelMinRise, refuelMinRise).
+		Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if usage.StartLevel != nil && usage.EndLevel != nil {
+		usage.Consumed = max(*usage.StartLevel-*usage.EndLevel+usage.Refuelled, 0)
+	}
+	return &usage, nil
+}
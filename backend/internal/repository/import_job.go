@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// ImportJobRepository defines import job repository interface
+type ImportJobRepository interface {
+	Create(job *entity.ImportJob) error
+	GetByID(id uint) (*entity.ImportJob, error)
+	GetByOrganizationIDsWithPagination(organizationIDs []uint, status string, limit, offset int) ([]entity.ImportJob, int64, error)
+	ClaimNext(now, staleBefore time.Time) (*entity.ImportJob, error)
+	UpdateProgress(job *entity.ImportJob) error
+}
+
+// importJobRepository implements ImportJobRepository interface
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+// NewImportJobRepository creates new import job repository instance
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+// Create creates a new import job
+func (r *importJobRepository) Create(job *entity.ImportJob) error {
+	return r.db.Omit("Vehicle").Create(job).Error
+}
+
+// GetByID gets import job by ID
+func (r *importJobRepository) GetByID(id uint) (*entity.ImportJob, error) {
+	var job entity.ImportJob
+	err := r.db.First(&job, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetByOrganizationIDsWithPagination gets import jobs of the organizations, newest first, optionally filtered by status
+func (r *importJobRepository) GetByOrganizationIDsWithPagination(organizationIDs []uint, status string, limit, offset int) ([]entity.ImportJob, int64, error) {
+	var jobs []entity.ImportJob
+	var total int64
+	if len(organizationIDs) == 0 {
+		return jobs, 0, nil
+	}
+
+	query := r.db.Model(&entity.ImportJob{}).Where("organization_id IN ?", organizationIDs)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&jobs).Error
+	return jobs, total, err
+}
+
+// ClaimNext marks the oldest pending import running and returns it. A running import last updated before
+// staleBefore was abandoned by its worker and is claimed again from the start. Returns gorm.ErrRecordNotFound
+// when there is nothing to import.
+func (r *importJobRepository) ClaimNext(now, staleBefore time.Time) (*entity.ImportJob, error) {
+	var jobs []entity.ImportJob
+	err := r.db.Raw(`
+		UPDATE import_jobs SET status = ?, started_at = ?, completed_at = NULL, error = NULL, row_errors = NULL,
+			progress = 0, processed_rows = 0, imported_rows = 0, duplicate_rows = 0, invalid_rows = 0
+		WHERE id = (
+			SELECT id FROM import_jobs
+			WHERE status = ? OR (status = ? AND updated_at < ?)
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		entity.ImportJobRunning, now,
+		entity.ImportJobPending, entity.ImportJobRunning, staleBefore).
+		Scan(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &jobs[0], nil
+}
+
+// UpdateProgress saves the status, counters and errors of an import job
+func (r *importJobRepository) UpdateProgress(job *entity.ImportJob) error {
+	return r.db.Model(job).
+		Select("status", "progress", "processed_rows", "imported_rows", "duplicate_rows", "invalid_rows", "row_errors", "error", "completed_at").
+		Updates(job).Error
+}
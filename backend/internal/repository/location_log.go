@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/pkg/geo"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +19,10 @@ type LocationLogRepository interface {
 	GetByUserIDWithPagination(userID uint, limit, offset int) ([]entity.LocationLog, int64, error)
 	GetByUserIDWithDateRange(userID uint, startDate, endDate time.Time, limit, offset int) ([]entity.LocationLog, int64, error)
 	GetLatestByVehicleID(vehicleID uint) (*entity.LocationLog, error)
+	GetLatestTrustedByVehicleID(vehicleID uint) (*entity.LocationLog, error)
+	GetLatestTrustedByVehicleIDs(vehicleIDs []uint) ([]entity.LocationLog, error)
+	GetLatestTrustedWithin(vehicleIDs []uint, shape geo.Shape) ([]entity.LocationLog, error)
+	GetVisitsWithin(vehicleIDs []uint, since, until time.Time, shape geo.Shape) ([]entity.AreaVisit, error)
 	Update(locationLog *entity.LocationLog) error
 	Delete(id uint) error
 	GetAll(limit, offset int) ([]entity.LocationLog, error)
@@ -25,16 +30,23 @@ type LocationLogRepository interface {
 	CountByVehicleID(vehicleID uint) (int64, error)
 	Count() (int64, error)
 	GetLocationHistory(vehicleID uint, startDate, endDate time.Time) ([]entity.LocationLog, error)
+	StreamLocationHistory(vehicleID uint, startDate, endDate time.Time, fn func(log *entity.LocationLog) error) error
+	GetTimestampsBetween(vehicleID uint, startDate, endDate time.Time) ([]time.Time, error)
+	CreateBatch(locationLogs []entity.LocationLog) error
+	GetByDriverIDWithPagination(driverID uint, limit, offset int) ([]entity.LocationLog, int64, error)
+	GetUsage(vehicleID uint, since, until time.Time) (*entity.VehicleUsage, error)
+	GetActivity(vehicleID uint, since, until time.Time) (*entity.VehicleActivity, error)
 }
 
 // locationLogRepository implements LocationLogRepository interface
 type locationLogRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	spatial *Spatial
 }
 
 // NewLocationLogRepository creates new location log repository instance
-func NewLocationLogRepository(db *gorm.DB) LocationLogRepository {
-	return &locationLogRepository{db: db}
+func NewLocationLogRepository(db *gorm.DB, spatial *Spatial) LocationLogRepository {
+	return &locationLogRepository{db: db, spatial: spatial}
 }
 
 // Create creates a new location log
@@ -124,7 +136,7 @@ func (r *locationLogRepository) GetByUserIDWithPagination(userID uint, limit, of
 	// Get total count by joining with vehicles table
 	err := r.db.Model(&entity.LocationLog{}).
 		Joins("JOIN vehicles ON location_logs.vehicle_id = vehicles.id").
-		Where("vehicles.user_id = ?", userID).
+		Where("vehicles.organization_id IN ("+memberOrganizationIDs+")", userID).
 		Count(&total).Error
 	if err != nil {
 		return nil, 0, err
@@ -132,7 +144,7 @@ func (r *locationLogRepository) GetByUserIDWithPagination(userID uint, limit, of
 
 	// Get paginated data by joining with vehicles table
 	err = r.db.Joins("JOIN vehicles ON location_logs.vehicle_id = vehicles.id").
-		Where("vehicles.user_id = ?", userID).
+		Where("vehicles.organization_id IN ("+memberOrganizationIDs+")", userID).
 		Preload("Vehicle").
 		Order("location_logs.timestamp DESC").
 		Limit(limit).Offset(offset).
@@ -149,7 +161,7 @@ func (r *locationLogRepository) GetByUserIDWithDateRange(userID uint, startDate,
 	// Get total count by joining with vehicles table and filtering by date range
 	err := r.db.Model(&entity.LocationLog{}).
 		Joins("JOIN vehicles ON location_logs.vehicle_id = vehicles.id").
-		Where("vehicles.user_id = ? AND location_logs.timestamp BETWEEN ? AND ?", userID, startDate, endDate).
+		Where("vehicles.organization_id IN ("+memberOrganizationIDs+") AND location_logs.timestamp BETWEEN ? AND ?", userID, startDate, endDate).
 		Count(&total).Error
 	if err != nil {
 		return nil, 0, err
@@ -157,7 +169,7 @@ func (r *locationLogRepository) GetByUserIDWithDateRange(userID uint, startDate,
 
 	// Get paginated data by joining with vehicles table and filtering by date range
 	err = r.db.Joins("JOIN vehicles ON location_logs.vehicle_id = vehicles.id").
-		Where("vehicles.user_id = ? AND location_logs.timestamp BETWEEN ? AND ?", userID, startDate, endDate).
+		Where("vehicles.organization_id IN ("+memberOrganizationIDs+") AND location_logs.timestamp BETWEEN ? AND ?", userID, startDate, endDate).
 		Preload("Vehicle").
 		Order("location_logs.timestamp DESC").
 		Limit(limit).Offset(offset).
@@ -179,6 +191,120 @@ func (r *locationLogRepository) GetLatestByVehicleID(vehicleID uint) (*entity.Lo
 	return &locationLog, nil
 }
 
+// GetLatestTrustedByVehicleID gets latest location log by vehicle ID that was not flagged as suspect
+func (r *locationLogRepository) GetLatestTrustedByVehicleID(vehicleID uint) (*entity.LocationLog, error) {
+	var locationLog entity.LocationLog
+	err := r.db.Where("vehicle_id = ? AND suspect = ?", vehicleID, false).
+		Order("timestamp DESC").
+		First(&locationLog).Error
+	if err != nil {
+		return nil, err
+	}
+	return &locationLog, nil
+}
+
+// GetLatestTrustedByVehicleIDs gets the latest location log not flagged as suspect of each vehicle.
+// Vehicles without one are left out.
+func (r *locationLogRepository) GetLatestTrustedByVehicleIDs(vehicleIDs []uint) ([]entity.LocationLog, error) {
+	var locationLogs []entity.LocationLog
+	if len(vehicleIDs) == 0 {
+		return locationLogs, nil
+	}
+
+	err := r.db.Raw(`
+		SELECT DISTINCT ON (vehicle_id) *
+		FROM location_logs
+		WHERE vehicle_id IN ? AND suspect = FALSE AND deleted_at IS NULL
+		ORDER BY vehicle_id, timestamp DESC`,
+		vehicleIDs).
+		Scan(&locationLogs).Error
+	return locationLogs, err
+}
+
+// GetLatestTrustedWithin gets the latest location log not flagged as suspect of each vehicle whose position
+// lies inside the shape. Vehicles last seen elsewhere are left out.
+func (r *locationLogRepository) GetLatestTrustedWithin(vehicleIDs []uint, shape geo.Shape) ([]entity.LocationLog, error) {
+	var locationLogs []entity.LocationLog
+	if len(vehicleIDs) == 0 {
+		return locationLogs, nil
+	}
+
+	condition, args := r.spatial.condition(shape)
+	err := r.db.Raw(`
+		SELECT *
+		FROM (
+			SELECT DISTINCT ON (vehicle_id) *
+			FROM location_logs
+			WHERE vehicle_id IN ? AND suspect = FALSE AND deleted_at IS NULL
+			ORDER BY vehicle_id, timestamp DESC
+		) latest
+		WHERE `+condition,
+		append([]interface{}{vehicleIDs}, args...)...).
+		Scan(&locationLogs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	within := locationLogs[:0]
+	for _, locationLog := range locationLogs {
+		if r.spatial.contains(shape, locationLog.Latitude, locationLog.Longitude) {
+			within = append(within, locationLog)
+		}
+	}
+	return within, nil
+}
+
+// GetVisitsWithin counts the trusted positions each vehicle reported inside the shape between since and until
+// with the time of the first and last one. Vehicles that never entered it are left out.
+func (r *locationLogRepository) GetVisitsWithin(vehicleIDs []uint, since, until time.Time, shape geo.Shape) ([]entity.AreaVisit, error) {
+	var visits []entity.AreaVisit
+	if len(vehicleIDs) == 0 {
+		return visits, nil
+	}
+
+	condition, args := r.spatial.condition(shape)
+	query := r.db.Model(&entity.LocationLog{}).
+		Where("vehicle_id IN ? AND timestamp BETWEEN ? AND ? AND suspect = ?", vehicleIDs, since, until, false).
+		Where(condition, args...)
+
+	if r.spatial.PostGIS() {
+		err := query.Select("vehicle_id, COUNT(*) AS positions, MIN(timestamp) AS first_at, MAX(timestamp) AS last_at").
+			Group("vehicle_id").
+			Order("vehicle_id ASC").
+			Scan(&visits).Error
+		return visits, err
+	}
+
+	// Only the bounding box was checked, so the positions are aggregated here
+	rows, err := query.Select("vehicle_id, latitude, longitude, timestamp").
+		Order("vehicle_id ASC, timestamp ASC").
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var vehicleID uint
+		var latitude, longitude float64
+		var timestamp time.Time
+		if err := rows.Scan(&vehicleID, &latitude, &longitude, &timestamp); err != nil {
+			return nil, err
+		}
+		if !r.spatial.contains(shape, latitude, longitude) {
+			continue
+		}
+
+		if len(visits) == 0 || visits[len(visits)-1].VehicleID != vehicleID {
+			visits = append(visits, entity.AreaVisit{VehicleID: vehicleID, FirstAt: timestamp})
+		}
+		visit := &visits[len(visits)-1]
+		visit.Positions++
+		visit.LastAt = timestamp
+	}
+	return visits, rows.Err()
+}
+
 // Update updates location log data
 func (r *locationLogRepository) Update(locationLog *entity.LocationLog) error {
 	return r.db.Save(locationLog).Error
@@ -242,3 +368,127 @@ func (r *locationLogRepository) GetLocationHistory(vehicleID uint, startDate, en
 		Find(&locationLogs).Error
 	return locationLogs, err
 }
+
+// StreamLocationHistory calls fn for each location log of the vehicle between startDate and endDate in
+// timestamp order, reading rows one at a time instead of loading the whole history. An error from fn stops it.
+func (r *locationLogRepository) StreamLocationHistory(vehicleID uint, startDate, endDate time.Time, fn func(log *entity.LocationLog) error) error {
+	rows, err := r.db.Model(&entity.LocationLog{}).
+		Where("vehicle_id = ? AND timestamp BETWEEN ? AND ?", vehicleID, startDate, endDate).
+		Order("timestamp ASC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var locationLog entity.LocationLog
+		if err := r.db.ScanRows(rows, &locationLog); err != nil {
+			return err
+		}
+		if err := fn(&locationLog); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetTimestampsBetween gets the timestamps of the vehicle's location logs between startDate and endDate
+func (r *locationLogRepository) GetTimestampsBetween(vehicleID uint, startDate, endDate time.Time) ([]time.Time, error) {
+	var timestamps []time.Time
+	err := r.db.Model(&entity.LocationLog{}).
+		Where("vehicle_id = ? AND timestamp BETWEEN ? AND ?", vehicleID, startDate, endDate).
+		Pluck("timestamp", &timestamps).Error
+	return timestamps, err
+}
+
+// CreateBatch creates location logs in a single insert
+func (r *locationLogRepository) CreateBatch(locationLogs []entity.LocationLog) error {
+	if len(locationLogs) == 0 {
+		return nil
+	}
+	return r.db.Omit("Vehicle").Create(&locationLogs).Error
+}
+
+// GetByDriverIDWithPagination gets location logs recorded while the driver was assigned to the vehicle
+func (r *locationLogRepository) GetByDriverIDWithPagination(driverID uint, limit, offset int) ([]entity.LocationLog, int64, error) {
+	var locationLogs []entity.LocationLog
+	var total int64
+
+	onDuty := "JOIN driver_assignments ON driver_assignments.vehicle_id = location_logs.vehicle_id " +
+		"AND location_logs.timestamp >= driver_assignments.start_at " +
+		"AND (driver_assignments.end_at IS NULL OR location_logs.timestamp < driver_assignments.end_at)"
+
+	// Get total count
+	err := r.db.Model(&entity.LocationLog{}).
+		Joins(onDuty).
+		Where("driver_assignments.driver_id = ?", driverID).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated data
+	err = r.db.Joins(onDuty).
+		Where("driver_assignments.driver_id = ?", driverID).
+		Preload("Vehicle").
+		Order("location_logs.timestamp DESC").
+		Limit(limit).Offset(offset).
+		Find(&locationLogs).Error
+
+	return locationLogs, total, err
+}
+
+// usageMaxGap is the longest gap between two consecutive logs still counted as continuous driving.
+// Longer gaps mean the device was off or out of coverage and contribute no engine time.
+const usageMaxGap = 5 * time.Minute
+
+// GetUsage derives distance (great-circle sum between consecutive positions) and engine hours
+// (time spent moving between consecutive logs) of a vehicle between since and until
+func (r *locationLogRepository) GetUsage(vehicleID uint, since, until time.Time) (*entity.VehicleUsage, error) {
+	var usage entity.VehicleUsage
+	err := r.db.Raw(`
+		WITH ordered AS (
+			SELECT latitude, longitude, timestamp,
+				LAG(latitude) OVER w AS prev_latitude,
+				LAG(longitude) OVER w AS prev_longitude,
+				LAG(timestamp) OVER w AS prev_timestamp,
+				LAG(speed) OVER w AS prev_speed
+			FROM location_logs
+			WHERE vehicle_id = ? AND timestamp >= ? AND timestamp <= ? AND deleted_at IS NULL
+			WINDOW w AS (ORDER BY timestamp)
+		)
+		SELECT
+			COALESCE(SUM(6371 * 2 * ASIN(SQRT(
+				POWER(SIN(RADIANS(latitude - prev_latitude) / 2), 2) +
+				COS(RADIANS(prev_latitude)) * COS(RADIANS(latitude)) *
+				POWER(SIN(RADIANS(longitude - prev_longitude) / 2), 2)
+			))), 0) AS distance_km,
+			COALESCE(SUM(CASE
+				WHEN prev_speed > 0 AND timestamp - prev_timestamp <= make_interval(secs => ?)
+				THEN EXTRACT(EPOCH FROM timestamp - prev_timestamp)
+				ELSE 0
+			END), 0) / 3600 AS engine_hours
+		FROM ordered
+		WHERE prev_timestamp IS NOT NULL`,
+		vehicleID, since, until, usageMaxGap.Seconds()).
+		Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// GetActivity counts the positions of a vehicle between since and until (exclusive)
+// with the time of the first and last one and the highest speed reported
+func (r *locationLogRepository) GetActivity(vehicleID uint, since, until time.Time) (*entity.VehicleActivity, error) {
+	var activity entity.VehicleActivity
+	err := r.db.Model(&entity.LocationLog{}).
+		Select("COUNT(*) AS positions, MIN(timestamp) AS first_at, MAX(timestamp) AS last_at, COALESCE(MAX(speed), 0) AS max_speed").
+		Where("vehicle_id = ? AND timestamp >= ? AND timestamp < ?", vehicleID, since, until).
+		Scan(&activity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
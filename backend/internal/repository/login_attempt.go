@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// LoginAttemptRepository defines login attempt repository interface
+type LoginAttemptRepository interface {
+	Create(attempt *entity.LoginAttempt) error
+	CountFailuresByIP(ipAddress string, since time.Time) (int64, error)
+	GetLastFailureByIP(ipAddress string) (*entity.LoginAttempt, error)
+	DeleteOlderThan(before time.Time) error
+}
+
+// loginAttemptRepository implements LoginAttemptRepository interface
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginAttemptRepository creates new login attempt repository instance
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+// Create records a login attempt
+func (r *loginAttemptRepository) Create(attempt *entity.LoginAttempt) error {
+	return r.db.Create(attempt).Error
+}
+
+// CountFailuresByIP counts failed login attempts from an IP address since the given time
+func (r *loginAttemptRepository) CountFailuresByIP(ipAddress string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.LoginAttempt{}).
+		Where("ip_address = ? AND success = ? AND created_at >= ?", ipAddress, false, since).
+		Count(&count).Error
+	return count, err
+}
+
+// GetLastFailureByIP gets the most recent failed login attempt from an IP address
+func (r *loginAttemptRepository) GetLastFailureByIP(ipAddress string) (*entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+	err := r.db.Where("ip_address = ? AND success = ?", ipAddress, false).
+		Order("created_at DESC").
+		First(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// DeleteOlderThan removes login attempts recorded before the given time
+func (r *loginAttemptRepository) DeleteOlderThan(before time.Time) error {
+	return r.db.Where("created_at < ?", before).Delete(&entity.LoginAttempt{}).Error
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// MaintenancePlanRepository defines maintenance plan repository interface
+type MaintenancePlanRepository interface {
+	Create(plan *entity.MaintenancePlan) error
+	GetByID(id uint) (*entity.MaintenancePlan, error)
+	GetByVehicleID(vehicleID uint) ([]entity.MaintenancePlan, error)
+	GetByOrganizationIDs(organizationIDs []uint) ([]entity.MaintenancePlan, error)
+	GetAll() ([]entity.MaintenancePlan, error)
+	Update(plan *entity.MaintenancePlan) error
+	Delete(id uint) error
+	MarkNotified(id uint, at time.Time) error
+}
+
+// maintenancePlanRepository implements MaintenancePlanRepository interface
+type maintenancePlanRepository struct {
+	db *gorm.DB
+}
+
+// NewMaintenancePlanRepository creates new maintenance plan repository instance
+func NewMaintenancePlanRepository(db *gorm.DB) MaintenancePlanRepository {
+	return &maintenancePlanRepository{db: db}
+}
+
+// Create creates a new maintenance plan
+func (r *maintenancePlanRepository) Create(plan *entity.MaintenancePlan) error {
+	return r.db.Omit("Vehicle").Create(plan).Error
+}
+
+// GetByID gets maintenance plan by ID
+func (r *maintenancePlanRepository) GetByID(id uint) (*entity.MaintenancePlan, error) {
+	var plan entity.MaintenancePlan
+	err := r.db.First(&plan, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// GetByVehicleID gets maintenance plans of a vehicle
+func (r *maintenancePlanRepository) GetByVehicleID(vehicleID uint) ([]entity.MaintenancePlan, error) {
+	var plans []entity.MaintenancePlan
+	err := r.db.Where("vehicle_id = ?", vehicleID).
+		Order("name ASC").
+		Find(&plans).Error
+	return plans, err
+}
+
+// GetByOrganizationIDs gets maintenance plans of every vehicle in the given organizations
+func (r *maintenancePlanRepository) GetByOrganizationIDs(organizationIDs []uint) ([]entity.MaintenancePlan, error) {
+	var plans []entity.MaintenancePlan
+	if len(organizationIDs) == 0 {
+		return plans, nil
+	}
+	err := r.db.Joins("Vehicle").
+		Where(`"Vehicle".organization_id IN ?`, organizationIDs).
+		Order("maintenance_plans.vehicle_id ASC, maintenance_plans.name ASC").
+		Find(&plans).Error
+	return plans, err
+}
+
+// GetAll gets every maintenance plan with its vehicle
+func (r *maintenancePlanRepository) GetAll() ([]entity.MaintenancePlan, error) {
+	var plans []entity.MaintenancePlan
+	err := r.db.Joins("Vehicle").
+		Order("maintenance_plans.id ASC").
+		Find(&plans).Error
+	return plans, err
+}
+
+// Update updates maintenance plan data
+func (r *maintenancePlanRepository) Update(plan *entity.MaintenancePlan) error {
+	return r.db.Omit("Vehicle").Save(plan).Error
+}
+
+// Delete soft deletes maintenance plan by ID
+func (r *maintenancePlanRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.MaintenancePlan{}, id).Error
+}
+
+// MarkNotified records that the current service cycle of the plan was reported as due
+func (r *maintenancePlanRepository) MarkNotified(id uint, at time.Time) error {
+	return r.db.Model(&entity.MaintenancePlan{}).
+		Where("id = ?", id).
+		Update("notified_at", at).Error
+}
+
+// ServiceRecordRepository defines service record repository interface
+type ServiceRecordRepository interface {
+	Create(record *entity.ServiceRecord) error
+	GetByID(id uint) (*entity.ServiceRecord, error)
+	GetByVehicleIDWithPagination(vehicleID uint, limit, offset int) ([]entity.ServiceRecord, int64, error)
+	Delete(id uint) error
+}
+
+// serviceRecordRepository implements ServiceRecordRepository interface
+type serviceRecordRepository struct {
+	db *gorm.DB
+}
+
+// NewServiceRecordRepository creates new service record repository instance
+func NewServiceRecordRepository(db *gorm.DB) ServiceRecordRepository {
+	return &serviceRecordRepository{db: db}
+}
+
+// Create creates a service record. When the record completes a plan and is newer than the plan's
+// current cycle, the plan starts a new cycle from the service date, atomically.
+func (r *serviceRecordRepository) Create(record *entity.ServiceRecord) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Plan").Create(record).Error; err != nil {
+			return err
+		}
+		if record.PlanID == nil {
+			return nil
+		}
+		return tx.Model(&entity.MaintenancePlan{}).
+			Where("id = ? AND last_service_at <= ?", *record.PlanID, record.ServicedAt).
+			Updates(map[string]interface{}{
+				"last_service_at": record.ServicedAt,
+				"notified_at":     nil,
+			}).Error
+	})
+}
+
+// GetByID gets service record by ID
+func (r *serviceRecordRepository) GetByID(id uint) (*entity.ServiceRecord, error) {
+	var record entity.ServiceRecord
+	err := r.db.Preload("Plan").First(&record, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// GetByVehicleIDWithPagination gets service records of a vehicle, newest first, with pagination info
+func (r *serviceRecordRepository) GetByVehicleIDWithPagination(vehicleID uint, limit, offset int) ([]entity.ServiceRecord, int64, error) {
+	var records []entity.ServiceRecord
+	var total int64
+
+	// Get total count
+	err := r.db.Model(&entity.ServiceRecord{}).Where("vehicle_id = ?", vehicleID).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated data
+	err = r.db.Where("vehicle_id = ?", vehicleID).
+		Preload("Plan").
+		Order("serviced_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&records).Error
+
+	return records, total, err
+}
+
+// Delete soft deletes service record by ID
+func (r *serviceRecordRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.ServiceRecord{}, id).Error
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// NotificationRepository defines notification repository interface
+type NotificationRepository interface {
+	CreateBatch(notifications []entity.Notification) error
+	GetByID(id uint) (*entity.Notification, error)
+	GetByUserIDWithPagination(userID uint, unreadOnly bool, limit, offset int) ([]entity.Notification, int64, error)
+	CountUnread(userID uint) (int64, error)
+	MarkRead(id uint, at time.Time) error
+	MarkAllRead(userID uint, at time.Time) (int64, error)
+}
+
+// notificationRepository implements NotificationRepository interface
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates new notification repository instance
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// CreateBatch creates several notifications at once
+func (r *notificationRepository) CreateBatch(notifications []entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.db.Omit("Vehicle").Create(&notifications).Error
+}
+
+// GetByID gets notification by ID
+func (r *notificationRepository) GetByID(id uint) (*entity.Notification, error) {
+	var notification entity.Notification
+	err := r.db.Preload("Vehicle").First(&notification, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// GetByUserIDWithPagination gets notifications of a user, newest first
+func (r *notificationRepository) GetByUserIDWithPagination(userID uint, unreadOnly bool, limit, offset int) ([]entity.Notification, int64, error) {
+	var notifications []entity.Notification
+	var total int64
+
+	query := r.db.Model(&entity.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Vehicle").
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&notifications).Error
+	return notifications, total, err
+}
+
+// CountUnread counts unread notifications of a user
+func (r *notificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead marks a notification read, keeping the first read time
+func (r *notificationRepository) MarkRead(id uint, at time.Time) error {
+	return r.db.Model(&entity.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", at).Error
+}
+
+// MarkAllRead marks every unread notification of a user read and returns how many changed
+func (r *notificationRepository) MarkAllRead(userID uint, at time.Time) (int64, error) {
+	result := r.db.Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
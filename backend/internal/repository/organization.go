@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository defines organization repository interface
+type OrganizationRepository interface {
+	Create(organization *entity.Organization, owner *entity.OrganizationMember) error
+	GetByID(id uint) (*entity.Organization, error)
+	GetByUserID(userID uint) ([]entity.OrganizationMember, error)
+	GetIDsByUserID(userID uint) ([]uint, error)
+	Update(organization *entity.Organization) error
+	Delete(id uint) error
+	GetMember(organizationID, userID uint) (*entity.OrganizationMember, error)
+	GetMembers(organizationID uint) ([]entity.OrganizationMember, error)
+	AddMember(member *entity.OrganizationMember) error
+	UpdateMember(member *entity.OrganizationMember) error
+	RemoveMember(organizationID, userID uint) error
+	CountByRole(organizationID uint, role entity.OrganizationRole) (int64, error)
+	CountVehicles(organizationID uint) (int64, error)
+	SaveInvitation(invitation *entity.OrganizationInvitation) error
+	GetInvitation(id uint) (*entity.OrganizationInvitation, error)
+	GetPendingInvitations(organizationID uint, now time.Time) ([]entity.OrganizationInvitation, error)
+	GetPendingInvitationsByEmail(email string, now time.Time) ([]entity.OrganizationInvitation, error)
+	DeleteInvitation(id uint) error
+	AcceptInvitation(invitation *entity.OrganizationInvitation, member *entity.OrganizationMember) error
+}
+
+// memberOrganizationIDs selects the IDs of the organizations, not deleted, that the user given as its only
+// parameter belongs to. Queries scoped to a user's organizations filter on it.
+const memberOrganizationIDs = "SELECT organization_members.organization_id FROM organization_members " +
+	"JOIN organizations ON organizations.id = organization_members.organization_id AND organizations.deleted_at IS NULL " +
+	"WHERE organization_members.user_id = ?"
+
+// organizationRepository implements OrganizationRepository interface
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates new organization repository instance
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+// Create creates an organization together with its first owner membership
+func (r *organizationRepository) Create(organization *entity.Organization, owner *entity.OrganizationMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(organization).Error; err != nil {
+			return err
+		}
+		owner.OrganizationID = organization.ID
+		return tx.Create(owner).Error
+	})
+}
+
+// GetByID gets organization by ID
+func (r *organizationRepository) GetByID(id uint) (*entity.Organization, error) {
+	var organization entity.Organization
+	err := r.db.First(&organization, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// GetByUserID gets the user's memberships with their organizations
+func (r *organizationRepository) GetByUserID(userID uint) ([]entity.OrganizationMember, error) {
+	var members []entity.OrganizationMember
+	err := r.db.Joins("Organization").
+		Where("organization_members.user_id = ?", userID).
+		Order("organization_members.created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+// GetIDsByUserID gets IDs of every organization the user belongs to
+func (r *organizationRepository) GetIDsByUserID(userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entity.OrganizationMember{}).
+		Joins("JOIN organizations ON organizations.id = organization_members.organization_id AND organizations.deleted_at IS NULL").
+		Where("organization_members.user_id = ?", userID).
+		Pluck("organization_members.organization_id", &ids).Error
+	return ids, err
+}
+
+// Update updates organization data
+func (r *organizationRepository) Update(organization *entity.Organization) error {
+	return r.db.Save(organization).Error
+}
+
+// Delete soft deletes organization by ID
+func (r *organizationRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.Organization{}, id).Error
+}
+
+// GetMember gets a user's membership in an organization
+func (r *organizationRepository) GetMember(organizationID, userID uint) (*entity.OrganizationMember, error) {
+	var member entity.OrganizationMember
+	err := r.db.Joins("Organization").
+		Where("organization_members.organization_id = ? AND organization_members.user_id = ?", organizationID, userID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetMembers gets all members of an organization
+func (r *organizationRepository) GetMembers(organizationID uint) ([]entity.OrganizationMember, error) {
+	var members []entity.OrganizationMember
+	err := r.db.Preload("User").
+		Where("organization_id = ?", organizationID).
+		Order("created_at ASC").
+		Find(&members).Error
+	return members, err
+}
+
+// AddMember adds a member to an organization
+func (r *organizationRepository) AddMember(member *entity.OrganizationMember) error {
+	return r.db.Omit("User", "Organization").Create(member).Error
+}
+
+// UpdateMember updates a membership
+func (r *organizationRepository) UpdateMember(member *entity.OrganizationMember) error {
+	return r.db.Model(&entity.OrganizationMember{}).
+		Where("id = ?", member.ID).
+		Update("role", member.Role).Error
+}
+
+// RemoveMember removes a user from an organization
+func (r *organizationRepository) RemoveMember(organizationID, userID uint) error {
+	return r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Delete(&entity.OrganizationMember{}).Error
+}
+
+// CountByRole counts members of an organization with the given role
+func (r *organizationRepository) CountByRole(organizationID uint, role entity.OrganizationRole) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", organizationID, role).
+		Count(&count).Error
+	return count, err
+}
+
+// CountVehicles counts vehicles belonging to an organization
+func (r *organizationRepository) CountVehicles(organizationID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Vehicle{}).Where("organization_id = ?", organizationID).Count(&count).Error
+	return count, err
+}
+
+// SaveInvitation stores an invitation, replacing an earlier one for the same organization and email
+func (r *organizationRepository) SaveInvitation(invitation *entity.OrganizationInvitation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_id = ? AND email = ?", invitation.OrganizationID, invitation.Email).
+			Delete(&entity.OrganizationInvitation{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Organization").Create(invitation).Error
+	})
+}
+
+// GetInvitation gets an invitation with its organization
+func (r *organizationRepository) GetInvitation(id uint) (*entity.OrganizationInvitation, error) {
+	var invitation entity.OrganizationInvitation
+	err := r.db.Joins("Organization").First(&invitation, "organization_invitations.id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetPendingInvitations gets the invitations of an organization that have not expired
+func (r *organizationRepository) GetPendingInvitations(organizationID uint, now time.Time) ([]entity.OrganizationInvitation, error) {
+	var invitations []entity.OrganizationInvitation
+	err := r.db.Where("organization_id = ? AND expires_at > ?", organizationID, now).
+		Order("created_at ASC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// GetPendingInvitationsByEmail gets the unexpired invitations sent to an email address, with their organizations
+func (r *organizationRepository) GetPendingInvitationsByEmail(email string, now time.Time) ([]entity.OrganizationInvitation, error) {
+	var invitations []entity.OrganizationInvitation
+	err := r.db.Joins("Organization").
+		Where("organization_invitations.email = ? AND organization_invitations.expires_at > ?", email, now).
+		Order("organization_invitations.created_at ASC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// DeleteInvitation deletes an invitation by ID
+func (r *organizationRepository) DeleteInvitation(id uint) error {
+	return r.db.Delete(&entity.OrganizationInvitation{}, id).Error
+}
+
+// AcceptInvitation adds the member and removes the invitation in one transaction
+func (r *organizationRepository) AcceptInvitation(invitation *entity.OrganizationInvitation, member *entity.OrganizationMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Organization").Create(member).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.OrganizationInvitation{}, invitation.ID).Error
+	})
+}
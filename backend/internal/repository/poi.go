@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/pkg/geo"
+	"gorm.io/gorm"
+)
+
+// POIRepository defines point of interest repository interface
+type POIRepository interface {
+	Create(poi *entity.POI) error
+	GetByID(id uint) (*entity.POI, error)
+	GetByOrganizationIDs(organizationIDs []uint, category string, limit, offset int) ([]entity.POI, int64, error)
+	GetByName(organizationID uint, name string) (*entity.POI, error)
+	GetWithin(organizationIDs []uint, shape geo.Shape) ([]entity.POI, error)
+	Update(poi *entity.POI) error
+	Delete(id uint) error
+}
+
+// poiRepository implements POIRepository interface
+type poiRepository struct {
+	db      *gorm.DB
+	spatial *Spatial
+}
+
+// NewPOIRepository creates new point of interest repository instance
+func NewPOIRepository(db *gorm.DB, spatial *Spatial) POIRepository {
+	return &poiRepository{db: db, spatial: spatial}
+}
+
+// Create creates a new point of interest
+func (r *poiRepository) Create(poi *entity.POI) error {
+	return r.db.Create(poi).Error
+}
+
+// GetByID gets point of interest by ID
+func (r *poiRepository) GetByID(id uint) (*entity.POI, error) {
+	var poi entity.POI
+	err := r.db.First(&poi, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &poi, nil
+}
+
+// GetByOrganizationIDs gets points of interest of the given organizations, optionally of one category,
+// with pagination info
+func (r *poiRepository) GetByOrganizationIDs(organizationIDs []uint, category string, limit, offset int) ([]entity.POI, int64, error) {
+	var pois []entity.POI
+	var total int64
+
+	if len(organizationIDs) == 0 {
+		return pois, 0, nil
+	}
+
+	query := r.db.Model(&entity.POI{}).Where("organization_id IN ?", organizationIDs)
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	// Get total count
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated data
+	err := query.Order("name ASC").
+		Limit(limit).Offset(offset).
+		Find(&pois).Error
+
+	return pois, total, err
+}
+
+// GetByName gets the point of interest of an organization with the given name
+func (r *poiRepository) GetByName(organizationID uint, name string) (*entity.POI, error) {
+	var poi entity.POI
+	err := r.db.Where("organization_id = ? AND name = ?", organizationID, name).First(&poi).Error
+	if err != nil {
+		return nil, err
+	}
+	return &poi, nil
+}
+
+// GetWithin gets points of interest of the given organizations whose center lies inside the shape
+func (r *poiRepository) GetWithin(organizationIDs []uint, shape geo.Shape) ([]entity.POI, error) {
+	var pois []entity.POI
+	if len(organizationIDs) == 0 {
+		return pois, nil
+	}
+
+	condition, args := r.spatial.condition(shape)
+	err := r.db.Where("organization_id IN ?", organizationIDs).
+		Where(condition, args...).
+		Order("id ASC").
+		Find(&pois).Error
+	if err != nil {
+		return nil, err
+	}
+
+	within := pois[:0]
+	for _, poi := range pois {
+		if r.spatial.contains(shape, poi.Latitude, poi.Longitude) {
+			within = append(within, poi)
+		}
+	}
+	return within, nil
+}
+
+// Update updates point of interest data
+func (r *poiRepository) Update(poi *entity.POI) error {
+	return r.db.Save(poi).Error
+}
+
+// Delete soft deletes point of interest by ID
+func (r *poiRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.POI{}, id).Error
+}
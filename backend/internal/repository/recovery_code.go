@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// RecoveryCodeRepository defines recovery code repository interface
+type RecoveryCodeRepository interface {
+	ReplaceForUser(userID uint, codes []entity.RecoveryCode) error
+	Consume(userID uint, codeHash string) error
+	CountUnused(userID uint) (int64, error)
+	DeleteByUserID(userID uint) error
+}
+
+// recoveryCodeRepository implements RecoveryCodeRepository interface
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewRecoveryCodeRepository creates new recovery code repository instance
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepository{db: db}
+}
+
+// ReplaceForUser deletes the user's existing codes and stores the new set in one transaction
+func (r *recoveryCodeRepository) ReplaceForUser(userID uint, codes []entity.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// Consume marks an unused code as used.
+// Returns gorm.ErrRecordNotFound if no unused code matches.
+func (r *recoveryCodeRepository) Consume(userID uint, codeHash string) error {
+	result := r.db.Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CountUnused counts the user's remaining recovery codes
+func (r *recoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteByUserID deletes all recovery codes of a user
+func (r *recoveryCodeRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// ReportDefinitionRepository defines report definition repository interface
+type ReportDefinitionRepository interface {
+	Create(definition *entity.ReportDefinition) error
+	GetByID(id uint) (*entity.ReportDefinition, error)
+	GetByOrganizationIDs(organizationIDs []uint) ([]entity.ReportDefinition, error)
+	GetDue(now time.Time, limit int) ([]entity.ReportDefinition, error)
+	AdvanceSchedule(id uint, scheduledAt, nextRunAt, now time.Time) (bool, error)
+	Update(definition *entity.ReportDefinition) error
+	Delete(id uint) error
+}
+
+// reportDefinitionRepository implements ReportDefinitionRepository interface
+type reportDefinitionRepository struct {
+	db *gorm.DB
+}
+
+// NewReportDefinitionRepository creates new report definition repository instance
+func NewReportDefinitionRepository(db *gorm.DB) ReportDefinitionRepository {
+	return &reportDefinitionRepository{db: db}
+}
+
+// Create creates a new report definition
+func (r *reportDefinitionRepository) Create(definition *entity.ReportDefinition) error {
+	return r.db.Create(definition).Error
+}
+
+// GetByID gets report definition by ID
+func (r *reportDefinitionRepository) GetByID(id uint) (*entity.ReportDefinition, error) {
+	var definition entity.ReportDefinition
+	err := r.db.First(&definition, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// GetByOrganizationIDs gets report definitions of the organizations
+func (r *reportDefinitionRepository) GetByOrganizationIDs(organizationIDs []uint) ([]entity.ReportDefinition, error) {
+	var definitions []entity.ReportDefinition
+	if len(organizationIDs) == 0 {
+		return definitions, nil
+	}
+	err := r.db.Where("organization_id IN ?", organizationIDs).
+		Order("id ASC").
+		Find(&definitions).Error
+	return definitions, err
+}
+
+// GetDue gets enabled report definitions scheduled at or before now, earliest first
+func (r *reportDefinitionRepository) GetDue(now time.Time, limit int) ([]entity.ReportDefinition, error) {
+	var definitions []entity.ReportDefinition
+	err := r.db.Where("enabled = ? AND next_run_at <= ?", true, now).
+		Order("next_run_at ASC").
+		Limit(limit).
+		Find(&definitions).Error
+	return definitions, err
+}
+
+// AdvanceSchedule moves a definition scheduled at scheduledAt to nextRunAt. It reports false when
+// the schedule was already advanced or changed meanwhile, so each scheduled run is claimed by one worker.
+func (r *reportDefinitionRepository) AdvanceSchedule(id uint, scheduledAt, nextRunAt, now time.Time) (bool, error) {
+	result := r.db.Model(&entity.ReportDefinition{}).
+		Where("id = ? AND next_run_at = ?", id, scheduledAt).
+		Updates(map[string]interface{}{"next_run_at": nextRunAt, "last_run_at": now})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Update updates report definition data
+func (r *reportDefinitionRepository) Update(definition *entity.ReportDefinition) error {
+	return r.db.Save(definition).Error
+}
+
+// Delete soft deletes report definition by ID
+func (r *reportDefinitionRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.ReportDefinition{}, id).Error
+}
+
+// ReportRunRepository defines report run repository interface
+type ReportRunRepository interface {
+	Create(run *entity.ReportRun) error
+	GetByID(id uint) (*entity.ReportRun, error)
+	GetByDefinitionIDWithPagination(definitionID uint, limit, offset int) ([]entity.ReportRun, int64, error)
+	Update(run *entity.ReportRun) error
+}
+
+// reportRunRepository implements ReportRunRepository interface
+type reportRunRepository struct {
+	db *gorm.DB
+}
+
+// NewReportRunRepository creates new report run repository instance
+func NewReportRunRepository(db *gorm.DB) ReportRunRepository {
+	return &reportRunRepository{db: db}
+}
+
+// Create creates a new report run
+func (r *reportRunRepository) Create(run *entity.ReportRun) error {
+	return r.db.Create(run).Error
+}
+
+// GetByID gets report run by ID
+func (r *reportRunRepository) GetByID(id uint) (*entity.ReportRun, error) {
+	var run entity.ReportRun
+	err := r.db.First(&run, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetByDefinitionIDWithPagination gets runs of a report definition, newest first
+func (r *reportRunRepository) GetByDefinitionIDWithPagination(definitionID uint, limit, offset int) ([]entity.ReportRun, int64, error) {
+	var runs []entity.ReportRun
+	var total int64
+
+	query := r.db.Model(&entity.ReportRun{}).Where("definition_id = ?", definitionID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&runs).Error
+	return runs, total, err
+}
+
+// Update updates report run data
+func (r *reportRunRepository) Update(run *entity.ReportRun) error {
+	return r.db.Save(run).Error
+}
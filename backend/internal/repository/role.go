@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// RoleRepository defines role repository interface
+type RoleRepository interface {
+	Create(role *entity.Role) error
+	GetByID(id uint) (*entity.Role, error)
+	GetByName(name string) (*entity.Role, error)
+	GetAll() ([]entity.Role, error)
+	Update(role *entity.Role, permissions []string) error
+	Delete(id uint) error
+}
+
+// roleRepository implements RoleRepository interface
+type roleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository creates new role repository instance
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+// Create creates a new role together with its permissions
+func (r *roleRepository) Create(role *entity.Role) error {
+	return r.db.Create(role).Error
+}
+
+// GetByID gets role by ID with its permissions
+func (r *roleRepository) GetByID(id uint) (*entity.Role, error) {
+	var role entity.Role
+	err := r.db.Preload("Permissions").First(&role, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetByName gets role by name with its permissions
+func (r *roleRepository) GetByName(name string) (*entity.Role, error) {
+	var role entity.Role
+	err := r.db.Preload("Permissions").Where("name = ?", name).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetAll gets all roles with their permissions
+func (r *roleRepository) GetAll() ([]entity.Role, error) {
+	var roles []entity.Role
+	err := r.db.Preload("Permissions").Order("id ASC").Find(&roles).Error
+	return roles, err
+}
+
+// Update updates role details and, when permissions is not nil, replaces its permissions
+func (r *roleRepository) Update(role *entity.Role, permissions []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Permissions").Save(role).Error; err != nil {
+			return err
+		}
+		if permissions == nil {
+			return nil
+		}
+
+		if err := tx.Where("role_id = ?", role.ID).Delete(&entity.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		role.Permissions = make([]entity.RolePermission, len(permissions))
+		for i, name := range permissions {
+			role.Permissions[i] = entity.RolePermission{RoleID: role.ID, Permission: name}
+		}
+		if len(role.Permissions) == 0 {
+			return nil
+		}
+		return tx.Create(&role.Permissions).Error
+	})
+}
+
+// Delete deletes role by ID; its permissions are removed by cascade
+func (r *roleRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.Role{}, id).Error
+}
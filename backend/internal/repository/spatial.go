@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/cartrack/backend/pkg/geo"
+	"gorm.io/gorm"
+)
+
+// Spatial turns geo shapes into SQL conditions on tables with latitude and longitude columns. When PostGIS
+// is enabled and the migration added the geography columns, conditions use them so the GiST indexes apply
+// and the database decides containment. Otherwise the condition is the shape's bounding box and rows are
+// narrowed to the shape in Go.
+type Spatial struct {
+	postGIS bool
+}
+
+// NewSpatial creates the spatial query helper. PostGIS is used only when enabled and both location_logs
+// and pois have their geography column.
+func NewSpatial(db *gorm.DB, enabled bool) *Spatial {
+	if !enabled {
+		return &Spatial{}
+	}
+
+	var columns int64
+	err := db.Raw(`
+		SELECT COUNT(*)
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND column_name = 'geog' AND table_name IN ('location_logs', 'pois')`).
+		Scan(&columns).Error
+	if err != nil {
+		// Log error but fall back to filtering in Go
+		log.Printf("Failed to detect PostGIS columns, filtering coordinates in Go: %v", err)
+		return &Spatial{}
+	}
+
+	return &Spatial{postGIS: columns == 2}
+}
+
+// PostGIS reports whether spatial conditions are evaluated by PostGIS
+func (s *Spatial) PostGIS() bool {
+	return s.postGIS
+}
+
+// condition returns the SQL condition and its arguments restricting rows to the shape. Without PostGIS
+// only the bounding box is checked, so results must still go through contains.
+func (s *Spatial) condition(shape geo.Shape) (string, []interface{}) {
+	b := shape.Bounds()
+	bounds := []interface{}{b.MinLat, b.MaxLat, b.MinLng, b.MaxLng}
+	if !s.postGIS {
+		return "latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", bounds
+	}
+
+	switch shape := shape.(type) {
+	case geo.Circle:
+		return "ST_DWithin(geog, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)",
+			[]interface{}{shape.Center.Longitude, shape.Center.Latitude, shape.Radius}
+	case geo.Polygon:
+		return "ST_Covers(ST_GeogFromText(?), geog)", []interface{}{polygonWKT(shape)}
+	default:
+		// The envelope lets the index narrow the rows; the edges of a geography envelope are great circles,
+		// so the box itself is checked on the coordinates
+		return "geog && ST_MakeEnvelope(?, ?, ?, ?, 4326)::geography AND latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?",
+			append([]interface{}{b.MinLng, b.MinLat, b.MaxLng, b.MaxLat}, bounds...)
+	}
+}
+
+// contains reports whether a row returned for a shape's condition lies inside the shape
+func (s *Spatial) contains(shape geo.Shape, latitude, longitude float64) bool {
+	return s.postGIS || shape.Contains(geo.Point{Latitude: latitude, Longitude: longitude})
+}
+
+// polygonWKT writes a polygon as EWKT, closing its ring
+func polygonWKT(polygon geo.Polygon) string {
+	var b strings.Builder
+	b.WriteString("SRID=4326;POLYGON((")
+	for i := 0; i <= len(polygon); i++ {
+		vertex := polygon[i%len(polygon)]
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.FormatFloat(vertex.Longitude, 'f', -1, 64))
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatFloat(vertex.Latitude, 'f', -1, 64))
+	}
+	b.WriteString("))")
+	return b.String()
+}
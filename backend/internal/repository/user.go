@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/cartrack/backend/internal/entity"
 	"gorm.io/gorm"
 )
@@ -8,14 +10,23 @@ import (
 // UserRepository defines user repository interface
 type UserRepository interface {
 	Create(user *entity.User) error
+	CreateWithOrganization(user *entity.User, organization *entity.Organization) error
 	GetByID(id uint) (*entity.User, error)
 	GetByEmail(email string) (*entity.User, error)
-	Update(user *entity.User) error
+	UpdateProfile(user *entity.User) error
+	UpdatePassword(id uint, passwordHash string) error
+	MarkEmailVerified(id uint, at time.Time) error
+	UpdateRole(id uint, role string) error
+	UpdateTwoFactor(user *entity.User) error
 	Delete(id uint) error
 	GetAll(limit, offset int) ([]entity.User, error)
 	GetByRole(role string, limit, offset int) ([]entity.User, error)
 	CountByRole(role string) (int64, error)
 	Count() (int64, error)
+	IncrementFailedLogins(id uint, at time.Time) (int, error)
+	LockUntil(id uint, until time.Time) error
+	ResetFailedLogins(id uint) error
+	AcceptTOTPStep(id uint, step int64) (bool, error)
 }
 
 // userRepository implements UserRepository interface
@@ -33,6 +44,23 @@ func (r *userRepository) Create(user *entity.User) error {
 	return r.db.Create(user).Error
 }
 
+// CreateWithOrganization creates a user together with an organization the user owns, so neither exists without the other
+func (r *userRepository) CreateWithOrganization(user *entity.User, organization *entity.Organization) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(organization).Error; err != nil {
+			return err
+		}
+		return tx.Create(&entity.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         user.ID,
+			Role:           entity.OrgRoleOwner,
+		}).Error
+	})
+}
+
 // GetByID gets user by ID
 func (r *userRepository) GetByID(id uint) (*entity.User, error) {
 	var user entity.User
@@ -53,9 +81,29 @@ func (r *userRepository) GetByEmail(email string) (*entity.User, error) {
 	return &user, nil
 }
 
-// Update updates user data
-func (r *userRepository) Update(user *entity.User) error {
-	return r.db.Save(user).Error
+// UpdateProfile saves the name and phone number of a user
+func (r *userRepository) UpdateProfile(user *entity.User) error {
+	return r.db.Model(user).Select("name", "phone_number").Updates(user).Error
+}
+
+// UpdatePassword replaces the password hash of a user
+func (r *userRepository) UpdatePassword(id uint, passwordHash string) error {
+	return r.db.Model(&entity.User{}).Where("id = ?", id).Update("password_hash", passwordHash).Error
+}
+
+// MarkEmailVerified records when the user's email was verified, keeping an earlier verification time
+func (r *userRepository) MarkEmailVerified(id uint, at time.Time) error {
+	return r.db.Model(&entity.User{}).Where("id = ? AND email_verified_at IS NULL", id).Update("email_verified_at", at).Error
+}
+
+// UpdateRole changes the role of a user
+func (r *userRepository) UpdateRole(id uint, role string) error {
+	return r.db.Model(&entity.User{}).Where("id = ?", id).Update("role", role).Error
+}
+
+// UpdateTwoFactor saves the TOTP secret, two-factor flag and last accepted TOTP step of a user
+func (r *userRepository) UpdateTwoFactor(user *entity.User) error {
+	return r.db.Model(user).Select("totp_secret", "two_factor_enabled", "totp_last_step").Updates(user).Error
 }
 
 // Delete soft deletes user by ID
@@ -90,3 +138,49 @@ func (r *userRepository) Count() (int64, error) {
 	err := r.db.Model(&entity.User{}).Count(&count).Error
 	return count, err
 }
+
+// IncrementFailedLogins atomically increments the failed login counter and returns the new value
+func (r *userRepository) IncrementFailedLogins(id uint, at time.Time) (int, error) {
+	err := r.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_login_attempts": gorm.Expr("failed_login_attempts + 1"),
+		"last_failed_login_at":  at,
+	}).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var user entity.User
+	if err := r.db.Select("failed_login_attempts").First(&user, id).Error; err != nil {
+		return 0, err
+	}
+	return user.FailedLoginAttempts, nil
+}
+
+// LockUntil locks the account until the given time and resets the failed login counter
+func (r *userRepository) LockUntil(id uint, until time.Time) error {
+	return r.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_login_attempts": 0,
+		"locked_until":          until,
+	}).Error
+}
+
+// ResetFailedLogins clears the failed login counter and any lockout
+func (r *userRepository) ResetFailedLogins(id uint) error {
+	return r.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_login_attempts": 0,
+		"last_failed_login_at":  nil,
+		"locked_until":          nil,
+	}).Error
+}
+
+// AcceptTOTPStep records step as the last accepted TOTP time step. It returns false without changing
+// anything when a code from the same or a later step was already accepted.
+func (r *userRepository) AcceptTOTPStep(id uint, step int64) (bool, error) {
+	result := r.db.Model(&entity.User{}).
+		Where("id = ? AND (totp_last_step IS NULL OR totp_last_step < ?)", id, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
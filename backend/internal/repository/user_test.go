@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+)
+
+func TestUserUpdatesOnlyWriteTheirColumns(t *testing.T) {
+	db, statements := newDryRunDB(t)
+	users := NewUserRepository(db)
+
+	secret := "JBSWY3DPEHPK3PXP"
+	user := &entity.User{ID: 7, Name: "Dina", Role: "user", TOTPSecret: &secret, TwoFactorEnabled: true}
+
+	// Columns written by the login protection and TOTP replay checks must never be overwritten by these
+	guarded := []string{"failed_login_attempts", "locked_until", "last_failed_login_at", "password_hash", "role"}
+
+	tests := []struct {
+		name    string
+		update  func() error
+		columns []string
+	}{
+		{name: "UpdateProfile", update: func() error { return users.UpdateProfile(user) }, columns: []string{"name", "phone_number"}},
+		{name: "UpdatePassword", update: func() error { return users.UpdatePassword(user.ID, "hash") }, columns: []string{"password_hash"}},
+		{name: "MarkEmailVerified", update: func() error { return users.MarkEmailVerified(user.ID, time.Now()) }, columns: []string{"email_verified_at"}},
+		{name: "UpdateRole", update: func() error { return users.UpdateRole(user.ID, "manager") }, columns: []string{"role"}},
+		{name: "UpdateTwoFactor", update: func() error { return users.UpdateTwoFactor(user) }, columns: []string{"totp_secret", "two_factor_enabled", "totp_last_step"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(statements())
+			if err := tt.update(); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			built := statements()[before:]
+			if len(built) != 1 {
+				t.Fatalf("%s() built %d statements, want 1", tt.name, len(built))
+			}
+
+			set := built[0][strings.Index(built[0], " SET ")+5 : strings.Index(built[0], " WHERE ")]
+			for _, column := range tt.columns {
+				if !strings.Contains(set, `"`+column+`"`) {
+					t.Errorf("%s() does not write %s:\n%s", tt.name, column, built[0])
+				}
+			}
+			for _, column := range guarded {
+				if !slices.Contains(tt.columns, column) && strings.Contains(set, `"`+column+`"`) {
+					t.Errorf("%s() overwrites %s:\n%s", tt.name, column, built[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// UserTokenRepository defines user token repository interface
+type UserTokenRepository interface {
+	Create(token *entity.UserToken) error
+	GetByHash(tokenHash string, purpose entity.TokenPurpose) (*entity.UserToken, error)
+	MarkUsed(id uint) error
+	InvalidateByUserID(userID uint, purpose entity.TokenPurpose) error
+	DeleteExpired(before time.Time) error
+}
+
+// userTokenRepository implements UserTokenRepository interface
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewUserTokenRepository creates new user token repository instance
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{db: db}
+}
+
+// Create creates a new user token
+func (r *userTokenRepository) Create(token *entity.UserToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByHash gets user token by hash and purpose
+func (r *userTokenRepository) GetByHash(tokenHash string, purpose entity.TokenPurpose) (*entity.UserToken, error) {
+	var token entity.UserToken
+	err := r.db.Preload("User").
+		Where("token_hash = ? AND purpose = ?", tokenHash, purpose).
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed marks a token as used so it cannot be redeemed again.
+// Returns gorm.ErrRecordNotFound if the token was already used.
+func (r *userTokenRepository) MarkUsed(id uint) error {
+	result := r.db.Model(&entity.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// InvalidateByUserID marks every outstanding token of a purpose for the user as used
+func (r *userTokenRepository) InvalidateByUserID(userID uint, purpose entity.TokenPurpose) error {
+	return r.db.Model(&entity.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
+
+// DeleteExpired removes tokens that expired before the given time
+func (r *userTokenRepository) DeleteExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&entity.UserToken{}).Error
+}
@@ -10,6 +10,7 @@ type VehicleRepository interface {
 	Create(vehicle *entity.Vehicle) error
 	GetByID(id uint) (*entity.Vehicle, error)
 	GetByUserID(userID uint, limit, offset int) ([]entity.Vehicle, error)
+	GetByOrganizationIDs(organizationIDs []uint, limit, offset int) ([]entity.Vehicle, error)
 	GetByPlateNumber(plateNumber string) (*entity.Vehicle, error)
 	GetByIMEI(imei string) (*entity.Vehicle, error)
 	Update(vehicle *entity.Vehicle) error
@@ -18,7 +19,7 @@ type VehicleRepository interface {
 	CountByUserID(userID uint) (int64, error)
 	Count() (int64, error)
 	GetWithLatestLocation(vehicleID uint) (*entity.Vehicle, error)
-	GetAllWithLatestLocation(userID uint, limit, offset int) ([]entity.Vehicle, error)
+	GetAllWithLatestLocation(userID uint, model string, limit, offset int) ([]entity.Vehicle, error)
 }
 
 // vehicleRepository implements VehicleRepository interface
@@ -56,6 +57,20 @@ func (r *vehicleRepository) GetByUserID(userID uint, limit, offset int) ([]entit
 	return vehicles, err
 }
 
+// GetByOrganizationIDs gets vehicles belonging to any of the given organizations with pagination
+func (r *vehicleRepository) GetByOrganizationIDs(organizationIDs []uint, limit, offset int) ([]entity.Vehicle, error) {
+	var vehicles []entity.Vehicle
+	if len(organizationIDs) == 0 {
+		return vehicles, nil
+	}
+	err := r.db.Where("organization_id IN ?", organizationIDs).
+		Preload("User").
+		Order("id ASC").
+		Limit(limit).Offset(offset).
+		Find(&vehicles).Error
+	return vehicles, err
+}
+
 // GetByPlateNumber gets vehicle by plate number
 func (r *vehicleRepository) GetByPlateNumber(plateNumber string) (*entity.Vehicle, error) {
 	var vehicle entity.Vehicle
@@ -127,18 +142,50 @@ func (r *vehicleRepository) GetWithLatestLocation(vehicleID uint) (*entity.Vehic
 	return &vehicle, nil
 }
 
-// GetAllWithLatestLocation gets all vehicles with their latest location
-func (r *vehicleRepository) GetAllWithLatestLocation(userID uint, limit, offset int) ([]entity.Vehicle, error) {
+// GetAllWithLatestLocation gets all vehicles, optionally of one model (case-insensitive), with their latest
+// location not flagged as suspect as the only entry of LocationLogs. Vehicles without one have no entry.
+func (r *vehicleRepository) GetAllWithLatestLocation(userID uint, model string, limit, offset int) ([]entity.Vehicle, error) {
 	var vehicles []entity.Vehicle
-	query := r.db.Preload("User").
-		Preload("LocationLogs", func(db *gorm.DB) *gorm.DB {
-			return db.Order("timestamp DESC").Limit(1)
-		})
+	query := r.db.Preload("User")
 
+	// Scope to organizations the user is a member of
 	if userID > 0 {
-		query = query.Where("user_id = ?", userID)
+		query = query.Where("organization_id IN ("+memberOrganizationIDs+")", userID)
+	}
+	if model != "" {
+		query = query.Where("LOWER(model) = LOWER(?)", model)
 	}
 
-	err := query.Limit(limit).Offset(offset).Find(&vehicles).Error
-	return vehicles, err
+	err := query.Order("id ASC").Limit(limit).Offset(offset).Find(&vehicles).Error
+	if err != nil || len(vehicles) == 0 {
+		return vehicles, err
+	}
+
+	// A limited preload would cap the logs of all vehicles together, so the latest log of each is queried instead
+	vehicleIDs := make([]uint, len(vehicles))
+	for i, vehicle := range vehicles {
+		vehicleIDs[i] = vehicle.ID
+	}
+	var locationLogs []entity.LocationLog
+	err = r.db.Raw(`
+		SELECT DISTINCT ON (vehicle_id) *
+		FROM location_logs
+		WHERE vehicle_id IN ? AND suspect = FALSE AND deleted_at IS NULL
+		ORDER BY vehicle_id, timestamp DESC`,
+		vehicleIDs).
+		Scan(&locationLogs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[uint]entity.LocationLog, len(locationLogs))
+	for _, locationLog := range locationLogs {
+		latest[locationLog.VehicleID] = locationLog
+	}
+	for i := range vehicles {
+		if locationLog, ok := latest[vehicles[i].ID]; ok {
+			vehicles[i].LocationLogs = []entity.LocationLog{locationLog}
+		}
+	}
+	return vehicles, nil
 }
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// VehicleDocumentRepository defines vehicle document repository interface
+type VehicleDocumentRepository interface {
+	Create(document *entity.VehicleDocument) error
+	GetByID(id uint) (*entity.VehicleDocument, error)
+	GetByVehicleID(vehicleID uint) ([]entity.VehicleDocument, error)
+	GetExpiringByOrganizationIDs(organizationIDs []uint, before time.Time) ([]entity.VehicleDocument, error)
+	GetExpiringUnnotified(before time.Time) ([]entity.VehicleDocument, error)
+	Update(document *entity.VehicleDocument) error
+	Delete(id uint) error
+	MarkExpiryNotified(id uint, at time.Time) error
+}
+
+// vehicleDocumentRepository implements VehicleDocumentRepository interface
+type vehicleDocumentRepository struct {
+	db *gorm.DB
+}
+
+// NewVehicleDocumentRepository creates new vehicle document repository instance
+func NewVehicleDocumentRepository(db *gorm.DB) VehicleDocumentRepository {
+	return &vehicleDocumentRepository{db: db}
+}
+
+// Create creates a new vehicle document
+func (r *vehicleDocumentRepository) Create(document *entity.VehicleDocument) error {
+	return r.db.Omit("Vehicle").Create(document).Error
+}
+
+// GetByID gets vehicle document by ID
+func (r *vehicleDocumentRepository) GetByID(id uint) (*entity.VehicleDocument, error) {
+	var document entity.VehicleDocument
+	err := r.db.First(&document, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// GetByVehicleID gets documents of a vehicle, soonest expiry first
+func (r *vehicleDocumentRepository) GetByVehicleID(vehicleID uint) ([]entity.VehicleDocument, error) {
+	var documents []entity.VehicleDocument
+	err := r.db.Where("vehicle_id = ?", vehicleID).
+		Order("expires_at ASC").
+		Find(&documents).Error
+	return documents, err
+}
+
+// GetExpiringByOrganizationIDs gets documents of the organizations' vehicles expiring on or before the given date,
+// including already expired ones
+func (r *vehicleDocumentRepository) GetExpiringByOrganizationIDs(organizationIDs []uint, before time.Time) ([]entity.VehicleDocument, error) {
+	var documents []entity.VehicleDocument
+	if len(organizationIDs) == 0 {
+		return documents, nil
+	}
+	err := r.db.Joins("Vehicle").
+		Where(`"Vehicle".organization_id IN ? AND vehicle_documents.expires_at <= ?`, organizationIDs, before).
+		Order("vehicle_documents.expires_at ASC").
+		Find(&documents).Error
+	return documents, err
+}
+
+// GetExpiringUnnotified gets documents expiring on or before the given date that have not been flagged yet
+func (r *vehicleDocumentRepository) GetExpiringUnnotified(before time.Time) ([]entity.VehicleDocument, error) {
+	var documents []entity.VehicleDocument
+	err := r.db.Joins("Vehicle").
+		Where(`"Vehicle".id IS NOT NULL AND vehicle_documents.expires_at <= ? AND vehicle_documents.expiry_notified_at IS NULL`, before).
+		Order("vehicle_documents.expires_at ASC").
+		Find(&documents).Error
+	return documents, err
+}
+
+// Update updates vehicle document data
+func (r *vehicleDocumentRepository) Update(document *entity.VehicleDocument) error {
+	return r.db.Omit("Vehicle").Save(document).Error
+}
+
+// Delete soft deletes vehicle document by ID
+func (r *vehicleDocumentRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.VehicleDocument{}, id).Error
+}
+
+// MarkExpiryNotified records that the document was flagged as expiring
+func (r *vehicleDocumentRepository) MarkExpiryNotified(id uint, at time.Time) error {
+	return r.db.Model(&entity.VehicleDocument{}).
+		Where("id = ?", id).
+		Update("expiry_notified_at", at).Error
+}
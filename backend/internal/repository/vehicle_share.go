@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// VehicleShareRepository defines vehicle share repository interface
+type VehicleShareRepository interface {
+	Create(share *entity.VehicleShare) error
+	GetByID(id uint) (*entity.VehicleShare, error)
+	GetByVehicleID(vehicleID uint) ([]entity.VehicleShare, error)
+	GetActiveByGranteeID(userID uint, now time.Time) ([]entity.VehicleShare, error)
+	Revoke(id uint, at time.Time) error
+	TouchAccessed(id uint, at time.Time) error
+}
+
+// vehicleShareRepository implements VehicleShareRepository interface
+type vehicleShareRepository struct {
+	db *gorm.DB
+}
+
+// NewVehicleShareRepository creates new vehicle share repository instance
+func NewVehicleShareRepository(db *gorm.DB) VehicleShareRepository {
+	return &vehicleShareRepository{db: db}
+}
+
+// Create creates a new vehicle share
+func (r *vehicleShareRepository) Create(share *entity.VehicleShare) error {
+	return r.db.Omit("Vehicle", "GranteeUser").Create(share).Error
+}
+
+// GetByID gets vehicle share by ID
+func (r *vehicleShareRepository) GetByID(id uint) (*entity.VehicleShare, error) {
+	var share entity.VehicleShare
+	err := r.db.Preload("GranteeUser").First(&share, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// GetByVehicleID gets all shares of a vehicle, newest first
+func (r *vehicleShareRepository) GetByVehicleID(vehicleID uint) ([]entity.VehicleShare, error) {
+	var shares []entity.VehicleShare
+	err := r.db.Preload("GranteeUser").
+		Where("vehicle_id = ?", vehicleID).
+		Order("created_at DESC").
+		Find(&shares).Error
+	return shares, err
+}
+
+// GetActiveByGranteeID gets unexpired, unrevoked shares granted to a user
+func (r *vehicleShareRepository) GetActiveByGranteeID(userID uint, now time.Time) ([]entity.VehicleShare, error) {
+	var shares []entity.VehicleShare
+	err := r.db.Where("grantee_user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("expires_at ASC").
+		Find(&shares).Error
+	return shares, err
+}
+
+// Revoke marks a share as revoked
+func (r *vehicleShareRepository) Revoke(id uint, at time.Time) error {
+	return r.db.Model(&entity.VehicleShare{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+// TouchAccessed records when a share was last used
+func (r *vehicleShareRepository) TouchAccessed(id uint, at time.Time) error {
+	return r.db.Model(&entity.VehicleShare{}).
+		Where("id = ?", id).
+		UpdateColumn("last_accessed_at", at).Error
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscriptionRepository defines webhook subscription repository interface
+type WebhookSubscriptionRepository interface {
+	Create(subscription *entity.WebhookSubscription) error
+	GetByID(id uint) (*entity.WebhookSubscription, error)
+	GetByOrganizationIDs(organizationIDs []uint) ([]entity.WebhookSubscription, error)
+	GetEnabledForEvent(organizationID uint, eventType string) ([]entity.WebhookSubscription, error)
+	Update(subscription *entity.WebhookSubscription) error
+	Delete(id uint) error
+}
+
+// webhookSubscriptionRepository implements WebhookSubscriptionRepository interface
+type webhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookSubscriptionRepository creates new webhook subscription repository instance
+func NewWebhookSubscriptionRepository(db *gorm.DB) WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{db: db}
+}
+
+// Create creates a new webhook subscription
+func (r *webhookSubscriptionRepository) Create(subscription *entity.WebhookSubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+// GetByID gets webhook subscription by ID
+func (r *webhookSubscriptionRepository) GetByID(id uint) (*entity.WebhookSubscription, error) {
+	var subscription entity.WebhookSubscription
+	err := r.db.First(&subscription, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetByOrganizationIDs gets webhook subscriptions of the organizations
+func (r *webhookSubscriptionRepository) GetByOrganizationIDs(organizationIDs []uint) ([]entity.WebhookSubscription, error) {
+	var subscriptions []entity.WebhookSubscription
+	if len(organizationIDs) == 0 {
+		return subscriptions, nil
+	}
+	err := r.db.Where("organization_id IN ?", organizationIDs).
+		Order("id ASC").
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// GetEnabledForEvent gets the organization's enabled subscriptions that include the event type
+func (r *webhookSubscriptionRepository) GetEnabledForEvent(organizationID uint, eventType string) ([]entity.WebhookSubscription, error) {
+	var subscriptions []entity.WebhookSubscription
+	err := r.db.Where("organization_id = ? AND enabled = ? AND ? = ANY(string_to_array(event_types, ','))", organizationID, true, eventType).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// Update updates webhook subscription data
+func (r *webhookSubscriptionRepository) Update(subscription *entity.WebhookSubscription) error {
+	return r.db.Save(subscription).Error
+}
+
+// Delete soft deletes webhook subscription by ID
+func (r *webhookSubscriptionRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.WebhookSubscription{}, id).Error
+}
+
+// WebhookDeliveryRepository defines webhook delivery repository interface
+type WebhookDeliveryRepository interface {
+	CreateBatch(deliveries []entity.WebhookDelivery) error
+	GetByID(id uint) (*entity.WebhookDelivery, error)
+	GetBySubscriptionIDWithPagination(subscriptionID uint, status string, limit, offset int) ([]entity.WebhookDelivery, int64, error)
+	ClaimDue(now time.Time, lease time.Duration, limit int) ([]entity.WebhookDelivery, error)
+	Update(delivery *entity.WebhookDelivery) error
+}
+
+// webhookDeliveryRepository implements WebhookDeliveryRepository interface
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository creates new webhook delivery repository instance
+func NewWebhookDeliveryRepository(db *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+// CreateBatch queues several deliveries at once
+func (r *webhookDeliveryRepository) CreateBatch(deliveries []entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Omit("Subscription").Create(&deliveries).Error
+}
+
+// GetByID gets webhook delivery by ID
+func (r *webhookDeliveryRepository) GetByID(id uint) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	err := r.db.First(&delivery, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// GetBySubscriptionIDWithPagination gets deliveries of a subscription, newest first, optionally with one status
+func (r *webhookDeliveryRepository) GetBySubscriptionIDWithPagination(subscriptionID uint, status string, limit, offset int) ([]entity.WebhookDelivery, int64, error) {
+	var deliveries []entity.WebhookDelivery
+	var total int64
+
+	query := r.db.Model(&entity.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// ClaimDue leases up to limit pending deliveries that are due by pushing their next attempt past the lease,
+// so concurrent dispatchers never pick the same delivery and a crashed dispatcher's deliveries are retried
+func (r *webhookDeliveryRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]entity.WebhookDelivery, error) {
+	var ids []uint
+	err := r.db.Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`,
+		now.Add(lease), entity.WebhookDeliveryPending, now, limit).
+		Scan(&ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var deliveries []entity.WebhookDelivery
+	err = r.db.Preload("Subscription", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("id IN ?", ids).
+		Order("id ASC").
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// Update updates webhook delivery data
+func (r *webhookDeliveryRepository) Update(delivery *entity.WebhookDelivery) error {
+	return r.db.Omit("Subscription").Save(delivery).Error
+}
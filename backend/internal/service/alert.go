@@ -0,0 +1,743 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/geo"
+	"github.com/cartrack/backend/pkg/timezone"
+	"gorm.io/gorm"
+)
+
+// defaultWorkingDays is used by after-hours rules that do not list their working days (Monday to Friday)
+const defaultWorkingDays = "1,2,3,4,5"
+
+// notifyTimeout bounds how long the notification channels of one alert may take altogether
+const notifyTimeout = time.Minute
+
+// AlertService defines alert service interface
+type AlertService interface {
+	CreateRule(userID uint, req *dto.CreateAlertRuleRequest) (*dto.AlertRuleResponse, error)
+	GetRules(userID uint) ([]dto.AlertRuleResponse, error)
+	GetRule(userID, ruleID uint) (*dto.AlertRuleResponse, error)
+	UpdateRule(userID, ruleID uint, req *dto.UpdateAlertRuleRequest) (*dto.AlertRuleResponse, error)
+	DeleteRule(userID, ruleID uint) error
+	GetEvents(userID uint, vehicleID *uint, since *time.Time, limit, offset int) ([]dto.AlertEventResponse, int64, error)
+	GetGeofenceVehicles(userID, ruleID uint) ([]dto.NearbyVehicleResponse, error)
+	EvaluateLocation(vehicle *entity.Vehicle, locationLog *entity.LocationLog)
+	EvaluateFuel(vehicle *entity.Vehicle, fuelLog *entity.FuelLog)
+	EvaluateOffline(ctx context.Context) error
+}
+
+// alertService implements AlertService interface
+type alertService struct {
+	ruleRepo            repository.AlertRuleRepository
+	stateRepo           repository.AlertStateRepository
+	eventRepo           repository.AlertEventRepository
+	vehicleRepo         repository.VehicleRepository
+	locationLogRepo     repository.LocationLogRepository
+	deviceStatusRepo    repository.DeviceStatusRepository
+	organizationService OrganizationService
+	webhookService      WebhookService
+	notifiers           map[string]Notifier
+}
+
+// NewAlertService creates new alert service instance
+func NewAlertService(ruleRepo repository.AlertRuleRepository, stateRepo repository.AlertStateRepository, eventRepo repository.AlertEventRepository, vehicleRepo repository.VehicleRepository, locationLogRepo repository.LocationLogRepository, deviceStatusRepo repository.DeviceStatusRepository, organizationService OrganizationService, webhookService WebhookService, notifiers ...Notifier) AlertService {
+	byChannel := make(map[string]Notifier, len(notifiers))
+	for _, notifier := range notifiers {
+		byChannel[notifier.Channel()] = notifier
+	}
+
+	return &alertService{
+		ruleRepo:            ruleRepo,
+		stateRepo:           stateRepo,
+		eventRepo:           eventRepo,
+		vehicleRepo:         vehicleRepo,
+		locationLogRepo:     locationLogRepo,
+		deviceStatusRepo:    deviceStatusRepo,
+		organizationService: organizationService,
+		webhookService:      webhookService,
+		notifiers:           byChannel,
+	}
+}
+
+// CreateRule creates an alert rule for a vehicle or for every vehicle of an organization
+func (s *alertService) CreateRule(userID uint, req *dto.CreateAlertRuleRequest) (*dto.AlertRuleResponse, error) {
+	var organizationID uint
+	if req.VehicleID != nil {
+		vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, *req.VehicleID, entity.OrgActionManageVehicles)
+		if err != nil {
+			return nil, err
+		}
+		if req.OrganizationID != nil && *req.OrganizationID != vehicle.OrganizationID {
+			return nil, errors.New("vehicle does not belong to the organization")
+		}
+		organizationID = vehicle.OrganizationID
+	} else {
+		id, err := s.organizationService.ResolveOrganizationID(userID, req.OrganizationID, entity.OrgActionManageVehicles)
+		if err != nil {
+			return nil, err
+		}
+		organizationID = id
+	}
+
+	rule := &entity.AlertRule{
+		OrganizationID:    organizationID,
+		VehicleID:         req.VehicleID,
+		CreatedByUserID:   userID,
+		Name:              req.Name,
+		Type:              entity.AlertType(req.Type),
+		Severity:          entity.SeverityWarning,
+		Threshold:         req.Threshold,
+		GeofenceLatitude:  req.GeofenceLatitude,
+		GeofenceLongitude: req.GeofenceLongitude,
+		GeofenceRadiusM:   req.GeofenceRadiusM,
+		Channels:          strings.Join(req.Channels, ","),
+		CooldownMinutes:   15,
+		Enabled:           true,
+	}
+	if req.Severity != "" {
+		rule.Severity = entity.Severity(req.Severity)
+	}
+	if req.WorkingHoursStart != "" {
+		rule.WorkingHoursStart = &req.WorkingHoursStart
+	}
+	if req.WorkingHoursEnd != "" {
+		rule.WorkingHoursEnd = &req.WorkingHoursEnd
+	}
+	if len(req.WorkingDays) > 0 {
+		days := joinDays(req.WorkingDays)
+		rule.WorkingDays = &days
+	}
+	if len(req.EmailRecipients) > 0 {
+		recipients := strings.Join(req.EmailRecipients, ",")
+		rule.EmailRecipients = &recipients
+	}
+	if req.WebhookURL != "" {
+		rule.WebhookURL = &req.WebhookURL
+	}
+	if req.CooldownMinutes != nil {
+		rule.CooldownMinutes = *req.CooldownMinutes
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+
+	if err := validateAlertRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(rule); err != nil {
+		return nil, fmt.Errorf("failed to create alert rule: %w", err)
+	}
+
+	return s.entityToResponse(rule), nil
+}
+
+// GetRules gets alert rules of the user's organizations
+func (s *alertService) GetRules(userID uint) ([]dto.AlertRuleResponse, error) {
+	orgIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.GetByOrganizationIDs(orgIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rules: %w", err)
+	}
+
+	responses := make([]dto.AlertRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = *s.entityToResponse(&rule)
+	}
+	return responses, nil
+}
+
+// GetRule gets an alert rule by ID
+func (s *alertService) GetRule(userID, ruleID uint) (*dto.AlertRuleResponse, error) {
+	rule, err := s.authorizeRule(userID, ruleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+	return s.entityToResponse(rule), nil
+}
+
+// UpdateRule updates an alert rule. Its current alert states are reset so the changed
+// condition is evaluated from scratch.
+func (s *alertService) UpdateRule(userID, ruleID uint, req *dto.UpdateAlertRuleRequest) (*dto.AlertRuleResponse, error) {
+	rule, err := s.authorizeRule(userID, ruleID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		rule.Name = req.Name
+	}
+	if req.Severity != "" {
+		rule.Severity = entity.Severity(req.Severity)
+	}
+	if req.Threshold != nil {
+		rule.Threshold = req.Threshold
+	}
+	if req.GeofenceLatitude != nil {
+		rule.GeofenceLatitude = req.GeofenceLatitude
+	}
+	if req.GeofenceLongitude != nil {
+		rule.GeofenceLongitude = req.GeofenceLongitude
+	}
+	if req.GeofenceRadiusM != nil {
+		rule.GeofenceRadiusM = req.GeofenceRadiusM
+	}
+	if req.WorkingHoursStart != "" {
+		rule.WorkingHoursStart = &req.WorkingHoursStart
+	}
+	if req.WorkingHoursEnd != "" {
+		rule.WorkingHoursEnd = &req.WorkingHoursEnd
+	}
+	if len(req.WorkingDays) > 0 {
+		days := joinDays(req.WorkingDays)
+		rule.WorkingDays = &days
+	}
+	if len(req.Channels) > 0 {
+		rule.Channels = strings.Join(req.Channels, ",")
+	}
+	if req.EmailRecipients != nil {
+		rule.EmailRecipients = nil
+		if len(req.EmailRecipients) > 0 {
+			recipients := strings.Join(req.EmailRecipients, ",")
+			rule.EmailRecipients = &recipients
+		}
+	}
+	if req.WebhookURL != nil {
+		rule.WebhookURL = nil
+		if *req.WebhookURL != "" {
+			rule.WebhookURL = req.WebhookURL
+		}
+	}
+	if req.CooldownMinutes != nil {
+		rule.CooldownMinutes = *req.CooldownMinutes
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+
+	if err := validateAlertRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Update(rule); err != nil {
+		return nil, fmt.Errorf("failed to update alert rule: %w", err)
+	}
+
+	if err := s.stateRepo.DeleteByRuleID(rule.ID); err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to reset alert states of rule %d: %v", rule.ID, err)
+	}
+
+	return s.entityToResponse(rule), nil
+}
+
+// DeleteRule deletes an alert rule
+func (s *alertService) DeleteRule(userID, ruleID uint) error {
+	rule, err := s.authorizeRule(userID, ruleID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ruleRepo.Delete(rule.ID); err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+
+	if err := s.stateRepo.DeleteByRuleID(rule.ID); err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to delete alert states of rule %d: %v", rule.ID, err)
+	}
+
+	return nil
+}
+
+// GetEvents gets triggered alerts of the user's organizations, optionally for one vehicle
+func (s *alertService) GetEvents(userID uint, vehicleID *uint, since *time.Time, limit, offset int) ([]dto.AlertEventResponse, int64, error) {
+	var orgIDs []uint
+	if vehicleID != nil {
+		vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, *vehicleID, entity.OrgActionRead)
+		if err != nil {
+			return nil, 0, err
+		}
+		orgIDs = []uint{vehicle.OrganizationID}
+	} else {
+		ids, err := s.organizationService.GetOrganizationIDs(userID)
+		if err != nil {
+			return nil, 0, err
+		}
+		orgIDs = ids
+	}
+
+	events, total, err := s.eventRepo.GetByOrganizationIDsWithPagination(orgIDs, vehicleID, since, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get alerts: %w", err)
+	}
+
+	responses := make([]dto.AlertEventResponse, len(events))
+	for i, event := range events {
+		responses[i] = dto.AlertEventResponse{
+			ID:          event.ID,
+			RuleID:      event.RuleID,
+			RuleName:    event.Rule.Name,
+			VehicleID:   event.VehicleID,
+			PlateNumber: event.Vehicle.PlateNumber,
+			Type:        string(event.Type),
+			Severity:    string(event.Severity),
+			Message:     event.Message,
+			Value:       event.Value,
+			TriggeredAt: event.TriggeredAt,
+		}
+	}
+	return responses, total, nil
+}
+
+// GetGeofenceVehicles gets the vehicles watched by a geofence rule whose latest trusted position lies inside
+// its geofence, nearest to the center first
+func (s *alertService) GetGeofenceVehicles(userID, ruleID uint) ([]dto.NearbyVehicleResponse, error) {
+	rule, err := s.authorizeRule(userID, ruleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Type != entity.AlertTypeGeofenceExit || rule.GeofenceLatitude == nil || rule.GeofenceLongitude == nil || rule.GeofenceRadiusM == nil {
+		return nil, errors.New("alert rule has no geofence")
+	}
+
+	var vehicles []entity.Vehicle
+	if rule.VehicleID != nil {
+		vehicle, err := s.vehicleRepo.GetByID(*rule.VehicleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vehicle: %w", err)
+		}
+		vehicles = []entity.Vehicle{*vehicle}
+	} else {
+		vehicles, err = s.vehicleRepo.GetByOrganizationIDs([]uint{rule.OrganizationID}, -1, -1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vehicles: %w", err)
+		}
+	}
+
+	center := geo.Point{Latitude: *rule.GeofenceLatitude, Longitude: *rule.GeofenceLongitude}
+	vehicleIDs := make([]uint, len(vehicles))
+	for i, vehicle := range vehicles {
+		vehicleIDs[i] = vehicle.ID
+	}
+
+	locations, err := s.locationLogRepo.GetLatestTrustedWithin(vehicleIDs, geo.Circle{Center: center, Radius: *rule.GeofenceRadiusM})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest locations: %w", err)
+	}
+
+	return nearbyVehicles(vehicles, locations, center), nil
+}
+
+// EvaluateLocation checks the location-based rules of the vehicle against a newly stored location log.
+// A report also resolves any offline alert of the vehicle.
+func (s *alertService) EvaluateLocation(vehicle *entity.Vehicle, locationLog *entity.LocationLog) {
+	rules, err := s.ruleRepo.GetEnabledForVehicle(vehicle,
+		entity.AlertTypeOffline, entity.AlertTypeOverspeed, entity.AlertTypeGeofenceExit, entity.AlertTypeAfterHoursUse)
+	if err != nil {
+		log.Printf("Failed to get alert rules of vehicle %d: %v", vehicle.ID, err)
+		return
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		switch rule.Type {
+		case entity.AlertTypeOffline:
+			s.apply(rule, vehicle, false, nil, "", locationLog.Timestamp)
+
+		case entity.AlertTypeOverspeed:
+			if locationLog.Speed == nil || rule.Threshold == nil {
+				continue
+			}
+			speed := *locationLog.Speed
+			s.apply(rule, vehicle, speed > *rule.Threshold, &speed,
+				fmt.Sprintf("Vehicle %s is driving at %.0f km/h, above the %.0f km/h limit", vehicle.PlateNumber, speed, *rule.Threshold),
+				locationLog.Timestamp)
+
+		case entity.AlertTypeGeofenceExit:
+			if rule.GeofenceLatitude == nil || rule.GeofenceLongitude == nil || rule.GeofenceRadiusM == nil {
+				continue
+			}
+			distance := geo.DistanceMeters(*rule.GeofenceLatitude, *rule.GeofenceLongitude, locationLog.Latitude, locationLog.Longitude)
+			s.apply(rule, vehicle, distance > *rule.GeofenceRadiusM, &distance,
+				fmt.Sprintf("Vehicle %s left the geofence of %s and is %.0f m from its center", vehicle.PlateNumber, rule.Name, distance),
+				locationLog.Timestamp)
+
+		case entity.AlertTypeAfterHoursUse:
+			// Devices without an ignition input are considered running while moving
+			ignition := locationLog.Speed != nil && *locationLog.Speed > 0
+			if locationLog.Ignition != nil {
+				ignition = *locationLog.Ignition
+			}
+			local := locationLog.Timestamp.In(timezone.JakartaLocation)
+			s.apply(rule, vehicle, ignition && !withinWorkingHours(rule, local), nil,
+				fmt.Sprintf("Vehicle %s is in use outside working hours (%s)", vehicle.PlateNumber, local.Format("Mon 15:04")),
+				locationLog.Timestamp)
+		}
+	}
+}
+
+// EvaluateFuel checks the low fuel rules of the vehicle against a newly stored fuel log
+func (s *alertService) EvaluateFuel(vehicle *entity.Vehicle, fuelLog *entity.FuelLog) {
+	rules, err := s.ruleRepo.GetEnabledForVehicle(vehicle, entity.AlertTypeLowFuel)
+	if err != nil {
+		log.Printf("Failed to get alert rules of vehicle %d: %v", vehicle.ID, err)
+		return
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Threshold == nil {
+			continue
+		}
+		level := fuelLog.FuelLevel
+		s.apply(rule, vehicle, level < *rule.Threshold, &level,
+			fmt.Sprintf("Fuel level of vehicle %s is %.1f%%, below %.0f%%", vehicle.PlateNumber, level, *rule.Threshold),
+			fuelLog.Timestamp)
+	}
+}
+
+// EvaluateOffline raises offline alerts for watched vehicles whose device was last seen longer ago than the rule allows.
+// Vehicles whose device never reported are not considered offline.
+func (s *alertService) EvaluateOffline(ctx context.Context) error {
+	rules, err := s.ruleRepo.GetEnabledByType(entity.AlertTypeOffline)
+	if err != nil {
+		return fmt.Errorf("failed to get offline alert rules: %w", err)
+	}
+
+	vehicles := make(map[uint]*entity.Vehicle)
+	watched := make(map[uint][]*entity.Vehicle)
+	for i := range rules {
+		rule := &rules[i]
+		if rule.VehicleID != nil {
+			vehicle, ok := vehicles[*rule.VehicleID]
+			if !ok {
+				if vehicle, err = s.vehicleRepo.GetByID(*rule.VehicleID); err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						continue
+					}
+					return fmt.Errorf("failed to get vehicle: %w", err)
+				}
+				vehicles[vehicle.ID] = vehicle
+			}
+			watched[rule.ID] = []*entity.Vehicle{vehicle}
+			continue
+		}
+
+		fleet, err := s.vehicleRepo.GetByOrganizationIDs([]uint{rule.OrganizationID}, -1, -1)
+		if err != nil {
+			return fmt.Errorf("failed to get vehicles: %w", err)
+		}
+		for j := range fleet {
+			vehicle, ok := vehicles[fleet[j].ID]
+			if !ok {
+				vehicle = &fleet[j]
+				vehicles[vehicle.ID] = vehicle
+			}
+			watched[rule.ID] = append(watched[rule.ID], vehicle)
+		}
+	}
+
+	vehicleIDs := make([]uint, 0, len(vehicles))
+	for id := range vehicles {
+		vehicleIDs = append(vehicleIDs, id)
+	}
+	statuses, err := s.deviceStatusRepo.GetByVehicleIDs(vehicleIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get device statuses: %w", err)
+	}
+	lastSeen := make(map[uint]time.Time, len(statuses))
+	for _, status := range statuses {
+		lastSeen[status.VehicleID] = status.LastSeenAt
+	}
+
+	now := time.Now()
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Threshold == nil {
+			continue
+		}
+		for _, vehicle := range watched[rule.ID] {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			seen, ok := lastSeen[vehicle.ID]
+			if !ok {
+				continue
+			}
+			minutes := now.Sub(seen).Minutes()
+			s.apply(rule, vehicle, minutes > *rule.Threshold, &minutes,
+				fmt.Sprintf("Vehicle %s has not reported for %.0f minutes (last seen %s)",
+					vehicle.PlateNumber, minutes, seen.In(timezone.JakartaLocation).Format("2006-01-02 15:04")),
+				now)
+		}
+	}
+
+	return nil
+}
+
+// apply moves the rule's state for the vehicle and notifies when the condition starts to hold.
+// An ongoing condition notifies once; after it clears, a new occurrence within the cooldown is not notified again.
+func (s *alertService) apply(rule *entity.AlertRule, vehicle *entity.Vehicle, triggered bool, value *float64, message string, at time.Time) {
+	// State transitions are atomic in the database, so concurrent reports of one condition, even from
+	// different processes, notify once
+	now := time.Now()
+	if !triggered {
+		resolved, err := s.stateRepo.Resolve(rule.ID, vehicle.ID, now)
+		if err != nil {
+			log.Printf("Failed to resolve alert state of rule %d for vehicle %d: %v", rule.ID, vehicle.ID, err)
+			return
+		}
+		if resolved {
+			s.publishGeofenceCrossing(rule, vehicle, entity.WebhookEventGeofenceEnter, value, at)
+		}
+		return
+	}
+
+	cooldown := time.Duration(rule.CooldownMinutes) * time.Minute
+	activated, notify, err := s.stateRepo.Activate(rule.ID, vehicle.ID, now, cooldown)
+	if err != nil {
+		log.Printf("Failed to save alert state of rule %d for vehicle %d: %v", rule.ID, vehicle.ID, err)
+		return
+	}
+	if !activated {
+		return
+	}
+	s.publishGeofenceCrossing(rule, vehicle, entity.WebhookEventGeofenceExit, value, at)
+	if !notify {
+		return
+	}
+
+	event := &entity.AlertEvent{
+		RuleID:         rule.ID,
+		OrganizationID: rule.OrganizationID,
+		VehicleID:      vehicle.ID,
+		Type:           rule.Type,
+		Severity:       rule.Severity,
+		Message:        message,
+		Value:          value,
+		TriggeredAt:    at,
+	}
+	if err := s.eventRepo.Create(event); err != nil {
+		log.Printf("Failed to record alert of rule %d for vehicle %d: %v", rule.ID, vehicle.ID, err)
+		return
+	}
+
+	s.webhookService.Publish(rule.OrganizationID, entity.WebhookEventAlertTriggered, dto.AlertEventResponse{
+		ID:          event.ID,
+		RuleID:      rule.ID,
+		RuleName:    rule.Name,
+		VehicleID:   vehicle.ID,
+		PlateNumber: vehicle.PlateNumber,
+		Type:        string(event.Type),
+		Severity:    string(event.Severity),
+		Message:     event.Message,
+		Value:       event.Value,
+		TriggeredAt: event.TriggeredAt,
+	})
+
+	go s.dispatch(&AlertNotification{Event: event, Rule: rule, Vehicle: vehicle})
+}
+
+// publishGeofenceCrossing publishes a vehicle leaving or re-entering the geofence of a geofence_exit rule.
+// Crossings are published regardless of the rule's cooldown.
+func (s *alertService) publishGeofenceCrossing(rule *entity.AlertRule, vehicle *entity.Vehicle, eventType string, distance *float64, at time.Time) {
+	if rule.Type != entity.AlertTypeGeofenceExit {
+		return
+	}
+
+	s.webhookService.Publish(rule.OrganizationID, eventType, dto.GeofenceCrossingEvent{
+		RuleID:      rule.ID,
+		RuleName:    rule.Name,
+		VehicleID:   vehicle.ID,
+		PlateNumber: vehicle.PlateNumber,
+		Latitude:    *rule.GeofenceLatitude,
+		Longitude:   *rule.GeofenceLongitude,
+		RadiusM:     *rule.GeofenceRadiusM,
+		DistanceM:   distance,
+		OccurredAt:  at,
+	})
+}
+
+// dispatch delivers an alert through every channel of its rule. Channel failures are logged.
+func (s *alertService) dispatch(n *AlertNotification) {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	for _, channel := range n.Rule.ChannelList() {
+		notifier, ok := s.notifiers[channel]
+		if !ok {
+			log.Printf("No notifier for alert channel %q", channel)
+			continue
+		}
+		if err := notifier.Notify(ctx, n); err != nil {
+			log.Printf("Failed to deliver alert %d via %s: %v", n.Event.ID, channel, err)
+		}
+	}
+}
+
+// authorizeRule loads an alert rule and checks the user may perform the action in its organization
+func (s *alertService) authorizeRule(userID, ruleID uint, action entity.OrgAction) (*entity.AlertRule, error) {
+	rule, err := s.ruleRepo.GetByID(ruleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("alert rule not found")
+		}
+		return nil, fmt.Errorf("failed to get alert rule: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, rule.OrganizationID, action); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("alert rule not found")
+	}
+
+	return rule, nil
+}
+
+// entityToResponse converts entity to response DTO
+func (s *alertService) entityToResponse(rule *entity.AlertRule) *dto.AlertRuleResponse {
+	response := &dto.AlertRuleResponse{
+		ID:                rule.ID,
+		OrganizationID:    rule.OrganizationID,
+		VehicleID:         rule.VehicleID,
+		Name:              rule.Name,
+		Type:              string(rule.Type),
+		Severity:          string(rule.Severity),
+		Threshold:         rule.Threshold,
+		GeofenceLatitude:  rule.GeofenceLatitude,
+		GeofenceLongitude: rule.GeofenceLongitude,
+		GeofenceRadiusM:   rule.GeofenceRadiusM,
+		WorkingHoursStart: rule.WorkingHoursStart,
+		WorkingHoursEnd:   rule.WorkingHoursEnd,
+		Channels:          rule.ChannelList(),
+		EmailRecipients:   rule.EmailList(),
+		WebhookURL:        rule.WebhookURL,
+		CooldownMinutes:   rule.CooldownMinutes,
+		Enabled:           rule.Enabled,
+		CreatedAt:         rule.CreatedAt,
+		UpdatedAt:         rule.UpdatedAt,
+	}
+	if rule.Type == entity.AlertTypeAfterHoursUse {
+		response.WorkingDays = workingDays(rule)
+	}
+	return response
+}
+
+// validateAlertRule checks the rule carries the settings its type and channels need
+func validateAlertRule(rule *entity.AlertRule) error {
+	switch rule.Type {
+	case entity.AlertTypeOffline, entity.AlertTypeOverspeed:
+		if rule.Threshold == nil {
+			return fmt.Errorf("threshold is required for %s alerts", rule.Type)
+		}
+	case entity.AlertTypeLowFuel:
+		if rule.Threshold == nil || *rule.Threshold > 100 {
+			return errors.New("threshold between 0 and 100 percent is required for low_fuel alerts")
+		}
+	case entity.AlertTypeGeofenceExit:
+		if rule.GeofenceLatitude == nil || rule.GeofenceLongitude == nil || rule.GeofenceRadiusM == nil {
+			return errors.New("geofence latitude, longitude and radius are required for geofence_exit alerts")
+		}
+	case entity.AlertTypeAfterHoursUse:
+		if rule.WorkingHoursStart == nil || rule.WorkingHoursEnd == nil {
+			return errors.New("working hours start and end are required for after_hours_use alerts")
+		}
+		if *rule.WorkingHoursStart == *rule.WorkingHoursEnd {
+			return errors.New("working hours start and end must differ")
+		}
+	default:
+		return errors.New("invalid alert type")
+	}
+
+	for _, channel := range rule.ChannelList() {
+		if channel != entity.ChannelWebhook {
+			continue
+		}
+		if rule.WebhookURL == nil {
+			return errors.New("webhook_url is required for the webhook channel")
+		}
+		if err := validateWebhookURL("webhook_url", *rule.WebhookURL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withinWorkingHours checks if a local time falls inside the rule's working days and hours.
+// A window whose end is before its start spans midnight and belongs to the day it starts.
+func withinWorkingHours(rule *entity.AlertRule, local time.Time) bool {
+	start, _ := time.Parse("15:04", *rule.WorkingHoursStart)
+	end, _ := time.Parse("15:04", *rule.WorkingHoursEnd)
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	day := local
+	if startMinute < endMinute {
+		if minute < startMinute || minute >= endMinute {
+			return false
+		}
+	} else {
+		if minute < startMinute && minute >= endMinute {
+			return false
+		}
+		if minute < endMinute {
+			day = local.AddDate(0, 0, -1)
+		}
+	}
+
+	weekday := int(day.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	for _, working := range workingDays(rule) {
+		if working == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// workingDays returns the rule's ISO weekdays
+func workingDays(rule *entity.AlertRule) []int {
+	value := defaultWorkingDays
+	if rule.WorkingDays != nil {
+		value = *rule.WorkingDays
+	}
+
+	var days []int
+	for _, item := range strings.Split(value, ",") {
+		if day, err := strconv.Atoi(strings.TrimSpace(item)); err == nil {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// joinDays stores weekdays as a comma-separated list
+func joinDays(days []int) string {
+	items := make([]string, len(days))
+	for i, day := range days {
+		items[i] = strconv.Itoa(day)
+	}
+	return strings.Join(items, ",")
+}
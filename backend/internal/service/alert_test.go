@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/repository"
+)
+
+// fakeAlertStateRepo applies the same transitions as the SQL in alertStateRepository, one call at a time
+type fakeAlertStateRepo struct {
+	repository.AlertStateRepository
+	mu           sync.Mutex
+	active       bool
+	lastNotified *time.Time
+}
+
+func (r *fakeAlertStateRepo) Activate(ruleID, vehicleID uint, now time.Time, cooldown time.Duration) (bool, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.active {
+		return false, false, nil
+	}
+	r.active = true
+	if r.lastNotified != nil && r.lastNotified.After(now.Add(-cooldown)) {
+		return true, false, nil
+	}
+	r.lastNotified = &now
+	return true, true, nil
+}
+
+func (r *fakeAlertStateRepo) Resolve(ruleID, vehicleID uint, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resolved := r.active
+	r.active = false
+	return resolved, nil
+}
+
+// fakeAlertEventRepo counts recorded events
+type fakeAlertEventRepo struct {
+	repository.AlertEventRepository
+	mu     sync.Mutex
+	events []*entity.AlertEvent
+}
+
+func (r *fakeAlertEventRepo) Create(event *entity.AlertEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *fakeAlertEventRepo) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+// fakeWebhookService counts published events by type
+type fakeWebhookService struct {
+	WebhookService
+	mu        sync.Mutex
+	published map[string]int
+}
+
+func (s *fakeWebhookService) Publish(organizationID uint, eventType string, data interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.published == nil {
+		s.published = make(map[string]int)
+	}
+	s.published[eventType]++
+}
+
+func newTestAlertService() (*alertService, *fakeAlertEventRepo) {
+	events := &fakeAlertEventRepo{}
+	return &alertService{stateRepo: &fakeAlertStateRepo{}, eventRepo: events, webhookService: &fakeWebhookService{}}, events
+}
+
+func TestApplyConcurrentReportsNotifyOnce(t *testing.T) {
+	svc, events := newTestAlertService()
+	rule := &entity.AlertRule{ID: 1, OrganizationID: 1, Type: entity.AlertTypeOverspeed, CooldownMinutes: 15}
+	vehicle := &entity.Vehicle{ID: 1, PlateNumber: "B 1234 XYZ"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			speed := 130.0
+			svc.apply(rule, vehicle, true, &speed, "speeding", time.Now())
+		}()
+	}
+	wg.Wait()
+
+	if got := events.count(); got != 1 {
+		t.Errorf("recorded %d events for one ongoing condition, want 1", got)
+	}
+	if got := svc.webhookService.(*fakeWebhookService).published[entity.WebhookEventAlertTriggered]; got != 1 {
+		t.Errorf("published %d alert webhooks for one ongoing condition, want 1", got)
+	}
+}
+
+func TestApplyCooldown(t *testing.T) {
+	tests := []struct {
+		name     string
+		cooldown int
+		want     int
+	}{
+		{name: "re-trigger within cooldown", cooldown: 15, want: 1},
+		{name: "re-trigger without cooldown", cooldown: 0, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, events := newTestAlertService()
+			rule := &entity.AlertRule{ID: 1, OrganizationID: 1, Type: entity.AlertTypeOverspeed, CooldownMinutes: tt.cooldown}
+			vehicle := &entity.Vehicle{ID: 1, PlateNumber: "B 1234 XYZ"}
+			speed := 130.0
+
+			svc.apply(rule, vehicle, true, &speed, "speeding", time.Now())
+			svc.apply(rule, vehicle, true, &speed, "speeding", time.Now())
+			svc.apply(rule, vehicle, false, nil, "", time.Now())
+			svc.apply(rule, vehicle, true, &speed, "speeding", time.Now())
+
+			if got := events.count(); got != tt.want {
+				t.Errorf("recorded %d events, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAlertRuleWebhookURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "public address", url: "https://93.184.216.34/hooks/alerts", wantErr: false},
+		{name: "loopback", url: "http://127.0.0.1:8080/hook", wantErr: true},
+		{name: "private network", url: "http://10.0.0.5/hook", wantErr: true},
+		{name: "cloud metadata", url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{name: "unsupported scheme", url: "ftp://93.184.216.34/hook", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			threshold := 100.0
+			url := tt.url
+			rule := &entity.AlertRule{
+				Type:       entity.AlertTypeOverspeed,
+				Threshold:  &threshold,
+				Channels:   entity.ChannelWebhook,
+				WebhookURL: &url,
+			}
+			if err := validateAlertRule(rule); (err != nil) != tt.wantErr {
+				t.Errorf("validateAlertRule(%s) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifierRefusesInternalAddresses(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	url := server.URL
+	notification := &AlertNotification{
+		Event:   &entity.AlertEvent{ID: 1, Type: entity.AlertTypeOverspeed},
+		Rule:    &entity.AlertRule{ID: 1, WebhookURL: &url},
+		Vehicle: &entity.Vehicle{ID: 1},
+	}
+
+	if err := NewWebhookNotifier(time.Second).Notify(context.Background(), notification); err == nil {
+		t.Error("Notify() to a loopback address error = nil, want refused")
+	}
+	if called {
+		t.Error("Notify() reached a loopback address")
+	}
+}
@@ -26,15 +26,17 @@ type APIKeyService interface {
 
 // apiKeyService implements APIKeyService interface
 type apiKeyService struct {
-	apiKeyRepo  repository.APIKeyRepository
-	vehicleRepo repository.VehicleRepository
+	apiKeyRepo          repository.APIKeyRepository
+	vehicleRepo         repository.VehicleRepository
+	organizationService OrganizationService
 }
 
 // NewAPIKeyService creates new API key service instance
-func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, vehicleRepo repository.VehicleRepository) APIKeyService {
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, vehicleRepo repository.VehicleRepository, organizationService OrganizationService) APIKeyService {
 	return &apiKeyService{
-		apiKeyRepo:  apiKeyRepo,
-		vehicleRepo: vehicleRepo,
+		apiKeyRepo:          apiKeyRepo,
+		vehicleRepo:         vehicleRepo,
+		organizationService: organizationService,
 	}
 }
 
@@ -49,6 +51,12 @@ func generateAPIKey() (string, error) {
 
 // Create creates a new API key
 func (s *apiKeyService) Create(userID uint, req *dto.CreateAPIKeyRequest) (*dto.APIKeyResponse, error) {
+	// API keys grant device access to every vehicle of the organization
+	organizationID, err := s.organizationService.ResolveOrganizationID(userID, req.OrganizationID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate API key
 	key, err := generateAPIKey()
 	if err != nil {
@@ -56,11 +64,12 @@ func (s *apiKeyService) Create(userID uint, req *dto.CreateAPIKeyRequest) (*dto.
 	}
 
 	apiKey := &entity.APIKey{
-		Key:         key,
-		Name:        req.Name,
-		Description: req.Description,
-		UserID:      userID,
-		IsActive:    true,
+		Key:            key,
+		Name:           req.Name,
+		Description:    req.Description,
+		UserID:         userID,
+		OrganizationID: organizationID,
+		IsActive:       true,
 	}
 
 	if err := s.apiKeyRepo.Create(apiKey); err != nil {
@@ -72,25 +81,22 @@ func (s *apiKeyService) Create(userID uint, req *dto.CreateAPIKeyRequest) (*dto.
 
 // GetByID gets API key by ID
 func (s *apiKeyService) GetByID(userID, id uint) (*dto.APIKeyResponse, error) {
-	apiKey, err := s.apiKeyRepo.GetByID(id)
+	apiKey, err := s.authorize(userID, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("API key not found")
-		}
-		return nil, fmt.Errorf("failed to get API key: %w", err)
-	}
-
-	// Verify ownership
-	if apiKey.UserID != userID {
-		return nil, errors.New("API key not found")
+		return nil, err
 	}
 
 	return s.entityToResponse(apiKey), nil
 }
 
-// GetByUserID gets API keys by user ID
+// GetByUserID gets API keys of every organization where the user manages vehicles
 func (s *apiKeyService) GetByUserID(userID uint) ([]dto.APIKeyResponse, error) {
-	apiKeys, err := s.apiKeyRepo.GetByUserID(userID)
+	organizationIDs, err := s.organizationService.GetOrganizationIDsFor(userID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys, err := s.apiKeyRepo.GetByOrganizationIDs(organizationIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get API keys: %w", err)
 	}
@@ -105,17 +111,9 @@ func (s *apiKeyService) GetByUserID(userID uint) ([]dto.APIKeyResponse, error) {
 
 // Update updates API key
 func (s *apiKeyService) Update(userID, id uint, req *dto.UpdateAPIKeyRequest) (*dto.APIKeyResponse, error) {
-	apiKey, err := s.apiKeyRepo.GetByID(id)
+	apiKey, err := s.authorize(userID, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("API key not found")
-		}
-		return nil, fmt.Errorf("failed to get API key: %w", err)
-	}
-
-	// Verify ownership
-	if apiKey.UserID != userID {
-		return nil, errors.New("API key not found")
+		return nil, err
 	}
 
 	// Update fields
@@ -138,17 +136,8 @@ func (s *apiKeyService) Update(userID, id uint, req *dto.UpdateAPIKeyRequest) (*
 
 // Delete deletes API key
 func (s *apiKeyService) Delete(userID, id uint) error {
-	apiKey, err := s.apiKeyRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("API key not found")
-		}
-		return fmt.Errorf("failed to get API key: %w", err)
-	}
-
-	// Verify ownership
-	if apiKey.UserID != userID {
-		return errors.New("API key not found")
+	if _, err := s.authorize(userID, id); err != nil {
+		return err
 	}
 
 	return s.apiKeyRepo.Delete(id)
@@ -156,27 +145,19 @@ func (s *apiKeyService) Delete(userID, id uint) error {
 
 // ValidateAPIKey validates API key and returns the associated vehicle
 func (s *apiKeyService) ValidateAPIKey(key string) (*entity.APIKey, error) {
-	// Debug logging
-	fmt.Printf("DEBUG: Validating API key: '%s'\n", key)
-
 	apiKey, err := s.apiKeyRepo.GetByKey(key)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			fmt.Printf("DEBUG: API key not found in database: '%s'\n", key)
 			return nil, errors.New("invalid API key")
 		}
-		fmt.Printf("DEBUG: Database error while validating API key: %v\n", err)
 		return nil, fmt.Errorf("failed to validate API key: %w", err)
 	}
 
 	// Additional check for is_active (redundant but for safety)
 	if !apiKey.IsActive {
-		fmt.Printf("DEBUG: API key is inactive: '%s'\n", key)
 		return nil, errors.New("invalid API key")
 	}
 
-	fmt.Printf("DEBUG: API key validation successful for user ID: %d\n", apiKey.UserID)
-
 	// Update last used timestamp
 	if err := s.apiKeyRepo.UpdateLastUsed(apiKey.ID); err != nil {
 		// Log error but don't fail the request
@@ -202,9 +183,14 @@ func (s *apiKeyService) GetAll(userID uint, limit, offset int) ([]dto.APIKeyResp
 	return responses, nil
 }
 
-// GetAllWithPagination gets all API keys with pagination info (admin only)
+// GetAllWithPagination gets API keys of the user's organizations with pagination info
 func (s *apiKeyService) GetAllWithPagination(userID uint, limit, offset int) ([]dto.APIKeyResponse, int64, error) {
-	apiKeys, total, err := s.apiKeyRepo.GetAllWithPagination(limit, offset)
+	organizationIDs, err := s.organizationService.GetOrganizationIDsFor(userID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	apiKeys, total, err := s.apiKeyRepo.GetByOrganizationIDsWithPagination(organizationIDs, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get API keys: %w", err)
 	}
@@ -217,18 +203,40 @@ func (s *apiKeyService) GetAllWithPagination(userID uint, limit, offset int) ([]
 	return responses, total, nil
 }
 
+// authorize loads an API key and checks the user may manage keys of its organization.
+// Keys outside the user's organizations are reported as not found.
+func (s *apiKeyService) authorize(userID, id uint) (*entity.APIKey, error) {
+	apiKey, err := s.apiKeyRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("API key not found")
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, apiKey.OrganizationID, entity.OrgActionManageVehicles); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("API key not found")
+	}
+
+	return apiKey, nil
+}
+
 // entityToResponse converts entity to response DTO
 func (s *apiKeyService) entityToResponse(apiKey *entity.APIKey) *dto.APIKeyResponse {
 	response := &dto.APIKeyResponse{
-		ID:          apiKey.ID,
-		Key:         apiKey.Key,
-		Name:        apiKey.Name,
-		Description: apiKey.Description,
-		UserID:      apiKey.UserID,
-		IsActive:    apiKey.IsActive,
-		LastUsedAt:  apiKey.LastUsedAt,
-		CreatedAt:   apiKey.CreatedAt,
-		UpdatedAt:   apiKey.UpdatedAt,
+		ID:             apiKey.ID,
+		Key:            apiKey.Key,
+		Name:           apiKey.Name,
+		Description:    apiKey.Description,
+		UserID:         apiKey.UserID,
+		OrganizationID: apiKey.OrganizationID,
+		IsActive:       apiKey.IsActive,
+		LastUsedAt:     apiKey.LastUsedAt,
+		CreatedAt:      apiKey.CreatedAt,
+		UpdatedAt:      apiKey.UpdatedAt,
 	}
 
 	// Include user information if loaded
@@ -243,19 +251,20 @@ func (s *apiKeyService) entityToResponse(apiKey *entity.APIKey) *dto.APIKeyRespo
 			UpdatedAt:   apiKey.User.UpdatedAt,
 		}
 
-		// Get all vehicles for this user (using a large limit to get all vehicles)
-		vehicles, err := s.vehicleRepo.GetByUserID(apiKey.UserID, 1000, 0)
+		// Get all vehicles the key can access (using a large limit to get all vehicles)
+		vehicles, err := s.vehicleRepo.GetByOrganizationIDs([]uint{apiKey.OrganizationID}, 1000, 0)
 		if err == nil {
 			response.Vehicles = make([]dto.VehicleResponse, len(vehicles))
 			for i, vehicle := range vehicles {
 				response.Vehicles[i] = dto.VehicleResponse{
-					ID:          vehicle.ID,
-					UserID:      vehicle.UserID,
-					PlateNumber: vehicle.PlateNumber,
-					Model:       vehicle.Model,
-					IMEI:        vehicle.IMEI,
-					CreatedAt:   vehicle.CreatedAt,
-					UpdatedAt:   vehicle.UpdatedAt,
+					ID:             vehicle.ID,
+					UserID:         vehicle.UserID,
+					OrganizationID: vehicle.OrganizationID,
+					PlateNumber:    vehicle.PlateNumber,
+					Model:          vehicle.Model,
+					IMEI:           vehicle.IMEI,
+					CreatedAt:      vehicle.CreatedAt,
+					UpdatedAt:      vehicle.UpdatedAt,
 				}
 			}
 		}
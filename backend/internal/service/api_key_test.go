@@ -0,0 +1,86 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// fakeAPIKeyRepo knows a single key and can fail the last-used update
+type fakeAPIKeyRepo struct {
+	repository.APIKeyRepository
+	apiKey        entity.APIKey
+	lastUsedError error
+}
+
+func (r *fakeAPIKeyRepo) GetByKey(key string) (*entity.APIKey, error) {
+	if key != r.apiKey.Key {
+		return nil, gorm.ErrRecordNotFound
+	}
+	apiKey := r.apiKey
+	return &apiKey, nil
+}
+
+func (r *fakeAPIKeyRepo) UpdateLastUsed(id uint) error {
+	return r.lastUsedError
+}
+
+// captureOutput returns everything written to stdout and the standard logger while fn runs
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer func() {
+		os.Stdout = stdout
+		log.SetOutput(os.Stderr)
+	}()
+
+	fn()
+
+	writer.Close()
+	printed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read stdout: %v", err)
+	}
+	return string(printed) + logged.String()
+}
+
+func TestValidateAPIKeyDoesNotLogKeys(t *testing.T) {
+	const validKey = "ck_live_4f9d2a7e1b3c5d6e"
+	const unknownKey = "ck_live_0000deadbeef0000"
+
+	svc := &apiKeyService{apiKeyRepo: &fakeAPIKeyRepo{
+		apiKey:        entity.APIKey{ID: 1, Key: validKey, IsActive: true},
+		lastUsedError: errors.New("connection reset"),
+	}}
+
+	output := captureOutput(t, func() {
+		if _, err := svc.ValidateAPIKey(validKey); err != nil {
+			t.Errorf("ValidateAPIKey(valid) error = %v", err)
+		}
+		if _, err := svc.ValidateAPIKey(unknownKey); err == nil {
+			t.Errorf("ValidateAPIKey(unknown) error = nil, want invalid API key")
+		}
+	})
+
+	for _, key := range []string{validKey, unknownKey} {
+		if strings.Contains(output, key) {
+			t.Errorf("output contains API key %q:\n%s", key, output)
+		}
+	}
+}
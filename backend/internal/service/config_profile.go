@@ -0,0 +1,427 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ErrNoConfigProfile is returned when neither an assigned nor a default profile applies to a vehicle
+var ErrNoConfigProfile = errors.New("no configuration profile applies to this vehicle")
+
+// Sources of a vehicle's effective configuration
+const (
+	configSourceAssigned = "assigned"
+	configSourceDefault  = "default"
+	configSourceNone     = "none"
+)
+
+// ConfigProfileService defines configuration profile service interface
+type ConfigProfileService interface {
+	Create(userID uint, req *dto.CreateConfigProfileRequest) (*dto.ConfigProfileResponse, error)
+	GetMyProfiles(userID uint) ([]dto.ConfigProfileResponse, error)
+	GetByID(userID, profileID uint) (*dto.ConfigProfileResponse, error)
+	Update(userID, profileID uint, req *dto.UpdateConfigProfileRequest) (*dto.ConfigProfileResponse, error)
+	Delete(userID, profileID uint) error
+	GetVersions(userID, profileID uint) ([]dto.ConfigProfileVersionResponse, error)
+	AssignVehicles(userID, profileID uint, req *dto.AssignConfigProfileRequest) (*dto.ConfigProfileResponse, error)
+	UnassignVehicle(userID, vehicleID uint) error
+	GetVehicleConfig(userID, vehicleID uint) (*dto.VehicleConfigResponse, error)
+	GetForDevice(organizationID, vehicleID uint) (*dto.ESP32ConfigResponse, string, error)
+	RecordApplied(organizationID uint, req *dto.ESP32ConfigAppliedRequest) error
+}
+
+// configProfileService implements ConfigProfileService interface
+type configProfileService struct {
+	profileRepo         repository.ConfigProfileRepository
+	vehicleRepo         repository.VehicleRepository
+	deviceStatusRepo    repository.DeviceStatusRepository
+	organizationService OrganizationService
+}
+
+// NewConfigProfileService creates new configuration profile service instance
+func NewConfigProfileService(profileRepo repository.ConfigProfileRepository, vehicleRepo repository.VehicleRepository, deviceStatusRepo repository.DeviceStatusRepository, organizationService OrganizationService) ConfigProfileService {
+	return &configProfileService{
+		profileRepo:         profileRepo,
+		vehicleRepo:         vehicleRepo,
+		deviceStatusRepo:    deviceStatusRepo,
+		organizationService: organizationService,
+	}
+}
+
+// Create creates a configuration profile at version 1
+func (s *configProfileService) Create(userID uint, req *dto.CreateConfigProfileRequest) (*dto.ConfigProfileResponse, error) {
+	organizationID, err := s.organizationService.ResolveOrganizationID(userID, req.OrganizationID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := json.Marshal(req.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode settings: %w", err)
+	}
+
+	profile := &entity.ConfigProfile{
+		OrganizationID:  organizationID,
+		CreatedByUserID: userID,
+		Name:            req.Name,
+		Version:         1,
+		Settings:        string(settings),
+		IsDefault:       req.IsDefault,
+	}
+	if req.Description != "" {
+		profile.Description = &req.Description
+	}
+
+	if err := s.profileRepo.Create(profile); err != nil {
+		return nil, fmt.Errorf("failed to create configuration profile: %w", err)
+	}
+
+	return s.entityToResponse(profile), nil
+}
+
+// GetMyProfiles gets configuration profiles of the user's organizations
+func (s *configProfileService) GetMyProfiles(userID uint) ([]dto.ConfigProfileResponse, error) {
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := s.profileRepo.GetByOrganizationIDs(organizationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration profiles: %w", err)
+	}
+
+	responses := make([]dto.ConfigProfileResponse, len(profiles))
+	for i, profile := range profiles {
+		responses[i] = *s.entityToResponse(&profile)
+	}
+	return responses, nil
+}
+
+// GetByID gets a configuration profile with the vehicles it is assigned to
+func (s *configProfileService) GetByID(userID, profileID uint) (*dto.ConfigProfileResponse, error) {
+	profile, err := s.authorizeProfile(userID, profileID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+	return s.responseWithAssignments(profile)
+}
+
+// Update updates a configuration profile. Changed settings bump the version, so devices pull them on their next check.
+func (s *configProfileService) Update(userID, profileID uint, req *dto.UpdateConfigProfileRequest) (*dto.ConfigProfileResponse, error) {
+	profile, err := s.authorizeProfile(userID, profileID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		profile.Name = req.Name
+	}
+	if req.Description != nil {
+		profile.Description = req.Description
+		if *req.Description == "" {
+			profile.Description = nil
+		}
+	}
+	if req.IsDefault != nil {
+		profile.IsDefault = *req.IsDefault
+	}
+
+	var version *entity.ConfigProfileVersion
+	if req.Settings != nil {
+		settings, err := json.Marshal(req.Settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode settings: %w", err)
+		}
+		if !sameSettings(profile.Settings, settings) {
+			profile.Version++
+			profile.Settings = string(settings)
+			version = &entity.ConfigProfileVersion{
+				ProfileID:       profile.ID,
+				Version:         profile.Version,
+				Settings:        profile.Settings,
+				CreatedByUserID: userID,
+			}
+		}
+	}
+
+	if err := s.profileRepo.Update(profile, version); err != nil {
+		return nil, fmt.Errorf("failed to update configuration profile: %w", err)
+	}
+
+	return s.responseWithAssignments(profile)
+}
+
+// Delete deletes a configuration profile. Its vehicles fall back to the organization default.
+func (s *configProfileService) Delete(userID, profileID uint) error {
+	profile, err := s.authorizeProfile(userID, profileID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return err
+	}
+
+	if err := s.profileRepo.Delete(profile.ID); err != nil {
+		return fmt.Errorf("failed to delete configuration profile: %w", err)
+	}
+	return nil
+}
+
+// GetVersions gets the version history of a configuration profile
+func (s *configProfileService) GetVersions(userID, profileID uint) ([]dto.ConfigProfileVersionResponse, error) {
+	profile, err := s.authorizeProfile(userID, profileID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.profileRepo.GetVersions(profile.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration profile versions: %w", err)
+	}
+
+	responses := make([]dto.ConfigProfileVersionResponse, len(versions))
+	for i, version := range versions {
+		responses[i] = dto.ConfigProfileVersionResponse{
+			Version:         version.Version,
+			Settings:        decodeSettings(version.Settings),
+			CreatedByUserID: version.CreatedByUserID,
+			CreatedAt:       version.CreatedAt,
+		}
+	}
+	return responses, nil
+}
+
+// AssignVehicles assigns a configuration profile to each listed vehicle of its organization.
+// The profile is stored per vehicle; there is no group that later vehicles join.
+func (s *configProfileService) AssignVehicles(userID, profileID uint, req *dto.AssignConfigProfileRequest) (*dto.ConfigProfileResponse, error) {
+	profile, err := s.authorizeProfile(userID, profileID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicleIDs := make([]uint, 0, len(req.VehicleIDs))
+	seen := make(map[uint]bool, len(req.VehicleIDs))
+	for _, vehicleID := range req.VehicleIDs {
+		if seen[vehicleID] {
+			continue
+		}
+		seen[vehicleID] = true
+
+		vehicle, err := s.vehicleRepo.GetByID(vehicleID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("vehicle %d not found", vehicleID)
+			}
+			return nil, fmt.Errorf("failed to get vehicle: %w", err)
+		}
+		if vehicle.OrganizationID != profile.OrganizationID {
+			return nil, fmt.Errorf("vehicle %d not found", vehicleID)
+		}
+		vehicleIDs = append(vehicleIDs, vehicleID)
+	}
+
+	if err := s.profileRepo.AssignVehicles(profile.ID, vehicleIDs, userID); err != nil {
+		return nil, fmt.Errorf("failed to assign configuration profile: %w", err)
+	}
+
+	return s.responseWithAssignments(profile)
+}
+
+// UnassignVehicle removes the profile assigned to a vehicle, so the organization default applies again
+func (s *configProfileService) UnassignVehicle(userID, vehicleID uint) error {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return err
+	}
+
+	removed, err := s.profileRepo.UnassignVehicle(vehicleID)
+	if err != nil {
+		return fmt.Errorf("failed to unassign configuration profile: %w", err)
+	}
+	if !removed {
+		return errors.New("vehicle has no assigned configuration profile")
+	}
+	return nil
+}
+
+// GetVehicleConfig gets the effective configuration of a vehicle and the version its device applied
+func (s *configProfileService) GetVehicleConfig(userID, vehicleID uint) (*dto.VehicleConfigResponse, error) {
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, source, err := s.effectiveProfile(vehicle)
+	if err != nil && !errors.Is(err, ErrNoConfigProfile) {
+		return nil, err
+	}
+
+	response := &dto.VehicleConfigResponse{VehicleID: vehicle.ID, Source: source}
+	if profile != nil {
+		settings := decodeSettings(profile.Settings)
+		response.ProfileID = &profile.ID
+		response.ProfileName = profile.Name
+		response.Version = &profile.Version
+		response.Settings = &settings
+	}
+
+	status, err := s.deviceStatusRepo.GetByVehicleID(vehicle.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get device status: %w", err)
+	}
+	if status != nil {
+		response.AppliedProfile = status.ConfigProfileID
+		response.AppliedVersion = status.ConfigVersion
+		response.AppliedAt = status.ConfigAppliedAt
+	}
+	response.InSync = profile != nil && response.AppliedProfile != nil && response.AppliedVersion != nil &&
+		*response.AppliedProfile == profile.ID && *response.AppliedVersion == profile.Version
+
+	return response, nil
+}
+
+// GetForDevice gets the effective configuration of a vehicle of the organization and its ETag (ESP32 access)
+func (s *configProfileService) GetForDevice(organizationID, vehicleID uint) (*dto.ESP32ConfigResponse, string, error) {
+	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", errors.New("vehicle not found")
+		}
+		return nil, "", fmt.Errorf("failed to get vehicle: %w", err)
+	}
+	if vehicle.OrganizationID != organizationID {
+		return nil, "", errors.New("vehicle not found")
+	}
+
+	profile, _, err := s.effectiveProfile(vehicle)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &dto.ESP32ConfigResponse{
+		ProfileID: profile.ID,
+		Version:   profile.Version,
+		Settings:  decodeSettings(profile.Settings),
+	}, profile.ETag(), nil
+}
+
+// RecordApplied records that the tracker of a vehicle of the organization applied a profile version (ESP32 access)
+func (s *configProfileService) RecordApplied(organizationID uint, req *dto.ESP32ConfigAppliedRequest) error {
+	vehicle, err := s.vehicleRepo.GetByID(req.VehicleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("vehicle not found")
+		}
+		return fmt.Errorf("failed to get vehicle: %w", err)
+	}
+	if vehicle.OrganizationID != organizationID {
+		return errors.New("vehicle not found")
+	}
+
+	profile, err := s.profileRepo.GetByID(req.ProfileID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("configuration profile not found")
+		}
+		return fmt.Errorf("failed to get configuration profile: %w", err)
+	}
+	if profile.OrganizationID != organizationID {
+		return errors.New("configuration profile not found")
+	}
+	if req.Version > profile.Version {
+		return fmt.Errorf("configuration profile has no version %d", req.Version)
+	}
+
+	if err := s.deviceStatusRepo.RecordConfigApplied(vehicle.ID, profile.ID, req.Version, time.Now()); err != nil {
+		return fmt.Errorf("failed to record applied configuration: %w", err)
+	}
+	return nil
+}
+
+// effectiveProfile resolves the profile that applies to a vehicle: its assigned profile, else the organization default
+func (s *configProfileService) effectiveProfile(vehicle *entity.Vehicle) (*entity.ConfigProfile, string, error) {
+	profile, err := s.profileRepo.GetAssignedToVehicle(vehicle.ID)
+	if err == nil {
+		return profile, configSourceAssigned, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, "", fmt.Errorf("failed to get assigned configuration profile: %w", err)
+	}
+
+	profile, err = s.profileRepo.GetDefault(vehicle.OrganizationID)
+	if err == nil {
+		return profile, configSourceDefault, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, "", fmt.Errorf("failed to get default configuration profile: %w", err)
+	}
+
+	return nil, configSourceNone, ErrNoConfigProfile
+}
+
+// authorizeProfile gets a configuration profile and checks the user may perform the action in its organization
+func (s *configProfileService) authorizeProfile(userID, profileID uint, action entity.OrgAction) (*entity.ConfigProfile, error) {
+	profile, err := s.profileRepo.GetByID(profileID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("configuration profile not found")
+		}
+		return nil, fmt.Errorf("failed to get configuration profile: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, profile.OrganizationID, action); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("configuration profile not found")
+	}
+
+	return profile, nil
+}
+
+// responseWithAssignments converts a profile to response including the vehicles it is assigned to
+func (s *configProfileService) responseWithAssignments(profile *entity.ConfigProfile) (*dto.ConfigProfileResponse, error) {
+	vehicleIDs, err := s.profileRepo.GetAssignedVehicleIDs(profile.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned vehicles: %w", err)
+	}
+
+	response := s.entityToResponse(profile)
+	response.AssignedVehicleIDs = vehicleIDs
+	return response, nil
+}
+
+// entityToResponse converts entity to response DTO
+func (s *configProfileService) entityToResponse(profile *entity.ConfigProfile) *dto.ConfigProfileResponse {
+	return &dto.ConfigProfileResponse{
+		ID:             profile.ID,
+		OrganizationID: profile.OrganizationID,
+		Name:           profile.Name,
+		Description:    profile.Description,
+		Version:        profile.Version,
+		Settings:       decodeSettings(profile.Settings),
+		IsDefault:      profile.IsDefault,
+		CreatedAt:      profile.CreatedAt,
+		UpdatedAt:      profile.UpdatedAt,
+	}
+}
+
+// decodeSettings decodes stored profile settings
+func decodeSettings(value string) dto.DeviceSettings {
+	var settings dto.DeviceSettings
+	if err := json.Unmarshal([]byte(value), &settings); err != nil {
+		log.Printf("Failed to decode configuration profile settings: %v", err)
+	}
+	return settings
+}
+
+// sameSettings reports whether stored settings equal newly encoded ones
+func sameSettings(stored string, encoded []byte) bool {
+	current, err := json.Marshal(decodeSettings(stored))
+	return err == nil && string(current) == string(encoded)
+}
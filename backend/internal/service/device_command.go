@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ErrDeviceCommandNotFound is returned for unknown or inaccessible device commands
+var ErrDeviceCommandNotFound = errors.New("device command not found")
+
+// DeviceCommandService defines device command service interface
+type DeviceCommandService interface {
+	Enqueue(userID, vehicleID uint, req *dto.CreateDeviceCommandRequest) (*dto.DeviceCommandResponse, error)
+	GetByVehicleID(userID, vehicleID uint, status string, limit, offset int) ([]dto.DeviceCommandResponse, int64, error)
+	GetByID(userID, vehicleID, commandID uint) (*dto.DeviceCommandResponse, error)
+	FetchForDevice(organizationID, vehicleID uint) ([]dto.ESP32CommandResponse, error)
+	ReportResult(organizationID, commandID uint, req *dto.ESP32CommandResultRequest) (*dto.DeviceCommandResponse, error)
+	ExpireDue(ctx context.Context) error
+}
+
+// deviceCommandService implements DeviceCommandService interface
+type deviceCommandService struct {
+	cfg                 configs.CommandsConfig
+	commandRepo         repository.DeviceCommandRepository
+	vehicleRepo         repository.VehicleRepository
+	organizationService OrganizationService
+}
+
+// NewDeviceCommandService creates new device command service instance
+func NewDeviceCommandService(cfg configs.CommandsConfig, commandRepo repository.DeviceCommandRepository, vehicleRepo repository.VehicleRepository, organizationService OrganizationService) DeviceCommandService {
+	return &deviceCommandService{
+		cfg:                 cfg,
+		commandRepo:         commandRepo,
+		vehicleRepo:         vehicleRepo,
+		organizationService: organizationService,
+	}
+}
+
+// Enqueue queues a command for the tracker of a vehicle
+func (s *deviceCommandService) Enqueue(userID, vehicleID uint, req *dto.CreateDeviceCommandRequest) (*dto.DeviceCommandResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return nil, err
+	}
+
+	commandType := entity.DeviceCommandType(req.Type)
+	payload, err := validateCommandPayload(commandType, req.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := s.cfg.DefaultTTL
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+	if ttl > s.cfg.MaxTTL {
+		return nil, fmt.Errorf("ttl_seconds must not exceed %.0f", s.cfg.MaxTTL.Seconds())
+	}
+
+	command := &entity.DeviceCommand{
+		VehicleID:      vehicleID,
+		IssuedByUserID: userID,
+		Type:           commandType,
+		Payload:        payload,
+		Status:         entity.DeviceCommandPending,
+		ExpiresAt:      time.Now().Add(ttl),
+	}
+	if err := s.commandRepo.Create(command); err != nil {
+		return nil, fmt.Errorf("failed to create device command: %w", err)
+	}
+
+	return s.entityToResponse(command), nil
+}
+
+// GetByVehicleID gets the command history of a vehicle
+func (s *deviceCommandService) GetByVehicleID(userID, vehicleID uint, status string, limit, offset int) ([]dto.DeviceCommandResponse, int64, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, 0, err
+	}
+
+	commands, total, err := s.commandRepo.GetByVehicleIDWithPagination(vehicleID, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get device commands: %w", err)
+	}
+
+	responses := make([]dto.DeviceCommandResponse, len(commands))
+	for i, command := range commands {
+		responses[i] = *s.entityToResponse(&command)
+	}
+
+	return responses, total, nil
+}
+
+// GetByID gets a command of a vehicle
+func (s *deviceCommandService) GetByID(userID, vehicleID, commandID uint) (*dto.DeviceCommandResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
+	}
+
+	command, err := s.commandRepo.GetByID(commandID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeviceCommandNotFound
+		}
+		return nil, fmt.Errorf("failed to get device command: %w", err)
+	}
+	if command.VehicleID != vehicleID {
+		return nil, ErrDeviceCommandNotFound
+	}
+
+	return s.entityToResponse(command), nil
+}
+
+// FetchForDevice hands the pending commands of a vehicle of the organization to its tracker (ESP32 access).
+// Fetched commands are marked delivered and are not handed out again.
+func (s *deviceCommandService) FetchForDevice(organizationID, vehicleID uint) ([]dto.ESP32CommandResponse, error) {
+	if _, err := s.vehicleForOrganization(organizationID, vehicleID); err != nil {
+		return nil, err
+	}
+
+	commands, err := s.commandRepo.ClaimPending(vehicleID, time.Now(), s.cfg.FetchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch device commands: %w", err)
+	}
+
+	responses := make([]dto.ESP32CommandResponse, len(commands))
+	for i, command := range commands {
+		responses[i] = dto.ESP32CommandResponse{
+			ID:        command.ID,
+			Type:      string(command.Type),
+			Payload:   rawJSON(command.Payload),
+			ExpiresAt: command.ExpiresAt,
+		}
+	}
+
+	return responses, nil
+}
+
+// ReportResult records the outcome of a delivered command reported by the tracker (ESP32 access)
+func (s *deviceCommandService) ReportResult(organizationID, commandID uint, req *dto.ESP32CommandResultRequest) (*dto.DeviceCommandResponse, error) {
+	command, err := s.commandRepo.GetByID(commandID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeviceCommandNotFound
+		}
+		return nil, fmt.Errorf("failed to get device command: %w", err)
+	}
+	if command.Vehicle.OrganizationID != organizationID {
+		return nil, ErrDeviceCommandNotFound
+	}
+
+	var result *string
+	if len(req.Result) > 0 && string(req.Result) != "null" {
+		if !json.Valid(req.Result) {
+			return nil, errors.New("result must be valid JSON")
+		}
+		value := string(req.Result)
+		result = &value
+	}
+	var errorMessage *string
+	if req.Error != "" {
+		errorMessage = &req.Error
+	}
+
+	completed, err := s.commandRepo.Complete(command.ID, entity.DeviceCommandStatus(req.Status), result, errorMessage, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to record device command result: %w", err)
+	}
+	if !completed {
+		return nil, fmt.Errorf("device command is %s and cannot accept a result", command.Status)
+	}
+
+	if command, err = s.commandRepo.GetByID(command.ID); err != nil {
+		return nil, fmt.Errorf("failed to get device command: %w", err)
+	}
+	return s.entityToResponse(command), nil
+}
+
+// ExpireDue expires commands that were not completed in time
+func (s *deviceCommandService) ExpireDue(ctx context.Context) error {
+	if _, err := s.commandRepo.ExpireDue(time.Now()); err != nil {
+		return fmt.Errorf("failed to expire device commands: %w", err)
+	}
+	return nil
+}
+
+// vehicleForOrganization gets a vehicle of the organization
+func (s *deviceCommandService) vehicleForOrganization(organizationID, vehicleID uint) (*entity.Vehicle, error) {
+	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("vehicle not found")
+		}
+		return nil, fmt.Errorf("failed to get vehicle: %w", err)
+	}
+	if vehicle.OrganizationID != organizationID {
+		return nil, errors.New("vehicle not found")
+	}
+	return vehicle, nil
+}
+
+// entityToResponse converts device command entity to response DTO
+func (s *deviceCommandService) entityToResponse(command *entity.DeviceCommand) *dto.DeviceCommandResponse {
+	return &dto.DeviceCommandResponse{
+		ID:             command.ID,
+		VehicleID:      command.VehicleID,
+		IssuedByUserID: command.IssuedByUserID,
+		IssuedByName:   command.IssuedByUser.Name,
+		Type:           string(command.Type),
+		Payload:        rawJSON(command.Payload),
+		Status:         string(command.Status),
+		ExpiresAt:      command.ExpiresAt,
+		DeliveredAt:    command.DeliveredAt,
+		CompletedAt:    command.CompletedAt,
+		Result:         rawJSON(command.Result),
+		Error:          command.Error,
+		CreatedAt:      command.CreatedAt,
+	}
+}
+
+// validateCommandPayload checks the payload a command type takes and returns it for storage
+func validateCommandPayload(commandType entity.DeviceCommandType, payload json.RawMessage) (*string, error) {
+	if len(payload) == 0 || string(payload) == "null" {
+		if commandType == entity.DeviceCommandSetReportInterval {
+			return nil, errors.New("payload.interval_seconds is required for set_report_interval")
+		}
+		return nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, errors.New("payload must be a JSON object")
+	}
+
+	if commandType == entity.DeviceCommandSetReportInterval {
+		var interval struct {
+			IntervalSeconds int `json:"interval_seconds"`
+		}
+		if err := json.Unmarshal(payload, &interval); err != nil || interval.IntervalSeconds < 5 || interval.IntervalSeconds > 86400 {
+			return nil, errors.New("payload.interval_seconds must be a whole number between 5 and 86400")
+		}
+	}
+
+	value := string(payload)
+	return &value, nil
+}
+
+// rawJSON returns stored JSON as a raw message, or nil when nothing is stored
+func rawJSON(value *string) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	return json.RawMessage(*value)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DeviceStatusService defines device status service interface
+type DeviceStatusService interface {
+	RecordContact(vehicleID uint, ip, firmwareVersion *string, signalStrength *int) error
+	RecordLocation(vehicleID uint, at time.Time, moving bool) error
+	GetFleetStatus(userID uint, state string) (*dto.FleetStatusResponse, error)
+	GetVehicleStatus(userID, vehicleID uint) (*dto.VehicleStatusResponse, error)
+}
+
+// deviceStatusService implements DeviceStatusService interface
+type deviceStatusService struct {
+	cfg                 configs.DevicesConfig
+	deviceStatusRepo    repository.DeviceStatusRepository
+	vehicleRepo         repository.VehicleRepository
+	organizationService OrganizationService
+}
+
+// NewDeviceStatusService creates new device status service instance
+func NewDeviceStatusService(cfg configs.DevicesConfig, deviceStatusRepo repository.DeviceStatusRepository, vehicleRepo repository.VehicleRepository, organizationService OrganizationService) DeviceStatusService {
+	return &deviceStatusService{
+		cfg:                 cfg,
+		deviceStatusRepo:    deviceStatusRepo,
+		vehicleRepo:         vehicleRepo,
+		organizationService: organizationService,
+	}
+}
+
+// RecordContact records an authenticated request from the vehicle's device
+func (s *deviceStatusService) RecordContact(vehicleID uint, ip, firmwareVersion *string, signalStrength *int) error {
+	if err := s.deviceStatusRepo.RecordContact(vehicleID, time.Now(), ip, firmwareVersion, signalStrength); err != nil {
+		return fmt.Errorf("failed to record device contact: %w", err)
+	}
+	return nil
+}
+
+// RecordLocation records a location reported for the vehicle
+func (s *deviceStatusService) RecordLocation(vehicleID uint, at time.Time, moving bool) error {
+	if err := s.deviceStatusRepo.RecordLocation(vehicleID, at, moving); err != nil {
+		return fmt.Errorf("failed to record device location: %w", err)
+	}
+	return nil
+}
+
+// GetFleetStatus gets the device status of every vehicle of the user's organizations,
+// optionally narrowed to a single state
+func (s *deviceStatusService) GetFleetStatus(userID uint, state string) (*dto.FleetStatusResponse, error) {
+	switch entity.DeviceState(state) {
+	case "", entity.DeviceStateOnline, entity.DeviceStateIdle, entity.DeviceStateOffline:
+	default:
+		return nil, errors.New("invalid status, must be one of online, idle, offline")
+	}
+
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles, err := s.vehicleRepo.GetByOrganizationIDs(organizationIDs, -1, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicles: %w", err)
+	}
+
+	vehicleIDs := make([]uint, len(vehicles))
+	for i, vehicle := range vehicles {
+		vehicleIDs[i] = vehicle.ID
+	}
+	statuses, err := s.deviceStatusRepo.GetByVehicleIDs(vehicleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device statuses: %w", err)
+	}
+	byVehicle := make(map[uint]*entity.DeviceStatus, len(statuses))
+	for i := range statuses {
+		byVehicle[statuses[i].VehicleID] = &statuses[i]
+	}
+
+	now := time.Now()
+	fleet := &dto.FleetStatusResponse{Vehicles: make([]dto.VehicleStatusResponse, 0, len(vehicles))}
+	for i := range vehicles {
+		response := s.toResponse(&vehicles[i], byVehicle[vehicles[i].ID], now)
+		switch entity.DeviceState(response.Status) {
+		case entity.DeviceStateOnline:
+			fleet.Online++
+		case entity.DeviceStateIdle:
+			fleet.Idle++
+		default:
+			fleet.Offline++
+		}
+		if state == "" || response.Status == state {
+			fleet.Vehicles = append(fleet.Vehicles, *response)
+		}
+	}
+
+	return fleet, nil
+}
+
+// GetVehicleStatus gets the device status of a vehicle
+func (s *deviceStatusService) GetVehicleStatus(userID, vehicleID uint) (*dto.VehicleStatusResponse, error) {
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.deviceStatusRepo.GetByVehicleID(vehicleID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get device status: %w", err)
+		}
+		status = nil
+	}
+
+	return s.toResponse(vehicle, status, time.Now()), nil
+}
+
+// toResponse converts a vehicle and its device status to response. A vehicle whose device never reported is offline.
+func (s *deviceStatusService) toResponse(vehicle *entity.Vehicle, status *entity.DeviceStatus, now time.Time) *dto.VehicleStatusResponse {
+	response := &dto.VehicleStatusResponse{
+		VehicleID:   vehicle.ID,
+		PlateNumber: vehicle.PlateNumber,
+		Status:      string(entity.DeviceStateOffline),
+	}
+	if status == nil {
+		return response
+	}
+
+	lastSeenAt := status.LastSeenAt
+	response.Status = string(status.State(now, s.cfg.IdleAfter, s.cfg.OfflineAfter))
+	response.LastSeenAt = &lastSeenAt
+	response.LastIP = status.LastIP
+	response.FirmwareVersion = status.FirmwareVersion
+	response.SignalStrength = status.SignalStrength
+	response.LastLocationAt = status.LastLocationAt
+	response.LastMovingAt = status.LastMovingAt
+	response.ConfigProfileID = status.ConfigProfileID
+	response.ConfigVersion = status.ConfigVersion
+	return response
+}
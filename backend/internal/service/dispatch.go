@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/geo"
+)
+
+// DispatchService defines dispatch service interface
+type DispatchService interface {
+	GetNearestVehicles(userID uint, req *dto.NearestVehiclesRequest) ([]dto.DispatchVehicleResponse, error)
+}
+
+// dispatchService implements DispatchService interface
+type dispatchService struct {
+	cfg              configs.DevicesConfig
+	vehicleRepo      repository.VehicleRepository
+	fuelLogRepo      repository.FuelLogRepository
+	deviceStatusRepo repository.DeviceStatusRepository
+}
+
+// NewDispatchService creates new dispatch service instance
+func NewDispatchService(cfg configs.DevicesConfig, vehicleRepo repository.VehicleRepository, fuelLogRepo repository.FuelLogRepository, deviceStatusRepo repository.DeviceStatusRepository) DispatchService {
+	return &dispatchService{
+		cfg:              cfg,
+		vehicleRepo:      vehicleRepo,
+		fuelLogRepo:      fuelLogRepo,
+		deviceStatusRepo: deviceStatusRepo,
+	}
+}
+
+// GetNearestVehicles ranks the vehicles of the user's organizations by the distance of their latest trusted
+// position from a coordinate, with the age of that position, the device status and the latest fuel level.
+// Vehicles that never reported a position are left out.
+func (s *dispatchService) GetNearestVehicles(userID uint, req *dto.NearestVehiclesRequest) ([]dto.DispatchVehicleResponse, error) {
+	vehicles, err := s.vehicleRepo.GetAllWithLatestLocation(userID, strings.TrimSpace(req.Model), -1, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicles: %w", err)
+	}
+
+	vehicleIDs := make([]uint, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		if len(vehicle.LocationLogs) > 0 {
+			vehicleIDs = append(vehicleIDs, vehicle.ID)
+		}
+	}
+
+	fuelLogs, err := s.fuelLogRepo.GetLatestByVehicleIDs(vehicleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest fuel levels: %w", err)
+	}
+	fuelByVehicle := make(map[uint]*entity.FuelLog, len(fuelLogs))
+	for i := range fuelLogs {
+		fuelByVehicle[fuelLogs[i].VehicleID] = &fuelLogs[i]
+	}
+
+	statuses, err := s.deviceStatusRepo.GetByVehicleIDs(vehicleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device statuses: %w", err)
+	}
+	statusByVehicle := make(map[uint]*entity.DeviceStatus, len(statuses))
+	for i := range statuses {
+		statusByVehicle[statuses[i].VehicleID] = &statuses[i]
+	}
+
+	now := time.Now()
+	maxAge := time.Duration(req.MaxAgeSeconds) * time.Second
+	responses := make([]dto.DispatchVehicleResponse, 0, len(vehicleIDs))
+	for _, vehicle := range vehicles {
+		if len(vehicle.LocationLogs) == 0 {
+			continue
+		}
+		location := vehicle.LocationLogs[0]
+
+		age := now.Sub(location.Timestamp)
+		if maxAge > 0 && age > maxAge {
+			continue
+		}
+		fuel := fuelByVehicle[vehicle.ID]
+		if req.MinFuelLevel != nil && (fuel == nil || fuel.FuelLevel < *req.MinFuelLevel) {
+			continue
+		}
+
+		response := dto.DispatchVehicleResponse{
+			VehicleID:          vehicle.ID,
+			PlateNumber:        vehicle.PlateNumber,
+			Model:              vehicle.Model,
+			DistanceMeters:     geo.DistanceMeters(*req.Latitude, *req.Longitude, location.Latitude, location.Longitude),
+			Latitude:           location.Latitude,
+			Longitude:          location.Longitude,
+			Speed:              location.Speed,
+			Ignition:           location.Ignition,
+			PositionAt:         location.Timestamp,
+			PositionAgeSeconds: max(0, age.Seconds()),
+			Status:             string(entity.DeviceStateOffline),
+		}
+		// A vehicle whose device never reported a status is offline
+		if status := statusByVehicle[vehicle.ID]; status != nil {
+			lastSeenAt := status.LastSeenAt
+			response.Status = string(status.State(now, s.cfg.IdleAfter, s.cfg.OfflineAfter))
+			response.LastSeenAt = &lastSeenAt
+		}
+		if fuel != nil {
+			fuelLevel, fuelAt := fuel.FuelLevel, fuel.Timestamp
+			response.FuelLevel, response.FuelAt = &fuelLevel, &fuelAt
+		}
+		responses = append(responses, response)
+	}
+
+	// Nearest first; of equally distant vehicles the one with the fresher position
+	sort.SliceStable(responses, func(i, j int) bool {
+		if responses[i].DistanceMeters != responses[j].DistanceMeters {
+			return responses[i].DistanceMeters < responses[j].DistanceMeters
+		}
+		return responses[i].PositionAt.After(responses[j].PositionAt)
+	})
+	if len(responses) > req.Limit {
+		responses = responses[:req.Limit]
+	}
+	for i := range responses {
+		responses[i].Rank = i + 1
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,450 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DriverService defines driver service interface
+type DriverService interface {
+	Create(userID uint, req *dto.CreateDriverRequest) (*dto.DriverResponse, error)
+	GetMyDrivers(userID uint, limit, offset int) ([]dto.DriverResponse, int64, error)
+	GetByID(userID, driverID uint) (*dto.DriverResponse, error)
+	Update(userID, driverID uint, req *dto.UpdateDriverRequest) (*dto.DriverResponse, error)
+	Delete(userID, driverID uint) error
+	Assign(userID, driverID uint, req *dto.AssignDriverRequest) (*dto.DriverAssignmentResponse, error)
+	Unassign(userID, vehicleID uint) error
+	GetCurrentDriver(userID, vehicleID uint) (*dto.DriverAssignmentResponse, error)
+	GetAssignmentsByVehicleID(userID, vehicleID uint, limit, offset int) ([]dto.DriverAssignmentResponse, error)
+	GetAssignmentsByDriverID(userID, driverID uint, limit, offset int) ([]dto.DriverAssignmentResponse, error)
+	AssignFromDevice(organizationID, vehicleID uint, tag string, at time.Time) error
+	AttributeLocationLogs(logs []dto.LocationLogResponse) error
+}
+
+// driverService implements DriverService interface
+type driverService struct {
+	driverRepo          repository.DriverRepository
+	assignmentRepo      repository.DriverAssignmentRepository
+	vehicleRepo         repository.VehicleRepository
+	organizationService OrganizationService
+}
+
+// NewDriverService creates new driver service instance
+func NewDriverService(driverRepo repository.DriverRepository, assignmentRepo repository.DriverAssignmentRepository, vehicleRepo repository.VehicleRepository, organizationService OrganizationService) DriverService {
+	return &driverService{
+		driverRepo:          driverRepo,
+		assignmentRepo:      assignmentRepo,
+		vehicleRepo:         vehicleRepo,
+		organizationService: organizationService,
+	}
+}
+
+// Create creates a new driver in one of the user's organizations
+func (s *driverService) Create(userID uint, req *dto.CreateDriverRequest) (*dto.DriverResponse, error) {
+	organizationID, err := s.organizationService.ResolveOrganizationID(userID, req.OrganizationID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	driver := &entity.Driver{
+		OrganizationID: organizationID,
+		Name:           req.Name,
+		LicenseNumber:  req.LicenseNumber,
+	}
+
+	if req.LicenseExpiryDate != "" {
+		expiry, err := time.Parse("2006-01-02", req.LicenseExpiryDate)
+		if err != nil {
+			return nil, errors.New("invalid license expiry date format, use YYYY-MM-DD")
+		}
+		driver.LicenseExpiryDate = &expiry
+	}
+	if req.PhoneNumber != "" {
+		driver.PhoneNumber = &req.PhoneNumber
+	}
+	if tag := strings.TrimSpace(req.IdentificationTag); tag != "" {
+		if err := s.ensureTagAvailable(organizationID, tag, 0); err != nil {
+			return nil, err
+		}
+		driver.IdentificationTag = &tag
+	}
+
+	if err := s.driverRepo.Create(driver); err != nil {
+		return nil, fmt.Errorf("failed to create driver: %w", err)
+	}
+
+	return s.entityToResponse(driver), nil
+}
+
+// GetMyDrivers gets drivers of every organization the user belongs to with pagination info
+func (s *driverService) GetMyDrivers(userID uint, limit, offset int) ([]dto.DriverResponse, int64, error) {
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	drivers, total, err := s.driverRepo.GetByOrganizationIDs(organizationIDs, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get drivers: %w", err)
+	}
+
+	responses := make([]dto.DriverResponse, len(drivers))
+	for i, driver := range drivers {
+		responses[i] = *s.entityToResponse(&driver)
+	}
+
+	return responses, total, nil
+}
+
+// GetByID gets driver by ID
+func (s *driverService) GetByID(userID, driverID uint) (*dto.DriverResponse, error) {
+	driver, err := s.authorizeDriver(userID, driverID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.entityToResponse(driver), nil
+}
+
+// Update updates driver data
+func (s *driverService) Update(userID, driverID uint, req *dto.UpdateDriverRequest) (*dto.DriverResponse, error) {
+	driver, err := s.authorizeDriver(userID, driverID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		driver.Name = req.Name
+	}
+	if req.LicenseNumber != "" {
+		driver.LicenseNumber = req.LicenseNumber
+	}
+	if req.LicenseExpiryDate != "" {
+		expiry, err := time.Parse("2006-01-02", req.LicenseExpiryDate)
+		if err != nil {
+			return nil, errors.New("invalid license expiry date format, use YYYY-MM-DD")
+		}
+		driver.LicenseExpiryDate = &expiry
+	}
+	if req.PhoneNumber != nil {
+		driver.PhoneNumber = nil
+		if *req.PhoneNumber != "" {
+			driver.PhoneNumber = req.PhoneNumber
+		}
+	}
+	if req.IdentificationTag != nil {
+		// An empty tag unlinks the driver's card
+		driver.IdentificationTag = nil
+		if tag := strings.TrimSpace(*req.IdentificationTag); tag != "" {
+			if err := s.ensureTagAvailable(driver.OrganizationID, tag, driver.ID); err != nil {
+				return nil, err
+			}
+			driver.IdentificationTag = &tag
+		}
+	}
+
+	if err := s.driverRepo.Update(driver); err != nil {
+		return nil, fmt.Errorf("failed to update driver: %w", err)
+	}
+
+	return s.entityToResponse(driver), nil
+}
+
+// Delete deletes a driver and ends their current assignment
+func (s *driverService) Delete(userID, driverID uint) error {
+	driver, err := s.authorizeDriver(userID, driverID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return err
+	}
+
+	active, err := s.assignmentRepo.GetActiveByDriverID(driver.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get current assignment: %w", err)
+	}
+	if active != nil {
+		if err := s.assignmentRepo.End(active.ID, time.Now()); err != nil {
+			return fmt.Errorf("failed to end assignment: %w", err)
+		}
+	}
+
+	if err := s.driverRepo.Delete(driver.ID); err != nil {
+		return fmt.Errorf("failed to delete driver: %w", err)
+	}
+
+	return nil
+}
+
+// Assign puts a driver on duty in a vehicle, ending any overlapping assignment of either
+func (s *driverService) Assign(userID, driverID uint, req *dto.AssignDriverRequest) (*dto.DriverAssignmentResponse, error) {
+	driver, err := s.authorizeDriver(userID, driverID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, req.VehicleID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if vehicle.OrganizationID != driver.OrganizationID {
+		return nil, errors.New("driver and vehicle must belong to the same organization")
+	}
+
+	startAt := time.Now()
+	if req.StartAt != nil {
+		if req.StartAt.After(startAt) {
+			return nil, errors.New("start time cannot be in the future")
+		}
+		startAt = *req.StartAt
+	}
+
+	assignment := &entity.DriverAssignment{
+		DriverID:  driver.ID,
+		VehicleID: vehicle.ID,
+		StartAt:   startAt,
+		Source:    entity.AssignmentSourceManual,
+	}
+
+	if err := s.assignmentRepo.Reassign(assignment); err != nil {
+		return nil, fmt.Errorf("failed to assign driver: %w", err)
+	}
+	assignment.Driver = *driver
+
+	return s.assignmentToResponse(assignment), nil
+}
+
+// Unassign ends the current assignment of a vehicle
+func (s *driverService) Unassign(userID, vehicleID uint) error {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return err
+	}
+
+	active, err := s.assignmentRepo.GetActiveByVehicleID(vehicleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("no driver is assigned to this vehicle")
+		}
+		return fmt.Errorf("failed to get current assignment: %w", err)
+	}
+
+	if err := s.assignmentRepo.End(active.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to end assignment: %w", err)
+	}
+
+	return nil
+}
+
+// GetCurrentDriver gets the open assignment of a vehicle
+func (s *driverService) GetCurrentDriver(userID, vehicleID uint) (*dto.DriverAssignmentResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
+	}
+
+	active, err := s.assignmentRepo.GetActiveByVehicleID(vehicleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no driver is assigned to this vehicle")
+		}
+		return nil, fmt.Errorf("failed to get current assignment: %w", err)
+	}
+
+	return s.assignmentToResponse(active), nil
+}
+
+// GetAssignmentsByVehicleID gets assignment history of a vehicle
+func (s *driverService) GetAssignmentsByVehicleID(userID, vehicleID uint, limit, offset int) ([]dto.DriverAssignmentResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.assignmentRepo.GetByVehicleID(vehicleID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+
+	responses := make([]dto.DriverAssignmentResponse, len(assignments))
+	for i, assignment := range assignments {
+		responses[i] = *s.assignmentToResponse(&assignment)
+	}
+
+	return responses, nil
+}
+
+// GetAssignmentsByDriverID gets assignment history of a driver
+func (s *driverService) GetAssignmentsByDriverID(userID, driverID uint, limit, offset int) ([]dto.DriverAssignmentResponse, error) {
+	if _, err := s.authorizeDriver(userID, driverID, entity.OrgActionRead); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.assignmentRepo.GetByDriverID(driverID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+
+	responses := make([]dto.DriverAssignmentResponse, len(assignments))
+	for i, assignment := range assignments {
+		responses[i] = *s.assignmentToResponse(&assignment)
+	}
+
+	return responses, nil
+}
+
+// AssignFromDevice switches the vehicle's driver to the one carrying the tag read by the device.
+// Unknown tags are logged and ignored so telemetry is never rejected because of them.
+func (s *driverService) AssignFromDevice(organizationID, vehicleID uint, tag string, at time.Time) error {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil
+	}
+
+	driver, err := s.driverRepo.GetByIdentificationTag(organizationID, tag)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Unknown driver tag %q reported by vehicle %d", tag, vehicleID)
+			return nil
+		}
+		return fmt.Errorf("failed to get driver: %w", err)
+	}
+
+	active, err := s.assignmentRepo.GetActiveByVehicleID(vehicleID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get current assignment: %w", err)
+	}
+	if active != nil && active.DriverID == driver.ID {
+		return nil
+	}
+
+	assignment := &entity.DriverAssignment{
+		DriverID:  driver.ID,
+		VehicleID: vehicleID,
+		StartAt:   at,
+		Source:    entity.AssignmentSourceDevice,
+	}
+
+	if err := s.assignmentRepo.Reassign(assignment); err != nil {
+		return fmt.Errorf("failed to assign driver: %w", err)
+	}
+
+	return nil
+}
+
+// AttributeLocationLogs sets the driver who was on duty when each location log was recorded
+func (s *driverService) AttributeLocationLogs(logs []dto.LocationLogResponse) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	start, end := logs[0].Timestamp, logs[0].Timestamp
+	vehicleSet := make(map[uint]struct{})
+	for _, l := range logs {
+		if l.Timestamp.Before(start) {
+			start = l.Timestamp
+		}
+		if l.Timestamp.After(end) {
+			end = l.Timestamp
+		}
+		vehicleSet[l.VehicleID] = struct{}{}
+	}
+
+	vehicleIDs := make([]uint, 0, len(vehicleSet))
+	for id := range vehicleSet {
+		vehicleIDs = append(vehicleIDs, id)
+	}
+
+	assignments, err := s.assignmentRepo.GetOverlapping(vehicleIDs, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to get assignments: %w", err)
+	}
+
+	for i := range logs {
+		for _, assignment := range assignments {
+			if assignment.VehicleID == logs[i].VehicleID && assignment.Covers(logs[i].Timestamp) {
+				logs[i].Driver = &dto.DriverSummaryResponse{
+					ID:   assignment.Driver.ID,
+					Name: assignment.Driver.Name,
+				}
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// authorizeDriver loads a driver and checks the user's role in the driver's organization allows the action.
+// Drivers outside the user's organizations are reported as not found.
+func (s *driverService) authorizeDriver(userID, driverID uint, action entity.OrgAction) (*entity.Driver, error) {
+	driver, err := s.driverRepo.GetByID(driverID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("driver not found")
+		}
+		return nil, fmt.Errorf("failed to get driver: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, driver.OrganizationID, action); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("driver not found")
+	}
+
+	return driver, nil
+}
+
+// ensureTagAvailable checks no other driver of the organization carries the tag
+func (s *driverService) ensureTagAvailable(organizationID uint, tag string, driverID uint) error {
+	existing, err := s.driverRepo.GetByIdentificationTag(organizationID, tag)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to check identification tag: %w", err)
+	}
+	if existing != nil && existing.ID != driverID {
+		return errors.New("identification tag is already assigned to another driver")
+	}
+	return nil
+}
+
+// entityToResponse converts entity to response DTO
+func (s *driverService) entityToResponse(driver *entity.Driver) *dto.DriverResponse {
+	return &dto.DriverResponse{
+		ID:                driver.ID,
+		OrganizationID:    driver.OrganizationID,
+		Name:              driver.Name,
+		LicenseNumber:     driver.LicenseNumber,
+		LicenseExpiryDate: driver.LicenseExpiryDate,
+		LicenseExpired:    driver.IsLicenseExpired(time.Now()),
+		PhoneNumber:       driver.PhoneNumber,
+		IdentificationTag: driver.IdentificationTag,
+		CreatedAt:         driver.CreatedAt,
+		UpdatedAt:         driver.UpdatedAt,
+	}
+}
+
+// assignmentToResponse converts assignment entity to response DTO
+func (s *driverService) assignmentToResponse(assignment *entity.DriverAssignment) *dto.DriverAssignmentResponse {
+	response := &dto.DriverAssignmentResponse{
+		ID:        assignment.ID,
+		DriverID:  assignment.DriverID,
+		VehicleID: assignment.VehicleID,
+		StartAt:   assignment.StartAt,
+		EndAt:     assignment.EndAt,
+		Source:    string(assignment.Source),
+	}
+
+	if assignment.Driver.ID != 0 {
+		response.Driver = &dto.DriverSummaryResponse{
+			ID:   assignment.Driver.ID,
+			Name: assignment.Driver.Name,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,603 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/blobstore"
+	"github.com/cartrack/backend/pkg/signedlink"
+	"gorm.io/gorm"
+)
+
+const firmwareLinkPurpose = "firmware_download"
+
+// FirmwareFile is a firmware binary opened for download. The caller must close Content.
+type FirmwareFile struct {
+	Name    string
+	Size    int64
+	SHA256  string
+	Content io.ReadCloser
+}
+
+// FirmwareService defines firmware service interface
+type FirmwareService interface {
+	CreateRelease(userID uint, req *dto.CreateFirmwareReleaseRequest, size int64, content io.Reader) (*dto.FirmwareReleaseResponse, error)
+	GetReleases(userID uint, board string) ([]dto.FirmwareReleaseResponse, error)
+	GetRelease(userID, releaseID uint) (*dto.FirmwareReleaseResponse, error)
+	DeleteRelease(userID, releaseID uint) error
+	GetInstalls(userID, releaseID uint, status string, limit, offset int) ([]dto.FirmwareInstallResponse, int64, error)
+	CreateRollout(userID uint, req *dto.CreateFirmwareRolloutRequest) (*dto.FirmwareRolloutResponse, error)
+	GetRollouts(userID uint) ([]dto.FirmwareRolloutResponse, error)
+	GetRollout(userID, rolloutID uint) (*dto.FirmwareRolloutResponse, error)
+	UpdateRollout(userID, rolloutID uint, req *dto.UpdateFirmwareRolloutRequest) (*dto.FirmwareRolloutResponse, error)
+	DeleteRollout(userID, rolloutID uint) error
+	CheckForDevice(organizationID, vehicleID uint, board, currentVersion string) (*dto.ESP32FirmwareCheckResponse, error)
+	OpenDownload(token string) (*FirmwareFile, error)
+	ReportInstall(organizationID uint, req *dto.ESP32FirmwareReportRequest) (*dto.FirmwareInstallResponse, error)
+}
+
+// firmwareService implements FirmwareService interface
+type firmwareService struct {
+	cfg                 configs.FirmwareConfig
+	releaseRepo         repository.FirmwareReleaseRepository
+	rolloutRepo         repository.FirmwareRolloutRepository
+	installRepo         repository.FirmwareInstallRepository
+	vehicleRepo         repository.VehicleRepository
+	organizationService OrganizationService
+	blobs               blobstore.Store
+	signer              *signedlink.Signer
+}
+
+// NewFirmwareService creates new firmware service instance
+func NewFirmwareService(cfg *configs.Config, releaseRepo repository.FirmwareReleaseRepository, rolloutRepo repository.FirmwareRolloutRepository, installRepo repository.FirmwareInstallRepository, vehicleRepo repository.VehicleRepository, organizationService OrganizationService, blobs blobstore.Store) FirmwareService {
+	return &firmwareService{
+		cfg:                 cfg.Firmware,
+		releaseRepo:         releaseRepo,
+		rolloutRepo:         rolloutRepo,
+		installRepo:         installRepo,
+		vehicleRepo:         vehicleRepo,
+		organizationService: organizationService,
+		blobs:               blobs,
+		signer:              signedlink.NewSigner(cfg.JWT.SecretKey, firmwareLinkPurpose),
+	}
+}
+
+// CreateRelease stores an uploaded firmware binary after checking it against the declared SHA-256
+func (s *firmwareService) CreateRelease(userID uint, req *dto.CreateFirmwareReleaseRequest, size int64, content io.Reader) (*dto.FirmwareReleaseResponse, error) {
+	organizationID, err := s.organizationService.ResolveOrganizationID(userID, req.OrganizationID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cfg.MaxFileSize > 0 && size > s.cfg.MaxFileSize {
+		return nil, fmt.Errorf("file is larger than the %d byte limit", s.cfg.MaxFileSize)
+	}
+
+	exists, err := s.releaseRepo.ExistsVersion(organizationID, req.Board, req.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check firmware version: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("version %s already exists for board %s", req.Version, req.Board)
+	}
+
+	key, err := newFirmwareFileKey(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	written, err := s.blobs.Put(key, io.TeeReader(content, hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if checksum != strings.ToLower(req.SHA256) {
+		s.discardFile(key)
+		return nil, errors.New("SHA-256 of the uploaded file does not match the sha256 field")
+	}
+
+	release := &entity.FirmwareRelease{
+		OrganizationID:   organizationID,
+		UploadedByUserID: userID,
+		Version:          req.Version,
+		Board:            req.Board,
+		FileKey:          key,
+		FileSize:         written,
+		SHA256:           checksum,
+	}
+	if req.Notes != "" {
+		release.Notes = &req.Notes
+	}
+
+	if err := s.releaseRepo.Create(release); err != nil {
+		s.discardFile(key)
+		return nil, fmt.Errorf("failed to create firmware release: %w", err)
+	}
+
+	return s.releaseToResponse(release), nil
+}
+
+// GetReleases gets firmware releases of the user's organizations
+func (s *firmwareService) GetReleases(userID uint, board string) ([]dto.FirmwareReleaseResponse, error) {
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := s.releaseRepo.GetByOrganizationIDs(organizationIDs, board)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firmware releases: %w", err)
+	}
+
+	responses := make([]dto.FirmwareReleaseResponse, len(releases))
+	for i, release := range releases {
+		responses[i] = *s.releaseToResponse(&release)
+	}
+	return responses, nil
+}
+
+// GetRelease gets a firmware release by ID
+func (s *firmwareService) GetRelease(userID, releaseID uint) (*dto.FirmwareReleaseResponse, error) {
+	release, err := s.authorizeRelease(userID, releaseID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+	return s.releaseToResponse(release), nil
+}
+
+// DeleteRelease deletes a firmware release, its rollouts and its binary. Releases still rolling out cannot be deleted.
+func (s *firmwareService) DeleteRelease(userID, releaseID uint) error {
+	release, err := s.authorizeRelease(userID, releaseID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return err
+	}
+
+	active, err := s.rolloutRepo.CountEnabledByReleaseID(release.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check firmware rollouts: %w", err)
+	}
+	if active > 0 {
+		return errors.New("release has enabled rollouts, disable or delete them first")
+	}
+
+	if err := s.rolloutRepo.DeleteByReleaseID(release.ID); err != nil {
+		return fmt.Errorf("failed to delete firmware rollouts: %w", err)
+	}
+	if err := s.releaseRepo.Delete(release.ID); err != nil {
+		return fmt.Errorf("failed to delete firmware release: %w", err)
+	}
+	s.discardFile(release.FileKey)
+
+	return nil
+}
+
+// GetInstalls gets install reports of a firmware release
+func (s *firmwareService) GetInstalls(userID, releaseID uint, status string, limit, offset int) ([]dto.FirmwareInstallResponse, int64, error) {
+	release, err := s.authorizeRelease(userID, releaseID, entity.OrgActionRead)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	installs, total, err := s.installRepo.GetByReleaseIDWithPagination(release.ID, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get firmware installs: %w", err)
+	}
+
+	responses := make([]dto.FirmwareInstallResponse, len(installs))
+	for i, install := range installs {
+		install.Release = *release
+		responses[i] = *s.installToResponse(&install)
+	}
+	return responses, total, nil
+}
+
+// CreateRollout offers a release to a list of vehicles or a percentage of the organization's fleet
+func (s *firmwareService) CreateRollout(userID uint, req *dto.CreateFirmwareRolloutRequest) (*dto.FirmwareRolloutResponse, error) {
+	release, err := s.authorizeRelease(userID, req.ReleaseID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	rollout := &entity.FirmwareRollout{
+		OrganizationID:  release.OrganizationID,
+		ReleaseID:       release.ID,
+		CreatedByUserID: userID,
+		Name:            req.Name,
+		Enabled:         true,
+		Release:         *release,
+	}
+	if req.Enabled != nil {
+		rollout.Enabled = *req.Enabled
+	}
+	if err := s.applyTargets(rollout, req.VehicleIDs, req.Percentage); err != nil {
+		return nil, err
+	}
+
+	if err := s.rolloutRepo.Create(rollout); err != nil {
+		return nil, fmt.Errorf("failed to create firmware rollout: %w", err)
+	}
+
+	return s.rolloutToResponse(rollout), nil
+}
+
+// GetRollouts gets firmware rollouts of the user's organizations
+func (s *firmwareService) GetRollouts(userID uint) ([]dto.FirmwareRolloutResponse, error) {
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rollouts, err := s.rolloutRepo.GetByOrganizationIDs(organizationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firmware rollouts: %w", err)
+	}
+
+	responses := make([]dto.FirmwareRolloutResponse, len(rollouts))
+	for i, rollout := range rollouts {
+		responses[i] = *s.rolloutToResponse(&rollout)
+	}
+	return responses, nil
+}
+
+// GetRollout gets a firmware rollout with the install progress of the vehicles it targets
+func (s *firmwareService) GetRollout(userID, rolloutID uint) (*dto.FirmwareRolloutResponse, error) {
+	rollout, err := s.authorizeRollout(userID, rolloutID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles, err := s.vehicleRepo.GetByOrganizationIDs([]uint{rollout.OrganizationID}, -1, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicles: %w", err)
+	}
+	outcomes, err := s.installRepo.GetOutcomes(rollout.ReleaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firmware installs: %w", err)
+	}
+
+	stats := &dto.FirmwareRolloutStats{}
+	for _, vehicle := range vehicles {
+		if !rollout.Targets(vehicle.ID) {
+			continue
+		}
+		stats.Targeted++
+		installed, reported := outcomes[vehicle.ID]
+		switch {
+		case !reported:
+			stats.Pending++
+		case installed:
+			stats.Installed++
+		default:
+			stats.Failed++
+		}
+	}
+
+	response := s.rolloutToResponse(rollout)
+	response.Stats = stats
+	return response, nil
+}
+
+// UpdateRollout updates a firmware rollout
+func (s *firmwareService) UpdateRollout(userID, rolloutID uint, req *dto.UpdateFirmwareRolloutRequest) (*dto.FirmwareRolloutResponse, error) {
+	rollout, err := s.authorizeRollout(userID, rolloutID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		rollout.Name = req.Name
+	}
+	if req.Enabled != nil {
+		rollout.Enabled = *req.Enabled
+	}
+	if len(req.VehicleIDs) > 0 || req.Percentage != nil {
+		if err := s.applyTargets(rollout, req.VehicleIDs, req.Percentage); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.rolloutRepo.Update(rollout); err != nil {
+		return nil, fmt.Errorf("failed to update firmware rollout: %w", err)
+	}
+
+	return s.rolloutToResponse(rollout), nil
+}
+
+// DeleteRollout deletes a firmware rollout. Install reports are kept with the release.
+func (s *firmwareService) DeleteRollout(userID, rolloutID uint) error {
+	rollout, err := s.authorizeRollout(userID, rolloutID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return err
+	}
+
+	if err := s.rolloutRepo.Delete(rollout.ID); err != nil {
+		return fmt.Errorf("failed to delete firmware rollout: %w", err)
+	}
+	return nil
+}
+
+// CheckForDevice finds the release offered to a vehicle of the organization (ESP32 access). The newest enabled
+// rollout for the board that targets the vehicle wins, so a rollout of an older release rolls the fleet back.
+// A release the vehicle failed to install MaxInstallAttempts times is not offered again.
+func (s *firmwareService) CheckForDevice(organizationID, vehicleID uint, board, currentVersion string) (*dto.ESP32FirmwareCheckResponse, error) {
+	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("vehicle not found")
+		}
+		return nil, fmt.Errorf("failed to get vehicle: %w", err)
+	}
+	if vehicle.OrganizationID != organizationID {
+		return nil, errors.New("vehicle not found")
+	}
+
+	rollouts, err := s.rolloutRepo.GetEnabledForBoard(organizationID, board)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firmware rollouts: %w", err)
+	}
+
+	for _, rollout := range rollouts {
+		if !rollout.Targets(vehicleID) {
+			continue
+		}
+
+		release := rollout.Release
+		if release.Version == currentVersion {
+			return &dto.ESP32FirmwareCheckResponse{UpdateAvailable: false}, nil
+		}
+
+		if s.cfg.MaxInstallAttempts > 0 {
+			failed, err := s.installRepo.CountFailed(vehicleID, release.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to count firmware installs: %w", err)
+			}
+			if failed >= int64(s.cfg.MaxInstallAttempts) {
+				return &dto.ESP32FirmwareCheckResponse{UpdateAvailable: false}, nil
+			}
+		}
+
+		expiresAt := time.Now().Add(s.cfg.LinkTTL)
+		return &dto.ESP32FirmwareCheckResponse{
+			UpdateAvailable: true,
+			ReleaseID:       release.ID,
+			Version:         release.Version,
+			Size:            release.FileSize,
+			SHA256:          release.SHA256,
+			URL:             strings.TrimRight(s.cfg.PublicURL, "/") + "/api/v1/esp32/firmware/download/" + s.signer.Sign(release.ID, expiresAt),
+			URLExpiresAt:    &expiresAt,
+		}, nil
+	}
+
+	return &dto.ESP32FirmwareCheckResponse{UpdateAvailable: false}, nil
+}
+
+// OpenDownload opens the firmware binary a signed download link points to
+func (s *firmwareService) OpenDownload(token string) (*FirmwareFile, error) {
+	releaseID, err := s.signer.Verify(token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := s.releaseRepo.GetByID(releaseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("firmware release not found")
+		}
+		return nil, fmt.Errorf("failed to get firmware release: %w", err)
+	}
+
+	content, err := s.blobs.Open(release.FileKey)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, errors.New("firmware release not found")
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	return &FirmwareFile{
+		Name:    fmt.Sprintf("%s-%s.bin", release.Board, release.Version),
+		Size:    release.FileSize,
+		SHA256:  release.SHA256,
+		Content: content,
+	}, nil
+}
+
+// ReportInstall records the install result of a release reported by a vehicle of the organization (ESP32 access)
+func (s *firmwareService) ReportInstall(organizationID uint, req *dto.ESP32FirmwareReportRequest) (*dto.FirmwareInstallResponse, error) {
+	vehicle, err := s.vehicleRepo.GetByID(req.VehicleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("vehicle not found")
+		}
+		return nil, fmt.Errorf("failed to get vehicle: %w", err)
+	}
+	if vehicle.OrganizationID != organizationID {
+		return nil, errors.New("vehicle not found")
+	}
+
+	release, err := s.releaseRepo.GetByID(req.ReleaseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("firmware release not found")
+		}
+		return nil, fmt.Errorf("failed to get firmware release: %w", err)
+	}
+	if release.OrganizationID != organizationID {
+		return nil, errors.New("firmware release not found")
+	}
+
+	install := &entity.FirmwareInstall{
+		VehicleID: vehicle.ID,
+		ReleaseID: release.ID,
+		Status:    entity.FirmwareInstallStatus(req.Status),
+		Release:   *release,
+	}
+	if req.PreviousVersion != "" {
+		install.PreviousVersion = &req.PreviousVersion
+	}
+	if req.Error != "" {
+		install.Error = &req.Error
+	}
+
+	if err := s.installRepo.Create(install); err != nil {
+		return nil, fmt.Errorf("failed to record firmware install: %w", err)
+	}
+
+	return s.installToResponse(install), nil
+}
+
+// applyTargets sets the vehicles a rollout targets. Listed vehicles must belong to the rollout's organization.
+func (s *firmwareService) applyTargets(rollout *entity.FirmwareRollout, vehicleIDs []uint, percentage *int) error {
+	switch {
+	case len(vehicleIDs) > 0 && percentage != nil:
+		return errors.New("set either vehicle_ids or percentage, not both")
+	case percentage != nil:
+		rollout.Percentage = percentage
+		rollout.VehicleIDs = ""
+		return nil
+	case len(vehicleIDs) == 0:
+		return errors.New("vehicle_ids or percentage is required")
+	}
+
+	ids := make([]string, 0, len(vehicleIDs))
+	seen := make(map[uint]bool, len(vehicleIDs))
+	for _, vehicleID := range vehicleIDs {
+		if seen[vehicleID] {
+			continue
+		}
+		seen[vehicleID] = true
+
+		vehicle, err := s.vehicleRepo.GetByID(vehicleID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("vehicle %d not found", vehicleID)
+			}
+			return fmt.Errorf("failed to get vehicle: %w", err)
+		}
+		if vehicle.OrganizationID != rollout.OrganizationID {
+			return fmt.Errorf("vehicle %d not found", vehicleID)
+		}
+		ids = append(ids, strconv.FormatUint(uint64(vehicleID), 10))
+	}
+
+	rollout.VehicleIDs = strings.Join(ids, ",")
+	rollout.Percentage = nil
+	return nil
+}
+
+// authorizeRelease gets a firmware release and checks the user may perform the action in its organization
+func (s *firmwareService) authorizeRelease(userID, releaseID uint, action entity.OrgAction) (*entity.FirmwareRelease, error) {
+	release, err := s.releaseRepo.GetByID(releaseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("firmware release not found")
+		}
+		return nil, fmt.Errorf("failed to get firmware release: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, release.OrganizationID, action); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("firmware release not found")
+	}
+
+	return release, nil
+}
+
+// authorizeRollout gets a firmware rollout and checks the user may perform the action in its organization
+func (s *firmwareService) authorizeRollout(userID, rolloutID uint, action entity.OrgAction) (*entity.FirmwareRollout, error) {
+	rollout, err := s.rolloutRepo.GetByID(rolloutID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("firmware rollout not found")
+		}
+		return nil, fmt.Errorf("failed to get firmware rollout: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, rollout.OrganizationID, action); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("firmware rollout not found")
+	}
+
+	return rollout, nil
+}
+
+// discardFile deletes a stored binary that is no longer referenced
+func (s *firmwareService) discardFile(key string) {
+	if err := s.blobs.Delete(key); err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to delete firmware file %s: %v", key, err)
+	}
+}
+
+// releaseToResponse converts firmware release entity to response DTO
+func (s *firmwareService) releaseToResponse(release *entity.FirmwareRelease) *dto.FirmwareReleaseResponse {
+	return &dto.FirmwareReleaseResponse{
+		ID:               release.ID,
+		OrganizationID:   release.OrganizationID,
+		Version:          release.Version,
+		Board:            release.Board,
+		FileSize:         release.FileSize,
+		SHA256:           release.SHA256,
+		Notes:            release.Notes,
+		UploadedByUserID: release.UploadedByUserID,
+		CreatedAt:        release.CreatedAt,
+	}
+}
+
+// rolloutToResponse converts firmware rollout entity to response DTO
+func (s *firmwareService) rolloutToResponse(rollout *entity.FirmwareRollout) *dto.FirmwareRolloutResponse {
+	return &dto.FirmwareRolloutResponse{
+		ID:             rollout.ID,
+		OrganizationID: rollout.OrganizationID,
+		ReleaseID:      rollout.ReleaseID,
+		Version:        rollout.Release.Version,
+		Board:          rollout.Release.Board,
+		Name:           rollout.Name,
+		VehicleIDs:     rollout.VehicleIDList(),
+		Percentage:     rollout.Percentage,
+		Enabled:        rollout.Enabled,
+		CreatedAt:      rollout.CreatedAt,
+		UpdatedAt:      rollout.UpdatedAt,
+	}
+}
+
+// installToResponse converts firmware install entity to response DTO
+func (s *firmwareService) installToResponse(install *entity.FirmwareInstall) *dto.FirmwareInstallResponse {
+	return &dto.FirmwareInstallResponse{
+		ID:              install.ID,
+		VehicleID:       install.VehicleID,
+		ReleaseID:       install.ReleaseID,
+		Version:         install.Release.Version,
+		Status:          string(install.Status),
+		PreviousVersion: install.PreviousVersion,
+		Error:           install.Error,
+		CreatedAt:       install.CreatedAt,
+	}
+}
+
+// newFirmwareFileKey generates a random blob key for a firmware binary of the organization
+func newFirmwareFileKey(organizationID uint) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate file key: %w", err)
+	}
+	return fmt.Sprintf("firmware/%d/%s.bin", organizationID, hex.EncodeToString(b)), nil
+}
@@ -22,31 +22,30 @@ type FuelLogService interface {
 
 // fuelLogService implements FuelLogService interface
 type fuelLogService struct {
-	fuelLogRepo repository.FuelLogRepository
-	vehicleRepo repository.VehicleRepository
+	fuelLogRepo         repository.FuelLogRepository
+	vehicleRepo         repository.VehicleRepository
+	organizationService OrganizationService
+	alertService        AlertService
+	webhookService      WebhookService
 }
 
 // NewFuelLogService creates new fuel log service instance
-func NewFuelLogService(fuelLogRepo repository.FuelLogRepository, vehicleRepo repository.VehicleRepository) FuelLogService {
+func NewFuelLogService(fuelLogRepo repository.FuelLogRepository, vehicleRepo repository.VehicleRepository, organizationService OrganizationService, alertService AlertService, webhookService WebhookService) FuelLogService {
 	return &fuelLogService{
-		fuelLogRepo: fuelLogRepo,
-		vehicleRepo: vehicleRepo,
+		fuelLogRepo:         fuelLogRepo,
+		vehicleRepo:         vehicleRepo,
+		organizationService: organizationService,
+		alertService:        alertService,
+		webhookService:      webhookService,
 	}
 }
 
 // Create creates a new fuel log
 func (s *fuelLogService) Create(userID uint, req *dto.CreateFuelLogRequest) (*dto.FuelLogResponse, error) {
-	// Verify vehicle ownership
-	vehicle, err := s.vehicleRepo.GetByID(req.VehicleID)
+	// Verify the user's organization role allows logging telemetry for the vehicle
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, req.VehicleID, entity.OrgActionLogTelemetry)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("vehicle not found")
-		}
-		return nil, fmt.Errorf("failed to get vehicle: %w", err)
-	}
-
-	if vehicle.UserID != userID {
-		return nil, errors.New("vehicle not found")
+		return nil, err
 	}
 
 	fuelLog := &entity.FuelLog{
@@ -58,22 +57,19 @@ func (s *fuelLogService) Create(userID uint, req *dto.CreateFuelLogRequest) (*dt
 		return nil, fmt.Errorf("failed to create fuel log: %w", err)
 	}
 
-	return s.entityToResponse(fuelLog), nil
+	s.alertService.EvaluateFuel(vehicle, fuelLog)
+
+	response := s.entityToResponse(fuelLog)
+	s.webhookService.Publish(vehicle.OrganizationID, entity.WebhookEventFuelCreated, response)
+
+	return response, nil
 }
 
 // GetByVehicleID gets fuel logs by vehicle ID
 func (s *fuelLogService) GetByVehicleID(userID, vehicleID uint, limit, offset int) ([]dto.FuelLogResponse, error) {
-	// Verify vehicle ownership
-	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("vehicle not found")
-		}
-		return nil, fmt.Errorf("failed to get vehicle: %w", err)
-	}
-
-	if vehicle.UserID != userID {
-		return nil, errors.New("vehicle not found")
+	// Verify organization membership
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
 	}
 
 	logs, err := s.fuelLogRepo.GetByVehicleID(vehicleID, limit, offset)
@@ -91,17 +87,9 @@ func (s *fuelLogService) GetByVehicleID(userID, vehicleID uint, limit, offset in
 
 // GetCurrentFuelLevel gets current fuel level for vehicle
 func (s *fuelLogService) GetCurrentFuelLevel(userID, vehicleID uint) (*dto.FuelLogResponse, error) {
-	// Verify vehicle ownership
-	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("vehicle not found")
-		}
-		return nil, fmt.Errorf("failed to get vehicle: %w", err)
-	}
-
-	if vehicle.UserID != userID {
-		return nil, errors.New("vehicle not found")
+	// Verify organization membership
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
 	}
 
 	log, err := s.fuelLogRepo.GetLatestByVehicleID(vehicleID)
@@ -117,17 +105,9 @@ func (s *fuelLogService) GetCurrentFuelLevel(userID, vehicleID uint) (*dto.FuelL
 
 // GetFuelStatistics gets fuel statistics for vehicle
 func (s *fuelLogService) GetFuelStatistics(userID, vehicleID uint, startDate, endDate time.Time) (*dto.FuelStatisticsResponse, error) {
-	// Verify vehicle ownership
-	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("vehicle not found")
-		}
-		return nil, fmt.Errorf("failed to get vehicle: %w", err)
-	}
-
-	if vehicle.UserID != userID {
-		return nil, errors.New("vehicle not found")
+	// Verify organization membership
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
 	}
 
 	stats, err := s.fuelLogRepo.GetFuelStatistics(vehicleID, startDate, endDate)
@@ -0,0 +1,473 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/blobstore"
+	"github.com/cartrack/backend/pkg/trackimport"
+	"gorm.io/gorm"
+)
+
+// Limits of an imported position. Speeds are stored as decimal(5,2).
+const (
+	importMaxSpeed    = 999.99
+	importMaxFutureIn = 5 * time.Minute
+)
+
+// importEarliest is the earliest device timestamp accepted by imports
+var importEarliest = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ImportService defines location history import service interface
+type ImportService interface {
+	Create(userID uint, req *dto.CreateImportJobRequest, fileName string, size int64, content io.Reader) (*dto.ImportJobResponse, error)
+	GetMyJobs(userID uint, status string, limit, offset int) ([]dto.ImportJobResponse, int64, error)
+	GetByID(userID, jobID uint) (*dto.ImportJobResponse, error)
+	ProcessPending(ctx context.Context) error
+}
+
+// importService implements ImportService interface
+type importService struct {
+	cfg                 configs.ImportsConfig
+	importJobRepo       repository.ImportJobRepository
+	locationLogRepo     repository.LocationLogRepository
+	vehicleRepo         repository.VehicleRepository
+	organizationService OrganizationService
+	blobs               blobstore.Store
+}
+
+// NewImportService creates new import service instance
+func NewImportService(cfg configs.ImportsConfig, importJobRepo repository.ImportJobRepository, locationLogRepo repository.LocationLogRepository, vehicleRepo repository.VehicleRepository, organizationService OrganizationService, blobs blobstore.Store) ImportService {
+	return &importService{
+		cfg:                 cfg,
+		importJobRepo:       importJobRepo,
+		locationLogRepo:     locationLogRepo,
+		vehicleRepo:         vehicleRepo,
+		organizationService: organizationService,
+		blobs:               blobs,
+	}
+}
+
+// Create stores an uploaded history file and queues its import into the vehicle's location logs
+func (s *importService) Create(userID uint, req *dto.CreateImportJobRequest, fileName string, size int64, content io.Reader) (*dto.ImportJobResponse, error) {
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, req.VehicleID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cfg.MaxFileSize > 0 && size > s.cfg.MaxFileSize {
+		return nil, fmt.Errorf("file is larger than the %d byte limit", s.cfg.MaxFileSize)
+	}
+
+	formatName := req.Format
+	if formatName == "" {
+		formatName = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileName)), ".")
+	}
+	format, err := trackimport.ParseFormat(formatName)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &entity.ImportJob{
+		OrganizationID:  vehicle.OrganizationID,
+		VehicleID:       vehicle.ID,
+		CreatedByUserID: userID,
+		Format:          string(format),
+		FileName:        filepath.Base(fileName),
+		Status:          entity.ImportJobPending,
+	}
+
+	if format == trackimport.FormatCSV {
+		mapping, err := parseColumnMapping(req.ColumnMapping)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(mapping)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode column mapping: %w", err)
+		}
+		value := string(encoded)
+		job.ColumnMapping = &value
+	} else if req.ColumnMapping != "" {
+		return nil, errors.New("column_mapping is only used for CSV files")
+	}
+
+	key, err := newImportFileKey(vehicle.OrganizationID, format)
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := s.blobs.Put(key, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+	job.FileKey = key
+	job.FileSize = written
+
+	if err := s.importJobRepo.Create(job); err != nil {
+		s.discardFile(key)
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	return s.entityToResponse(job, false), nil
+}
+
+// GetMyJobs gets import jobs of the user's organizations, newest first
+func (s *importService) GetMyJobs(userID uint, status string, limit, offset int) ([]dto.ImportJobResponse, int64, error) {
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs, total, err := s.importJobRepo.GetByOrganizationIDsWithPagination(organizationIDs, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get import jobs: %w", err)
+	}
+
+	responses := make([]dto.ImportJobResponse, len(jobs))
+	for i, job := range jobs {
+		responses[i] = *s.entityToResponse(&job, false)
+	}
+	return responses, total, nil
+}
+
+// GetByID gets an import job with its progress and the rows that were not imported
+func (s *importService) GetByID(userID, jobID uint) (*dto.ImportJobResponse, error) {
+	job, err := s.importJobRepo.GetByID(jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("import job not found")
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, job.OrganizationID, entity.OrgActionRead); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("import job not found")
+	}
+
+	return s.entityToResponse(job, true), nil
+}
+
+// ProcessPending imports queued files one after another until none is left (scheduler job)
+func (s *importService) ProcessPending(ctx context.Context) error {
+	for ctx.Err() == nil {
+		now := time.Now()
+		job, err := s.importJobRepo.ClaimNext(now, now.Add(-s.cfg.StaleAfter))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to claim import job: %w", err)
+		}
+
+		s.process(ctx, job)
+	}
+	return nil
+}
+
+// importRun tracks an import job while its file is read
+type importRun struct {
+	job       *entity.ImportJob
+	rowErrors []dto.ImportRowError
+	batch     []trackimport.Fix
+	file      *countingReader
+}
+
+// process reads the file of a claimed job and imports its valid, new positions in batches
+func (s *importService) process(ctx context.Context, job *entity.ImportJob) {
+	file, err := s.blobs.Open(job.FileKey)
+	if err != nil {
+		s.fail(&importRun{job: job}, fmt.Sprintf("failed to open file: %v", err))
+		return
+	}
+	defer file.Close()
+
+	run := &importRun{job: job, file: &countingReader{r: file}}
+
+	mapping := trackimport.DefaultColumnMapping()
+	if job.ColumnMapping != nil {
+		if err := json.Unmarshal([]byte(*job.ColumnMapping), &mapping); err != nil {
+			s.fail(run, "invalid column mapping")
+			return
+		}
+	}
+
+	reader, err := trackimport.NewReader(trackimport.Format(job.Format), run.file, mapping)
+	if err != nil {
+		s.fail(run, err.Error())
+		return
+	}
+
+	startedAt := time.Now()
+	for {
+		if ctx.Err() != nil {
+			// Leave the job running; it is taken over once stale and restarts safely
+			return
+		}
+
+		fix, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr *trackimport.RowError
+		if errors.As(err, &rowErr) {
+			job.ProcessedRows++
+			s.rejectRow(run, rowErr.Row, rowErr.Message)
+			continue
+		}
+		if err != nil {
+			s.fail(run, err.Error())
+			return
+		}
+
+		job.ProcessedRows++
+		if message := validateImportFix(fix, startedAt); message != "" {
+			s.rejectRow(run, fix.Row, message)
+			continue
+		}
+
+		run.batch = append(run.batch, *fix)
+		if len(run.batch) >= s.cfg.BatchSize {
+			if err := s.flush(run); err != nil {
+				s.fail(run, err.Error())
+				return
+			}
+		}
+	}
+
+	if err := s.flush(run); err != nil {
+		s.fail(run, err.Error())
+		return
+	}
+
+	completedAt := time.Now()
+	job.Status = entity.ImportJobCompleted
+	job.Progress = 100
+	job.CompletedAt = &completedAt
+	s.save(run)
+	s.discardFile(job.FileKey)
+}
+
+// flush inserts the batch, skipping positions the vehicle already has at the same timestamp, and saves progress
+func (s *importService) flush(run *importRun) error {
+	job := run.job
+	if len(run.batch) > 0 {
+		earliest, latest := run.batch[0].Time, run.batch[0].Time
+		for _, fix := range run.batch[1:] {
+			if fix.Time.Before(earliest) {
+				earliest = fix.Time
+			}
+			if fix.Time.After(latest) {
+				latest = fix.Time
+			}
+		}
+
+		existing, err := s.locationLogRepo.GetTimestampsBetween(job.VehicleID, earliest, latest)
+		if err != nil {
+			return fmt.Errorf("failed to check existing positions: %w", err)
+		}
+		seen := make(map[int64]bool, len(existing)+len(run.batch))
+		for _, timestamp := range existing {
+			seen[timestamp.UnixMicro()] = true
+		}
+
+		logs := make([]entity.LocationLog, 0, len(run.batch))
+		for _, fix := range run.batch {
+			key := fix.Time.UnixMicro()
+			if seen[key] {
+				job.DuplicateRows++
+				continue
+			}
+			seen[key] = true
+
+			logs = append(logs, entity.LocationLog{
+				VehicleID: job.VehicleID,
+				Latitude:  fix.Latitude,
+				Longitude: fix.Longitude,
+				Speed:     fix.Speed,
+				Direction: fix.Direction,
+				Ignition:  fix.Ignition,
+				Timestamp: fix.Time.Truncate(time.Microsecond),
+			})
+		}
+
+		if err := s.locationLogRepo.CreateBatch(logs); err != nil {
+			return fmt.Errorf("failed to insert positions: %w", err)
+		}
+		job.ImportedRows += len(logs)
+		run.batch = run.batch[:0]
+	}
+
+	if job.FileSize > 0 {
+		job.Progress = int(min(run.file.n*100/job.FileSize, 99))
+	}
+	s.save(run)
+	return nil
+}
+
+// rejectRow counts an invalid row and keeps its error while fewer than the configured maximum are kept
+func (s *importService) rejectRow(run *importRun, row int, message string) {
+	run.job.InvalidRows++
+	if len(run.rowErrors) < s.cfg.MaxRowErrors {
+		run.rowErrors = append(run.rowErrors, dto.ImportRowError{Row: row, Message: message})
+	}
+}
+
+// fail ends an import that cannot continue. Positions imported before the failure are kept.
+func (s *importService) fail(run *importRun, message string) {
+	completedAt := time.Now()
+	run.job.Status = entity.ImportJobFailed
+	run.job.Error = &message
+	run.job.CompletedAt = &completedAt
+	s.save(run)
+	s.discardFile(run.job.FileKey)
+}
+
+// save stores the job's status, counters and row errors
+func (s *importService) save(run *importRun) {
+	if len(run.rowErrors) > 0 {
+		encoded, err := json.Marshal(run.rowErrors)
+		if err == nil {
+			value := string(encoded)
+			run.job.RowErrors = &value
+		}
+	}
+
+	if err := s.importJobRepo.UpdateProgress(run.job); err != nil {
+		// Log error but keep importing; the next save retries
+		log.Printf("Failed to save progress of import job %d: %v", run.job.ID, err)
+	}
+}
+
+// discardFile deletes an import file that is no longer needed
+func (s *importService) discardFile(key string) {
+	if err := s.blobs.Delete(key); err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to delete import file %s: %v", key, err)
+	}
+}
+
+// entityToResponse converts entity to response DTO
+func (s *importService) entityToResponse(job *entity.ImportJob, withRowErrors bool) *dto.ImportJobResponse {
+	response := &dto.ImportJobResponse{
+		ID:              job.ID,
+		OrganizationID:  job.OrganizationID,
+		VehicleID:       job.VehicleID,
+		CreatedByUserID: job.CreatedByUserID,
+		Format:          job.Format,
+		FileName:        job.FileName,
+		FileSize:        job.FileSize,
+		Status:          string(job.Status),
+		Progress:        job.Progress,
+		ProcessedRows:   job.ProcessedRows,
+		ImportedRows:    job.ImportedRows,
+		DuplicateRows:   job.DuplicateRows,
+		InvalidRows:     job.InvalidRows,
+		Error:           job.Error,
+		StartedAt:       job.StartedAt,
+		CompletedAt:     job.CompletedAt,
+		CreatedAt:       job.CreatedAt,
+	}
+
+	if withRowErrors && job.RowErrors != nil {
+		if err := json.Unmarshal([]byte(*job.RowErrors), &response.RowErrors); err != nil {
+			log.Printf("Failed to decode row errors of import job %d: %v", job.ID, err)
+		}
+	}
+	return response
+}
+
+// parseColumnMapping decodes a CSV column mapping, taking unset columns from the default mapping
+func parseColumnMapping(value string) (trackimport.ColumnMapping, error) {
+	mapping := trackimport.DefaultColumnMapping()
+	if value == "" {
+		return mapping, nil
+	}
+
+	var requested trackimport.ColumnMapping
+	if err := json.Unmarshal([]byte(value), &requested); err != nil {
+		return mapping, errors.New("column_mapping must be a JSON object")
+	}
+
+	if requested.Timestamp != "" {
+		mapping.Timestamp = requested.Timestamp
+	}
+	if requested.Latitude != "" {
+		mapping.Latitude = requested.Latitude
+	}
+	if requested.Longitude != "" {
+		mapping.Longitude = requested.Longitude
+	}
+	if requested.Speed != "" {
+		mapping.Speed = requested.Speed
+	}
+	if requested.Direction != "" {
+		mapping.Direction = requested.Direction
+	}
+	if requested.Ignition != "" {
+		mapping.Ignition = requested.Ignition
+	}
+	mapping.TimeFormat = requested.TimeFormat
+	mapping.SpeedUnit = requested.SpeedUnit
+	mapping.Delimiter = requested.Delimiter
+
+	return mapping, mapping.Validate()
+}
+
+// validateImportFix checks an imported position is plausible and returns why it is not
+func validateImportFix(fix *trackimport.Fix, now time.Time) string {
+	switch {
+	case fix.Latitude < -90 || fix.Latitude > 90:
+		return "latitude out of range"
+	case fix.Longitude < -180 || fix.Longitude > 180:
+		return "longitude out of range"
+	case fix.Latitude == 0 && fix.Longitude == 0:
+		return "position 0,0 is not a valid fix"
+	case fix.Time.Before(importEarliest):
+		return "timestamp is before 2000"
+	case fix.Time.After(now.Add(importMaxFutureIn)):
+		return "timestamp is in the future"
+	case fix.Speed != nil && (*fix.Speed < 0 || *fix.Speed > importMaxSpeed):
+		return "speed out of range"
+	}
+	return ""
+}
+
+// countingReader counts the bytes read through it to report import progress
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// newImportFileKey generates a random blob key for an uploaded import file
+func newImportFileKey(organizationID uint, format trackimport.Format) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate file key: %w", err)
+	}
+	return fmt.Sprintf("imports/%d/%s.%s", organizationID, hex.EncodeToString(b), format), nil
+}
@@ -3,45 +3,90 @@ package service
 import (
 	"errors"
 	"fmt"
+	"log"
+	"sort"
 	"time"
 
+	"github.com/cartrack/backend/configs"
 	"github.com/cartrack/backend/internal/entity"
 	"github.com/cartrack/backend/internal/http/dto"
 	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/geo"
+	"github.com/cartrack/backend/pkg/trackfilter"
 	"gorm.io/gorm"
 )
 
+// suspectReanchorFixes is the number of consecutive suspect positions, consistent with each other, after which
+// the latest is trusted again. A tracker whose first fix after a restart was wrong would otherwise have every
+// later position flagged.
+const suspectReanchorFixes = 3
+
 // LocationLogService defines location log service interface
 type LocationLogService interface {
 	Create(userID uint, req *dto.CreateLocationLogRequest) (*dto.LocationLogResponse, error)
 	GetByVehicleID(userID, vehicleID uint, limit, offset int) ([]dto.LocationLogResponse, error)
 	GetByVehicleIDWithPagination(userID, vehicleID uint, limit, offset int) ([]dto.LocationLogResponse, int64, error)
 	GetByDateRange(userID, vehicleID uint, startDate, endDate time.Time, limit, offset int) ([]dto.LocationLogResponse, error)
-	GetByDateRangeWithPagination(userID, vehicleID uint, startDate, endDate time.Time, limit, offset int) ([]dto.LocationLogResponse, int64, error)
+	GetByDateRangeWithPagination(userID, vehicleID uint, startDate, endDate time.Time, limit, offset int, filter trackfilter.Options) ([]dto.LocationLogResponse, int64, error)
 	GetByUserID(userID uint, limit, offset int) ([]dto.LocationLogResponse, int64, error)
 	GetByUserIDWithDateRange(userID uint, startDate, endDate time.Time, limit, offset int) ([]dto.LocationLogResponse, int64, error)
 	GetLatestByVehicleID(userID, vehicleID uint) (*dto.LocationLogResponse, error)
 	GetAll(limit, offset int) ([]dto.LocationLogResponse, error)                      // Admin only
 	GetAllWithPagination(limit, offset int) ([]dto.LocationLogResponse, int64, error) // Admin only
+	CreateForOrganization(organizationID uint, req *dto.CreateLocationLogRequest) (*dto.LocationLogResponse, error)
+	GetByDriverID(userID, driverID uint, limit, offset int) ([]dto.LocationLogResponse, int64, error)
+	GetAreaVisits(userID uint, req *dto.AreaVisitsRequest) ([]dto.AreaVisitResponse, error)
 }
 
 // locationLogService implements LocationLogService interface
 type locationLogService struct {
-	locationLogRepo repository.LocationLogRepository
-	vehicleRepo     repository.VehicleRepository
+	cfg                 configs.TracksConfig
+	locationLogRepo     repository.LocationLogRepository
+	vehicleRepo         repository.VehicleRepository
+	organizationService OrganizationService
+	driverService       DriverService
+	alertService        AlertService
+	webhookService      WebhookService
+	deviceStatusService DeviceStatusService
 }
 
 // NewLocationLogService creates new location log service instance
-func NewLocationLogService(locationLogRepo repository.LocationLogRepository, vehicleRepo repository.VehicleRepository) LocationLogService {
+func NewLocationLogService(cfg configs.TracksConfig, locationLogRepo repository.LocationLogRepository, vehicleRepo repository.VehicleRepository, organizationService OrganizationService, driverService DriverService, alertService AlertService, webhookService WebhookService, deviceStatusService DeviceStatusService) LocationLogService {
 	return &locationLogService{
-		locationLogRepo: locationLogRepo,
-		vehicleRepo:     vehicleRepo,
+		cfg:                 cfg,
+		locationLogRepo:     locationLogRepo,
+		vehicleRepo:         vehicleRepo,
+		organizationService: organizationService,
+		driverService:       driverService,
+		alertService:        alertService,
+		webhookService:      webhookService,
+		deviceStatusService: deviceStatusService,
 	}
 }
 
 // Create creates a new location log
 func (s *locationLogService) Create(userID uint, req *dto.CreateLocationLogRequest) (*dto.LocationLogResponse, error) {
-	// Verify vehicle exists
+	// If userID is 0, this is a public endpoint - skip organization verification
+	// Otherwise, verify the user's organization role allows logging telemetry for the vehicle
+	var vehicle *entity.Vehicle
+	var err error
+	if userID != 0 {
+		vehicle, err = authorizeVehicle(s.vehicleRepo, s.organizationService, userID, req.VehicleID, entity.OrgActionLogTelemetry)
+		if err != nil {
+			return nil, err
+		}
+	} else if vehicle, err = s.vehicleRepo.GetByID(req.VehicleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("vehicle not found")
+		}
+		return nil, fmt.Errorf("failed to get vehicle: %w", err)
+	}
+
+	return s.create(vehicle, req)
+}
+
+// CreateForOrganization creates a new location log for a vehicle of the organization (ESP32 access)
+func (s *locationLogService) CreateForOrganization(organizationID uint, req *dto.CreateLocationLogRequest) (*dto.LocationLogResponse, error) {
 	vehicle, err := s.vehicleRepo.GetByID(req.VehicleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -50,40 +95,58 @@ func (s *locationLogService) Create(userID uint, req *dto.CreateLocationLogReque
 		return nil, fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	// If userID is 0, this is a public endpoint - skip ownership verification
-	// Otherwise, verify vehicle ownership
-	if userID != 0 && vehicle.UserID != userID {
+	if vehicle.OrganizationID != organizationID {
 		return nil, errors.New("vehicle not found")
 	}
 
+	return s.create(vehicle, req)
+}
+
+// create stores a location log after access has been checked, updates the device status, evaluates the vehicle's
+// alert rules against it and publishes it to webhook subscribers. A position the vehicle could not have reached
+// from its previous one is stored flagged as suspect and does not trigger alerts.
+func (s *locationLogService) create(vehicle *entity.Vehicle, req *dto.CreateLocationLogRequest) (*dto.LocationLogResponse, error) {
+	timestamp := time.Now()
+	if req.Timestamp != nil && req.Timestamp.Before(timestamp) {
+		timestamp = *req.Timestamp
+	}
+
 	locationLog := &entity.LocationLog{
 		VehicleID: req.VehicleID,
 		Latitude:  req.Latitude,
 		Longitude: req.Longitude,
 		Speed:     req.Speed,
 		Direction: req.Direction,
+		Ignition:  req.Ignition,
+		Timestamp: timestamp,
+		Suspect:   s.isSuspect(req, timestamp),
 	}
 
 	if err := s.locationLogRepo.Create(locationLog); err != nil {
 		return nil, fmt.Errorf("failed to create location log: %w", err)
 	}
 
-	return s.entityToResponse(locationLog), nil
+	moving := locationLog.Speed != nil && *locationLog.Speed > 0
+	if err := s.deviceStatusService.RecordLocation(vehicle.ID, locationLog.Timestamp, moving); err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to update device status of vehicle %d: %v", vehicle.ID, err)
+	}
+
+	if !locationLog.Suspect {
+		s.alertService.EvaluateLocation(vehicle, locationLog)
+	}
+
+	response := s.entityToResponse(locationLog)
+	s.webhookService.Publish(vehicle.OrganizationID, entity.WebhookEventLocationCreated, response)
+
+	return response, nil
 }
 
 // GetByVehicleID gets location logs by vehicle ID
 func (s *locationLogService) GetByVehicleID(userID, vehicleID uint, limit, offset int) ([]dto.LocationLogResponse, error) {
-	// Verify vehicle ownership
-	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("vehicle not found")
-		}
-		return nil, fmt.Errorf("failed to get vehicle: %w", err)
-	}
-
-	if vehicle.UserID != userID {
-		return nil, errors.New("vehicle not found")
+	// Verify organization membership
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
 	}
 
 	logs, err := s.locationLogRepo.GetByVehicleID(vehicleID, limit, offset)
@@ -95,23 +158,16 @@ func (s *locationLogService) GetByVehicleID(userID, vehicleID uint, limit, offse
 	for i, log := range logs {
 		responses[i] = *s.entityToResponse(&log)
 	}
+	s.attributeDrivers(responses)
 
 	return responses, nil
 }
 
 // GetByVehicleIDWithPagination gets location logs by vehicle ID with pagination info
 func (s *locationLogService) GetByVehicleIDWithPagination(userID, vehicleID uint, limit, offset int) ([]dto.LocationLogResponse, int64, error) {
-	// Verify vehicle ownership
-	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, 0, errors.New("vehicle not found")
-		}
-		return nil, 0, fmt.Errorf("failed to get vehicle: %w", err)
-	}
-
-	if vehicle.UserID != userID {
-		return nil, 0, errors.New("vehicle not found")
+	// Verify organization membership
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, 0, err
 	}
 
 	logs, total, err := s.locationLogRepo.GetByVehicleIDWithPagination(vehicleID, limit, offset)
@@ -123,23 +179,16 @@ func (s *locationLogService) GetByVehicleIDWithPagination(userID, vehicleID uint
 	for i, log := range logs {
 		responses[i] = *s.entityToResponse(&log)
 	}
+	s.attributeDrivers(responses)
 
 	return responses, total, nil
 }
 
 // GetByDateRange gets location logs by date range
 func (s *locationLogService) GetByDateRange(userID, vehicleID uint, startDate, endDate time.Time, limit, offset int) ([]dto.LocationLogResponse, error) {
-	// Verify vehicle ownership
-	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("vehicle not found")
-		}
-		return nil, fmt.Errorf("failed to get vehicle: %w", err)
-	}
-
-	if vehicle.UserID != userID {
-		return nil, errors.New("vehicle not found")
+	// Verify organization membership
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
 	}
 
 	logs, err := s.locationLogRepo.GetByVehicleIDAndDateRange(vehicleID, startDate, endDate, limit, offset)
@@ -151,26 +200,27 @@ func (s *locationLogService) GetByDateRange(userID, vehicleID uint, startDate, e
 	for i, log := range logs {
 		responses[i] = *s.entityToResponse(&log)
 	}
+	s.attributeDrivers(responses)
 
 	return responses, nil
 }
 
-// GetByDateRangeWithPagination gets location logs by date range with pagination info
-func (s *locationLogService) GetByDateRangeWithPagination(userID, vehicleID uint, startDate, endDate time.Time, limit, offset int) ([]dto.LocationLogResponse, int64, error) {
-	// Verify vehicle ownership
-	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
+// GetByDateRangeWithPagination gets location logs by date range with pagination info. With a filter the whole
+// range is cleaned before it is paginated, so the page and the total both count the cleaned positions.
+func (s *locationLogService) GetByDateRangeWithPagination(userID, vehicleID uint, startDate, endDate time.Time, limit, offset int, filter trackfilter.Options) ([]dto.LocationLogResponse, int64, error) {
+	// Verify organization membership
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, 0, errors.New("vehicle not found")
-		}
-		return nil, 0, fmt.Errorf("failed to get vehicle: %w", err)
+		return nil, 0, err
 	}
 
-	if vehicle.UserID != userID {
-		return nil, 0, errors.New("vehicle not found")
+	var logs []entity.LocationLog
+	var total int64
+	if filter.Enabled() {
+		logs, total, err = s.getFilteredPage(vehicle, startDate, endDate, limit, offset, filter)
+	} else {
+		logs, total, err = s.locationLogRepo.GetByVehicleIDAndDateRangeWithPagination(vehicleID, startDate, endDate, limit, offset)
 	}
-
-	logs, total, err := s.locationLogRepo.GetByVehicleIDAndDateRangeWithPagination(vehicleID, startDate, endDate, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get location logs: %w", err)
 	}
@@ -179,23 +229,16 @@ func (s *locationLogService) GetByDateRangeWithPagination(userID, vehicleID uint
 	for i, log := range logs {
 		responses[i] = *s.entityToResponse(&log)
 	}
+	s.attributeDrivers(responses)
 
 	return responses, total, nil
 }
 
 // GetLatestByVehicleID gets latest location log for vehicle
 func (s *locationLogService) GetLatestByVehicleID(userID, vehicleID uint) (*dto.LocationLogResponse, error) {
-	// Verify vehicle ownership
-	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("vehicle not found")
-		}
-		return nil, fmt.Errorf("failed to get vehicle: %w", err)
-	}
-
-	if vehicle.UserID != userID {
-		return nil, errors.New("vehicle not found")
+	// Verify organization membership
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
 	}
 
 	log, err := s.locationLogRepo.GetLatestByVehicleID(vehicleID)
@@ -206,7 +249,10 @@ func (s *locationLogService) GetLatestByVehicleID(userID, vehicleID uint) (*dto.
 		return nil, fmt.Errorf("failed to get latest location: %w", err)
 	}
 
-	return s.entityToResponse(log), nil
+	responses := []dto.LocationLogResponse{*s.entityToResponse(log)}
+	s.attributeDrivers(responses)
+
+	return &responses[0], nil
 }
 
 // GetByUserID gets all location logs for a specific user across all their vehicles
@@ -220,6 +266,7 @@ func (s *locationLogService) GetByUserID(userID uint, limit, offset int) ([]dto.
 	for i, log := range logs {
 		responses[i] = *s.entityToResponse(&log)
 	}
+	s.attributeDrivers(responses)
 
 	return responses, total, nil
 }
@@ -235,10 +282,72 @@ func (s *locationLogService) GetByUserIDWithDateRange(userID uint, startDate, en
 	for i, log := range logs {
 		responses[i] = *s.entityToResponse(&log)
 	}
+	s.attributeDrivers(responses)
 
 	return responses, total, nil
 }
 
+// GetAreaVisits gets the vehicles of the user's organizations that reported trusted positions inside an area
+// during a period, in order of their first position there
+func (s *locationLogService) GetAreaVisits(userID uint, req *dto.AreaVisitsRequest) ([]dto.AreaVisitResponse, error) {
+	shape, err := areaShape(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.EndDate.After(req.StartDate) {
+		return nil, errors.New("end of the period must be after its start")
+	}
+	if req.EndDate.Sub(req.StartDate) > s.cfg.MaxRange {
+		return nil, fmt.Errorf("period must not be longer than %d days", int(s.cfg.MaxRange.Hours()/24))
+	}
+
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles, err := s.vehicleRepo.GetByOrganizationIDs(organizationIDs, -1, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicles: %w", err)
+	}
+
+	requested := make(map[uint]bool, len(req.VehicleIDs))
+	for _, vehicleID := range req.VehicleIDs {
+		requested[vehicleID] = true
+	}
+	plates := make(map[uint]string, len(vehicles))
+	vehicleIDs := make([]uint, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		if len(requested) > 0 && !requested[vehicle.ID] {
+			continue
+		}
+		plates[vehicle.ID] = vehicle.PlateNumber
+		vehicleIDs = append(vehicleIDs, vehicle.ID)
+	}
+
+	visits, err := s.locationLogRepo.GetVisitsWithin(vehicleIDs, req.StartDate, req.EndDate, shape)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get area visits: %w", err)
+	}
+
+	responses := make([]dto.AreaVisitResponse, len(visits))
+	for i, visit := range visits {
+		responses[i] = dto.AreaVisitResponse{
+			VehicleID:   visit.VehicleID,
+			PlateNumber: plates[visit.VehicleID],
+			Positions:   visit.Positions,
+			FirstAt:     visit.FirstAt,
+			LastAt:      visit.LastAt,
+		}
+	}
+	sort.SliceStable(responses, func(i, j int) bool {
+		return responses[i].FirstAt.Before(responses[j].FirstAt)
+	})
+
+	return responses, nil
+}
+
 // GetAll gets all location logs (admin only)
 func (s *locationLogService) GetAll(limit, offset int) ([]dto.LocationLogResponse, error) {
 	logs, err := s.locationLogRepo.GetAll(limit, offset)
@@ -250,6 +359,7 @@ func (s *locationLogService) GetAll(limit, offset int) ([]dto.LocationLogRespons
 	for i, log := range logs {
 		responses[i] = *s.entityToResponse(&log)
 	}
+	s.attributeDrivers(responses)
 
 	return responses, nil
 }
@@ -265,10 +375,118 @@ func (s *locationLogService) GetAllWithPagination(limit, offset int) ([]dto.Loca
 	for i, log := range logs {
 		responses[i] = *s.entityToResponse(&log)
 	}
+	s.attributeDrivers(responses)
 
 	return responses, total, nil
 }
 
+// GetByDriverID gets location logs recorded while the driver was on duty
+func (s *locationLogService) GetByDriverID(userID, driverID uint, limit, offset int) ([]dto.LocationLogResponse, int64, error) {
+	// Verify the driver belongs to one of the user's organizations
+	if _, err := s.driverService.GetByID(userID, driverID); err != nil {
+		return nil, 0, err
+	}
+
+	logs, total, err := s.locationLogRepo.GetByDriverIDWithPagination(driverID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get location logs for driver: %w", err)
+	}
+
+	responses := make([]dto.LocationLogResponse, len(logs))
+	for i, log := range logs {
+		responses[i] = *s.entityToResponse(&log)
+	}
+	s.attributeDrivers(responses)
+
+	return responses, total, nil
+}
+
+// getFilteredPage cleans the vehicle's track between startDate and endDate and returns one page of it,
+// newest first, with the number of cleaned positions
+func (s *locationLogService) getFilteredPage(vehicle *entity.Vehicle, startDate, endDate time.Time, limit, offset int, filter trackfilter.Options) ([]entity.LocationLog, int64, error) {
+	var logs []entity.LocationLog
+	err := s.locationLogRepo.StreamLocationHistory(vehicle.ID, startDate, endDate, func(log *entity.LocationLog) error {
+		logs = append(logs, *log)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	logs = filterTrack(logs, filter)
+	total := int64(len(logs))
+
+	page := make([]entity.LocationLog, 0, limit)
+	for i := len(logs) - 1 - offset; i >= 0 && len(page) < limit; i-- {
+		log := logs[i]
+		log.Vehicle = *vehicle
+		page = append(page, log)
+	}
+	return page, total, nil
+}
+
+// isSuspect reports whether a position taken at the given time is an impossible jump from the vehicle's latest
+// trusted one. The vehicle is re-anchored, as DropOutliers does for a bad first position, once the position
+// is consistent with the suspectReanchorFixes-1 fixes recorded just before it, all suspect themselves.
+func (s *locationLogService) isSuspect(req *dto.CreateLocationLogRequest, timestamp time.Time) bool {
+	if s.cfg.MaxSpeed <= 0 {
+		return false
+	}
+
+	previous, err := s.locationLogRepo.GetLatestTrustedByVehicleID(req.VehicleID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			// Log error but don't fail the request
+			log.Printf("Failed to get previous location of vehicle %d: %v", req.VehicleID, err)
+		}
+		return false
+	}
+	// A late fix from before the trusted one cannot be judged against it
+	if timestamp.Before(previous.Timestamp) {
+		return false
+	}
+
+	point := trackfilter.Point{Time: timestamp, Latitude: req.Latitude, Longitude: req.Longitude}
+	from := trackfilter.Point{Time: previous.Timestamp, Latitude: previous.Latitude, Longitude: previous.Longitude}
+	if !trackfilter.IsImpossibleJump(from, point, s.cfg.MaxSpeed) {
+		return false
+	}
+	return !s.reanchors(req.VehicleID, point)
+}
+
+// reanchors reports whether the vehicle's latest suspectReanchorFixes-1 positions are all suspect and form,
+// together with point, a track that is possible at the configured maximum speed
+func (s *locationLogService) reanchors(vehicleID uint, point trackfilter.Point) bool {
+	recent, err := s.locationLogRepo.GetByVehicleID(vehicleID, suspectReanchorFixes-1, 0)
+	if err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to get recent locations of vehicle %d: %v", vehicleID, err)
+		return false
+	}
+	if len(recent) < suspectReanchorFixes-1 {
+		return false
+	}
+
+	// recent is newest first
+	next := point
+	for _, log := range recent {
+		fix := trackfilter.Point{Time: log.Timestamp, Latitude: log.Latitude, Longitude: log.Longitude}
+		if !log.Suspect || fix.Time.After(next.Time) || trackfilter.IsImpossibleJump(fix, next, s.cfg.MaxSpeed) {
+			return false
+		}
+		next = fix
+	}
+	return true
+}
+
+// attributeDrivers sets the driver on duty for each location log
+func (s *locationLogService) attributeDrivers(responses []dto.LocationLogResponse) {
+	if err := s.driverService.AttributeLocationLogs(responses); err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to attribute drivers to location logs: %v", err)
+	}
+}
+
 // entityToResponse converts entity to response DTO
 func (s *locationLogService) entityToResponse(log *entity.LocationLog) *dto.LocationLogResponse {
 	response := &dto.LocationLogResponse{
@@ -278,6 +496,8 @@ func (s *locationLogService) entityToResponse(log *entity.LocationLog) *dto.Loca
 		Longitude: log.Longitude,
 		Speed:     log.Speed,
 		Direction: log.Direction,
+		Ignition:  log.Ignition,
+		Suspect:   log.Suspect,
 		Timestamp: log.Timestamp,
 		CreatedAt: log.CreatedAt,
 		UpdatedAt: log.UpdatedAt,
@@ -286,15 +506,50 @@ func (s *locationLogService) entityToResponse(log *entity.LocationLog) *dto.Loca
 	// Map Vehicle data if available
 	if log.Vehicle.ID != 0 {
 		response.Vehicle = &dto.VehicleResponse{
-			ID:          log.Vehicle.ID,
-			UserID:      log.Vehicle.UserID,
-			PlateNumber: log.Vehicle.PlateNumber,
-			Model:       log.Vehicle.Model,
-			IMEI:        log.Vehicle.IMEI,
-			CreatedAt:   log.Vehicle.CreatedAt,
-			UpdatedAt:   log.Vehicle.UpdatedAt,
+			ID:             log.Vehicle.ID,
+			UserID:         log.Vehicle.UserID,
+			OrganizationID: log.Vehicle.OrganizationID,
+			PlateNumber:    log.Vehicle.PlateNumber,
+			Model:          log.Vehicle.Model,
+			IMEI:           log.Vehicle.IMEI,
+			CreatedAt:      log.Vehicle.CreatedAt,
+			UpdatedAt:      log.Vehicle.UpdatedAt,
 		}
 	}
 
 	return response
 }
+
+// areaShape converts the area of a request to a shape, requiring exactly one of bounds, circle or polygon
+func areaShape(req *dto.AreaVisitsRequest) (geo.Shape, error) {
+	var shapes []geo.Shape
+	if req.Bounds != nil {
+		shapes = append(shapes, geo.Bounds{
+			MinLat: req.Bounds.MinLatitude,
+			MaxLat: req.Bounds.MaxLatitude,
+			MinLng: req.Bounds.MinLongitude,
+			MaxLng: req.Bounds.MaxLongitude,
+		})
+	}
+	if req.Center != nil || req.RadiusM != nil {
+		if req.Center == nil || req.RadiusM == nil {
+			return nil, errors.New("a circle needs both center and radius_m")
+		}
+		shapes = append(shapes, geo.Circle{
+			Center: geo.Point{Latitude: req.Center.Latitude, Longitude: req.Center.Longitude},
+			Radius: *req.RadiusM,
+		})
+	}
+	if len(req.Polygon) > 0 {
+		polygon := make(geo.Polygon, len(req.Polygon))
+		for i, vertex := range req.Polygon {
+			polygon[i] = geo.Point{Latitude: vertex.Latitude, Longitude: vertex.Longitude}
+		}
+		shapes = append(shapes, polygon)
+	}
+
+	if len(shapes) != 1 {
+		return nil, errors.New("specify exactly one area: bounds, center with radius_m, or polygon")
+	}
+	return shapes[0], nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/geo"
+	"github.com/cartrack/backend/pkg/trackfilter"
+	"gorm.io/gorm"
+)
+
+var trackStart = time.Date(2025, 8, 1, 8, 0, 0, 0, time.UTC)
+
+// fakeLocationLogRepo holds one vehicle's positions, oldest first
+type fakeLocationLogRepo struct {
+	repository.LocationLogRepository
+	logs []entity.LocationLog
+}
+
+func (r *fakeLocationLogRepo) StreamLocationHistory(vehicleID uint, startDate, endDate time.Time, fn func(log *entity.LocationLog) error) error {
+	for i := range r.logs {
+		log := r.logs[i]
+		if log.Timestamp.Before(startDate) || log.Timestamp.After(endDate) {
+			continue
+		}
+		if err := fn(&log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeLocationLogRepo) GetLatestTrustedByVehicleID(vehicleID uint) (*entity.LocationLog, error) {
+	for i := len(r.logs) - 1; i >= 0; i-- {
+		if !r.logs[i].Suspect {
+			log := r.logs[i]
+			return &log, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeLocationLogRepo) GetByVehicleID(vehicleID uint, limit, offset int) ([]entity.LocationLog, error) {
+	var logs []entity.LocationLog
+	for i := len(r.logs) - 1 - offset; i >= 0 && len(logs) < limit; i-- {
+		logs = append(logs, r.logs[i])
+	}
+	return logs, nil
+}
+
+// fakeVehicleRepo knows a single vehicle
+type fakeVehicleRepo struct {
+	repository.VehicleRepository
+	vehicle entity.Vehicle
+}
+
+func (r *fakeVehicleRepo) GetByID(id uint) (*entity.Vehicle, error) {
+	if id != r.vehicle.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	vehicle := r.vehicle
+	return &vehicle, nil
+}
+
+// memberOrganizationService lets every user act in every organization
+type memberOrganizationService struct {
+	OrganizationService
+}
+
+func (s *memberOrganizationService) Authorize(userID, organizationID uint, action entity.OrgAction) (*entity.OrganizationMember, error) {
+	return &entity.OrganizationMember{UserID: userID, OrganizationID: organizationID, Role: entity.OrgRoleOwner}, nil
+}
+
+// fakePOIService matches no position to a place
+type fakePOIService struct {
+	POIService
+}
+
+func (s *fakePOIService) ResolvePlaces(vehicle *entity.Vehicle, positions []geo.Point) ([]*dto.StopPlaceResponse, error) {
+	return make([]*dto.StopPlaceResponse, len(positions)), nil
+}
+
+// trackWithOutlier returns a vehicle driving north at about 33 km/h with one position 111 km off its route
+func trackWithOutlier() []entity.LocationLog {
+	var logs []entity.LocationLog
+	for i := 0; i < 6; i++ {
+		logs = append(logs, entity.LocationLog{
+			ID:        uint(i + 1),
+			VehicleID: 1,
+			Latitude:  -6.2 + float64(i)*0.005,
+			Longitude: 106.8,
+			Timestamp: trackStart.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	logs[3].Latitude += 1
+	logs[3].Suspect = true
+	return logs
+}
+
+func TestGetFilteredPageCountsCleanedPositions(t *testing.T) {
+	repo := &fakeLocationLogRepo{logs: trackWithOutlier()}
+	svc := &locationLogService{locationLogRepo: repo}
+	vehicle := &entity.Vehicle{ID: 1}
+
+	page, total, err := svc.getFilteredPage(vehicle, trackStart, trackStart.Add(time.Hour), 2, 1, trackfilter.Options{MaxSpeed: 250})
+	if err != nil {
+		t.Fatalf("getFilteredPage() error = %v", err)
+	}
+	if total != 5 {
+		t.Errorf("total = %d, want 5 positions without the outlier", total)
+	}
+
+	// Newest first, skipping the newest position: IDs 5 and 3, with the outlier (4) dropped
+	var ids []uint
+	for _, log := range page {
+		ids = append(ids, log.ID)
+	}
+	if len(ids) != 2 || ids[0] != 5 || ids[1] != 3 {
+		t.Errorf("page IDs = %v, want [5 3]", ids)
+	}
+}
+
+func TestIsSuspect(t *testing.T) {
+	trusted := entity.LocationLog{VehicleID: 1, Latitude: -6.2, Longitude: 106.8, Timestamp: trackStart}
+	// Two suspect fixes 111 km north of the trusted one, consistent with each other
+	relocated := []entity.LocationLog{
+		trusted,
+		{VehicleID: 1, Latitude: -5.2, Longitude: 106.8, Timestamp: trackStart.Add(time.Minute), Suspect: true},
+		{VehicleID: 1, Latitude: -5.195, Longitude: 106.8, Timestamp: trackStart.Add(2 * time.Minute), Suspect: true},
+	}
+
+	tests := []struct {
+		name      string
+		logs      []entity.LocationLog
+		latitude  float64
+		timestamp time.Time
+		want      bool
+	}{
+		{name: "first position", latitude: -6.2, timestamp: trackStart, want: false},
+		{name: "plausible move", logs: []entity.LocationLog{trusted}, latitude: -6.195, timestamp: trackStart.Add(time.Minute), want: false},
+		{name: "impossible jump", logs: []entity.LocationLog{trusted}, latitude: -5.2, timestamp: trackStart.Add(time.Minute), want: true},
+		{name: "late fix before the trusted one", logs: []entity.LocationLog{trusted}, latitude: -5.2, timestamp: trackStart.Add(-time.Minute), want: false},
+		{name: "re-anchors after consistent suspect fixes", logs: relocated, latitude: -5.19, timestamp: trackStart.Add(3 * time.Minute), want: false},
+		{name: "keeps flagging inconsistent fixes", logs: relocated, latitude: -4.2, timestamp: trackStart.Add(3 * time.Minute), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &locationLogService{
+				cfg:             configs.TracksConfig{MaxSpeed: 250},
+				locationLogRepo: &fakeLocationLogRepo{logs: tt.logs},
+			}
+			req := &dto.CreateLocationLogRequest{VehicleID: 1, Latitude: tt.latitude, Longitude: 106.8}
+			if got := svc.isSuspect(req, tt.timestamp); got != tt.want {
+				t.Errorf("isSuspect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaybackSkipsSuspectPositions(t *testing.T) {
+	logs := trackWithOutlier()
+	svc := &trackService{
+		cfg: configs.TracksConfig{
+			SegmentGap:        10 * time.Minute,
+			MaxRange:          24 * time.Hour,
+			PlaybackStep:      10 * time.Second,
+			PlaybackMaxFrames: 20000,
+			StopSpeed:         3,
+			StopMinDuration:   2 * time.Minute,
+			StopRadius:        50,
+		},
+		locationLogRepo:     &fakeLocationLogRepo{logs: logs},
+		vehicleRepo:         &fakeVehicleRepo{vehicle: entity.Vehicle{ID: 1, OrganizationID: 1}},
+		poiService:          &fakePOIService{},
+		organizationService: &memberOrganizationService{},
+	}
+
+	playback, err := svc.Playback(7, 1, trackStart, trackStart.Add(time.Hour), 0)
+	if err != nil {
+		t.Fatalf("Playback() error = %v", err)
+	}
+
+	// 0.025 degrees of latitude, about 2.8 km, without the detour through the outlier
+	if playback.DistanceKm < 2.7 || playback.DistanceKm > 2.9 {
+		t.Errorf("DistanceKm = %.2f, want about 2.8", playback.DistanceKm)
+	}
+	for _, frame := range playback.Frames {
+		if frame.Latitude > -6.17 {
+			t.Fatalf("frame at %s passes through the outlier (latitude %.4f)", frame.Time, frame.Latitude)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ErrTooManyLoginAttempts is returned when a login is rejected by brute-force protection
+var ErrTooManyLoginAttempts = errors.New("too many login attempts")
+
+// LoginProtectionService defines brute-force protection for the login flow
+type LoginProtectionService interface {
+	CheckAllowed(user *entity.User, ipAddress string) error
+	RecordFailure(user *entity.User, email, ipAddress string) error
+	RecordSuccess(user *entity.User, ipAddress string) error
+	Unlock(userID uint) error
+	PurgeAttempts(ctx context.Context) error
+}
+
+// loginProtectionService implements LoginProtectionService interface
+type loginProtectionService struct {
+	cfg              configs.LoginConfig
+	userRepo         repository.UserRepository
+	loginAttemptRepo repository.LoginAttemptRepository
+	systemLogRepo    repository.SystemLogRepository
+}
+
+// NewLoginProtectionService creates new login protection service instance
+func NewLoginProtectionService(cfg configs.LoginConfig, userRepo repository.UserRepository, loginAttemptRepo repository.LoginAttemptRepository, systemLogRepo repository.SystemLogRepository) LoginProtectionService {
+	return &loginProtectionService{
+		cfg:              cfg,
+		userRepo:         userRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		systemLogRepo:    systemLogRepo,
+	}
+}
+
+// CheckAllowed rejects the attempt if the account or IP is locked or still inside its progressive delay.
+// user may be nil when the email is not registered.
+func (s *loginProtectionService) CheckAllowed(user *entity.User, ipAddress string) error {
+	now := time.Now()
+
+	// Per-IP limit
+	ipFailures, err := s.loginAttemptRepo.CountFailuresByIP(ipAddress, now.Add(-s.cfg.IPWindow))
+	if err != nil {
+		return fmt.Errorf("failed to count login attempts: %w", err)
+	}
+	if s.cfg.IPMaxAttempts > 0 && int(ipFailures) >= s.cfg.IPMaxAttempts {
+		return fmt.Errorf("%w from this address, try again later", ErrTooManyLoginAttempts)
+	}
+	if delay := s.progressiveDelay(int(ipFailures)); delay > 0 {
+		lastFailure, err := s.loginAttemptRepo.GetLastFailureByIP(ipAddress)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get last login attempt: %w", err)
+		}
+		if lastFailure != nil {
+			if wait := lastFailure.CreatedAt.Add(delay).Sub(now); wait > 0 {
+				return fmt.Errorf("%w, try again in %s", ErrTooManyLoginAttempts, roundWait(wait))
+			}
+		}
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	// Per-account lockout
+	if user.IsLocked(now) {
+		return fmt.Errorf("%w, account is locked until %s", ErrTooManyLoginAttempts, user.LockedUntil.Format(time.RFC3339))
+	}
+	if delay := s.progressiveDelay(user.FailedLoginAttempts); delay > 0 && user.LastFailedLoginAt != nil {
+		if wait := user.LastFailedLoginAt.Add(delay).Sub(now); wait > 0 {
+			return fmt.Errorf("%w, try again in %s", ErrTooManyLoginAttempts, roundWait(wait))
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure records a failed attempt and locks the account once the limit is reached
+func (s *loginProtectionService) RecordFailure(user *entity.User, email, ipAddress string) error {
+	now := time.Now()
+
+	attempt := &entity.LoginAttempt{
+		Email:     email,
+		IPAddress: ipAddress,
+		Success:   false,
+	}
+	if user != nil {
+		attempt.UserID = &user.ID
+	}
+	if err := s.loginAttemptRepo.Create(attempt); err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+
+	// Log once when the IP crosses the limit
+	ipFailures, err := s.loginAttemptRepo.CountFailuresByIP(ipAddress, now.Add(-s.cfg.IPWindow))
+	if err != nil {
+		return fmt.Errorf("failed to count login attempts: %w", err)
+	}
+	if s.cfg.IPMaxAttempts > 0 && int(ipFailures) == s.cfg.IPMaxAttempts {
+		s.logSecurityEvent(fmt.Sprintf("[SECURITY] login blocked for IP %s after %d failed attempts within %s (last email tried: %s)",
+			ipAddress, ipFailures, s.cfg.IPWindow, email))
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	failures, err := s.userRepo.IncrementFailedLogins(user.ID, now)
+	if err != nil {
+		return fmt.Errorf("failed to update failed login counter: %w", err)
+	}
+
+	if s.cfg.MaxAttempts > 0 && failures >= s.cfg.MaxAttempts {
+		lockedUntil := now.Add(s.cfg.LockoutDuration)
+		if err := s.userRepo.LockUntil(user.ID, lockedUntil); err != nil {
+			return fmt.Errorf("failed to lock account: %w", err)
+		}
+		s.logSecurityEvent(fmt.Sprintf("[SECURITY] account %s (user %d) locked until %s after %d failed login attempts, last from IP %s",
+			user.Email, user.ID, lockedUntil.Format(time.RFC3339), failures, ipAddress))
+	}
+
+	return nil
+}
+
+// RecordSuccess records a successful attempt and clears the account's failure state
+func (s *loginProtectionService) RecordSuccess(user *entity.User, ipAddress string) error {
+	attempt := &entity.LoginAttempt{
+		UserID:    &user.ID,
+		Email:     user.Email,
+		IPAddress: ipAddress,
+		Success:   true,
+	}
+	if err := s.loginAttemptRepo.Create(attempt); err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+
+	if user.FailedLoginAttempts > 0 || user.LockedUntil != nil {
+		if err := s.userRepo.ResetFailedLogins(user.ID); err != nil {
+			return fmt.Errorf("failed to reset failed login counter: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Unlock clears the lockout of an account (admin only)
+func (s *loginProtectionService) Unlock(userID uint) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.userRepo.ResetFailedLogins(user.ID); err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+
+	s.logSecurityEvent(fmt.Sprintf("[SECURITY] account %s (user %d) unlocked by administrator", user.Email, user.ID))
+	return nil
+}
+
+// PurgeAttempts deletes login attempts older than the retention period. Attempts inside the
+// per-IP window are always kept so purging never resets a running limit.
+func (s *loginProtectionService) PurgeAttempts(ctx context.Context) error {
+	retention := s.cfg.AttemptRetention
+	if retention < s.cfg.IPWindow {
+		retention = s.cfg.IPWindow
+	}
+	if err := s.loginAttemptRepo.DeleteOlderThan(time.Now().Add(-retention)); err != nil {
+		return fmt.Errorf("failed to purge login attempts: %w", err)
+	}
+	return nil
+}
+
+// progressiveDelay returns how long to wait after the given number of consecutive failures.
+// The delay doubles with each failure past DelayAfter and never exceeds the lockout duration.
+func (s *loginProtectionService) progressiveDelay(failures int) time.Duration {
+	if failures <= s.cfg.DelayAfter || s.cfg.BaseDelay <= 0 {
+		return 0
+	}
+	delay := time.Duration(float64(s.cfg.BaseDelay) * math.Pow(2, float64(failures-s.cfg.DelayAfter-1)))
+	if s.cfg.LockoutDuration > 0 && delay > s.cfg.LockoutDuration {
+		delay = s.cfg.LockoutDuration
+	}
+	return delay
+}
+
+// logSecurityEvent writes a security event to system_logs
+func (s *loginProtectionService) logSecurityEvent(message string) {
+	systemLog := &entity.SystemLog{
+		LogType: entity.LogTypeWarning,
+		Message: message,
+	}
+	if err := s.systemLogRepo.Create(systemLog); err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to write security event: %v", err)
+	}
+}
+
+// roundWait rounds a wait duration up to whole seconds for display
+func roundWait(wait time.Duration) time.Duration {
+	return time.Duration(math.Ceil(wait.Seconds())) * time.Second
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/repository"
+)
+
+// fakeLoginAttemptRepo records the cutoff of the last purge
+type fakeLoginAttemptRepo struct {
+	repository.LoginAttemptRepository
+	purgedBefore time.Time
+}
+
+func (r *fakeLoginAttemptRepo) DeleteOlderThan(before time.Time) error {
+	r.purgedBefore = before
+	return nil
+}
+
+func TestPurgeAttemptsKeepsIPWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention time.Duration
+		want      time.Duration
+	}{
+		{name: "retention longer than the window", retention: 720 * time.Hour, want: 720 * time.Hour},
+		{name: "retention shorter than the window", retention: time.Minute, want: 15 * time.Minute},
+		{name: "no retention", retention: 0, want: 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeLoginAttemptRepo{}
+			svc := &loginProtectionService{
+				cfg:              configs.LoginConfig{IPWindow: 15 * time.Minute, AttemptRetention: tt.retention},
+				loginAttemptRepo: repo,
+			}
+
+			before := time.Now()
+			if err := svc.PurgeAttempts(context.Background()); err != nil {
+				t.Fatalf("PurgeAttempts() error = %v", err)
+			}
+			after := time.Now()
+
+			if repo.purgedBefore.Before(before.Add(-tt.want)) || repo.purgedBefore.After(after.Add(-tt.want)) {
+				t.Errorf("purged before %s, want %s ago", repo.purgedBefore.Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
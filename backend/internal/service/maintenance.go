@@ -0,0 +1,539 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// MaintenanceService defines maintenance service interface
+type MaintenanceService interface {
+	CreatePlan(userID, vehicleID uint, req *dto.CreateMaintenancePlanRequest) (*dto.MaintenancePlanResponse, error)
+	GetPlans(userID, vehicleID uint) ([]dto.MaintenancePlanResponse, error)
+	UpdatePlan(userID, vehicleID, planID uint, req *dto.UpdateMaintenancePlanRequest) (*dto.MaintenancePlanResponse, error)
+	DeletePlan(userID, vehicleID, planID uint) error
+	GetVehicleStatus(userID, vehicleID uint) ([]dto.MaintenanceStatusResponse, error)
+	GetDueItems(userID uint) ([]dto.MaintenanceStatusResponse, error)
+	CreateServiceRecord(userID, vehicleID uint, req *dto.CreateServiceRecordRequest) (*dto.ServiceRecordResponse, error)
+	GetServiceRecords(userID, vehicleID uint, limit, offset int) ([]dto.ServiceRecordResponse, int64, error)
+	DeleteServiceRecord(userID, vehicleID, recordID uint) error
+	GetUsage(userID, vehicleID uint) (*dto.VehicleUsageResponse, error)
+	CheckDue(ctx context.Context) error
+}
+
+// maintenanceService implements MaintenanceService interface
+type maintenanceService struct {
+	cfg                 configs.MaintenanceConfig
+	planRepo            repository.MaintenancePlanRepository
+	recordRepo          repository.ServiceRecordRepository
+	vehicleRepo         repository.VehicleRepository
+	locationLogRepo     repository.LocationLogRepository
+	systemLogRepo       repository.SystemLogRepository
+	organizationService OrganizationService
+}
+
+// NewMaintenanceService creates new maintenance service instance
+func NewMaintenanceService(cfg configs.MaintenanceConfig, planRepo repository.MaintenancePlanRepository, recordRepo repository.ServiceRecordRepository, vehicleRepo repository.VehicleRepository, locationLogRepo repository.LocationLogRepository, systemLogRepo repository.SystemLogRepository, organizationService OrganizationService) MaintenanceService {
+	return &maintenanceService{
+		cfg:                 cfg,
+		planRepo:            planRepo,
+		recordRepo:          recordRepo,
+		vehicleRepo:         vehicleRepo,
+		locationLogRepo:     locationLogRepo,
+		systemLogRepo:       systemLogRepo,
+		organizationService: organizationService,
+	}
+}
+
+// CreatePlan creates a maintenance plan for a vehicle
+func (s *maintenanceService) CreatePlan(userID, vehicleID uint, req *dto.CreateMaintenancePlanRequest) (*dto.MaintenancePlanResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return nil, err
+	}
+
+	plan := &entity.MaintenancePlan{
+		VehicleID:           vehicleID,
+		Name:                req.Name,
+		IntervalKm:          req.IntervalKm,
+		IntervalEngineHours: req.IntervalEngineHours,
+		IntervalDays:        req.IntervalDays,
+		LastServiceAt:       time.Now(),
+	}
+	if req.Description != "" {
+		plan.Description = &req.Description
+	}
+	if req.LastServiceAt != nil {
+		if req.LastServiceAt.After(plan.LastServiceAt) {
+			return nil, errors.New("last service time cannot be in the future")
+		}
+		plan.LastServiceAt = *req.LastServiceAt
+	}
+
+	if err := validatePlanIntervals(plan); err != nil {
+		return nil, err
+	}
+
+	if err := s.planRepo.Create(plan); err != nil {
+		return nil, fmt.Errorf("failed to create maintenance plan: %w", err)
+	}
+
+	return s.planToResponse(plan), nil
+}
+
+// GetPlans gets maintenance plans of a vehicle
+func (s *maintenanceService) GetPlans(userID, vehicleID uint) ([]dto.MaintenancePlanResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
+	}
+
+	plans, err := s.planRepo.GetByVehicleID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance plans: %w", err)
+	}
+
+	responses := make([]dto.MaintenancePlanResponse, len(plans))
+	for i, plan := range plans {
+		responses[i] = *s.planToResponse(&plan)
+	}
+
+	return responses, nil
+}
+
+// UpdatePlan updates a maintenance plan. Changing it re-arms the due warning.
+func (s *maintenanceService) UpdatePlan(userID, vehicleID, planID uint, req *dto.UpdateMaintenancePlanRequest) (*dto.MaintenancePlanResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.getPlan(vehicleID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		plan.Name = req.Name
+	}
+	if req.Description != nil {
+		plan.Description = nil
+		if *req.Description != "" {
+			plan.Description = req.Description
+		}
+	}
+	if req.IntervalKm != nil {
+		plan.IntervalKm = nil
+		if *req.IntervalKm > 0 {
+			plan.IntervalKm = req.IntervalKm
+		}
+	}
+	if req.IntervalEngineHours != nil {
+		plan.IntervalEngineHours = nil
+		if *req.IntervalEngineHours > 0 {
+			plan.IntervalEngineHours = req.IntervalEngineHours
+		}
+	}
+	if req.IntervalDays != nil {
+		plan.IntervalDays = nil
+		if *req.IntervalDays > 0 {
+			plan.IntervalDays = req.IntervalDays
+		}
+	}
+	if req.LastServiceAt != nil {
+		if req.LastServiceAt.After(time.Now()) {
+			return nil, errors.New("last service time cannot be in the future")
+		}
+		plan.LastServiceAt = *req.LastServiceAt
+	}
+
+	if err := validatePlanIntervals(plan); err != nil {
+		return nil, err
+	}
+
+	plan.NotifiedAt = nil
+	if err := s.planRepo.Update(plan); err != nil {
+		return nil, fmt.Errorf("failed to update maintenance plan: %w", err)
+	}
+
+	return s.planToResponse(plan), nil
+}
+
+// DeletePlan deletes a maintenance plan. Its service records are kept.
+func (s *maintenanceService) DeletePlan(userID, vehicleID, planID uint) error {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return err
+	}
+
+	plan, err := s.getPlan(vehicleID, planID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.planRepo.Delete(plan.ID); err != nil {
+		return fmt.Errorf("failed to delete maintenance plan: %w", err)
+	}
+
+	return nil
+}
+
+// GetVehicleStatus gets how close each plan of a vehicle is to being due
+func (s *maintenanceService) GetVehicleStatus(userID, vehicleID uint) ([]dto.MaintenanceStatusResponse, error) {
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := s.planRepo.GetByVehicleID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance plans: %w", err)
+	}
+
+	now := time.Now()
+	responses := make([]dto.MaintenanceStatusResponse, 0, len(plans))
+	for _, plan := range plans {
+		plan.Vehicle = *vehicle
+		status, err := s.evaluate(&plan, now)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *status)
+	}
+	sortByUrgency(responses)
+
+	return responses, nil
+}
+
+// GetDueItems gets upcoming and overdue maintenance across every vehicle of the user's organizations
+func (s *maintenanceService) GetDueItems(userID uint) ([]dto.MaintenanceStatusResponse, error) {
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := s.planRepo.GetByOrganizationIDs(organizationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance plans: %w", err)
+	}
+
+	now := time.Now()
+	responses := make([]dto.MaintenanceStatusResponse, 0)
+	for _, plan := range plans {
+		status, err := s.evaluate(&plan, now)
+		if err != nil {
+			return nil, err
+		}
+		if status.Status != string(entity.MaintenanceStatusOK) {
+			responses = append(responses, *status)
+		}
+	}
+	sortByUrgency(responses)
+
+	return responses, nil
+}
+
+// CreateServiceRecord records a service. Completing a plan starts its next cycle.
+func (s *maintenanceService) CreateServiceRecord(userID, vehicleID uint, req *dto.CreateServiceRecordRequest) (*dto.ServiceRecordResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return nil, err
+	}
+
+	record := &entity.ServiceRecord{
+		VehicleID:       vehicleID,
+		PlanID:          req.PlanID,
+		CreatedByUserID: userID,
+		Description:     req.Description,
+		ServicedAt:      time.Now(),
+		OdometerKm:      req.OdometerKm,
+		EngineHours:     req.EngineHours,
+		Cost:            req.Cost,
+	}
+	if req.ServicedAt != nil {
+		if req.ServicedAt.After(record.ServicedAt) {
+			return nil, errors.New("service time cannot be in the future")
+		}
+		record.ServicedAt = *req.ServicedAt
+	}
+	if req.Workshop != "" {
+		record.Workshop = &req.Workshop
+	}
+	if req.Notes != "" {
+		record.Notes = &req.Notes
+	}
+
+	if req.PlanID != nil {
+		plan, err := s.getPlan(vehicleID, *req.PlanID)
+		if err != nil {
+			return nil, err
+		}
+		record.Plan = plan
+	}
+
+	// Fill readings the workshop did not provide from the location-derived odometer
+	if record.OdometerKm == nil || record.EngineHours == nil {
+		usage, err := s.locationLogRepo.GetUsage(vehicleID, time.Time{}, record.ServicedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vehicle usage: %w", err)
+		}
+		if record.OdometerKm == nil {
+			record.OdometerKm = &usage.DistanceKm
+		}
+		if record.EngineHours == nil {
+			record.EngineHours = &usage.EngineHours
+		}
+	}
+
+	if err := s.recordRepo.Create(record); err != nil {
+		return nil, fmt.Errorf("failed to create service record: %w", err)
+	}
+
+	return s.recordToResponse(record), nil
+}
+
+// GetServiceRecords gets service history of a vehicle with pagination info
+func (s *maintenanceService) GetServiceRecords(userID, vehicleID uint, limit, offset int) ([]dto.ServiceRecordResponse, int64, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, 0, err
+	}
+
+	records, total, err := s.recordRepo.GetByVehicleIDWithPagination(vehicleID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get service records: %w", err)
+	}
+
+	responses := make([]dto.ServiceRecordResponse, len(records))
+	for i, record := range records {
+		responses[i] = *s.recordToResponse(&record)
+	}
+
+	return responses, total, nil
+}
+
+// DeleteServiceRecord deletes a service record. The plan's current cycle is left unchanged.
+func (s *maintenanceService) DeleteServiceRecord(userID, vehicleID, recordID uint) error {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return err
+	}
+
+	record, err := s.recordRepo.GetByID(recordID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("service record not found")
+		}
+		return fmt.Errorf("failed to get service record: %w", err)
+	}
+	if record.VehicleID != vehicleID {
+		return errors.New("service record not found")
+	}
+
+	if err := s.recordRepo.Delete(record.ID); err != nil {
+		return fmt.Errorf("failed to delete service record: %w", err)
+	}
+
+	return nil
+}
+
+// GetUsage gets the location-derived odometer and engine hours of a vehicle
+func (s *maintenanceService) GetUsage(userID, vehicleID uint) (*dto.VehicleUsageResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
+	}
+
+	usage, err := s.locationLogRepo.GetUsage(vehicleID, time.Time{}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicle usage: %w", err)
+	}
+
+	return &dto.VehicleUsageResponse{
+		VehicleID:   vehicleID,
+		DistanceKm:  usage.DistanceKm,
+		EngineHours: usage.EngineHours,
+	}, nil
+}
+
+// CheckDue writes a WARNING system log, once per service cycle, for every plan that has become overdue
+func (s *maintenanceService) CheckDue(ctx context.Context) error {
+	plans, err := s.planRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to get maintenance plans: %w", err)
+	}
+
+	now := time.Now()
+	for _, plan := range plans {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Skip plans already reported and plans of deleted vehicles
+		if plan.NotifiedAt != nil || plan.Vehicle.ID == 0 {
+			continue
+		}
+
+		status, err := s.evaluate(&plan, now)
+		if err != nil {
+			log.Printf("Failed to evaluate maintenance plan %d: %v", plan.ID, err)
+			continue
+		}
+		if status.Status != string(entity.MaintenanceStatusOverdue) {
+			continue
+		}
+
+		vehicleID := plan.VehicleID
+		systemLog := &entity.SystemLog{
+			VehicleID: &vehicleID,
+			LogType:   entity.LogTypeWarning,
+			Message:   fmt.Sprintf("[MAINTENANCE] %s is due for vehicle %s (%s)", plan.Name, plan.Vehicle.PlateNumber, describeDue(status, now)),
+		}
+		if err := s.systemLogRepo.Create(systemLog); err != nil {
+			return fmt.Errorf("failed to write maintenance warning: %w", err)
+		}
+		if err := s.planRepo.MarkNotified(plan.ID, now); err != nil {
+			return fmt.Errorf("failed to mark maintenance plan notified: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// evaluate compares the vehicle's usage since the last service with the plan's intervals.
+// The most urgent trigger decides the status.
+func (s *maintenanceService) evaluate(plan *entity.MaintenancePlan, now time.Time) (*dto.MaintenanceStatusResponse, error) {
+	usage, err := s.locationLogRepo.GetUsage(plan.VehicleID, plan.LastServiceAt, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicle usage: %w", err)
+	}
+
+	status := entity.MaintenanceStatusOK
+	escalate := func(remaining, upcoming float64) {
+		switch {
+		case remaining <= 0:
+			status = entity.MaintenanceStatusOverdue
+		case remaining <= upcoming && status == entity.MaintenanceStatusOK:
+			status = entity.MaintenanceStatusUpcoming
+		}
+	}
+
+	response := &dto.MaintenanceStatusResponse{
+		PlanID:                  plan.ID,
+		VehicleID:               plan.VehicleID,
+		PlateNumber:             plan.Vehicle.PlateNumber,
+		Name:                    plan.Name,
+		LastServiceAt:           plan.LastServiceAt,
+		DistanceSinceServiceKm:  usage.DistanceKm,
+		EngineHoursSinceService: usage.EngineHours,
+		DueDate:                 plan.NextDueDate(),
+	}
+
+	if plan.IntervalKm != nil {
+		remaining := *plan.IntervalKm - usage.DistanceKm
+		response.RemainingKm = &remaining
+		escalate(remaining, s.cfg.UpcomingKm)
+	}
+	if plan.IntervalEngineHours != nil {
+		remaining := *plan.IntervalEngineHours - usage.EngineHours
+		response.RemainingEngineHours = &remaining
+		escalate(remaining, s.cfg.UpcomingEngineHours)
+	}
+	if response.DueDate != nil {
+		escalate(response.DueDate.Sub(now).Hours()/24, float64(s.cfg.UpcomingDays))
+	}
+
+	response.Status = string(status)
+	return response, nil
+}
+
+// getPlan gets a plan of the vehicle with not-found mapping
+func (s *maintenanceService) getPlan(vehicleID, planID uint) (*entity.MaintenancePlan, error) {
+	plan, err := s.planRepo.GetByID(planID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("maintenance plan not found")
+		}
+		return nil, fmt.Errorf("failed to get maintenance plan: %w", err)
+	}
+	if plan.VehicleID != vehicleID {
+		return nil, errors.New("maintenance plan not found")
+	}
+	return plan, nil
+}
+
+// planToResponse converts plan entity to response DTO
+func (s *maintenanceService) planToResponse(plan *entity.MaintenancePlan) *dto.MaintenancePlanResponse {
+	return &dto.MaintenancePlanResponse{
+		ID:                  plan.ID,
+		VehicleID:           plan.VehicleID,
+		Name:                plan.Name,
+		Description:         plan.Description,
+		IntervalKm:          plan.IntervalKm,
+		IntervalEngineHours: plan.IntervalEngineHours,
+		IntervalDays:        plan.IntervalDays,
+		LastServiceAt:       plan.LastServiceAt,
+		NextDueDate:         plan.NextDueDate(),
+		CreatedAt:           plan.CreatedAt,
+		UpdatedAt:           plan.UpdatedAt,
+	}
+}
+
+// recordToResponse converts service record entity to response DTO
+func (s *maintenanceService) recordToResponse(record *entity.ServiceRecord) *dto.ServiceRecordResponse {
+	response := &dto.ServiceRecordResponse{
+		ID:          record.ID,
+		VehicleID:   record.VehicleID,
+		PlanID:      record.PlanID,
+		Description: record.Description,
+		ServicedAt:  record.ServicedAt,
+		OdometerKm:  record.OdometerKm,
+		EngineHours: record.EngineHours,
+		Cost:        record.Cost,
+		Workshop:    record.Workshop,
+		Notes:       record.Notes,
+		CreatedAt:   record.CreatedAt,
+	}
+
+	if record.Plan != nil {
+		response.PlanName = &record.Plan.Name
+	}
+
+	return response
+}
+
+// validatePlanIntervals checks the plan has at least one trigger
+func validatePlanIntervals(plan *entity.MaintenancePlan) error {
+	if plan.IntervalKm == nil && plan.IntervalEngineHours == nil && plan.IntervalDays == nil {
+		return errors.New("at least one of interval_km, interval_engine_hours or interval_days is required")
+	}
+	return nil
+}
+
+// sortByUrgency orders statuses overdue first, then upcoming, then ok
+func sortByUrgency(statuses []dto.MaintenanceStatusResponse) {
+	rank := map[string]int{
+		string(entity.MaintenanceStatusOverdue):  0,
+		string(entity.MaintenanceStatusUpcoming): 1,
+		string(entity.MaintenanceStatusOK):       2,
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return rank[statuses[i].Status] < rank[statuses[j].Status]
+	})
+}
+
+// describeDue explains which triggers of an overdue plan have been exceeded
+func describeDue(status *dto.MaintenanceStatusResponse, now time.Time) string {
+	var reasons []string
+	if status.RemainingKm != nil && *status.RemainingKm <= 0 {
+		reasons = append(reasons, fmt.Sprintf("%.0f km since last service", status.DistanceSinceServiceKm))
+	}
+	if status.RemainingEngineHours != nil && *status.RemainingEngineHours <= 0 {
+		reasons = append(reasons, fmt.Sprintf("%.1f engine hours since last service", status.EngineHoursSinceService))
+	}
+	if status.DueDate != nil && !now.Before(*status.DueDate) {
+		reasons = append(reasons, "due on "+status.DueDate.Format("2006-01-02"))
+	}
+	return strings.Join(reasons, ", ")
+}
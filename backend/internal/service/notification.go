@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/pubsub"
+	"gorm.io/gorm"
+)
+
+// NotificationService defines notification service interface
+type NotificationService interface {
+	Send(notifications []entity.Notification) error
+	GetByUserID(userID uint, unreadOnly bool, limit, offset int) ([]dto.NotificationResponse, int64, error)
+	CountUnread(userID uint) (int64, error)
+	MarkRead(userID, notificationID uint) (*dto.NotificationResponse, error)
+	MarkAllRead(userID uint) (int64, error)
+	Subscribe(userID uint) (<-chan dto.NotificationEvent, func())
+}
+
+// notificationService implements NotificationService interface
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+	hub              *pubsub.Hub[dto.NotificationEvent]
+}
+
+// NewNotificationService creates new notification service instance.
+// Live events only reach streams connected to the same process.
+func NewNotificationService(notificationRepo repository.NotificationRepository) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		hub:              pubsub.NewHub[dto.NotificationEvent](),
+	}
+}
+
+// Send stores notifications and pushes them to their recipients' live streams
+func (s *notificationService) Send(notifications []entity.Notification) error {
+	if err := s.notificationRepo.CreateBatch(notifications); err != nil {
+		return fmt.Errorf("failed to create notifications: %w", err)
+	}
+
+	for i := range notifications {
+		notification := &notifications[i]
+		count, err := s.notificationRepo.CountUnread(notification.UserID)
+		if err != nil {
+			// Log error but don't fail the request
+			log.Printf("Failed to count unread notifications of user %d: %v", notification.UserID, err)
+			continue
+		}
+		s.hub.Publish(notification.UserID, dto.NotificationEvent{
+			Type:         dto.NotificationEventCreated,
+			Notification: s.entityToResponse(notification),
+			UnreadCount:  count,
+		})
+	}
+
+	return nil
+}
+
+// GetByUserID gets the user's notifications, newest first
+func (s *notificationService) GetByUserID(userID uint, unreadOnly bool, limit, offset int) ([]dto.NotificationResponse, int64, error) {
+	notifications, total, err := s.notificationRepo.GetByUserIDWithPagination(userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	responses := make([]dto.NotificationResponse, len(notifications))
+	for i, notification := range notifications {
+		responses[i] = *s.entityToResponse(&notification)
+	}
+	return responses, total, nil
+}
+
+// CountUnread counts the user's unread notifications
+func (s *notificationService) CountUnread(userID uint) (int64, error) {
+	count, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks one of the user's notifications read
+func (s *notificationService) MarkRead(userID, notificationID uint) (*dto.NotificationResponse, error) {
+	notification, err := s.notificationRepo.GetByID(notificationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("notification not found")
+		}
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+	if notification.UserID != userID {
+		return nil, errors.New("notification not found")
+	}
+
+	if !notification.IsRead() {
+		now := time.Now()
+		if err := s.notificationRepo.MarkRead(notification.ID, now); err != nil {
+			return nil, fmt.Errorf("failed to mark notification read: %w", err)
+		}
+		notification.ReadAt = &now
+		s.publishUnreadCount(userID)
+	}
+
+	return s.entityToResponse(notification), nil
+}
+
+// MarkAllRead marks every unread notification of the user read and returns how many changed
+func (s *notificationService) MarkAllRead(userID uint) (int64, error) {
+	updated, err := s.notificationRepo.MarkAllRead(userID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	if updated > 0 {
+		s.publishUnreadCount(userID)
+	}
+	return updated, nil
+}
+
+// Subscribe opens a live stream of the user's notification events. The returned function closes it.
+func (s *notificationService) Subscribe(userID uint) (<-chan dto.NotificationEvent, func()) {
+	return s.hub.Subscribe(userID)
+}
+
+// publishUnreadCount pushes the user's current unread count, so every open client updates its badge
+func (s *notificationService) publishUnreadCount(userID uint) {
+	count, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		log.Printf("Failed to count unread notifications of user %d: %v", userID, err)
+		return
+	}
+	s.hub.Publish(userID, dto.NotificationEvent{
+		Type:        dto.NotificationEventUnreadCount,
+		UnreadCount: count,
+	})
+}
+
+// entityToResponse converts entity to response DTO
+func (s *notificationService) entityToResponse(notification *entity.Notification) *dto.NotificationResponse {
+	response := &dto.NotificationResponse{
+		ID:           notification.ID,
+		VehicleID:    notification.VehicleID,
+		AlertEventID: notification.AlertEventID,
+		Severity:     string(notification.Severity),
+		Title:        notification.Title,
+		Message:      notification.Message,
+		Read:         notification.IsRead(),
+		ReadAt:       notification.ReadAt,
+		CreatedAt:    notification.CreatedAt,
+	}
+	if notification.Vehicle != nil {
+		response.PlateNumber = notification.Vehicle.PlateNumber
+	}
+	return response
+}
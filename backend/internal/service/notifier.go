@@ -0,0 +1,207 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/mailer"
+	"github.com/cartrack/backend/pkg/safehttp"
+)
+
+// AlertNotification is a triggered alert handed to the notification channels of its rule
+type AlertNotification struct {
+	Event   *entity.AlertEvent
+	Rule    *entity.AlertRule
+	Vehicle *entity.Vehicle
+}
+
+// Title returns a one-line summary of the alert
+func (n *AlertNotification) Title() string {
+	return fmt.Sprintf("[%s] %s: %s", strings.ToUpper(string(n.Event.Severity)), n.Rule.Name, n.Vehicle.PlateNumber)
+}
+
+// Notifier delivers triggered alerts through one channel
+type Notifier interface {
+	Channel() string
+	Notify(ctx context.Context, n *AlertNotification) error
+}
+
+// webhookNotifier posts alerts as JSON to the rule's webhook URL
+type webhookNotifier struct {
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a notifier that posts alerts to the rule's webhook URL. Like outgoing webhooks
+// it refuses internal addresses and does not follow redirects.
+func NewWebhookNotifier(timeout time.Duration) Notifier {
+	return &webhookNotifier{client: safehttp.NewClient(timeout)}
+}
+
+// webhookPayload is the JSON body posted to alert webhooks
+type webhookPayload struct {
+	Event       string    `json:"event"`
+	AlertID     uint      `json:"alert_id"`
+	RuleID      uint      `json:"rule_id"`
+	RuleName    string    `json:"rule_name"`
+	Type        string    `json:"type"`
+	Severity    string    `json:"severity"`
+	VehicleID   uint      `json:"vehicle_id"`
+	PlateNumber string    `json:"plate_number"`
+	Message     string    `json:"message"`
+	Value       *float64  `json:"value"`
+	TriggeredAt time.Time `json:"triggered_at"`
+}
+
+// Channel returns the channel name used in alert rules
+func (w *webhookNotifier) Channel() string {
+	return entity.ChannelWebhook
+}
+
+// Notify posts the alert and treats any non-2xx response as a failure
+func (w *webhookNotifier) Notify(ctx context.Context, n *AlertNotification) error {
+	if n.Rule.WebhookURL == nil || *n.Rule.WebhookURL == "" {
+		return errors.New("alert rule has no webhook URL")
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		Event:       "alert.triggered",
+		AlertID:     n.Event.ID,
+		RuleID:      n.Rule.ID,
+		RuleName:    n.Rule.Name,
+		Type:        string(n.Event.Type),
+		Severity:    string(n.Event.Severity),
+		VehicleID:   n.Vehicle.ID,
+		PlateNumber: n.Vehicle.PlateNumber,
+		Message:     n.Event.Message,
+		Value:       n.Event.Value,
+		TriggeredAt: n.Event.TriggeredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *n.Rule.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// emailNotifier mails alerts to the rule's recipients, or to the organization's owners and managers
+type emailNotifier struct {
+	mailer           mailer.Mailer
+	organizationRepo repository.OrganizationRepository
+}
+
+// NewEmailNotifier creates a notifier that sends alerts by email
+func NewEmailNotifier(mailer mailer.Mailer, organizationRepo repository.OrganizationRepository) Notifier {
+	return &emailNotifier{mailer: mailer, organizationRepo: organizationRepo}
+}
+
+// Channel returns the channel name used in alert rules
+func (e *emailNotifier) Channel() string {
+	return entity.ChannelEmail
+}
+
+// Notify sends the alert as a plain-text email
+func (e *emailNotifier) Notify(ctx context.Context, n *AlertNotification) error {
+	recipients := n.Rule.EmailList()
+	if len(recipients) == 0 {
+		users, err := alertRecipients(e.organizationRepo, n.Rule.OrganizationID)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			recipients = append(recipients, user.Email)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	body := fmt.Sprintf("%s\n\nRule: %s\nVehicle: %s\nSeverity: %s\nTriggered at: %s\n",
+		n.Event.Message, n.Rule.Name, n.Vehicle.PlateNumber, n.Event.Severity,
+		n.Event.TriggeredAt.Format(time.RFC1123))
+
+	return e.mailer.Send(mailer.Message{
+		To:      recipients,
+		Subject: n.Title(),
+		Body:    body,
+	})
+}
+
+// inAppNotifier sends alerts to the notification inbox of the organization's owners and managers
+type inAppNotifier struct {
+	notificationService NotificationService
+	organizationRepo    repository.OrganizationRepository
+}
+
+// NewInAppNotifier creates a notifier that sends alerts as in-app notifications
+func NewInAppNotifier(notificationService NotificationService, organizationRepo repository.OrganizationRepository) Notifier {
+	return &inAppNotifier{notificationService: notificationService, organizationRepo: organizationRepo}
+}
+
+// Channel returns the channel name used in alert rules
+func (a *inAppNotifier) Channel() string {
+	return entity.ChannelInApp
+}
+
+// Notify creates one notification per recipient
+func (a *inAppNotifier) Notify(ctx context.Context, n *AlertNotification) error {
+	users, err := alertRecipients(a.organizationRepo, n.Rule.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	vehicleID := n.Vehicle.ID
+	eventID := n.Event.ID
+	notifications := make([]entity.Notification, len(users))
+	for i, user := range users {
+		notifications[i] = entity.Notification{
+			UserID:       user.ID,
+			VehicleID:    &vehicleID,
+			AlertEventID: &eventID,
+			Severity:     n.Event.Severity,
+			Title:        n.Title(),
+			Message:      n.Event.Message,
+			Vehicle:      n.Vehicle,
+		}
+	}
+
+	return a.notificationService.Send(notifications)
+}
+
+// alertRecipients returns the members of an organization who manage its vehicles
+func alertRecipients(organizationRepo repository.OrganizationRepository, organizationID uint) ([]entity.User, error) {
+	members, err := organizationRepo.GetMembers(organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization members: %w", err)
+	}
+
+	var users []entity.User
+	for _, member := range members {
+		if member.Role.Can(entity.OrgActionManageVehicles) {
+			users = append(users, member.User)
+		}
+	}
+	return users, nil
+}
@@ -0,0 +1,566 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/mailer"
+	"gorm.io/gorm"
+)
+
+// organizationInvitationTTL is how long an invitation can be accepted
+const organizationInvitationTTL = 7 * 24 * time.Hour
+
+// ErrOrganizationForbidden is returned when a member's role does not allow an action
+var ErrOrganizationForbidden = errors.New("your organization role does not allow this action")
+
+// OrganizationService defines organization service interface
+type OrganizationService interface {
+	Create(userID uint, req *dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error)
+	GetMyOrganizations(userID uint) ([]dto.OrganizationResponse, error)
+	GetByID(userID, organizationID uint) (*dto.OrganizationResponse, error)
+	Update(userID, organizationID uint, req *dto.UpdateOrganizationRequest) (*dto.OrganizationResponse, error)
+	Delete(userID, organizationID uint) error
+	GetMembers(userID, organizationID uint) ([]dto.OrganizationMemberResponse, error)
+	InviteMember(userID, organizationID uint, req *dto.InviteOrganizationMemberRequest) (*dto.OrganizationInvitationResponse, error)
+	GetInvitations(userID, organizationID uint) ([]dto.OrganizationInvitationResponse, error)
+	RevokeInvitation(userID, organizationID, invitationID uint) error
+	GetMyInvitations(userID uint) ([]dto.OrganizationInvitationResponse, error)
+	AcceptInvitation(userID, invitationID uint) (*dto.OrganizationResponse, error)
+	DeclineInvitation(userID, invitationID uint) error
+	UpdateMember(userID, organizationID, memberUserID uint, req *dto.UpdateOrganizationMemberRequest) (*dto.OrganizationMemberResponse, error)
+	RemoveMember(userID, organizationID, memberUserID uint) error
+	Authorize(userID, organizationID uint, action entity.OrgAction) (*entity.OrganizationMember, error)
+	ResolveOrganizationID(userID uint, requested *uint, action entity.OrgAction) (uint, error)
+	GetOrganizationIDs(userID uint) ([]uint, error)
+	GetOrganizationIDsFor(userID uint, action entity.OrgAction) ([]uint, error)
+}
+
+// organizationService implements OrganizationService interface
+type organizationService struct {
+	organizationRepo repository.OrganizationRepository
+	userRepo         repository.UserRepository
+	mailer           mailer.Mailer
+	appURL           string
+}
+
+// NewOrganizationService creates new organization service instance
+func NewOrganizationService(cfg *configs.Config, organizationRepo repository.OrganizationRepository, userRepo repository.UserRepository, mailer mailer.Mailer) OrganizationService {
+	return &organizationService{
+		organizationRepo: organizationRepo,
+		userRepo:         userRepo,
+		mailer:           mailer,
+		appURL:           strings.TrimRight(cfg.AppURL, "/"),
+	}
+}
+
+// Create creates a new organization owned by the user
+func (s *organizationService) Create(userID uint, req *dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error) {
+	organization := &entity.Organization{Name: req.Name}
+	owner := &entity.OrganizationMember{UserID: userID, Role: entity.OrgRoleOwner}
+
+	if err := s.organizationRepo.Create(organization, owner); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return s.entityToResponse(organization, owner.Role), nil
+}
+
+// personalOrganization returns the default organization of a newly registered user
+func personalOrganization(user *entity.User) *entity.Organization {
+	return &entity.Organization{Name: user.Name + "'s Fleet"}
+}
+
+// GetMyOrganizations gets every organization the user belongs to
+func (s *organizationService) GetMyOrganizations(userID uint) ([]dto.OrganizationResponse, error) {
+	members, err := s.organizationRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+
+	responses := make([]dto.OrganizationResponse, 0, len(members))
+	for _, member := range members {
+		if member.Organization.ID == 0 {
+			continue
+		}
+		responses = append(responses, *s.entityToResponse(&member.Organization, member.Role))
+	}
+
+	return responses, nil
+}
+
+// GetByID gets organization by ID (members only)
+func (s *organizationService) GetByID(userID, organizationID uint) (*dto.OrganizationResponse, error) {
+	member, err := s.Authorize(userID, organizationID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.entityToResponse(&member.Organization, member.Role), nil
+}
+
+// Update updates organization details
+func (s *organizationService) Update(userID, organizationID uint, req *dto.UpdateOrganizationRequest) (*dto.OrganizationResponse, error) {
+	member, err := s.Authorize(userID, organizationID, entity.OrgActionManageOrganization)
+	if err != nil {
+		return nil, err
+	}
+
+	organization := &member.Organization
+	organization.Name = req.Name
+	if err := s.organizationRepo.Update(organization); err != nil {
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return s.entityToResponse(organization, member.Role), nil
+}
+
+// Delete deletes an organization that no longer has vehicles
+func (s *organizationService) Delete(userID, organizationID uint) error {
+	if _, err := s.Authorize(userID, organizationID, entity.OrgActionManageOrganization); err != nil {
+		return err
+	}
+
+	vehicles, err := s.organizationRepo.CountVehicles(organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to count vehicles: %w", err)
+	}
+	if vehicles > 0 {
+		return errors.New("organization still has vehicles, move or delete them first")
+	}
+
+	if err := s.organizationRepo.Delete(organizationID); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+
+	return nil
+}
+
+// GetMembers gets all members of an organization
+func (s *organizationService) GetMembers(userID, organizationID uint) ([]dto.OrganizationMemberResponse, error) {
+	if _, err := s.Authorize(userID, organizationID, entity.OrgActionRead); err != nil {
+		return nil, err
+	}
+
+	members, err := s.organizationRepo.GetMembers(organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+
+	responses := make([]dto.OrganizationMemberResponse, len(members))
+	for i, member := range members {
+		responses[i] = *s.memberToResponse(&member)
+	}
+
+	return responses, nil
+}
+
+// InviteMember invites the owner of an email address to join the organization.
+// The response is the same whether or not the email belongs to an account, and nobody becomes a member
+// until they accept the invitation themselves.
+func (s *organizationService) InviteMember(userID, organizationID uint, req *dto.InviteOrganizationMemberRequest) (*dto.OrganizationInvitationResponse, error) {
+	actor, err := s.Authorize(userID, organizationID, entity.OrgActionManageMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	role := entity.OrganizationRole(req.Role)
+	if role == entity.OrgRoleOwner && actor.Role != entity.OrgRoleOwner {
+		return nil, errors.New("only owners can invite other owners")
+	}
+
+	invitation := &entity.OrganizationInvitation{
+		OrganizationID: organizationID,
+		Email:          strings.ToLower(strings.TrimSpace(req.Email)),
+		Role:           role,
+		InvitedByID:    &userID,
+		ExpiresAt:      time.Now().Add(organizationInvitationTTL),
+	}
+	if err := s.organizationRepo.SaveInvitation(invitation); err != nil {
+		return nil, fmt.Errorf("failed to save invitation: %w", err)
+	}
+
+	// Log error but don't fail the request; the invitation is listed for the invitee either way
+	if err := s.sendInvitation(&actor.Organization, invitation); err != nil {
+		log.Printf("Failed to send invitation %d: %v", invitation.ID, err)
+	}
+
+	return s.invitationToResponse(invitation), nil
+}
+
+// GetInvitations gets the pending invitations of an organization
+func (s *organizationService) GetInvitations(userID, organizationID uint) ([]dto.OrganizationInvitationResponse, error) {
+	if _, err := s.Authorize(userID, organizationID, entity.OrgActionManageMembers); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.organizationRepo.GetPendingInvitations(organizationID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+
+	responses := make([]dto.OrganizationInvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		responses[i] = *s.invitationToResponse(&invitation)
+	}
+
+	return responses, nil
+}
+
+// RevokeInvitation withdraws an invitation of the organization
+func (s *organizationService) RevokeInvitation(userID, organizationID, invitationID uint) error {
+	if _, err := s.Authorize(userID, organizationID, entity.OrgActionManageMembers); err != nil {
+		return err
+	}
+
+	invitation, err := s.organizationRepo.GetInvitation(invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invitation not found")
+		}
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if invitation.OrganizationID != organizationID {
+		return errors.New("invitation not found")
+	}
+
+	if err := s.organizationRepo.DeleteInvitation(invitation.ID); err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+
+	return nil
+}
+
+// GetMyInvitations gets the pending invitations sent to the user's email address
+func (s *organizationService) GetMyInvitations(userID uint) ([]dto.OrganizationInvitationResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	invitations, err := s.organizationRepo.GetPendingInvitationsByEmail(strings.ToLower(user.Email), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+
+	responses := make([]dto.OrganizationInvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		if invitation.Organization.ID == 0 {
+			continue
+		}
+		responses = append(responses, *s.invitationToResponse(&invitation))
+	}
+
+	return responses, nil
+}
+
+// AcceptInvitation makes the user a member of the inviting organization
+func (s *organizationService) AcceptInvitation(userID, invitationID uint) (*dto.OrganizationResponse, error) {
+	invitation, err := s.getMyInvitation(userID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.organizationRepo.GetMember(invitation.OrganizationID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+	if existing != nil {
+		return nil, errors.New("you are already a member of this organization")
+	}
+
+	member := &entity.OrganizationMember{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+		Role:           invitation.Role,
+	}
+	if err := s.organizationRepo.AcceptInvitation(invitation, member); err != nil {
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	return s.entityToResponse(&invitation.Organization, member.Role), nil
+}
+
+// DeclineInvitation deletes an invitation sent to the user
+func (s *organizationService) DeclineInvitation(userID, invitationID uint) error {
+	invitation, err := s.getMyInvitation(userID, invitationID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.organizationRepo.DeleteInvitation(invitation.ID); err != nil {
+		return fmt.Errorf("failed to decline invitation: %w", err)
+	}
+
+	return nil
+}
+
+// getMyInvitation gets a pending invitation addressed to the user's email.
+// Invitations for other addresses are reported as not found.
+func (s *organizationService) getMyInvitation(userID, invitationID uint) (*entity.OrganizationInvitation, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	invitation, err := s.organizationRepo.GetInvitation(invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invitation not found")
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	if !strings.EqualFold(invitation.Email, user.Email) || invitation.Organization.ID == 0 {
+		return nil, errors.New("invitation not found")
+	}
+	if !invitation.IsPending(time.Now()) {
+		return nil, errors.New("invitation has expired")
+	}
+
+	return invitation, nil
+}
+
+// sendInvitation emails the invitee where to accept the invitation
+func (s *organizationService) sendInvitation(organization *entity.Organization, invitation *entity.OrganizationInvitation) error {
+	msg := mailer.Message{
+		To:      []string{invitation.Email},
+		Subject: fmt.Sprintf("You have been invited to %s on Cartrack", organization.Name),
+		Body: fmt.Sprintf("Hi,\n\nYou have been invited to join %s on Cartrack as %s. Sign in, or create an account with this email address, and open the link below to accept:\n\n%s/invitations\n\nThe invitation expires in %d days. If you were not expecting it, you can ignore this email.",
+			organization.Name, invitation.Role, s.appURL, int(organizationInvitationTTL.Hours()/24)),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		return fmt.Errorf("failed to send invitation email: %w", err)
+	}
+	return nil
+}
+
+// UpdateMember changes a member's role
+func (s *organizationService) UpdateMember(userID, organizationID, memberUserID uint, req *dto.UpdateOrganizationMemberRequest) (*dto.OrganizationMemberResponse, error) {
+	actor, err := s.Authorize(userID, organizationID, entity.OrgActionManageMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.organizationRepo.GetMember(organizationID, memberUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("member not found")
+		}
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	role := entity.OrganizationRole(req.Role)
+	if (role == entity.OrgRoleOwner || member.Role == entity.OrgRoleOwner) && actor.Role != entity.OrgRoleOwner {
+		return nil, errors.New("only owners can grant or change the owner role")
+	}
+	if member.Role == entity.OrgRoleOwner && role != entity.OrgRoleOwner {
+		if err := s.ensureAnotherOwner(organizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	member.Role = role
+	if err := s.organizationRepo.UpdateMember(member); err != nil {
+		return nil, fmt.Errorf("failed to update member: %w", err)
+	}
+
+	return s.memberToResponse(member), nil
+}
+
+// RemoveMember removes a member; any member may remove themselves
+func (s *organizationService) RemoveMember(userID, organizationID, memberUserID uint) error {
+	action := entity.OrgActionManageMembers
+	if userID == memberUserID {
+		action = entity.OrgActionRead
+	}
+
+	actor, err := s.Authorize(userID, organizationID, action)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.organizationRepo.GetMember(organizationID, memberUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("member not found")
+		}
+		return fmt.Errorf("failed to get member: %w", err)
+	}
+
+	if member.Role == entity.OrgRoleOwner {
+		if actor.Role != entity.OrgRoleOwner {
+			return errors.New("only owners can remove an owner")
+		}
+		if err := s.ensureAnotherOwner(organizationID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.organizationRepo.RemoveMember(organizationID, memberUserID); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	return nil
+}
+
+// Authorize checks that the user is a member of the organization and their role allows the action.
+// Non-members get "organization not found" so other organizations' existence is not revealed.
+func (s *organizationService) Authorize(userID, organizationID uint, action entity.OrgAction) (*entity.OrganizationMember, error) {
+	member, err := s.organizationRepo.GetMember(organizationID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("organization not found")
+		}
+		return nil, fmt.Errorf("failed to get membership: %w", err)
+	}
+
+	if member.Organization.ID == 0 {
+		return nil, errors.New("organization not found")
+	}
+
+	if !member.Role.Can(action) {
+		return nil, ErrOrganizationForbidden
+	}
+
+	return member, nil
+}
+
+// ResolveOrganizationID returns the requested organization after authorization,
+// or the user's oldest organization that allows the action when none is requested
+func (s *organizationService) ResolveOrganizationID(userID uint, requested *uint, action entity.OrgAction) (uint, error) {
+	if requested != nil && *requested != 0 {
+		if _, err := s.Authorize(userID, *requested, action); err != nil {
+			return 0, err
+		}
+		return *requested, nil
+	}
+
+	members, err := s.organizationRepo.GetByUserID(userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get organizations: %w", err)
+	}
+
+	for _, member := range members {
+		if member.Organization.ID != 0 && member.Role.Can(action) {
+			return member.OrganizationID, nil
+		}
+	}
+
+	return 0, errors.New("you are not a member of any organization that allows this action")
+}
+
+// GetOrganizationIDs gets IDs of every organization the user belongs to
+func (s *organizationService) GetOrganizationIDs(userID uint) ([]uint, error) {
+	ids, err := s.organizationRepo.GetIDsByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+	return ids, nil
+}
+
+// GetOrganizationIDsFor gets IDs of organizations where the user's role allows the action
+func (s *organizationService) GetOrganizationIDsFor(userID uint, action entity.OrgAction) ([]uint, error) {
+	members, err := s.organizationRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+
+	ids := make([]uint, 0, len(members))
+	for _, member := range members {
+		if member.Organization.ID != 0 && member.Role.Can(action) {
+			ids = append(ids, member.OrganizationID)
+		}
+	}
+
+	return ids, nil
+}
+
+// ensureAnotherOwner prevents an organization from losing its last owner
+func (s *organizationService) ensureAnotherOwner(organizationID uint) error {
+	owners, err := s.organizationRepo.CountByRole(organizationID, entity.OrgRoleOwner)
+	if err != nil {
+		return fmt.Errorf("failed to count owners: %w", err)
+	}
+	if owners <= 1 {
+		return errors.New("organization must keep at least one owner")
+	}
+	return nil
+}
+
+// entityToResponse converts organization entity to response DTO
+func (s *organizationService) entityToResponse(organization *entity.Organization, role entity.OrganizationRole) *dto.OrganizationResponse {
+	return &dto.OrganizationResponse{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		MyRole:    string(role),
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+	}
+}
+
+// memberToResponse converts organization member entity to response DTO
+func (s *organizationService) memberToResponse(member *entity.OrganizationMember) *dto.OrganizationMemberResponse {
+	response := &dto.OrganizationMemberResponse{
+		ID:             member.ID,
+		OrganizationID: member.OrganizationID,
+		UserID:         member.UserID,
+		Role:           string(member.Role),
+		CreatedAt:      member.CreatedAt,
+	}
+
+	if member.User.ID != 0 {
+		response.User = &dto.UserResponse{
+			ID:          member.User.ID,
+			Name:        member.User.Name,
+			Email:       member.User.Email,
+			PhoneNumber: member.User.PhoneNumber,
+			Role:        member.User.Role,
+			CreatedAt:   member.User.CreatedAt,
+			UpdatedAt:   member.User.UpdatedAt,
+		}
+	}
+
+	return response
+}
+
+// invitationToResponse converts organization invitation entity to response DTO
+func (s *organizationService) invitationToResponse(invitation *entity.OrganizationInvitation) *dto.OrganizationInvitationResponse {
+	return &dto.OrganizationInvitationResponse{
+		ID:               invitation.ID,
+		OrganizationID:   invitation.OrganizationID,
+		OrganizationName: invitation.Organization.Name,
+		Email:            invitation.Email,
+		Role:             string(invitation.Role),
+		ExpiresAt:        invitation.ExpiresAt,
+		CreatedAt:        invitation.CreatedAt,
+	}
+}
+
+// authorizeVehicle loads a vehicle and checks the user's role in the vehicle's organization allows the action.
+// Vehicles outside the user's organizations are reported as not found.
+func authorizeVehicle(vehicleRepo repository.VehicleRepository, organizations OrganizationService, userID, vehicleID uint, action entity.OrgAction) (*entity.Vehicle, error) {
+	vehicle, err := vehicleRepo.GetByID(vehicleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("vehicle not found")
+		}
+		return nil, fmt.Errorf("failed to get vehicle: %w", err)
+	}
+
+	if _, err := organizations.Authorize(userID, vehicle.OrganizationID, action); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("vehicle not found")
+	}
+
+	return vehicle, nil
+}
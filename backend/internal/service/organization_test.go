@@ -0,0 +1,149 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// fakeOrganizationRepo holds the members and invitations of a single organization
+type fakeOrganizationRepo struct {
+	repository.OrganizationRepository
+	organization entity.Organization
+	members      map[uint]entity.OrganizationRole
+	invitations  map[uint]*entity.OrganizationInvitation
+	nextID       uint
+}
+
+func newFakeOrganizationRepo(members map[uint]entity.OrganizationRole) *fakeOrganizationRepo {
+	return &fakeOrganizationRepo{
+		organization: entity.Organization{ID: 1, Name: "Fleet"},
+		members:      members,
+		invitations:  make(map[uint]*entity.OrganizationInvitation),
+	}
+}
+
+func (r *fakeOrganizationRepo) GetMember(organizationID, userID uint) (*entity.OrganizationMember, error) {
+	role, ok := r.members[userID]
+	if !ok || organizationID != r.organization.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &entity.OrganizationMember{OrganizationID: organizationID, UserID: userID, Role: role, Organization: r.organization}, nil
+}
+
+func (r *fakeOrganizationRepo) SaveInvitation(invitation *entity.OrganizationInvitation) error {
+	for id, existing := range r.invitations {
+		if existing.Email == invitation.Email {
+			delete(r.invitations, id)
+		}
+	}
+	r.nextID++
+	invitation.ID = r.nextID
+	saved := *invitation
+	r.invitations[saved.ID] = &saved
+	return nil
+}
+
+func (r *fakeOrganizationRepo) GetInvitation(id uint) (*entity.OrganizationInvitation, error) {
+	invitation, ok := r.invitations[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *invitation
+	found.Organization = r.organization
+	return &found, nil
+}
+
+func (r *fakeOrganizationRepo) AcceptInvitation(invitation *entity.OrganizationInvitation, member *entity.OrganizationMember) error {
+	r.members[member.UserID] = member.Role
+	delete(r.invitations, invitation.ID)
+	return nil
+}
+
+func newTestOrganizationService() (*organizationService, *fakeOrganizationRepo, *fakeMailer) {
+	repo := newFakeOrganizationRepo(map[uint]entity.OrganizationRole{1: entity.OrgRoleOwner})
+	users := newFakeUserRepo(
+		&entity.User{ID: 1, Email: "owner@example.com"},
+		&entity.User{ID: 2, Email: "Dina@Example.com"},
+		&entity.User{ID: 3, Email: "other@example.com"},
+	)
+	mail := &fakeMailer{}
+	return &organizationService{organizationRepo: repo, userRepo: users, mailer: mail}, repo, mail
+}
+
+func TestInviteMemberDoesNotRevealAccounts(t *testing.T) {
+	svc, repo, mail := newTestOrganizationService()
+
+	registered, err := svc.InviteMember(1, 1, &dto.InviteOrganizationMemberRequest{Email: "dina@example.com", Role: "manager"})
+	if err != nil {
+		t.Fatalf("InviteMember(registered) error = %v", err)
+	}
+	unknown, err := svc.InviteMember(1, 1, &dto.InviteOrganizationMemberRequest{Email: "nobody@example.com", Role: "manager"})
+	if err != nil {
+		t.Fatalf("InviteMember(unknown) error = %v", err)
+	}
+
+	// Apart from the values that identify the invitation itself, both responses look the same
+	registered.ID, unknown.ID = 0, 0
+	registered.Email, unknown.Email = "", ""
+	registered.ExpiresAt, unknown.ExpiresAt = time.Time{}, time.Time{}
+	if !reflect.DeepEqual(registered, unknown) {
+		t.Errorf("responses differ for a registered and an unknown email:\n%+v\n%+v", registered, unknown)
+	}
+	if len(mail.sent) != 2 {
+		t.Errorf("sent %d invitation emails, want 2", len(mail.sent))
+	}
+	if _, ok := repo.members[2]; ok {
+		t.Errorf("invited user became a member before accepting")
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	svc, repo, _ := newTestOrganizationService()
+
+	invitation, err := svc.InviteMember(1, 1, &dto.InviteOrganizationMemberRequest{Email: "dina@example.com", Role: "viewer"})
+	if err != nil {
+		t.Fatalf("InviteMember() error = %v", err)
+	}
+
+	if _, err := svc.AcceptInvitation(3, invitation.ID); err == nil || err.Error() != "invitation not found" {
+		t.Errorf("AcceptInvitation() by another user error = %v, want invitation not found", err)
+	}
+
+	organization, err := svc.AcceptInvitation(2, invitation.ID)
+	if err != nil {
+		t.Fatalf("AcceptInvitation() error = %v", err)
+	}
+	if organization.ID != 1 || organization.MyRole != "viewer" {
+		t.Errorf("AcceptInvitation() = %+v, want organization 1 as viewer", organization)
+	}
+	if repo.members[2] != entity.OrgRoleViewer {
+		t.Errorf("member role = %q, want viewer", repo.members[2])
+	}
+	if _, err := svc.AcceptInvitation(2, invitation.ID); err == nil {
+		t.Errorf("AcceptInvitation() twice error = nil, want invitation not found")
+	}
+}
+
+func TestAcceptExpiredInvitation(t *testing.T) {
+	svc, repo, _ := newTestOrganizationService()
+	repo.invitations[7] = &entity.OrganizationInvitation{
+		ID:             7,
+		OrganizationID: 1,
+		Email:          "dina@example.com",
+		Role:           entity.OrgRoleViewer,
+		ExpiresAt:      time.Now().Add(-time.Minute),
+	}
+
+	if _, err := svc.AcceptInvitation(2, 7); err == nil || err.Error() != "invitation has expired" {
+		t.Errorf("AcceptInvitation() error = %v, want invitation has expired", err)
+	}
+	if _, ok := repo.members[2]; ok {
+		t.Errorf("expired invitation created a membership")
+	}
+}
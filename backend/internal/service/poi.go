@@ -0,0 +1,590 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/geo"
+	"gorm.io/gorm"
+)
+
+// Place types a position can be matched to
+const (
+	PlacePOI      = "poi"
+	PlaceGeofence = "geofence"
+)
+
+// poiImportColumns lists the accepted CSV header names of each POI field
+var poiImportColumns = map[string][]string{
+	"name":      {"name"},
+	"latitude":  {"latitude", "lat"},
+	"longitude": {"longitude", "lng", "lon"},
+	"radius":    {"radius_m", "radius"},
+	"category":  {"category"},
+	"address":   {"address"},
+}
+
+// POIService defines point of interest service interface
+type POIService interface {
+	Create(userID uint, req *dto.CreatePOIRequest) (*dto.POIResponse, error)
+	GetMyPOIs(userID uint, category string, limit, offset int) ([]dto.POIResponse, int64, error)
+	GetByID(userID, poiID uint) (*dto.POIResponse, error)
+	Update(userID, poiID uint, req *dto.UpdatePOIRequest) (*dto.POIResponse, error)
+	Delete(userID, poiID uint) error
+	Import(userID uint, req *dto.ImportPOIsRequest, content io.Reader) (*dto.POIImportResponse, error)
+	GetNearestVehicles(userID, poiID uint, radius float64, limit int) ([]dto.NearbyVehicleResponse, error)
+	GetNearbyPOIs(userID, vehicleID uint, radius float64, limit int) ([]dto.NearbyPOIResponse, error)
+	ResolvePlaces(vehicle *entity.Vehicle, positions []geo.Point) ([]*dto.StopPlaceResponse, error)
+}
+
+// poiService implements POIService interface
+type poiService struct {
+	cfg                 configs.POIsConfig
+	poiRepo             repository.POIRepository
+	vehicleRepo         repository.VehicleRepository
+	locationLogRepo     repository.LocationLogRepository
+	alertRuleRepo       repository.AlertRuleRepository
+	organizationService OrganizationService
+}
+
+// NewPOIService creates new point of interest service instance
+func NewPOIService(cfg configs.POIsConfig, poiRepo repository.POIRepository, vehicleRepo repository.VehicleRepository, locationLogRepo repository.LocationLogRepository, alertRuleRepo repository.AlertRuleRepository, organizationService OrganizationService) POIService {
+	return &poiService{
+		cfg:                 cfg,
+		poiRepo:             poiRepo,
+		vehicleRepo:         vehicleRepo,
+		locationLogRepo:     locationLogRepo,
+		alertRuleRepo:       alertRuleRepo,
+		organizationService: organizationService,
+	}
+}
+
+// Create creates a new point of interest in one of the user's organizations
+func (s *poiService) Create(userID uint, req *dto.CreatePOIRequest) (*dto.POIResponse, error) {
+	organizationID, err := s.organizationService.ResolveOrganizationID(userID, req.OrganizationID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	poi := &entity.POI{
+		OrganizationID: organizationID,
+		Name:           strings.TrimSpace(req.Name),
+		Latitude:       *req.Latitude,
+		Longitude:      *req.Longitude,
+		RadiusM:        s.cfg.DefaultRadius,
+		Category:       optionalString(req.Category),
+		Address:        optionalString(req.Address),
+	}
+	if req.RadiusM != nil {
+		poi.RadiusM = *req.RadiusM
+	}
+	if err := s.validate(poi); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureNameAvailable(organizationID, poi.Name, 0); err != nil {
+		return nil, err
+	}
+
+	if err := s.poiRepo.Create(poi); err != nil {
+		return nil, fmt.Errorf("failed to create POI: %w", err)
+	}
+
+	return s.entityToResponse(poi), nil
+}
+
+// GetMyPOIs gets points of interest of every organization the user belongs to with pagination info
+func (s *poiService) GetMyPOIs(userID uint, category string, limit, offset int) ([]dto.POIResponse, int64, error) {
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pois, total, err := s.poiRepo.GetByOrganizationIDs(organizationIDs, category, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get POIs: %w", err)
+	}
+
+	responses := make([]dto.POIResponse, len(pois))
+	for i, poi := range pois {
+		responses[i] = *s.entityToResponse(&poi)
+	}
+
+	return responses, total, nil
+}
+
+// GetByID gets point of interest by ID
+func (s *poiService) GetByID(userID, poiID uint) (*dto.POIResponse, error) {
+	poi, err := s.authorizePOI(userID, poiID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.entityToResponse(poi), nil
+}
+
+// Update updates point of interest data
+func (s *poiService) Update(userID, poiID uint, req *dto.UpdatePOIRequest) (*dto.POIResponse, error) {
+	poi, err := s.authorizePOI(userID, poiID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" && name != poi.Name {
+		if err := s.ensureNameAvailable(poi.OrganizationID, name, poi.ID); err != nil {
+			return nil, err
+		}
+		poi.Name = name
+	}
+	if req.Category != nil {
+		poi.Category = optionalString(*req.Category)
+	}
+	if req.Address != nil {
+		poi.Address = optionalString(*req.Address)
+	}
+	if req.Latitude != nil {
+		poi.Latitude = *req.Latitude
+	}
+	if req.Longitude != nil {
+		poi.Longitude = *req.Longitude
+	}
+	if req.RadiusM != nil {
+		poi.RadiusM = *req.RadiusM
+	}
+	if err := s.validate(poi); err != nil {
+		return nil, err
+	}
+
+	if err := s.poiRepo.Update(poi); err != nil {
+		return nil, fmt.Errorf("failed to update POI: %w", err)
+	}
+
+	return s.entityToResponse(poi), nil
+}
+
+// Delete deletes a point of interest
+func (s *poiService) Delete(userID, poiID uint) error {
+	poi, err := s.authorizePOI(userID, poiID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return err
+	}
+
+	if err := s.poiRepo.Delete(poi.ID); err != nil {
+		return fmt.Errorf("failed to delete POI: %w", err)
+	}
+
+	return nil
+}
+
+// Import creates or updates points of interest from a CSV file with a header row. Name, latitude and longitude
+// columns are required; radius_m, category and address are optional. A row naming an existing POI of the
+// organization updates it. Invalid rows are reported and skipped.
+func (s *poiService) Import(userID uint, req *dto.ImportPOIsRequest, content io.Reader) (*dto.POIImportResponse, error) {
+	organizationID, err := s.organizationService.ResolveOrganizationID(userID, req.OrganizationID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(content)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(poiImportColumns))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for field, aliases := range poiImportColumns {
+			for _, alias := range aliases {
+				if _, taken := columns[field]; name == alias && !taken {
+					columns[field] = i
+				}
+			}
+		}
+	}
+	for _, required := range []string{"name", "latitude", "longitude"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header has no %q column", required)
+		}
+	}
+
+	// The whole file is checked before anything is saved, so an oversized file changes nothing
+	result := &dto.POIImportResponse{Errors: []dto.ImportRowError{}}
+	var pois []*entity.POI
+	for rows := 1; ; rows++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if rows > s.cfg.MaxImportRows {
+			return nil, fmt.Errorf("file has more than %d rows", s.cfg.MaxImportRows)
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("failed to read CSV: %w", err)
+			}
+			result.Errors = append(result.Errors, dto.ImportRowError{Row: parseErr.Line, Message: parseErr.Err.Error()})
+			continue
+		}
+
+		row, _ := reader.FieldPos(0)
+		poi, message := s.parseImportRow(organizationID, record, columns)
+		if message != "" {
+			result.Errors = append(result.Errors, dto.ImportRowError{Row: row, Message: message})
+			continue
+		}
+		pois = append(pois, poi)
+	}
+
+	for _, poi := range pois {
+		created, err := s.upsert(poi)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+// GetNearestVehicles gets the vehicles of a POI's organization ranked by the distance of their latest trusted
+// position from it, only those within radius meters when radius is positive. Vehicles that never reported a
+// position are left out.
+func (s *poiService) GetNearestVehicles(userID, poiID uint, radius float64, limit int) ([]dto.NearbyVehicleResponse, error) {
+	poi, err := s.authorizePOI(userID, poiID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles, err := s.vehicleRepo.GetByOrganizationIDs([]uint{poi.OrganizationID}, -1, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicles: %w", err)
+	}
+
+	vehicleIDs := make([]uint, len(vehicles))
+	for i, vehicle := range vehicles {
+		vehicleIDs[i] = vehicle.ID
+	}
+
+	center := geo.Point{Latitude: poi.Latitude, Longitude: poi.Longitude}
+	var locations []entity.LocationLog
+	if radius > 0 {
+		locations, err = s.locationLogRepo.GetLatestTrustedWithin(vehicleIDs, geo.Circle{Center: center, Radius: radius})
+	} else {
+		locations, err = s.locationLogRepo.GetLatestTrustedByVehicleIDs(vehicleIDs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest locations: %w", err)
+	}
+
+	responses := nearbyVehicles(vehicles, locations, center)
+	if len(responses) > limit {
+		responses = responses[:limit]
+	}
+
+	return responses, nil
+}
+
+// GetNearbyPOIs gets the POIs of a vehicle's organization within radius meters of its latest trusted position,
+// nearest first
+func (s *poiService) GetNearbyPOIs(userID, vehicleID uint, radius float64, limit int) ([]dto.NearbyPOIResponse, error) {
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := s.locationLogRepo.GetLatestTrustedByVehicleID(vehicle.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no location data found")
+		}
+		return nil, fmt.Errorf("failed to get latest location: %w", err)
+	}
+
+	center := geo.Point{Latitude: location.Latitude, Longitude: location.Longitude}
+	pois, err := s.poiRepo.GetWithin([]uint{vehicle.OrganizationID}, geo.Circle{Center: center, Radius: radius})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get POIs: %w", err)
+	}
+
+	responses := make([]dto.NearbyPOIResponse, 0, len(pois))
+	for _, poi := range pois {
+		distance := geo.DistanceMeters(location.Latitude, location.Longitude, poi.Latitude, poi.Longitude)
+		responses = append(responses, dto.NearbyPOIResponse{
+			POIResponse:    *s.entityToResponse(&poi),
+			DistanceMeters: distance,
+			Inside:         distance <= poi.RadiusM,
+		})
+	}
+
+	sort.SliceStable(responses, func(i, j int) bool {
+		return responses[i].DistanceMeters < responses[j].DistanceMeters
+	})
+	if len(responses) > limit {
+		responses = responses[:limit]
+	}
+
+	return responses, nil
+}
+
+// ResolvePlaces matches each position to the POI of the vehicle's organization it lies in, or failing that to
+// the geofence of one of the vehicle's geofence_exit alert rules. The nearest center wins when several contain
+// a position. Unmatched positions get nil.
+func (s *poiService) ResolvePlaces(vehicle *entity.Vehicle, positions []geo.Point) ([]*dto.StopPlaceResponse, error) {
+	places := make([]*dto.StopPlaceResponse, len(positions))
+	if len(positions) == 0 {
+		return places, nil
+	}
+
+	pois, err := s.poisAround(vehicle.OrganizationID, positions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get POIs: %w", err)
+	}
+
+	geofences, err := s.alertRuleRepo.GetEnabledForVehicle(vehicle, entity.AlertTypeGeofenceExit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get geofences: %w", err)
+	}
+
+	for i, position := range positions {
+		places[i] = matchPOI(pois, position)
+		if places[i] == nil {
+			places[i] = matchGeofence(geofences, position)
+		}
+	}
+	return places, nil
+}
+
+// poisAround loads the POIs of an organization close enough to contain any of the positions. No POI is larger
+// than the configured maximum radius, so only the positions' bounding box widened by it is searched.
+func (s *poiService) poisAround(organizationID uint, positions []geo.Point) ([]entity.POI, error) {
+	minLat, maxLat := positions[0].Latitude, positions[0].Latitude
+	minLng, maxLng := positions[0].Longitude, positions[0].Longitude
+	for _, position := range positions[1:] {
+		minLat, maxLat = min(minLat, position.Latitude), max(maxLat, position.Latitude)
+		minLng, maxLng = min(minLng, position.Longitude), max(maxLng, position.Longitude)
+	}
+
+	// A degree of longitude shrinks away from the equator, so widen by the amount at the latitude furthest from it
+	south, _, westAtSouth, _ := geo.BoundingBox(minLat, minLng, s.cfg.MaxRadius)
+	_, north, westAtNorth, _ := geo.BoundingBox(maxLat, minLng, s.cfg.MaxRadius)
+	widen := minLng - min(westAtSouth, westAtNorth)
+
+	return s.poiRepo.GetWithin([]uint{organizationID}, geo.Bounds{MinLat: south, MaxLat: north, MinLng: minLng - widen, MaxLng: maxLng + widen})
+}
+
+// parseImportRow converts a CSV row to a POI, or returns why the row is invalid
+func (s *poiService) parseImportRow(organizationID uint, record []string, columns map[string]int) (*entity.POI, string) {
+	value := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	poi := &entity.POI{
+		OrganizationID: organizationID,
+		Name:           value("name"),
+		RadiusM:        s.cfg.DefaultRadius,
+		Category:       optionalString(value("category")),
+		Address:        optionalString(value("address")),
+	}
+	if poi.Name == "" {
+		return nil, "name is required"
+	}
+	if len(poi.Name) > 100 {
+		return nil, "name is longer than 100 characters"
+	}
+	if poi.Category != nil && len(*poi.Category) > 50 {
+		return nil, "category is longer than 50 characters"
+	}
+	if poi.Address != nil && len(*poi.Address) > 255 {
+		return nil, "address is longer than 255 characters"
+	}
+
+	var err error
+	if poi.Latitude, err = strconv.ParseFloat(value("latitude"), 64); err != nil {
+		return nil, "invalid latitude"
+	}
+	if poi.Longitude, err = strconv.ParseFloat(value("longitude"), 64); err != nil {
+		return nil, "invalid longitude"
+	}
+	if radius := value("radius"); radius != "" {
+		if poi.RadiusM, err = strconv.ParseFloat(radius, 64); err != nil {
+			return nil, "invalid radius"
+		}
+	}
+
+	if err := s.validate(poi); err != nil {
+		return nil, err.Error()
+	}
+	return poi, ""
+}
+
+// upsert creates a POI or updates the organization's POI of the same name, reporting whether it was created
+func (s *poiService) upsert(poi *entity.POI) (bool, error) {
+	existing, err := s.poiRepo.GetByName(poi.OrganizationID, poi.Name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, fmt.Errorf("failed to get POI: %w", err)
+	}
+
+	if existing == nil {
+		if err := s.poiRepo.Create(poi); err != nil {
+			return false, fmt.Errorf("failed to create POI: %w", err)
+		}
+		return true, nil
+	}
+
+	existing.Latitude, existing.Longitude, existing.RadiusM = poi.Latitude, poi.Longitude, poi.RadiusM
+	existing.Category, existing.Address = poi.Category, poi.Address
+	if err := s.poiRepo.Update(existing); err != nil {
+		return false, fmt.Errorf("failed to update POI: %w", err)
+	}
+	return false, nil
+}
+
+// validate checks the coordinates and radius of a POI
+func (s *poiService) validate(poi *entity.POI) error {
+	if poi.Latitude < -90 || poi.Latitude > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+	if poi.Longitude < -180 || poi.Longitude > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+	if poi.RadiusM <= 0 || poi.RadiusM > s.cfg.MaxRadius {
+		return fmt.Errorf("radius must be greater than 0 and at most %.0f meters", s.cfg.MaxRadius)
+	}
+	return nil
+}
+
+// ensureNameAvailable checks no other POI of the organization has the name
+func (s *poiService) ensureNameAvailable(organizationID uint, name string, poiID uint) error {
+	existing, err := s.poiRepo.GetByName(organizationID, name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to check POI name: %w", err)
+	}
+	if existing != nil && existing.ID != poiID {
+		return errors.New("a POI with this name already exists in the organization")
+	}
+	return nil
+}
+
+// authorizePOI gets a POI and checks the user's organization role allows the action
+func (s *poiService) authorizePOI(userID, poiID uint, action entity.OrgAction) (*entity.POI, error) {
+	poi, err := s.poiRepo.GetByID(poiID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("POI not found")
+		}
+		return nil, fmt.Errorf("failed to get POI: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, poi.OrganizationID, action); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("POI not found")
+	}
+
+	return poi, nil
+}
+
+// entityToResponse converts entity to response DTO
+func (s *poiService) entityToResponse(poi *entity.POI) *dto.POIResponse {
+	return &dto.POIResponse{
+		ID:             poi.ID,
+		OrganizationID: poi.OrganizationID,
+		Name:           poi.Name,
+		Category:       poi.Category,
+		Address:        poi.Address,
+		Latitude:       poi.Latitude,
+		Longitude:      poi.Longitude,
+		RadiusM:        poi.RadiusM,
+		CreatedAt:      poi.CreatedAt,
+		UpdatedAt:      poi.UpdatedAt,
+	}
+}
+
+// matchPOI returns the POI containing a position whose center is nearest to it, if any
+func matchPOI(pois []entity.POI, position geo.Point) *dto.StopPlaceResponse {
+	var place *dto.StopPlaceResponse
+	for _, poi := range pois {
+		distance := geo.DistanceMeters(poi.Latitude, poi.Longitude, position.Latitude, position.Longitude)
+		if distance > poi.RadiusM || (place != nil && distance >= place.DistanceMeters) {
+			continue
+		}
+		place = &dto.StopPlaceResponse{Type: PlacePOI, ID: poi.ID, Name: poi.Name, DistanceMeters: distance}
+	}
+	return place
+}
+
+// matchGeofence returns the geofence containing a position whose center is nearest to it, if any
+func matchGeofence(geofences []entity.AlertRule, position geo.Point) *dto.StopPlaceResponse {
+	var place *dto.StopPlaceResponse
+	for _, rule := range geofences {
+		if rule.GeofenceLatitude == nil || rule.GeofenceLongitude == nil || rule.GeofenceRadiusM == nil {
+			continue
+		}
+		distance := geo.DistanceMeters(*rule.GeofenceLatitude, *rule.GeofenceLongitude, position.Latitude, position.Longitude)
+		if distance > *rule.GeofenceRadiusM || (place != nil && distance >= place.DistanceMeters) {
+			continue
+		}
+		place = &dto.StopPlaceResponse{Type: PlaceGeofence, ID: rule.ID, Name: rule.Name, DistanceMeters: distance}
+	}
+	return place
+}
+
+// nearbyVehicles pairs latest locations with their vehicles, nearest to the center first
+func nearbyVehicles(vehicles []entity.Vehicle, locations []entity.LocationLog, center geo.Point) []dto.NearbyVehicleResponse {
+	vehiclesByID := make(map[uint]*entity.Vehicle, len(vehicles))
+	for i := range vehicles {
+		vehiclesByID[vehicles[i].ID] = &vehicles[i]
+	}
+
+	responses := make([]dto.NearbyVehicleResponse, 0, len(locations))
+	for _, location := range locations {
+		vehicle := vehiclesByID[location.VehicleID]
+		responses = append(responses, dto.NearbyVehicleResponse{
+			VehicleID:      vehicle.ID,
+			PlateNumber:    vehicle.PlateNumber,
+			Model:          vehicle.Model,
+			Latitude:       location.Latitude,
+			Longitude:      location.Longitude,
+			Speed:          location.Speed,
+			Ignition:       location.Ignition,
+			Timestamp:      location.Timestamp,
+			DistanceMeters: geo.DistanceMeters(center.Latitude, center.Longitude, location.Latitude, location.Longitude),
+		})
+	}
+
+	sort.SliceStable(responses, func(i, j int) bool {
+		return responses[i].DistanceMeters < responses[j].DistanceMeters
+	})
+	return responses
+}
+
+// optionalString returns nil for an empty or blank string
+func optionalString(value string) *string {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil
+	}
+	return &value
+}
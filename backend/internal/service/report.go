@@ -0,0 +1,720 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/blobstore"
+	"github.com/cartrack/backend/pkg/mailer"
+	"github.com/cartrack/backend/pkg/pdf"
+	"github.com/cartrack/backend/pkg/timezone"
+	"gorm.io/gorm"
+)
+
+// Schedule defaults of a new report: 06:00 local time, weekly on Monday, monthly on the 1st
+const (
+	defaultReportHour       = 6
+	defaultReportWeekday    = 1
+	defaultReportDayOfMonth = 1
+)
+
+// reportViolationTypes are the alert types listed by violations reports
+var reportViolationTypes = []entity.AlertType{entity.AlertTypeOverspeed, entity.AlertTypeGeofenceExit, entity.AlertTypeAfterHoursUse}
+
+// reportContentTypes maps report formats to the content type of their files
+var reportContentTypes = map[string]string{
+	entity.ReportFormatPDF: "application/pdf",
+	entity.ReportFormatCSV: "text/csv; charset=utf-8",
+}
+
+// ReportFile is a generated report opened for download. The caller must close Content.
+type ReportFile struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
+
+// ReportService defines scheduled report service interface
+type ReportService interface {
+	Create(userID uint, req *dto.CreateReportRequest) (*dto.ReportResponse, error)
+	GetMyReports(userID uint) ([]dto.ReportResponse, error)
+	GetByID(userID, reportID uint) (*dto.ReportResponse, error)
+	Update(userID, reportID uint, req *dto.UpdateReportRequest) (*dto.ReportResponse, error)
+	Delete(userID, reportID uint) error
+	RunNow(userID, reportID uint) (*dto.ReportRunResponse, error)
+	GetRuns(userID, reportID uint, limit, offset int) ([]dto.ReportRunResponse, int64, error)
+	OpenRunFile(userID, runID uint) (*ReportFile, error)
+	DispatchDue(ctx context.Context) error
+}
+
+// reportService implements ReportService interface
+type reportService struct {
+	cfg                 configs.ReportsConfig
+	definitionRepo      repository.ReportDefinitionRepository
+	runRepo             repository.ReportRunRepository
+	vehicleRepo         repository.VehicleRepository
+	locationLogRepo     repository.LocationLogRepository
+	fuelLogRepo         repository.FuelLogRepository
+	alertEventRepo      repository.AlertEventRepository
+	organizationRepo    repository.OrganizationRepository
+	organizationService OrganizationService
+	blobs               blobstore.Store
+	mailer              mailer.Mailer
+}
+
+// NewReportService creates new report service instance
+func NewReportService(cfg configs.ReportsConfig, definitionRepo repository.ReportDefinitionRepository, runRepo repository.ReportRunRepository, vehicleRepo repository.VehicleRepository, locationLogRepo repository.LocationLogRepository, fuelLogRepo repository.FuelLogRepository, alertEventRepo repository.AlertEventRepository, organizationRepo repository.OrganizationRepository, organizationService OrganizationService, blobs blobstore.Store, mailer mailer.Mailer) ReportService {
+	return &reportService{
+		cfg:                 cfg,
+		definitionRepo:      definitionRepo,
+		runRepo:             runRepo,
+		vehicleRepo:         vehicleRepo,
+		locationLogRepo:     locationLogRepo,
+		fuelLogRepo:         fuelLogRepo,
+		alertEventRepo:      alertEventRepo,
+		organizationRepo:    organizationRepo,
+		organizationService: organizationService,
+		blobs:               blobs,
+		mailer:              mailer,
+	}
+}
+
+// Create creates a report definition and schedules its first run
+func (s *reportService) Create(userID uint, req *dto.CreateReportRequest) (*dto.ReportResponse, error) {
+	organizationID, err := s.organizationService.ResolveOrganizationID(userID, req.OrganizationID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	definition := &entity.ReportDefinition{
+		OrganizationID:  organizationID,
+		CreatedByUserID: userID,
+		Name:            req.Name,
+		Type:            entity.ReportType(req.Type),
+		Format:          req.Format,
+		Frequency:       entity.ReportFrequency(req.Frequency),
+		Hour:            defaultReportHour,
+		Weekday:         defaultReportWeekday,
+		DayOfMonth:      defaultReportDayOfMonth,
+		Enabled:         true,
+	}
+	if req.Hour != nil {
+		definition.Hour = *req.Hour
+	}
+	if req.Weekday != nil {
+		definition.Weekday = *req.Weekday
+	}
+	if req.DayOfMonth != nil {
+		definition.DayOfMonth = *req.DayOfMonth
+	}
+	if len(req.Recipients) > 0 {
+		recipients := strings.Join(req.Recipients, ",")
+		definition.Recipients = &recipients
+	}
+	if req.Enabled != nil {
+		definition.Enabled = *req.Enabled
+	}
+	definition.NextRunAt = definition.NextRunAfter(time.Now(), timezone.JakartaLocation)
+
+	if err := s.definitionRepo.Create(definition); err != nil {
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	return s.entityToResponse(definition), nil
+}
+
+// GetMyReports gets report definitions of the user's organizations
+func (s *reportService) GetMyReports(userID uint) ([]dto.ReportResponse, error) {
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := s.definitionRepo.GetByOrganizationIDs(organizationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reports: %w", err)
+	}
+
+	responses := make([]dto.ReportResponse, len(definitions))
+	for i, definition := range definitions {
+		responses[i] = *s.entityToResponse(&definition)
+	}
+	return responses, nil
+}
+
+// GetByID gets a report definition by ID
+func (s *reportService) GetByID(userID, reportID uint) (*dto.ReportResponse, error) {
+	definition, err := s.authorizeDefinition(userID, reportID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+	return s.entityToResponse(definition), nil
+}
+
+// Update updates a report definition. A changed schedule takes effect from its next occurrence.
+func (s *reportService) Update(userID, reportID uint, req *dto.UpdateReportRequest) (*dto.ReportResponse, error) {
+	definition, err := s.authorizeDefinition(userID, reportID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		definition.Name = req.Name
+	}
+	if req.Type != "" {
+		definition.Type = entity.ReportType(req.Type)
+	}
+	if req.Format != "" {
+		definition.Format = req.Format
+	}
+	if req.Frequency != "" {
+		definition.Frequency = entity.ReportFrequency(req.Frequency)
+	}
+	if req.Hour != nil {
+		definition.Hour = *req.Hour
+	}
+	if req.Weekday != nil {
+		definition.Weekday = *req.Weekday
+	}
+	if req.DayOfMonth != nil {
+		definition.DayOfMonth = *req.DayOfMonth
+	}
+	if req.Recipients != nil {
+		definition.Recipients = nil
+		if len(req.Recipients) > 0 {
+			recipients := strings.Join(req.Recipients, ",")
+			definition.Recipients = &recipients
+		}
+	}
+	if req.Enabled != nil {
+		definition.Enabled = *req.Enabled
+	}
+	definition.NextRunAt = definition.NextRunAfter(time.Now(), timezone.JakartaLocation)
+
+	if err := s.definitionRepo.Update(definition); err != nil {
+		return nil, fmt.Errorf("failed to update report: %w", err)
+	}
+
+	return s.entityToResponse(definition), nil
+}
+
+// Delete deletes a report definition. Files of its past runs can still be downloaded.
+func (s *reportService) Delete(userID, reportID uint) error {
+	definition, err := s.authorizeDefinition(userID, reportID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return err
+	}
+
+	if err := s.definitionRepo.Delete(definition.ID); err != nil {
+		return fmt.Errorf("failed to delete report: %w", err)
+	}
+	return nil
+}
+
+// RunNow generates and emails a report for its most recent complete period, without changing its schedule.
+// A failed generation is recorded on the returned run.
+func (s *reportService) RunNow(userID, reportID uint) (*dto.ReportRunResponse, error) {
+	definition, err := s.authorizeDefinition(userID, reportID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := s.run(definition, time.Now(), &userID)
+	if run == nil {
+		return nil, err
+	}
+	return s.runToResponse(run), nil
+}
+
+// GetRuns gets past runs of a report definition, newest first
+func (s *reportService) GetRuns(userID, reportID uint, limit, offset int) ([]dto.ReportRunResponse, int64, error) {
+	definition, err := s.authorizeDefinition(userID, reportID, entity.OrgActionRead)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	runs, total, err := s.runRepo.GetByDefinitionIDWithPagination(definition.ID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get report runs: %w", err)
+	}
+
+	responses := make([]dto.ReportRunResponse, len(runs))
+	for i, run := range runs {
+		responses[i] = *s.runToResponse(&run)
+	}
+	return responses, total, nil
+}
+
+// OpenRunFile opens the file of a completed report run for download
+func (s *reportService) OpenRunFile(userID, runID uint) (*ReportFile, error) {
+	run, err := s.runRepo.GetByID(runID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("report run not found")
+		}
+		return nil, fmt.Errorf("failed to get report run: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, run.OrganizationID, entity.OrgActionRead); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("report run not found")
+	}
+
+	if run.FileKey == nil {
+		return nil, errors.New("report run has no file")
+	}
+
+	content, err := s.blobs.Open(*run.FileKey)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, errors.New("report run has no file")
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	return &ReportFile{
+		Name:        *run.FileName,
+		ContentType: reportContentTypes[run.Format],
+		Size:        run.FileSize,
+		Content:     content,
+	}, nil
+}
+
+// DispatchDue generates the reports whose scheduled time has passed (scheduler job). Each definition is
+// moved to its next occurrence before it is generated, so a report is produced once per schedule even
+// with several workers; occurrences missed while the scheduler was down are not made up.
+func (s *reportService) DispatchDue(ctx context.Context) error {
+	now := time.Now()
+	definitions, err := s.definitionRepo.GetDue(now, s.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get due reports: %w", err)
+	}
+
+	for i := range definitions {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		definition := &definitions[i]
+		scheduledAt := definition.NextRunAt
+		claimed, err := s.definitionRepo.AdvanceSchedule(definition.ID, scheduledAt, definition.NextRunAfter(now, timezone.JakartaLocation), now)
+		if err != nil {
+			log.Printf("Failed to advance schedule of report %d: %v", definition.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if _, err := s.run(definition, scheduledAt, nil); err != nil {
+			log.Printf("Failed to generate report %d: %v", definition.ID, err)
+		}
+	}
+	return nil
+}
+
+// run generates a report for the period ending before runAt, stores its file and emails it.
+// The run is returned whenever it was recorded, together with any generation error.
+func (s *reportService) run(definition *entity.ReportDefinition, runAt time.Time, userID *uint) (*entity.ReportRun, error) {
+	start, end := definition.Period(runAt, timezone.JakartaLocation)
+	run := &entity.ReportRun{
+		DefinitionID:      definition.ID,
+		OrganizationID:    definition.OrganizationID,
+		TriggeredByUserID: userID,
+		Status:            entity.ReportRunRunning,
+		PeriodStart:       start,
+		PeriodEnd:         end,
+		Format:            definition.Format,
+	}
+	if err := s.runRepo.Create(run); err != nil {
+		return nil, fmt.Errorf("failed to create report run: %w", err)
+	}
+
+	content, err := s.generate(definition, start, end)
+	if err == nil {
+		err = s.store(definition, run, content)
+	}
+	completedAt := time.Now()
+	run.CompletedAt = &completedAt
+	if err != nil {
+		message := err.Error()
+		run.Status = entity.ReportRunFailed
+		run.Error = &message
+		if updateErr := s.runRepo.Update(run); updateErr != nil {
+			log.Printf("Failed to update report run %d: %v", run.ID, updateErr)
+		}
+		return run, err
+	}
+	run.Status = entity.ReportRunCompleted
+
+	if err := s.email(definition, run, content); err != nil {
+		// Log error but keep the report available for download
+		log.Printf("Failed to email report run %d: %v", run.ID, err)
+		message := err.Error()
+		run.EmailError = &message
+	} else {
+		emailedAt := time.Now()
+		run.EmailedAt = &emailedAt
+	}
+
+	if err := s.runRepo.Update(run); err != nil {
+		return run, fmt.Errorf("failed to update report run: %w", err)
+	}
+	return run, nil
+}
+
+// store saves a generated file and records it on the run
+func (s *reportService) store(definition *entity.ReportDefinition, run *entity.ReportRun, content []byte) error {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Errorf("failed to generate file key: %w", err)
+	}
+	key := fmt.Sprintf("reports/%d/%s.%s", definition.OrganizationID, hex.EncodeToString(b), definition.Format)
+
+	size, err := s.blobs.Put(key, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to store file: %w", err)
+	}
+
+	fileName := reportFileName(definition, run.PeriodStart, run.PeriodEnd)
+	run.FileKey = &key
+	run.FileName = &fileName
+	run.FileSize = size
+	return nil
+}
+
+// email sends a generated report to its recipients, by default the organization's owners and managers
+func (s *reportService) email(definition *entity.ReportDefinition, run *entity.ReportRun, content []byte) error {
+	recipients := definition.RecipientList()
+	if len(recipients) == 0 {
+		users, err := alertRecipients(s.organizationRepo, definition.OrganizationID)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			recipients = append(recipients, user.Email)
+		}
+	}
+	if len(recipients) == 0 {
+		return errors.New("report has no recipients")
+	}
+
+	body := fmt.Sprintf("Your %s report \"%s\" for %s is attached.\n\nPast reports can be downloaded from the reports page.\n",
+		definition.Frequency, definition.Name, reportPeriodLabel(run.PeriodStart, run.PeriodEnd))
+
+	return s.mailer.Send(mailer.Message{
+		To:      recipients,
+		Subject: fmt.Sprintf("%s (%s)", definition.Name, reportPeriodLabel(run.PeriodStart, run.PeriodEnd)),
+		Body:    body,
+		Attachments: []mailer.Attachment{{
+			Name:        *run.FileName,
+			ContentType: reportContentTypes[run.Format],
+			Data:        content,
+		}},
+	})
+}
+
+// generate builds the report table of a period and renders it in the definition's format
+func (s *reportService) generate(definition *entity.ReportDefinition, start, end time.Time) ([]byte, error) {
+	organization, err := s.organizationRepo.GetByID(definition.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	table := &pdf.Table{
+		Title:    definition.Name,
+		Subtitle: []string{organization.Name, "Period: " + reportPeriodLabel(start, end)},
+	}
+
+	switch definition.Type {
+	case entity.ReportTypeDailyActivity:
+		err = s.activityTable(table, definition.OrganizationID, start, end)
+	case entity.ReportTypeWeeklyDistance:
+		err = s.distanceTable(table, definition.OrganizationID, start, end)
+	case entity.ReportTypeFuelUsage:
+		err = s.fuelTable(table, definition.OrganizationID, start, end)
+	case entity.ReportTypeViolations:
+		err = s.violationsTable(table, definition.OrganizationID, start, end)
+	default:
+		err = fmt.Errorf("unknown report type %q", definition.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if definition.Format == entity.ReportFormatCSV {
+		w := csv.NewWriter(&buf)
+		if err = w.Write(table.Headers); err == nil {
+			err = w.WriteAll(table.Rows)
+		}
+	} else {
+		err = table.Render(&buf)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// activityTable lists per vehicle the positions reported, the first and last one, distance, driving time and top speed
+func (s *reportService) activityTable(table *pdf.Table, organizationID uint, start, end time.Time) error {
+	vehicles, err := s.vehicleRepo.GetByOrganizationIDs([]uint{organizationID}, -1, -1)
+	if err != nil {
+		return fmt.Errorf("failed to get vehicles: %w", err)
+	}
+
+	table.Headers = []string{"Vehicle", "Model", "Positions", "First position", "Last position", "Distance (km)", "Driving time (h)", "Max speed (km/h)"}
+	for _, vehicle := range vehicles {
+		activity, err := s.locationLogRepo.GetActivity(vehicle.ID, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to get activity of vehicle %d: %w", vehicle.ID, err)
+		}
+		usage, err := s.locationLogRepo.GetUsage(vehicle.ID, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to get usage of vehicle %d: %w", vehicle.ID, err)
+		}
+
+		table.Rows = append(table.Rows, []string{
+			vehicle.PlateNumber,
+			reportModel(&vehicle),
+			strconv.FormatInt(activity.Positions, 10),
+			reportTime(activity.FirstAt),
+			reportTime(activity.LastAt),
+			reportNumber(usage.DistanceKm),
+			reportNumber(usage.EngineHours),
+			reportNumber(activity.MaxSpeed),
+		})
+	}
+	return nil
+}
+
+// distanceTable lists the distance of each vehicle per day of the period, or per week for periods longer than a week
+func (s *reportService) distanceTable(table *pdf.Table, organizationID uint, start, end time.Time) error {
+	vehicles, err := s.vehicleRepo.GetByOrganizationIDs([]uint{organizationID}, -1, -1)
+	if err != nil {
+		return fmt.Errorf("failed to get vehicles: %w", err)
+	}
+
+	step := 1
+	if end.Sub(start) > 7*24*time.Hour {
+		step = 7
+	}
+	var buckets []time.Time
+	table.Headers = []string{"Vehicle", "Model"}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, step) {
+		buckets = append(buckets, day)
+		if step == 1 {
+			table.Headers = append(table.Headers, day.Format("Mon 02 Jan"))
+		} else {
+			table.Headers = append(table.Headers, "Week of "+day.Format("02 Jan"))
+		}
+	}
+	table.Headers = append(table.Headers, "Total (km)")
+
+	for _, vehicle := range vehicles {
+		row := []string{vehicle.PlateNumber, reportModel(&vehicle)}
+		total := 0.0
+		for i, bucketStart := range buckets {
+			bucketEnd := end
+			if i+1 < len(buckets) {
+				bucketEnd = buckets[i+1]
+			}
+			usage, err := s.locationLogRepo.GetUsage(vehicle.ID, bucketStart, bucketEnd)
+			if err != nil {
+				return fmt.Errorf("failed to get usage of vehicle %d: %w", vehicle.ID, err)
+			}
+			total += usage.DistanceKm
+			row = append(row, reportNumber(usage.DistanceKm))
+		}
+		table.Rows = append(table.Rows, append(row, reportNumber(total)))
+	}
+	return nil
+}
+
+// fuelTable lists per vehicle how much fuel was consumed and refuelled, in percent of the tank
+func (s *reportService) fuelTable(table *pdf.Table, organizationID uint, start, end time.Time) error {
+	vehicles, err := s.vehicleRepo.GetByOrganizationIDs([]uint{organizationID}, -1, -1)
+	if err != nil {
+		return fmt.Errorf("failed to get vehicles: %w", err)
+	}
+
+	table.Headers = []string{"Vehicle", "Model", "Readings", "Start level (%)", "End level (%)", "Consumed (%)", "Refuelled (%)", "Refuels"}
+	for _, vehicle := range vehicles {
+		usage, err := s.fuelLogRepo.GetUsage(vehicle.ID, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to get fuel usage of vehicle %d: %w", vehicle.ID, err)
+		}
+
+		startLevel, endLevel := "-", "-"
+		if usage.StartLevel != nil {
+			startLevel = reportNumber(*usage.StartLevel)
+		}
+		if usage.EndLevel != nil {
+			endLevel = reportNumber(*usage.EndLevel)
+		}
+
+		table.Rows = append(table.Rows, []string{
+			vehicle.PlateNumber,
+			reportModel(&vehicle),
+			strconv.FormatInt(usage.Readings, 10),
+			startLevel,
+			endLevel,
+			reportNumber(usage.Consumed),
+			reportNumber(usage.Refuelled),
+			strconv.FormatInt(usage.Refuels, 10),
+		})
+	}
+	return nil
+}
+
+// violationsTable lists overspeed, geofence exit and after-hours alert events, oldest first
+func (s *reportService) violationsTable(table *pdf.Table, organizationID uint, start, end time.Time) error {
+	events, err := s.alertEventRepo.GetByOrganizationIDBetween(organizationID, reportViolationTypes, start, end, s.cfg.MaxRows)
+	if err != nil {
+		return fmt.Errorf("failed to get alert events: %w", err)
+	}
+
+	summary := fmt.Sprintf("%d violations", len(events))
+	if len(events) == s.cfg.MaxRows {
+		summary = fmt.Sprintf("First %d violations", len(events))
+	}
+	table.Subtitle = append(table.Subtitle, summary)
+
+	table.Headers = []string{"Time", "Vehicle", "Type", "Severity", "Message"}
+	for _, event := range events {
+		table.Rows = append(table.Rows, []string{
+			reportTime(&event.TriggeredAt),
+			event.Vehicle.PlateNumber,
+			string(event.Type),
+			string(event.Severity),
+			event.Message,
+		})
+	}
+	return nil
+}
+
+// authorizeDefinition gets a report definition the user may act on
+func (s *reportService) authorizeDefinition(userID, reportID uint, action entity.OrgAction) (*entity.ReportDefinition, error) {
+	definition, err := s.definitionRepo.GetByID(reportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("report not found")
+		}
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, definition.OrganizationID, action); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("report not found")
+	}
+
+	return definition, nil
+}
+
+// entityToResponse converts entity to response DTO
+func (s *reportService) entityToResponse(definition *entity.ReportDefinition) *dto.ReportResponse {
+	return &dto.ReportResponse{
+		ID:             definition.ID,
+		OrganizationID: definition.OrganizationID,
+		Name:           definition.Name,
+		Type:           string(definition.Type),
+		Format:         definition.Format,
+		Frequency:      string(definition.Frequency),
+		Hour:           definition.Hour,
+		Weekday:        definition.Weekday,
+		DayOfMonth:     definition.DayOfMonth,
+		Recipients:     definition.RecipientList(),
+		Enabled:        definition.Enabled,
+		NextRunAt:      definition.NextRunAt,
+		LastRunAt:      definition.LastRunAt,
+		CreatedAt:      definition.CreatedAt,
+		UpdatedAt:      definition.UpdatedAt,
+	}
+}
+
+// runToResponse converts a report run to response DTO
+func (s *reportService) runToResponse(run *entity.ReportRun) *dto.ReportRunResponse {
+	return &dto.ReportRunResponse{
+		ID:                run.ID,
+		DefinitionID:      run.DefinitionID,
+		TriggeredByUserID: run.TriggeredByUserID,
+		Status:            string(run.Status),
+		PeriodStart:       run.PeriodStart,
+		PeriodEnd:         run.PeriodEnd,
+		Format:            run.Format,
+		FileName:          run.FileName,
+		FileSize:          run.FileSize,
+		Error:             run.Error,
+		EmailedAt:         run.EmailedAt,
+		EmailError:        run.EmailError,
+		CompletedAt:       run.CompletedAt,
+		CreatedAt:         run.CreatedAt,
+	}
+}
+
+// reportFileName names a report file after the definition and the days its period covers
+func reportFileName(definition *entity.ReportDefinition, start, end time.Time) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, definition.Name)
+
+	last := end.In(timezone.JakartaLocation).AddDate(0, 0, -1)
+	return fmt.Sprintf("%s_%s_%s.%s", name, start.In(timezone.JakartaLocation).Format("2006-01-02"), last.Format("2006-01-02"), definition.Format)
+}
+
+// reportPeriodLabel describes the days a period covers, e.g. "06 Jan 2025 - 12 Jan 2025"
+func reportPeriodLabel(start, end time.Time) string {
+	first := start.In(timezone.JakartaLocation)
+	last := end.In(timezone.JakartaLocation).AddDate(0, 0, -1)
+	if first.Equal(last) {
+		return first.Format("02 Jan 2006")
+	}
+	return first.Format("02 Jan 2006") + " - " + last.Format("02 Jan 2006")
+}
+
+// reportTime formats a time in local time for report cells
+func reportTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.In(timezone.JakartaLocation).Format("2006-01-02 15:04")
+}
+
+// reportNumber formats a number with two decimals for report cells
+func reportNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+// reportModel returns the model of a vehicle for report cells
+func reportModel(vehicle *entity.Vehicle) string {
+	if vehicle.Model == nil {
+		return "-"
+	}
+	return *vehicle.Model
+}
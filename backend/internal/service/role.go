@@ -0,0 +1,346 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/permission"
+	"gorm.io/gorm"
+)
+
+// rolePermissionsTTL bounds how long cached role permissions and user roles are trusted,
+// so changes made through another instance are picked up
+const rolePermissionsTTL = time.Minute
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// RoleService defines role and permission service interface
+type RoleService interface {
+	permission.Resolver
+	GetAll() ([]dto.RoleResponse, error)
+	GetByID(id uint) (*dto.RoleResponse, error)
+	Create(req *dto.CreateRoleRequest) (*dto.RoleResponse, error)
+	Update(id uint, req *dto.UpdateRoleRequest) (*dto.RoleResponse, error)
+	Delete(id uint) error
+	GetPermissions() []permission.Definition
+	AssignToUser(actorID, userID uint, req *dto.AssignRoleRequest) error
+}
+
+// cachedRole holds the permission set of a role as loaded at a point in time
+type cachedRole struct {
+	permissions map[string]struct{}
+	loadedAt    time.Time
+}
+
+// cachedUserRole holds the role of a user as loaded at a point in time
+type cachedUserRole struct {
+	role     string
+	loadedAt time.Time
+}
+
+// roleService implements RoleService interface
+type roleService struct {
+	roleRepo repository.RoleRepository
+	userRepo repository.UserRepository
+
+	mu        sync.RWMutex
+	cache     map[string]cachedRole
+	userRoles map[uint]cachedUserRole
+}
+
+// NewRoleService creates new role service instance
+func NewRoleService(roleRepo repository.RoleRepository, userRepo repository.UserRepository) RoleService {
+	return &roleService{
+		roleRepo:  roleRepo,
+		userRepo:  userRepo,
+		cache:     make(map[string]cachedRole),
+		userRoles: make(map[uint]cachedUserRole),
+	}
+}
+
+// UserRole returns the role the user currently holds, which may differ from the role in an older token
+func (s *roleService) UserRole(userID uint) (string, error) {
+	s.mu.RLock()
+	cached, ok := s.userRoles[userID]
+	s.mu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < rolePermissionsTTL {
+		return cached.role, nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", permission.ErrUnknownUser
+		}
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	s.mu.Lock()
+	s.userRoles[userID] = cachedUserRole{role: user.Role, loadedAt: time.Now()}
+	s.mu.Unlock()
+
+	return user.Role, nil
+}
+
+// RoleHasPermissions checks that the role grants every required permission
+func (s *roleService) RoleHasPermissions(role string, required []string) (bool, error) {
+	granted, err := s.permissionsOf(role)
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range required {
+		if _, ok := granted[name]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// GetAll gets all roles
+func (s *roleService) GetAll() ([]dto.RoleResponse, error) {
+	roles, err := s.roleRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+
+	responses := make([]dto.RoleResponse, len(roles))
+	for i, role := range roles {
+		responses[i] = *s.entityToResponse(&role)
+	}
+
+	return responses, nil
+}
+
+// GetByID gets role by ID
+func (s *roleService) GetByID(id uint) (*dto.RoleResponse, error) {
+	role, err := s.getRole(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.entityToResponse(role), nil
+}
+
+// Create creates a new role
+func (s *roleService) Create(req *dto.CreateRoleRequest) (*dto.RoleResponse, error) {
+	if !roleNamePattern.MatchString(req.Name) {
+		return nil, errors.New("role name must start with a lowercase letter and contain only lowercase letters, digits, '_' or '-'")
+	}
+
+	existing, err := s.roleRepo.GetByName(req.Name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing role: %w", err)
+	}
+	if existing != nil {
+		return nil, errors.New("role already exists")
+	}
+
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &entity.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: make([]entity.RolePermission, len(permissions)),
+	}
+	for i, name := range permissions {
+		role.Permissions[i] = entity.RolePermission{Permission: name}
+	}
+
+	if err := s.roleRepo.Create(role); err != nil {
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	return s.entityToResponse(role), nil
+}
+
+// Update updates role description and permissions
+func (s *roleService) Update(id uint, req *dto.UpdateRoleRequest) (*dto.RoleResponse, error) {
+	role, err := s.getRole(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []string
+	if req.Permissions != nil {
+		// The admin role always keeps every permission so the system cannot be locked out
+		if role.Name == entity.RoleAdmin {
+			return nil, errors.New("permissions of the admin role cannot be changed")
+		}
+		if permissions, err = normalizePermissions(req.Permissions); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Description != nil {
+		role.Description = req.Description
+	}
+
+	if err := s.roleRepo.Update(role, permissions); err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	s.invalidate(role.Name)
+
+	return s.entityToResponse(role), nil
+}
+
+// Delete deletes a role that is not built-in and not assigned to any user
+func (s *roleService) Delete(id uint) error {
+	role, err := s.getRole(id)
+	if err != nil {
+		return err
+	}
+
+	if role.IsSystem {
+		return errors.New("built-in roles cannot be deleted")
+	}
+
+	users, err := s.userRepo.CountByRole(role.Name)
+	if err != nil {
+		return fmt.Errorf("failed to count users: %w", err)
+	}
+	if users > 0 {
+		return fmt.Errorf("role is still assigned to %d user(s)", users)
+	}
+
+	if err := s.roleRepo.Delete(role.ID); err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	s.invalidate(role.Name)
+
+	return nil
+}
+
+// GetPermissions gets every known permission
+func (s *roleService) GetPermissions() []permission.Definition {
+	return permission.All()
+}
+
+// AssignToUser changes the role of a user. The new role applies to the user's existing tokens
+// immediately on this instance and within rolePermissionsTTL on others.
+func (s *roleService) AssignToUser(actorID, userID uint, req *dto.AssignRoleRequest) error {
+	if actorID == userID {
+		return errors.New("you cannot change your own role")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if _, err := s.roleRepo.GetByName(req.Role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("role not found")
+		}
+		return fmt.Errorf("failed to get role: %w", err)
+	}
+
+	if user.Role == entity.RoleAdmin && req.Role != entity.RoleAdmin {
+		admins, err := s.userRepo.CountByRole(entity.RoleAdmin)
+		if err != nil {
+			return fmt.Errorf("failed to count admins: %w", err)
+		}
+		if admins <= 1 {
+			return errors.New("the last admin cannot be demoted")
+		}
+	}
+
+	if err := s.userRepo.UpdateRole(user.ID, req.Role); err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+	s.mu.Lock()
+	delete(s.userRoles, user.ID)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// permissionsOf returns the permission set of a role, loading it when not cached or stale
+func (s *roleService) permissionsOf(name string) (map[string]struct{}, error) {
+	s.mu.RLock()
+	cached, ok := s.cache[name]
+	s.mu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < rolePermissionsTTL {
+		return cached.permissions, nil
+	}
+
+	granted := make(map[string]struct{})
+	role, err := s.roleRepo.GetByName(name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	if role != nil {
+		for _, p := range role.Permissions {
+			granted[p.Permission] = struct{}{}
+		}
+	}
+
+	s.mu.Lock()
+	s.cache[name] = cachedRole{permissions: granted, loadedAt: time.Now()}
+	s.mu.Unlock()
+
+	return granted, nil
+}
+
+// invalidate drops a role from the permission cache
+func (s *roleService) invalidate(name string) {
+	s.mu.Lock()
+	delete(s.cache, name)
+	s.mu.Unlock()
+}
+
+// getRole gets role by ID with not-found mapping
+func (s *roleService) getRole(id uint) (*entity.Role, error) {
+	role, err := s.roleRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("role not found")
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	return role, nil
+}
+
+// entityToResponse converts entity to response DTO
+func (s *roleService) entityToResponse(role *entity.Role) *dto.RoleResponse {
+	return &dto.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: role.PermissionNames(),
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+// normalizePermissions validates permission names and removes duplicates
+func normalizePermissions(names []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(names))
+	permissions := make([]string, 0, len(names))
+	for _, name := range names {
+		if !permission.IsValid(name) {
+			return nil, fmt.Errorf("unknown permission: %s", name)
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		permissions = append(permissions, name)
+	}
+	sort.Strings(permissions)
+	return permissions, nil
+}
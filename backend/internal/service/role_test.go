@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// roleUserRepo counts database reads and applies role changes on top of fakeUserRepo
+type roleUserRepo struct {
+	*fakeUserRepo
+	reads int
+}
+
+func (r *roleUserRepo) GetByID(id uint) (*entity.User, error) {
+	r.reads++
+	return r.fakeUserRepo.GetByID(id)
+}
+
+func (r *roleUserRepo) UpdateRole(id uint, role string) error {
+	r.users[id].Role = role
+	return nil
+}
+
+func (r *roleUserRepo) CountByRole(role string) (int64, error) {
+	var count int64
+	for _, user := range r.users {
+		if user.Role == role {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// fakeRoleRepo knows the built-in roles
+type fakeRoleRepo struct {
+	repository.RoleRepository
+}
+
+func (r *fakeRoleRepo) GetByName(name string) (*entity.Role, error) {
+	if name != entity.RoleAdmin && name != entity.RoleUser {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &entity.Role{Name: name}, nil
+}
+
+func TestUserRoleFollowsAssignment(t *testing.T) {
+	users := &roleUserRepo{fakeUserRepo: newFakeUserRepo(
+		&entity.User{ID: 1, Role: entity.RoleAdmin},
+		&entity.User{ID: 2, Role: entity.RoleAdmin},
+	)}
+	svc := NewRoleService(&fakeRoleRepo{}, users)
+
+	for i := 0; i < 2; i++ {
+		role, err := svc.UserRole(2)
+		if err != nil {
+			t.Fatalf("UserRole() error = %v", err)
+		}
+		if role != entity.RoleAdmin {
+			t.Fatalf("UserRole() = %q, want %q", role, entity.RoleAdmin)
+		}
+	}
+	if users.reads != 1 {
+		t.Errorf("loaded the user %d times, want 1 with the cache", users.reads)
+	}
+
+	if err := svc.AssignToUser(1, 2, &dto.AssignRoleRequest{Role: entity.RoleUser}); err != nil {
+		t.Fatalf("AssignToUser() error = %v", err)
+	}
+	role, err := svc.UserRole(2)
+	if err != nil {
+		t.Fatalf("UserRole() error = %v", err)
+	}
+	if role != entity.RoleUser {
+		t.Errorf("UserRole() after demotion = %q, want %q", role, entity.RoleUser)
+	}
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/geo"
+	"github.com/cartrack/backend/pkg/trackstops"
+)
+
+// StopService defines vehicle stop detection service interface
+type StopService interface {
+	GetStops(userID, vehicleID uint, startDate, endDate time.Time, radius float64, minDuration time.Duration) (*dto.VehicleStopsResponse, error)
+	Export(userID, vehicleID uint, startDate, endDate time.Time, radius float64, minDuration time.Duration, format string) (*TrackExport, error)
+}
+
+// stopService implements StopService interface
+type stopService struct {
+	cfg                 configs.TracksConfig
+	locationLogRepo     repository.LocationLogRepository
+	vehicleRepo         repository.VehicleRepository
+	poiService          POIService
+	organizationService OrganizationService
+}
+
+// NewStopService creates new stop service instance
+func NewStopService(cfg configs.TracksConfig, locationLogRepo repository.LocationLogRepository, vehicleRepo repository.VehicleRepository, poiService POIService, organizationService OrganizationService) StopService {
+	return &stopService{
+		cfg:                 cfg,
+		locationLogRepo:     locationLogRepo,
+		vehicleRepo:         vehicleRepo,
+		poiService:          poiService,
+		organizationService: organizationService,
+	}
+}
+
+// GetStops detects where a vehicle stopped between startDate and endDate, for how long and whether its
+// engine idled. Without a radius or minimum duration the configured stop thresholds are used.
+func (s *stopService) GetStops(userID, vehicleID uint, startDate, endDate time.Time, radius float64, minDuration time.Duration) (*dto.VehicleStopsResponse, error) {
+	// Verify organization membership
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.detect(vehicle, startDate, endDate, radius, minDuration)
+}
+
+// Export prepares the stops of a vehicle between startDate and endDate as CSV or GeoJSON
+func (s *stopService) Export(userID, vehicleID uint, startDate, endDate time.Time, radius float64, minDuration time.Duration, format string) (*TrackExport, error) {
+	format = strings.ToLower(format)
+	if format != "csv" && format != "geojson" {
+		return nil, fmt.Errorf("unsupported export format %q, use csv or geojson", format)
+	}
+
+	// Verify organization membership
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	stops, err := s.detect(vehicle, startDate, endDate, radius, minDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &TrackExport{
+		FileName:    fmt.Sprintf("stops-%d-%s-%s.%s", vehicle.ID, startDate.Format("20060102"), endDate.Format("20060102"), format),
+		ContentType: "text/csv",
+		write: func(w io.Writer) error {
+			return writeStopsCSV(w, stops.Stops)
+		},
+	}
+	if format == "geojson" {
+		export.ContentType = "application/geo+json"
+		export.write = func(w io.Writer) error {
+			return writeStopsGeoJSON(w, vehicle.PlateNumber, stops.Stops)
+		}
+	}
+	return export, nil
+}
+
+// detect reads the vehicle's positions in the period, finds the stops among them and matches them to POIs
+// and geofences
+func (s *stopService) detect(vehicle *entity.Vehicle, startDate, endDate time.Time, radius float64, minDuration time.Duration) (*dto.VehicleStopsResponse, error) {
+	if radius == 0 {
+		radius = s.cfg.StopRadius
+	}
+	if minDuration == 0 {
+		minDuration = s.cfg.StopMinDuration
+	}
+
+	if !endDate.After(startDate) {
+		return nil, errors.New("end of the period must be after its start")
+	}
+	if endDate.Sub(startDate) > s.cfg.MaxRange {
+		return nil, fmt.Errorf("period must not be longer than %d days", int(s.cfg.MaxRange.Hours()/24))
+	}
+
+	var fixes []trackstops.Fix
+	err := s.locationLogRepo.StreamLocationHistory(vehicle.ID, startDate, endDate, func(log *entity.LocationLog) error {
+		// Positions flagged as impossible jumps would split a stop in two
+		if log.Suspect {
+			return nil
+		}
+		fixes = append(fixes, trackstops.Fix{
+			Time:      log.Timestamp,
+			Latitude:  log.Latitude,
+			Longitude: log.Longitude,
+			Speed:     log.Speed,
+			Ignition:  log.Ignition,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location history: %w", err)
+	}
+
+	stops := trackstops.Detect(fixes, trackstops.Options{
+		Radius:      radius,
+		MinDuration: minDuration,
+		IdleSpeed:   s.cfg.StopSpeed,
+	})
+
+	positions := make([]geo.Point, len(stops))
+	for i, stop := range stops {
+		positions[i] = geo.Point{Latitude: stop.Latitude, Longitude: stop.Longitude}
+	}
+	places, err := s.poiService.ResolvePlaces(vehicle, positions)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.VehicleStopsResponse{
+		VehicleID:       vehicle.ID,
+		PeriodStart:     startDate,
+		PeriodEnd:       endDate,
+		RadiusMeters:    radius,
+		MinDurationSecs: minDuration.Seconds(),
+		TotalStops:      len(stops),
+		Stops:           make([]dto.VehicleStopResponse, len(stops)),
+	}
+	for i, stop := range stops {
+		response.Stops[i] = dto.VehicleStopResponse{
+			Start:           stop.Start,
+			End:             stop.End,
+			DurationSeconds: stop.Duration().Seconds(),
+			IdleSeconds:     stop.Idle.Seconds(),
+			Kind:            string(stop.Kind),
+			Latitude:        stop.Latitude,
+			Longitude:       stop.Longitude,
+			Positions:       stop.Fixes,
+			Place:           places[i],
+		}
+		response.TotalDwellSeconds += stop.Duration().Seconds()
+		response.TotalIdleSeconds += stop.Idle.Seconds()
+	}
+	return response, nil
+}
+
+// writeStopsCSV writes one row per stop. Times are UTC RFC 3339 like track exports.
+func writeStopsCSV(w io.Writer, stops []dto.VehicleStopResponse) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"start", "end", "duration_seconds", "idle_seconds", "kind", "latitude", "longitude", "positions", "place_type", "place_name"}); err != nil {
+		return err
+	}
+
+	for _, stop := range stops {
+		record := []string{
+			stop.Start.UTC().Format(time.RFC3339),
+			stop.End.UTC().Format(time.RFC3339),
+			strconv.FormatFloat(stop.DurationSeconds, 'f', 0, 64),
+			strconv.FormatFloat(stop.IdleSeconds, 'f', 0, 64),
+			stop.Kind,
+			strconv.FormatFloat(stop.Latitude, 'f', 6, 64),
+			strconv.FormatFloat(stop.Longitude, 'f', 6, 64),
+			strconv.Itoa(stop.Positions),
+			"", "",
+		}
+		if stop.Place != nil {
+			record[8], record[9] = stop.Place.Type, stop.Place.Name
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeStopsGeoJSON writes a FeatureCollection with one point feature per stop
+func writeStopsGeoJSON(w io.Writer, name string, stops []dto.VehicleStopResponse) error {
+	type geometry struct {
+		Type        string     `json:"type"`
+		Coordinates [2]float64 `json:"coordinates"`
+	}
+	type feature struct {
+		Type       string                  `json:"type"`
+		Geometry   geometry                `json:"geometry"`
+		Properties dto.VehicleStopResponse `json:"properties"`
+	}
+
+	features := make([]feature, len(stops))
+	for i, stop := range stops {
+		features[i] = feature{
+			Type:       "Feature",
+			Geometry:   geometry{Type: "Point", Coordinates: [2]float64{stop.Longitude, stop.Latitude}},
+			Properties: stop,
+		}
+	}
+
+	return json.NewEncoder(w).Encode(struct {
+		Type     string    `json:"type"`
+		Name     string    `json:"name"`
+		Features []feature `json:"features"`
+	}{Type: "FeatureCollection", Name: name, Features: features})
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/geo"
+	"github.com/cartrack/backend/pkg/trackexport"
+	"github.com/cartrack/backend/pkg/trackfilter"
+	"github.com/cartrack/backend/pkg/trackplayback"
+)
+
+// TrackExport is a vehicle track ready to be streamed in an export format
+type TrackExport struct {
+	FileName    string
+	ContentType string
+	write       func(w io.Writer) error
+}
+
+// WriteTrack streams the track to w. Location logs are read from the database while the file is written.
+func (e *TrackExport) WriteTrack(w io.Writer) error {
+	return e.write(w)
+}
+
+// TrackService defines vehicle track service interface
+type TrackService interface {
+	Export(userID, vehicleID uint, startDate, endDate time.Time, format string, filter trackfilter.Options) (*TrackExport, error)
+	Playback(userID, vehicleID uint, startDate, endDate time.Time, step time.Duration) (*dto.TrackPlaybackResponse, error)
+}
+
+// trackService implements TrackService interface
+type trackService struct {
+	cfg                 configs.TracksConfig
+	locationLogRepo     repository.LocationLogRepository
+	vehicleRepo         repository.VehicleRepository
+	poiService          POIService
+	organizationService OrganizationService
+}
+
+// NewTrackService creates new track service instance
+func NewTrackService(cfg configs.TracksConfig, locationLogRepo repository.LocationLogRepository, vehicleRepo repository.VehicleRepository, poiService POIService, organizationService OrganizationService) TrackService {
+	return &trackService{
+		cfg:                 cfg,
+		locationLogRepo:     locationLogRepo,
+		vehicleRepo:         vehicleRepo,
+		poiService:          poiService,
+		organizationService: organizationService,
+	}
+}
+
+// Export prepares the track of a vehicle between startDate and endDate as GPX, KML, GeoJSON or CSV.
+// The track is split into segments wherever consecutive positions are further apart than the configured gap.
+// With a filter each segment is cleaned on its own, so only one segment is held in memory at a time.
+func (s *trackService) Export(userID, vehicleID uint, startDate, endDate time.Time, format string, filter trackfilter.Options) (*TrackExport, error) {
+	exportFormat, err := trackexport.ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	// Verify organization membership
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s %s - %s", vehicle.PlateNumber, startDate.Format("2006-01-02 15:04"), endDate.Format("2006-01-02 15:04"))
+
+	return &TrackExport{
+		FileName:    fmt.Sprintf("track-%d-%s-%s.%s", vehicle.ID, startDate.Format("20060102"), endDate.Format("20060102"), exportFormat.Extension()),
+		ContentType: exportFormat.ContentType(),
+		write: func(w io.Writer) error {
+			writer, err := trackexport.NewWriter(exportFormat, w, name)
+			if err != nil {
+				return err
+			}
+
+			var segment []entity.LocationLog
+			flush := func() error {
+				if len(segment) == 0 {
+					return nil
+				}
+				if err := writer.StartSegment(); err != nil {
+					return err
+				}
+				for _, log := range filterTrack(segment, filter) {
+					if err := writer.WritePoint(toTrackPoint(&log)); err != nil {
+						return err
+					}
+				}
+				segment = segment[:0]
+				return nil
+			}
+
+			var last time.Time
+			err = s.locationLogRepo.StreamLocationHistory(vehicle.ID, startDate, endDate, func(log *entity.LocationLog) error {
+				if last.IsZero() || log.Timestamp.Sub(last) > s.cfg.SegmentGap {
+					if err := flush(); err != nil {
+						return err
+					}
+					if !filter.Enabled() {
+						if err := writer.StartSegment(); err != nil {
+							return err
+						}
+					}
+				}
+				last = log.Timestamp
+
+				if filter.Enabled() {
+					segment = append(segment, *log)
+					return nil
+				}
+				return writer.WritePoint(toTrackPoint(log))
+			})
+			if err == nil {
+				err = flush()
+			}
+			if err != nil {
+				return fmt.Errorf("failed to export track: %w", err)
+			}
+
+			return writer.Close()
+		},
+	}, nil
+}
+
+// Playback resamples the track of a vehicle between startDate and endDate to one frame per step,
+// interpolating between positions and marking stops and gaps. Without a step the configured one is used.
+func (s *trackService) Playback(userID, vehicleID uint, startDate, endDate time.Time, step time.Duration) (*dto.TrackPlaybackResponse, error) {
+	if step == 0 {
+		step = s.cfg.PlaybackStep
+	}
+	if step < time.Second {
+		return nil, errors.New("step must be at least one second")
+	}
+
+	if err := s.validateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	// Verify organization membership
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixes []trackplayback.Fix
+	err = s.locationLogRepo.StreamLocationHistory(vehicle.ID, startDate, endDate, func(log *entity.LocationLog) error {
+		if log.Suspect {
+			return nil
+		}
+		fixes = append(fixes, trackplayback.Fix{
+			Time:      log.Timestamp,
+			Latitude:  log.Latitude,
+			Longitude: log.Longitude,
+			Speed:     log.Speed,
+			Ignition:  log.Ignition,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location history: %w", err)
+	}
+
+	if len(fixes) > 0 {
+		frames := trackplayback.FrameCount(fixes[0].Time, fixes[len(fixes)-1].Time, step)
+		if frames > s.cfg.PlaybackMaxFrames {
+			return nil, fmt.Errorf("period has too many frames at this step (%d, at most %d); use a larger step or a shorter period", frames, s.cfg.PlaybackMaxFrames)
+		}
+	}
+
+	playback := trackplayback.Build(fixes, trackplayback.Options{
+		Step:            step,
+		GapAfter:        s.cfg.SegmentGap,
+		StopSpeed:       s.cfg.StopSpeed,
+		StopRadius:      s.cfg.StopRadius,
+		StopMinDuration: s.cfg.StopMinDuration,
+	})
+
+	positions := make([]geo.Point, len(playback.Stops))
+	for i, stop := range playback.Stops {
+		positions[i] = geo.Point{Latitude: stop.Latitude, Longitude: stop.Longitude}
+	}
+	places, err := s.poiService.ResolvePlaces(vehicle, positions)
+	if err != nil {
+		return nil, err
+	}
+
+	response := playbackToResponse(vehicle.ID, startDate, endDate, step, playback)
+	for i := range response.Stops {
+		response.Stops[i].Place = places[i]
+	}
+	return response, nil
+}
+
+// filterTrack applies a track filter to location logs ordered by time
+func filterTrack(logs []entity.LocationLog, filter trackfilter.Options) []entity.LocationLog {
+	points := make([]trackfilter.Point, len(logs))
+	for i, log := range logs {
+		points[i] = trackfilter.Point{Index: i, Time: log.Timestamp, Latitude: log.Latitude, Longitude: log.Longitude, Suspect: log.Suspect}
+	}
+
+	points = trackfilter.Apply(points, filter)
+
+	filtered := make([]entity.LocationLog, len(points))
+	for i, point := range points {
+		filtered[i] = logs[point.Index]
+		// Snapped jitter moves positions onto the point the vehicle stood at
+		filtered[i].Latitude, filtered[i].Longitude = point.Latitude, point.Longitude
+	}
+	return filtered
+}
+
+// validateRange checks a requested period is ordered and no longer than the configured maximum
+func (s *trackService) validateRange(startDate, endDate time.Time) error {
+	if !endDate.After(startDate) {
+		return errors.New("end of the period must be after its start")
+	}
+	if endDate.Sub(startDate) > s.cfg.MaxRange {
+		return fmt.Errorf("period must not be longer than %d days", int(s.cfg.MaxRange.Hours()/24))
+	}
+	return nil
+}
+
+// playbackToResponse converts a resampled track to response DTO
+func playbackToResponse(vehicleID uint, startDate, endDate time.Time, step time.Duration, playback *trackplayback.Playback) *dto.TrackPlaybackResponse {
+	response := &dto.TrackPlaybackResponse{
+		VehicleID:   vehicleID,
+		PeriodStart: startDate,
+		PeriodEnd:   endDate,
+		StepSeconds: step.Seconds(),
+		DistanceKm:  playback.DistanceKm,
+		Frames:      make([]dto.TrackPlaybackFrame, len(playback.Frames)),
+		Stops:       make([]dto.TrackPlaybackSpan, len(playback.Stops)),
+		Gaps:        make([]dto.TrackPlaybackSpan, len(playback.Gaps)),
+	}
+
+	for i, frame := range playback.Frames {
+		response.Frames[i] = dto.TrackPlaybackFrame{
+			Time:       frame.Time,
+			Latitude:   frame.Latitude,
+			Longitude:  frame.Longitude,
+			Speed:      frame.Speed,
+			Heading:    frame.Heading,
+			State:      string(frame.State),
+			DistanceKm: frame.DistanceKm,
+			Ignition:   frame.Ignition,
+		}
+	}
+	for i, stop := range playback.Stops {
+		response.Stops[i] = playbackSpan(stop)
+	}
+	for i, gap := range playback.Gaps {
+		response.Gaps[i] = playbackSpan(gap)
+	}
+	return response
+}
+
+// playbackSpan converts a stop or gap to response DTO
+func playbackSpan(interval trackplayback.Interval) dto.TrackPlaybackSpan {
+	return dto.TrackPlaybackSpan{
+		Start:           interval.Start,
+		End:             interval.End,
+		DurationSeconds: interval.End.Sub(interval.Start).Seconds(),
+		Latitude:        interval.Latitude,
+		Longitude:       interval.Longitude,
+	}
+}
+
+// toTrackPoint converts a location log to a track point
+func toTrackPoint(log *entity.LocationLog) trackexport.Point {
+	return trackexport.Point{
+		Latitude:  log.Latitude,
+		Longitude: log.Longitude,
+		Time:      log.Timestamp,
+		Speed:     log.Speed,
+		Direction: log.Direction,
+		Ignition:  log.Ignition,
+	}
+}
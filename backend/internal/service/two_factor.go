@@ -0,0 +1,279 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/totp"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	recoveryCodeCount = 10
+	// totpSkew accepts codes from one step before and after the current one
+	totpSkew = 1
+)
+
+// TwoFactorService defines two-factor authentication service interface
+type TwoFactorService interface {
+	Setup(userID uint) (*dto.TwoFactorSetupResponse, error)
+	Enable(userID uint, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error)
+	Disable(userID uint, req *dto.TwoFactorDisableRequest) error
+	RegenerateRecoveryCodes(userID uint, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error)
+	GetStatus(userID uint) (*dto.TwoFactorStatusResponse, error)
+	VerifySecondFactor(user *entity.User, code, recoveryCode string) error
+}
+
+// twoFactorService implements TwoFactorService interface
+type twoFactorService struct {
+	cfg              configs.MFAConfig
+	userRepo         repository.UserRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+}
+
+// NewTwoFactorService creates new two-factor service instance
+func NewTwoFactorService(cfg configs.MFAConfig, userRepo repository.UserRepository, recoveryCodeRepo repository.RecoveryCodeRepository) TwoFactorService {
+	return &twoFactorService{
+		cfg:              cfg,
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+	}
+}
+
+// Setup generates a new pending TOTP secret for the user
+func (s *twoFactorService) Setup(userID uint) (*dto.TwoFactorSetupResponse, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	// The secret stays pending until Enable confirms a valid code; no code of it has been used yet
+	user.TOTPSecret = &secret
+	user.TOTPLastStep = nil
+	if err := s.userRepo.UpdateTwoFactor(user); err != nil {
+		return nil, fmt.Errorf("failed to save two-factor secret: %w", err)
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(s.cfg.Issuer, user.Email, secret),
+	}, nil
+}
+
+// Enable confirms the pending secret with a code and issues recovery codes
+func (s *twoFactorService) Enable(userID uint, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == nil {
+		return nil, errors.New("two-factor setup has not been started")
+	}
+	if err := s.acceptCode(user, req.Code); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.TwoFactorEnabled = true
+	if err := s.userRepo.UpdateTwoFactor(user); err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns off two-factor authentication after re-checking password and second factor
+func (s *twoFactorService) Disable(userID uint, req *dto.TwoFactorDisableRequest) error {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TwoFactorEnabled {
+		return errors.New("two-factor authentication is not enabled")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return errors.New("invalid password")
+	}
+	if err := s.VerifySecondFactor(user, req.Code, req.Code); err != nil {
+		return err
+	}
+
+	if err := s.recoveryCodeRepo.DeleteByUserID(user.ID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	user.TwoFactorEnabled = false
+	user.TOTPSecret = nil
+	user.TOTPLastStep = nil
+	if err := s.userRepo.UpdateTwoFactor(user); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after checking a TOTP code
+func (s *twoFactorService) RegenerateRecoveryCodes(userID uint, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TwoFactorEnabled {
+		return nil, errors.New("two-factor authentication is not enabled")
+	}
+	if err := s.acceptCode(user, req.Code); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// GetStatus gets two-factor status of the user
+func (s *twoFactorService) GetStatus(userID uint) (*dto.TwoFactorStatusResponse, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &dto.TwoFactorStatusResponse{Enabled: user.TwoFactorEnabled}
+	if user.TwoFactorEnabled {
+		remaining, err := s.recoveryCodeRepo.CountUnused(user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+		}
+		status.RecoveryCodesRemaining = remaining
+	}
+
+	return status, nil
+}
+
+// VerifySecondFactor checks a TOTP code, falling back to consuming a recovery code
+func (s *twoFactorService) VerifySecondFactor(user *entity.User, code, recoveryCode string) error {
+	if !user.TwoFactorEnabled || user.TOTPSecret == nil {
+		return errors.New("two-factor authentication is not enabled")
+	}
+
+	if code != "" {
+		err := s.acceptCode(user, code)
+		if err == nil || recoveryCode == "" {
+			return err
+		}
+	}
+
+	if recoveryCode != "" {
+		err := s.recoveryCodeRepo.Consume(user.ID, hashToken(normalizeRecoveryCode(recoveryCode)))
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to check recovery code: %w", err)
+		}
+	}
+
+	return errors.New("invalid two-factor code")
+}
+
+// acceptCode checks a TOTP code and claims its time step, so each code can be used only once
+func (s *twoFactorService) acceptCode(user *entity.User, code string) error {
+	step, ok := totp.ValidateStep(*user.TOTPSecret, code, time.Now(), totpSkew)
+	if !ok {
+		return errors.New("invalid two-factor code")
+	}
+
+	accepted, err := s.userRepo.AcceptTOTPStep(user.ID, step)
+	if err != nil {
+		return fmt.Errorf("failed to record two-factor code: %w", err)
+	}
+	if !accepted {
+		return errors.New("two-factor code has already been used")
+	}
+
+	user.TOTPLastStep = &step
+	return nil
+}
+
+// replaceRecoveryCodes generates a new set of recovery codes and stores their hashes
+func (s *twoFactorService) replaceRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	records := make([]entity.RecoveryCode, recoveryCodeCount)
+
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		records[i] = entity.RecoveryCode{
+			UserID:   userID,
+			CodeHash: hashToken(normalizeRecoveryCode(code)),
+		}
+	}
+
+	if err := s.recoveryCodeRepo.ReplaceForUser(userID, records); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	return codes, nil
+}
+
+// getUser gets user by ID with not-found mapping
+func (s *twoFactorService) getUser(userID uint) (*entity.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// generateRecoveryCode returns a random code formatted as xxxxx-xxxxx
+func generateRecoveryCode() (string, error) {
+	bytes := make([]byte, 7)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	raw := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(bytes))[:10]
+	return raw[:5] + "-" + raw[5:], nil
+}
+
+// normalizeRecoveryCode strips separators and case so users can type codes loosely
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/token"
+	"github.com/cartrack/backend/pkg/totp"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// fakeUserRepo keeps users in memory; methods a test does not need panic through the nil interface
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[uint]*entity.User
+}
+
+func newFakeUserRepo(users ...*entity.User) *fakeUserRepo {
+	repo := &fakeUserRepo{users: make(map[uint]*entity.User)}
+	for _, user := range users {
+		repo.users[user.ID] = user
+	}
+	return repo
+}
+
+func (r *fakeUserRepo) GetByID(id uint) (*entity.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *fakeUserRepo) GetByEmail(email string) (*entity.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeUserRepo) AcceptTOTPStep(id uint, step int64) (bool, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return false, nil
+	}
+	if user.TOTPLastStep != nil && *user.TOTPLastStep >= step {
+		return false, nil
+	}
+	user.TOTPLastStep = &step
+	return true, nil
+}
+
+// fakeLoginGuard records which outcomes were reported and rejects every attempt with blocked when set
+type fakeLoginGuard struct {
+	blocked   error
+	failures  int
+	successes int
+}
+
+func (g *fakeLoginGuard) CheckAllowed(user *entity.User, ipAddress string) error { return g.blocked }
+
+func (g *fakeLoginGuard) RecordFailure(user *entity.User, email, ipAddress string) error {
+	g.failures++
+	return nil
+}
+
+func (g *fakeLoginGuard) RecordSuccess(user *entity.User, ipAddress string) error {
+	g.successes++
+	return nil
+}
+
+func (g *fakeLoginGuard) Unlock(userID uint) error { return nil }
+
+func (g *fakeLoginGuard) PurgeAttempts(ctx context.Context) error { return nil }
+
+func twoFactorUser(t *testing.T) *entity.User {
+	t.Helper()
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+	return &entity.User{
+		ID:               1,
+		Email:            "driver@example.com",
+		PasswordHash:     string(hash),
+		Role:             "user",
+		TOTPSecret:       &secret,
+		TwoFactorEnabled: true,
+	}
+}
+
+func TestVerifySecondFactorRejectsReplayedCode(t *testing.T) {
+	user := twoFactorUser(t)
+	svc := &twoFactorService{userRepo: newFakeUserRepo(user)}
+
+	code, err := totp.GenerateCode(*user.TOTPSecret, time.Now())
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	if err := svc.VerifySecondFactor(user, code, ""); err != nil {
+		t.Fatalf("first VerifySecondFactor() error = %v", err)
+	}
+	err = svc.VerifySecondFactor(user, code, "")
+	if err == nil || err.Error() != "two-factor code has already been used" {
+		t.Fatalf("replayed VerifySecondFactor() error = %v, want code already used", err)
+	}
+}
+
+func TestLoginWithTwoFactorDefersRecordSuccess(t *testing.T) {
+	user := twoFactorUser(t)
+	guard := &fakeLoginGuard{}
+	svc := &userService{
+		userRepo:     newFakeUserRepo(user),
+		loginGuard:   guard,
+		tokenManager: token.NewTokenManager("test-secret"),
+		mfaCfg:       configs.MFAConfig{ChallengeTTL: 5 * time.Minute},
+	}
+
+	resp, err := svc.Login(&dto.LoginRequest{Email: user.Email, Password: "correct horse"}, "203.0.113.7")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if !resp.MFARequired || resp.MFAToken == "" {
+		t.Fatalf("Login() = %+v, want an MFA challenge", resp)
+	}
+	if resp.AccessToken != "" {
+		t.Errorf("Login() issued an access token before the second factor")
+	}
+	if guard.successes != 0 {
+		t.Errorf("RecordSuccess called %d times before the second factor, want 0", guard.successes)
+	}
+}
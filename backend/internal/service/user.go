@@ -1,12 +1,22 @@
 package service
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
+	"time"
 
+	"github.com/cartrack/backend/configs"
 	"github.com/cartrack/backend/internal/entity"
 	"github.com/cartrack/backend/internal/http/dto"
 	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/mailer"
+	"github.com/cartrack/backend/pkg/permission"
 	"github.com/cartrack/backend/pkg/token"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -15,7 +25,8 @@ import (
 // UserService defines user service interface
 type UserService interface {
 	Register(req *dto.RegisterRequest) (*dto.UserResponse, error)
-	Login(req *dto.LoginRequest) (*dto.LoginResponse, error)
+	Login(req *dto.LoginRequest, ipAddress string) (*dto.LoginResponse, error)
+	CompleteMFALogin(req *dto.MFALoginRequest, ipAddress string) (*dto.LoginResponse, error)
 	GetProfile(userID uint) (*dto.UserResponse, error)
 	UpdateProfile(userID uint, req *dto.UpdateUserRequest) (*dto.UserResponse, error)
 	ChangePassword(userID uint, req *dto.ChangePasswordRequest) error
@@ -23,19 +34,44 @@ type UserService interface {
 	GetAllUsers(limit, offset int) ([]dto.UserResponse, error)
 	GetUserByID(id uint) (*dto.UserResponse, error)
 	DeleteUser(id uint) error
+	ForgotPassword(req *dto.ForgotPasswordRequest, ipAddress string) error
+	ResetPassword(req *dto.ResetPasswordRequest) error
+	VerifyEmail(req *dto.VerifyEmailRequest) (*dto.UserResponse, error)
+	ResendVerification(userID uint) error
+	UnlockUser(id uint) error
+	PurgeExpiredTokens(ctx context.Context) error
 }
 
+const (
+	passwordResetTTL     = time.Hour
+	emailVerificationTTL = 24 * time.Hour
+)
+
 // userService implements UserService interface
 type userService struct {
-	userRepo     repository.UserRepository
-	tokenManager *token.TokenManager
+	userRepo      repository.UserRepository
+	userTokenRepo repository.UserTokenRepository
+	loginGuard    LoginProtectionService
+	twoFactor     TwoFactorService
+	tokenManager  *token.TokenManager
+	mailer        mailer.Mailer
+	appURL        string
+	mfaCfg        configs.MFAConfig
+	permissions   permission.Resolver
 }
 
 // NewUserService creates new user service instance
-func NewUserService(userRepo repository.UserRepository, tokenManager *token.TokenManager) UserService {
+func NewUserService(cfg *configs.Config, userRepo repository.UserRepository, userTokenRepo repository.UserTokenRepository, loginGuard LoginProtectionService, twoFactor TwoFactorService, permissions permission.Resolver, tokenManager *token.TokenManager, mailer mailer.Mailer) UserService {
 	return &userService{
-		userRepo:     userRepo,
-		tokenManager: tokenManager,
+		userRepo:      userRepo,
+		userTokenRepo: userTokenRepo,
+		loginGuard:    loginGuard,
+		twoFactor:     twoFactor,
+		tokenManager:  tokenManager,
+		mailer:        mailer,
+		appURL:        strings.TrimRight(cfg.AppURL, "/"),
+		mfaCfg:        cfg.MFA,
+		permissions:   permissions,
 	}
 }
 
@@ -61,37 +97,67 @@ func (s *userService) Register(req *dto.RegisterRequest) (*dto.UserResponse, err
 		Name:         req.Name,
 		Email:        req.Email,
 		PasswordHash: string(hashedPassword),
-		Role:         "user", // Default role
+		Role:         entity.RoleUser, // Default role
 	}
 
 	if req.PhoneNumber != "" {
 		user.PhoneNumber = &req.PhoneNumber
 	}
 
-	// Save user to database
-	if err := s.userRepo.Create(user); err != nil {
+	// Every user starts with a personal fleet they own; more can be created or joined later
+	if err := s.userRepo.CreateWithOrganization(user, personalOrganization(user)); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	// Registration succeeds even if the mail cannot be sent; the user can request a new link
+	if err := s.sendVerificationEmail(user); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+	}
+
 	return s.entityToResponse(user), nil
 }
 
 // Login authenticates user and returns tokens
-func (s *userService) Login(req *dto.LoginRequest) (*dto.LoginResponse, error) {
+func (s *userService) Login(req *dto.LoginRequest, ipAddress string) (*dto.LoginResponse, error) {
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(req.Email)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("invalid email or password")
-		}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	// Reject early if the account or IP is locked out
+	if err := s.loginGuard.CheckAllowed(user, ipAddress); err != nil {
+		return nil, err
+	}
+
 	// Verify password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+	if user == nil || bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)) != nil {
+		if err := s.loginGuard.RecordFailure(user, req.Email, ipAddress); err != nil {
+			log.Printf("Failed to record failed login for %s: %v", req.Email, err)
+		}
 		return nil, errors.New("invalid email or password")
 	}
 
+	// Two-factor users get a challenge token instead of access tokens; the lockout counters are only
+	// reset once the second factor has been verified in CompleteMFALogin
+	if user.TwoFactorEnabled {
+		mfaToken, err := s.tokenManager.GenerateMFAChallengeToken(int(user.ID), user.Email, s.mfaCfg.ChallengeTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate MFA challenge: %w", err)
+		}
+
+		return &dto.LoginResponse{
+			TokenType:   "Bearer",
+			ExpiresIn:   int(s.mfaCfg.ChallengeTTL.Seconds()),
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		}, nil
+	}
+
+	if err := s.loginGuard.RecordSuccess(user, ipAddress); err != nil {
+		log.Printf("Failed to record successful login for user %d: %v", user.ID, err)
+	}
+
 	// Generate tokens
 	accessToken, refreshToken, err := s.tokenManager.GenerateTokenPair(
 		int(user.ID),
@@ -104,7 +170,73 @@ func (s *userService) Login(req *dto.LoginRequest) (*dto.LoginResponse, error) {
 	}
 
 	return &dto.LoginResponse{
-		User:         *s.entityToResponse(user),
+		User:                  s.entityToResponse(user),
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             3600 * 24, // 1 day
+		MFAEnrollmentRequired: s.mfaEnrollmentRequired(user),
+	}, nil
+}
+
+// mfaEnrollmentRequired checks if the user must enroll in two-factor before using privileged routes
+func (s *userService) mfaEnrollmentRequired(user *entity.User) bool {
+	if !s.mfaCfg.RequireForAdmin {
+		return false
+	}
+
+	privileged, err := permission.IsPrivileged(s.permissions, user.Role)
+	if err != nil {
+		// Log error but don't fail the request; the MFA middleware enforces enrollment anyway
+		log.Printf("Failed to check privileges of role %s: %v", user.Role, err)
+		return true
+	}
+	return privileged
+}
+
+// CompleteMFALogin exchanges an MFA challenge token and a second factor for access tokens
+func (s *userService) CompleteMFALogin(req *dto.MFALoginRequest, ipAddress string) (*dto.LoginResponse, error) {
+	claims, err := s.tokenManager.ValidateToken(req.MFAToken)
+	if err != nil || !claims.IsMFAChallenge() {
+		return nil, errors.New("invalid or expired MFA token")
+	}
+
+	user, err := s.userRepo.GetByID(uint(claims.UserID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired MFA token")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Second-factor guesses count towards the same lockout as passwords
+	if err := s.loginGuard.CheckAllowed(user, ipAddress); err != nil {
+		return nil, err
+	}
+
+	if err := s.twoFactor.VerifySecondFactor(user, req.Code, req.RecoveryCode); err != nil {
+		if recordErr := s.loginGuard.RecordFailure(user, user.Email, ipAddress); recordErr != nil {
+			log.Printf("Failed to record failed MFA login for user %d: %v", user.ID, recordErr)
+		}
+		return nil, err
+	}
+
+	if err := s.loginGuard.RecordSuccess(user, ipAddress); err != nil {
+		log.Printf("Failed to record successful login for user %d: %v", user.ID, err)
+	}
+
+	accessToken, refreshToken, err := s.tokenManager.GenerateVerifiedTokenPair(
+		int(user.ID),
+		user.Email,
+		user.Name,
+		user.Role,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+
+	return &dto.LoginResponse{
+		User:         s.entityToResponse(user),
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		TokenType:    "Bearer",
@@ -144,7 +276,7 @@ func (s *userService) UpdateProfile(userID uint, req *dto.UpdateUserRequest) (*d
 	}
 
 	// Save updated user
-	if err := s.userRepo.Update(user); err != nil {
+	if err := s.userRepo.UpdateProfile(user); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
@@ -173,8 +305,7 @@ func (s *userService) ChangePassword(userID uint, req *dto.ChangePasswordRequest
 	}
 
 	// Update password
-	user.PasswordHash = string(hashedPassword)
-	if err := s.userRepo.Update(user); err != nil {
+	if err := s.userRepo.UpdatePassword(user.ID, string(hashedPassword)); err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
 
@@ -185,16 +316,29 @@ func (s *userService) ChangePassword(userID uint, req *dto.ChangePasswordRequest
 func (s *userService) RefreshToken(req *dto.RefreshTokenRequest) (*dto.RefreshTokenResponse, error) {
 	// Validate refresh token
 	claims, err := s.tokenManager.ValidateToken(req.RefreshToken)
-	if err != nil {
+	if err != nil || claims.IsMFAChallenge() {
 		return nil, errors.New("invalid refresh token")
 	}
 
-	// Generate new access token
-	accessToken, err := s.tokenManager.GenerateAccessToken(
-		claims.UserID,
-		claims.Email,
-		claims.Username,
-		claims.Role,
+	// Reload the user so role changes apply to the new token
+	user, err := s.userRepo.GetByID(uint(claims.UserID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid refresh token")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Generate new access token, keeping the second-factor state of the session
+	generate := s.tokenManager.GenerateAccessToken
+	if claims.MFAVerified {
+		generate = s.tokenManager.GenerateVerifiedAccessToken
+	}
+	accessToken, err := generate(
+		int(user.ID),
+		user.Email,
+		user.Name,
+		user.Role,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
@@ -254,15 +398,221 @@ func (s *userService) DeleteUser(id uint) error {
 	return nil
 }
 
+// UnlockUser clears a brute-force lockout on a user account (admin only)
+func (s *userService) UnlockUser(id uint) error {
+	return s.loginGuard.Unlock(id)
+}
+
+// ForgotPassword sends a password reset link if the email is registered.
+// It never reveals whether the email exists: failures after the account was found are only logged.
+func (s *userService) ForgotPassword(req *dto.ForgotPasswordRequest, ipAddress string) error {
+	// Reset requests share the per-IP login limit so the endpoint cannot be used to flood inboxes
+	if err := s.loginGuard.CheckAllowed(nil, ipAddress); err != nil {
+		return err
+	}
+	if err := s.loginGuard.RecordFailure(nil, req.Email, ipAddress); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.sendPasswordReset(user); err != nil {
+		log.Printf("Failed to send password reset link to user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// sendPasswordReset issues a new password reset token for the user and emails the link
+func (s *userService) sendPasswordReset(user *entity.User) error {
+	// Only the most recent reset link stays valid
+	if err := s.userTokenRepo.InvalidateByUserID(user.ID, entity.TokenPurposePasswordReset); err != nil {
+		return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+	}
+
+	rawToken, err := s.issueToken(user.ID, entity.TokenPurposePasswordReset, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", s.appURL, rawToken)
+	msg := mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Reset your Cartrack password",
+		Body: fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\nThe link expires in %d minutes. If you did not request this, you can ignore this email.",
+			user.Name, link, int(passwordResetTTL.Minutes())),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		return fmt.Errorf("failed to send reset email: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token
+func (s *userService) ResetPassword(req *dto.ResetPasswordRequest) error {
+	userToken, err := s.redeemToken(req.Token, entity.TokenPurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.userRepo.UpdatePassword(userToken.UserID, string(hashedPassword)); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	// Receiving the reset link proves ownership of the mailbox
+	if userToken.User.EmailVerifiedAt == nil {
+		if err := s.userRepo.MarkEmailVerified(userToken.UserID, time.Now()); err != nil {
+			return fmt.Errorf("failed to verify email: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the user's email as verified using a verification token
+func (s *userService) VerifyEmail(req *dto.VerifyEmailRequest) (*dto.UserResponse, error) {
+	userToken, err := s.redeemToken(req.Token, entity.TokenPurposeEmailVerification)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &userToken.User
+	if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		if err := s.userRepo.MarkEmailVerified(user.ID, now); err != nil {
+			return nil, fmt.Errorf("failed to verify email: %w", err)
+		}
+		user.EmailVerifiedAt = &now
+	}
+
+	return s.entityToResponse(user), nil
+}
+
+// ResendVerification sends a new verification link to the user
+func (s *userService) ResendVerification(userID uint) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.IsEmailVerified() {
+		return errors.New("email already verified")
+	}
+
+	if err := s.userTokenRepo.InvalidateByUserID(user.ID, entity.TokenPurposeEmailVerification); err != nil {
+		return fmt.Errorf("failed to invalidate verification tokens: %w", err)
+	}
+
+	return s.sendVerificationEmail(user)
+}
+
+// sendVerificationEmail issues a verification token and mails the link to the user
+func (s *userService) sendVerificationEmail(user *entity.User) error {
+	rawToken, err := s.issueToken(user.ID, entity.TokenPurposeEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", s.appURL, rawToken)
+	msg := mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Verify your Cartrack email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %d hours.",
+			user.Name, link, int(emailVerificationTTL.Hours())),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeExpiredTokens deletes password reset and email verification tokens that have expired
+func (s *userService) PurgeExpiredTokens(ctx context.Context) error {
+	if err := s.userTokenRepo.DeleteExpired(time.Now()); err != nil {
+		return fmt.Errorf("failed to purge expired tokens: %w", err)
+	}
+	return nil
+}
+
+// issueToken generates a random token, stores its hash and returns the raw value
+func (s *userService) issueToken(userID uint, purpose entity.TokenPurpose, ttl time.Duration) (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	rawToken := hex.EncodeToString(bytes)
+
+	userToken := &entity.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(rawToken),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.userTokenRepo.Create(userToken); err != nil {
+		return "", fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return rawToken, nil
+}
+
+// redeemToken validates a raw token and marks it as used
+func (s *userService) redeemToken(rawToken string, purpose entity.TokenPurpose) (*entity.UserToken, error) {
+	userToken, err := s.userTokenRepo.GetByHash(hashToken(rawToken), purpose)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired token")
+		}
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	if !userToken.IsUsable(time.Now()) {
+		return nil, errors.New("invalid or expired token")
+	}
+
+	// MarkUsed only succeeds once, so concurrent redemptions of the same token fail here
+	if err := s.userTokenRepo.MarkUsed(userToken.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired token")
+		}
+		return nil, fmt.Errorf("failed to redeem token: %w", err)
+	}
+
+	return userToken, nil
+}
+
+// hashToken returns the hex-encoded SHA-256 of a raw token
+func hashToken(rawToken string) string {
+	sum := sha256.Sum256([]byte(rawToken))
+	return hex.EncodeToString(sum[:])
+}
+
 // entityToResponse converts user entity to response DTO
 func (s *userService) entityToResponse(user *entity.User) *dto.UserResponse {
 	return &dto.UserResponse{
-		ID:          user.ID,
-		Name:        user.Name,
-		Email:       user.Email,
-		PhoneNumber: user.PhoneNumber,
-		Role:        user.Role,
-		CreatedAt:   user.CreatedAt,
-		UpdatedAt:   user.UpdatedAt,
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		PhoneNumber:      user.PhoneNumber,
+		Role:             user.Role,
+		EmailVerifiedAt:  user.EmailVerifiedAt,
+		LockedUntil:      user.LockedUntil,
+		TwoFactorEnabled: user.TwoFactorEnabled,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
 	}
 }
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/mailer"
+	"github.com/cartrack/backend/pkg/permission"
+)
+
+// registerUserRepo fails or records CreateWithOrganization on top of fakeUserRepo
+type registerUserRepo struct {
+	*fakeUserRepo
+	createErr     error
+	organizations []*entity.Organization
+}
+
+func (r *registerUserRepo) CreateWithOrganization(user *entity.User, organization *entity.Organization) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	user.ID = uint(len(r.users) + 1)
+	r.users[user.ID] = user
+	r.organizations = append(r.organizations, organization)
+	return nil
+}
+
+// fakeUserTokenRepo keeps the tokens it is asked to store
+type fakeUserTokenRepo struct {
+	repository.UserTokenRepository
+	tokens []*entity.UserToken
+}
+
+func (r *fakeUserTokenRepo) Create(userToken *entity.UserToken) error {
+	r.tokens = append(r.tokens, userToken)
+	return nil
+}
+
+func (r *fakeUserTokenRepo) InvalidateByUserID(userID uint, purpose entity.TokenPurpose) error {
+	return nil
+}
+
+func (r *fakeUserTokenRepo) DeleteExpired(before time.Time) error {
+	var kept []*entity.UserToken
+	for _, userToken := range r.tokens {
+		if !userToken.ExpiresAt.Before(before) {
+			kept = append(kept, userToken)
+		}
+	}
+	r.tokens = kept
+	return nil
+}
+
+// fakeMailer collects sent messages
+type fakeMailer struct {
+	sent []mailer.Message
+}
+
+func (m *fakeMailer) Send(msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func TestRegisterCreatesPersonalOrganization(t *testing.T) {
+	repo := &registerUserRepo{fakeUserRepo: newFakeUserRepo()}
+	mail := &fakeMailer{}
+	svc := &userService{userRepo: repo, userTokenRepo: &fakeUserTokenRepo{}, mailer: mail}
+
+	resp, err := svc.Register(&dto.RegisterRequest{Name: "Dina", Email: "dina@example.com", Password: "secret123"})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if resp.ID == 0 {
+		t.Errorf("Register() returned a user without ID")
+	}
+	if len(repo.organizations) != 1 || repo.organizations[0].Name != "Dina's Fleet" {
+		t.Errorf("organizations = %+v, want one named %q", repo.organizations, "Dina's Fleet")
+	}
+	if len(mail.sent) != 1 {
+		t.Errorf("sent %d mails, want 1 verification mail", len(mail.sent))
+	}
+}
+
+func TestRegisterFailsWhenOrganizationCannotBeCreated(t *testing.T) {
+	repo := &registerUserRepo{fakeUserRepo: newFakeUserRepo(), createErr: errors.New("insert organization: connection reset")}
+	mail := &fakeMailer{}
+	svc := &userService{userRepo: repo, userTokenRepo: &fakeUserTokenRepo{}, mailer: mail}
+
+	if _, err := svc.Register(&dto.RegisterRequest{Name: "Dina", Email: "dina@example.com", Password: "secret123"}); err == nil {
+		t.Fatal("Register() error = nil, want the creation error")
+	}
+	if len(repo.users) != 0 {
+		t.Errorf("users = %d, want none left behind", len(repo.users))
+	}
+	if len(mail.sent) != 0 {
+		t.Errorf("sent %d mails for a failed registration, want 0", len(mail.sent))
+	}
+}
+
+// fakeResolver grants each role a fixed set of permissions
+type fakeResolver struct {
+	roles map[string][]string
+	err   error
+}
+
+func (r *fakeResolver) UserRole(userID uint) (string, error) {
+	return "", permission.ErrUnknownUser
+}
+
+func (r *fakeResolver) RoleHasPermissions(role string, required []string) (bool, error) {
+	if r.err != nil {
+		return false, r.err
+	}
+	granted := make(map[string]bool)
+	for _, name := range r.roles[role] {
+		granted[name] = true
+	}
+	for _, name := range required {
+		if !granted[name] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func TestMFAEnrollmentRequired(t *testing.T) {
+	resolver := &fakeResolver{roles: map[string][]string{
+		"admin":         {permission.UsersManage, permission.RolesManage},
+		"fleet_manager": {permission.UsersManage},
+		"user":          {permission.VehiclesRead},
+	}}
+
+	tests := []struct {
+		name     string
+		require  bool
+		resolver permission.Resolver
+		role     string
+		want     bool
+	}{
+		{name: "admin", require: true, resolver: resolver, role: "admin", want: true},
+		{name: "custom role managing users", require: true, resolver: resolver, role: "fleet_manager", want: true},
+		{name: "unprivileged role", require: true, resolver: resolver, role: "user", want: false},
+		{name: "not required", require: false, resolver: resolver, role: "admin", want: false},
+		{name: "resolver error fails closed", require: true, resolver: &fakeResolver{err: errors.New("db down")}, role: "user", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &userService{mfaCfg: configs.MFAConfig{RequireForAdmin: tt.require}, permissions: tt.resolver}
+			if got := svc.mfaEnrollmentRequired(&entity.User{Role: tt.role}); got != tt.want {
+				t.Errorf("mfaEnrollmentRequired(%s) = %v, want %v", tt.role, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPurgeExpiredTokens(t *testing.T) {
+	now := time.Now()
+	tokens := &fakeUserTokenRepo{tokens: []*entity.UserToken{
+		{ID: 1, Purpose: entity.TokenPurposePasswordReset, ExpiresAt: now.Add(-time.Minute)},
+		{ID: 2, Purpose: entity.TokenPurposePasswordReset, ExpiresAt: now.Add(time.Hour)},
+	}}
+	svc := &userService{userTokenRepo: tokens}
+
+	if err := svc.PurgeExpiredTokens(context.Background()); err != nil {
+		t.Fatalf("PurgeExpiredTokens() error = %v", err)
+	}
+	if len(tokens.tokens) != 1 || tokens.tokens[0].ID != 2 {
+		t.Errorf("tokens left = %+v, want only the unexpired token", tokens.tokens)
+	}
+}
+
+func TestForgotPasswordSharesLoginIPLimit(t *testing.T) {
+	user := &entity.User{ID: 1, Name: "Dina", Email: "dina@example.com"}
+
+	tests := []struct {
+		name     string
+		blocked  error
+		wantErr  error
+		wantSent int
+	}{
+		{name: "allowed", wantSent: 1},
+		{name: "IP blocked", blocked: ErrTooManyLoginAttempts, wantErr: ErrTooManyLoginAttempts, wantSent: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := &fakeLoginGuard{blocked: tt.blocked}
+			mail := &fakeMailer{}
+			svc := &userService{
+				userRepo:      newFakeUserRepo(user),
+				userTokenRepo: &fakeUserTokenRepo{},
+				loginGuard:    guard,
+				mailer:        mail,
+			}
+
+			err := svc.ForgotPassword(&dto.ForgotPasswordRequest{Email: user.Email}, "203.0.113.7")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ForgotPassword() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(mail.sent) != tt.wantSent {
+				t.Errorf("sent %d emails, want %d", len(mail.sent), tt.wantSent)
+			}
+			if tt.blocked == nil && guard.failures != 1 {
+				t.Errorf("recorded %d attempts against the IP, want 1", guard.failures)
+			}
+		})
+	}
+}
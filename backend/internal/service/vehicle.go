@@ -18,22 +18,31 @@ type VehicleService interface {
 	Update(userID, vehicleID uint, req *dto.UpdateVehicleRequest) (*dto.VehicleResponse, error)
 	Delete(userID, vehicleID uint) error
 	GetAll(limit, offset int) ([]dto.VehicleResponse, error) // Admin only
+	GetByOrganization(organizationID, vehicleID uint) (*dto.VehicleResponse, error)
+	GetByOrganizationID(organizationID uint, limit, offset int) ([]dto.VehicleResponse, error)
 }
 
 // vehicleService implements VehicleService interface
 type vehicleService struct {
-	vehicleRepo repository.VehicleRepository
+	vehicleRepo         repository.VehicleRepository
+	organizationService OrganizationService
 }
 
 // NewVehicleService creates new vehicle service instance
-func NewVehicleService(vehicleRepo repository.VehicleRepository) VehicleService {
+func NewVehicleService(vehicleRepo repository.VehicleRepository, organizationService OrganizationService) VehicleService {
 	return &vehicleService{
-		vehicleRepo: vehicleRepo,
+		vehicleRepo:         vehicleRepo,
+		organizationService: organizationService,
 	}
 }
 
 // Create creates a new vehicle
 func (s *vehicleService) Create(userID uint, req *dto.CreateVehicleRequest) (*dto.VehicleResponse, error) {
+	organizationID, err := s.organizationService.ResolveOrganizationID(userID, req.OrganizationID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if plate number already exists
 	existingVehicle, err := s.vehicleRepo.GetByPlateNumber(req.PlateNumber)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -55,8 +64,9 @@ func (s *vehicleService) Create(userID uint, req *dto.CreateVehicleRequest) (*dt
 	}
 
 	vehicle := &entity.Vehicle{
-		UserID:      userID,
-		PlateNumber: req.PlateNumber,
+		UserID:         userID,
+		OrganizationID: organizationID,
+		PlateNumber:    req.PlateNumber,
 	}
 
 	if req.Model != "" {
@@ -74,9 +84,14 @@ func (s *vehicleService) Create(userID uint, req *dto.CreateVehicleRequest) (*dt
 	return s.entityToResponse(vehicle), nil
 }
 
-// GetByUserID gets vehicles by user ID with pagination
+// GetByUserID gets vehicles of every organization the user belongs to with pagination
 func (s *vehicleService) GetByUserID(userID uint, limit, offset int) ([]dto.VehicleResponse, error) {
-	vehicles, err := s.vehicleRepo.GetByUserID(userID, limit, offset)
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles, err := s.vehicleRepo.GetByOrganizationIDs(organizationIDs, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vehicles: %w", err)
 	}
@@ -89,8 +104,18 @@ func (s *vehicleService) GetByUserID(userID uint, limit, offset int) ([]dto.Vehi
 	return responses, nil
 }
 
-// GetByID gets vehicle by ID (with ownership check)
+// GetByID gets vehicle by ID (with organization membership check)
 func (s *vehicleService) GetByID(userID, vehicleID uint) (*dto.VehicleResponse, error) {
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.entityToResponse(vehicle), nil
+}
+
+// GetByOrganization gets vehicle by ID only if it belongs to the organization (ESP32 access)
+func (s *vehicleService) GetByOrganization(organizationID, vehicleID uint) (*dto.VehicleResponse, error) {
 	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -99,29 +124,34 @@ func (s *vehicleService) GetByID(userID, vehicleID uint) (*dto.VehicleResponse,
 		return nil, fmt.Errorf("failed to get vehicle: %w", err)
 	}
 
-	// If userID is 0, this is ESP32 access - skip ownership verification
-	// Otherwise, check ownership
-	if userID != 0 && vehicle.UserID != userID {
-		return nil, errors.New("vehicle not found") // Don't reveal existence of other user's vehicles
+	if vehicle.OrganizationID != organizationID {
+		return nil, errors.New("vehicle not found") // Don't reveal existence of other organizations' vehicles
 	}
 
 	return s.entityToResponse(vehicle), nil
 }
 
-// Update updates vehicle information
-func (s *vehicleService) Update(userID, vehicleID uint, req *dto.UpdateVehicleRequest) (*dto.VehicleResponse, error) {
-	// Get existing vehicle
-	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
+// GetByOrganizationID gets vehicles of an organization with pagination (ESP32 access)
+func (s *vehicleService) GetByOrganizationID(organizationID uint, limit, offset int) ([]dto.VehicleResponse, error) {
+	vehicles, err := s.vehicleRepo.GetByOrganizationIDs([]uint{organizationID}, limit, offset)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("vehicle not found")
-		}
-		return nil, fmt.Errorf("failed to get vehicle: %w", err)
+		return nil, fmt.Errorf("failed to get vehicles: %w", err)
 	}
 
-	// Check ownership
-	if vehicle.UserID != userID {
-		return nil, errors.New("vehicle not found")
+	responses := make([]dto.VehicleResponse, len(vehicles))
+	for i, vehicle := range vehicles {
+		responses[i] = *s.entityToResponse(&vehicle)
+	}
+
+	return responses, nil
+}
+
+// Update updates vehicle information
+func (s *vehicleService) Update(userID, vehicleID uint, req *dto.UpdateVehicleRequest) (*dto.VehicleResponse, error) {
+	// Get existing vehicle and check the user may manage it
+	vehicle, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
 	}
 
 	// Check if new plate number already exists (exclude current vehicle)
@@ -162,18 +192,9 @@ func (s *vehicleService) Update(userID, vehicleID uint, req *dto.UpdateVehicleRe
 
 // Delete deletes a vehicle
 func (s *vehicleService) Delete(userID, vehicleID uint) error {
-	// Get existing vehicle
-	vehicle, err := s.vehicleRepo.GetByID(vehicleID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("vehicle not found")
-		}
-		return fmt.Errorf("failed to get vehicle: %w", err)
-	}
-
-	// Check ownership
-	if vehicle.UserID != userID {
-		return errors.New("vehicle not found")
+	// Check the user may manage the vehicle
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return err
 	}
 
 	if err := s.vehicleRepo.Delete(vehicleID); err != nil {
@@ -201,12 +222,13 @@ func (s *vehicleService) GetAll(limit, offset int) ([]dto.VehicleResponse, error
 // entityToResponse converts entity to response DTO
 func (s *vehicleService) entityToResponse(vehicle *entity.Vehicle) *dto.VehicleResponse {
 	return &dto.VehicleResponse{
-		ID:          vehicle.ID,
-		UserID:      vehicle.UserID,
-		PlateNumber: vehicle.PlateNumber,
-		Model:       vehicle.Model,
-		IMEI:        vehicle.IMEI,
-		CreatedAt:   vehicle.CreatedAt,
-		UpdatedAt:   vehicle.UpdatedAt,
+		ID:             vehicle.ID,
+		UserID:         vehicle.UserID,
+		OrganizationID: vehicle.OrganizationID,
+		PlateNumber:    vehicle.PlateNumber,
+		Model:          vehicle.Model,
+		IMEI:           vehicle.IMEI,
+		CreatedAt:      vehicle.CreatedAt,
+		UpdatedAt:      vehicle.UpdatedAt,
 	}
 }
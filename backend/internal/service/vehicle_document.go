@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/blobstore"
+	"gorm.io/gorm"
+)
+
+// DocumentFile is an attachment opened for download. The caller must close Content.
+type DocumentFile struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
+
+// VehicleDocumentService defines vehicle document service interface
+type VehicleDocumentService interface {
+	Create(userID, vehicleID uint, req *dto.CreateVehicleDocumentRequest) (*dto.VehicleDocumentResponse, error)
+	GetByVehicleID(userID, vehicleID uint) ([]dto.VehicleDocumentResponse, error)
+	GetByID(userID, vehicleID, documentID uint) (*dto.VehicleDocumentResponse, error)
+	Update(userID, vehicleID, documentID uint, req *dto.UpdateVehicleDocumentRequest) (*dto.VehicleDocumentResponse, error)
+	Delete(userID, vehicleID, documentID uint) error
+	UploadFile(userID, vehicleID, documentID uint, name, contentType string, size int64, content io.Reader) (*dto.VehicleDocumentResponse, error)
+	OpenFile(userID, vehicleID, documentID uint) (*DocumentFile, error)
+	DeleteFile(userID, vehicleID, documentID uint) error
+	GetExpiring(userID uint, withinDays int) ([]dto.VehicleDocumentResponse, error)
+	CheckExpiring(ctx context.Context) error
+}
+
+// vehicleDocumentService implements VehicleDocumentService interface
+type vehicleDocumentService struct {
+	cfg                 configs.DocumentsConfig
+	documentRepo        repository.VehicleDocumentRepository
+	vehicleRepo         repository.VehicleRepository
+	systemLogRepo       repository.SystemLogRepository
+	organizationService OrganizationService
+	blobs               blobstore.Store
+}
+
+// NewVehicleDocumentService creates new vehicle document service instance
+func NewVehicleDocumentService(cfg configs.DocumentsConfig, documentRepo repository.VehicleDocumentRepository, vehicleRepo repository.VehicleRepository, systemLogRepo repository.SystemLogRepository, organizationService OrganizationService, blobs blobstore.Store) VehicleDocumentService {
+	return &vehicleDocumentService{
+		cfg:                 cfg,
+		documentRepo:        documentRepo,
+		vehicleRepo:         vehicleRepo,
+		systemLogRepo:       systemLogRepo,
+		organizationService: organizationService,
+		blobs:               blobs,
+	}
+}
+
+// Create creates a document for a vehicle
+func (s *vehicleDocumentService) Create(userID, vehicleID uint, req *dto.CreateVehicleDocumentRequest) (*dto.VehicleDocumentResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := time.Parse("2006-01-02", req.ExpiresAt)
+	if err != nil {
+		return nil, errors.New("invalid expiry date format, use YYYY-MM-DD")
+	}
+
+	document := &entity.VehicleDocument{
+		VehicleID: vehicleID,
+		Type:      entity.DocumentType(req.Type),
+		Number:    req.Number,
+		ExpiresAt: expiresAt,
+	}
+	if req.Issuer != "" {
+		document.Issuer = &req.Issuer
+	}
+	if req.IssuedAt != "" {
+		issuedAt, err := time.Parse("2006-01-02", req.IssuedAt)
+		if err != nil {
+			return nil, errors.New("invalid issue date format, use YYYY-MM-DD")
+		}
+		document.IssuedAt = &issuedAt
+	}
+	if req.Notes != "" {
+		document.Notes = &req.Notes
+	}
+
+	if err := validateDocumentDates(document); err != nil {
+		return nil, err
+	}
+
+	if err := s.documentRepo.Create(document); err != nil {
+		return nil, fmt.Errorf("failed to create document: %w", err)
+	}
+
+	return s.entityToResponse(document, time.Now()), nil
+}
+
+// GetByVehicleID gets documents of a vehicle
+func (s *vehicleDocumentService) GetByVehicleID(userID, vehicleID uint) ([]dto.VehicleDocumentResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionRead); err != nil {
+		return nil, err
+	}
+
+	documents, err := s.documentRepo.GetByVehicleID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+
+	now := time.Now()
+	responses := make([]dto.VehicleDocumentResponse, len(documents))
+	for i, document := range documents {
+		responses[i] = *s.entityToResponse(&document, now)
+	}
+
+	return responses, nil
+}
+
+// GetByID gets a document of a vehicle
+func (s *vehicleDocumentService) GetByID(userID, vehicleID, documentID uint) (*dto.VehicleDocumentResponse, error) {
+	document, err := s.authorizeDocument(userID, vehicleID, documentID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.entityToResponse(document, time.Now()), nil
+}
+
+// Update updates a document. A new expiry date re-arms the expiry flag.
+func (s *vehicleDocumentService) Update(userID, vehicleID, documentID uint, req *dto.UpdateVehicleDocumentRequest) (*dto.VehicleDocumentResponse, error) {
+	document, err := s.authorizeDocument(userID, vehicleID, documentID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Type != "" {
+		document.Type = entity.DocumentType(req.Type)
+	}
+	if req.Number != "" {
+		document.Number = req.Number
+	}
+	if req.Issuer != nil {
+		document.Issuer = nil
+		if *req.Issuer != "" {
+			document.Issuer = req.Issuer
+		}
+	}
+	if req.IssuedAt != "" {
+		issuedAt, err := time.Parse("2006-01-02", req.IssuedAt)
+		if err != nil {
+			return nil, errors.New("invalid issue date format, use YYYY-MM-DD")
+		}
+		document.IssuedAt = &issuedAt
+	}
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse("2006-01-02", req.ExpiresAt)
+		if err != nil {
+			return nil, errors.New("invalid expiry date format, use YYYY-MM-DD")
+		}
+		if !expiresAt.Equal(document.ExpiresAt) {
+			document.ExpiresAt = expiresAt
+			document.ExpiryNotifiedAt = nil
+		}
+	}
+	if req.Notes != nil {
+		document.Notes = nil
+		if *req.Notes != "" {
+			document.Notes = req.Notes
+		}
+	}
+
+	if err := validateDocumentDates(document); err != nil {
+		return nil, err
+	}
+
+	if err := s.documentRepo.Update(document); err != nil {
+		return nil, fmt.Errorf("failed to update document: %w", err)
+	}
+
+	return s.entityToResponse(document, time.Now()), nil
+}
+
+// Delete deletes a document and its attachment
+func (s *vehicleDocumentService) Delete(userID, vehicleID, documentID uint) error {
+	document, err := s.authorizeDocument(userID, vehicleID, documentID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return err
+	}
+
+	if err := s.documentRepo.Delete(document.ID); err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+
+	if document.HasFile() {
+		if err := s.blobs.Delete(*document.FileKey); err != nil {
+			// Log error but don't fail the request, the document is already gone
+			log.Printf("Failed to delete attachment of document %d: %v", document.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// UploadFile attaches a file to a document, replacing any previous attachment
+func (s *vehicleDocumentService) UploadFile(userID, vehicleID, documentID uint, name, contentType string, size int64, content io.Reader) (*dto.VehicleDocumentResponse, error) {
+	document, err := s.authorizeDocument(userID, vehicleID, documentID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cfg.MaxFileSize > 0 && size > s.cfg.MaxFileSize {
+		return nil, fmt.Errorf("file is larger than the %d byte limit", s.cfg.MaxFileSize)
+	}
+
+	key, err := newDocumentFileKey(vehicleID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := s.blobs.Put(key, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	previous := document.FileKey
+	name = filepath.Base(name)
+	document.FileKey = &key
+	document.FileName = &name
+	document.FileContentType = &contentType
+	document.FileSize = &written
+
+	if err := s.documentRepo.Update(document); err != nil {
+		if delErr := s.blobs.Delete(key); delErr != nil {
+			log.Printf("Failed to clean up attachment %s: %v", key, delErr)
+		}
+		return nil, fmt.Errorf("failed to update document: %w", err)
+	}
+
+	if previous != nil {
+		if err := s.blobs.Delete(*previous); err != nil {
+			// Log error but don't fail the request
+			log.Printf("Failed to delete previous attachment of document %d: %v", document.ID, err)
+		}
+	}
+
+	return s.entityToResponse(document, time.Now()), nil
+}
+
+// OpenFile opens the attachment of a document for download
+func (s *vehicleDocumentService) OpenFile(userID, vehicleID, documentID uint) (*DocumentFile, error) {
+	document, err := s.authorizeDocument(userID, vehicleID, documentID, entity.OrgActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if !document.HasFile() {
+		return nil, errors.New("document has no attached file")
+	}
+
+	content, err := s.blobs.Open(*document.FileKey)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, errors.New("document has no attached file")
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	file := &DocumentFile{
+		Name:        *document.FileName,
+		ContentType: "application/octet-stream",
+		Content:     content,
+	}
+	if document.FileContentType != nil && *document.FileContentType != "" {
+		file.ContentType = *document.FileContentType
+	}
+	if document.FileSize != nil {
+		file.Size = *document.FileSize
+	}
+
+	return file, nil
+}
+
+// DeleteFile removes the attachment of a document
+func (s *vehicleDocumentService) DeleteFile(userID, vehicleID, documentID uint) error {
+	document, err := s.authorizeDocument(userID, vehicleID, documentID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return err
+	}
+
+	if !document.HasFile() {
+		return errors.New("document has no attached file")
+	}
+
+	key := *document.FileKey
+	document.FileKey = nil
+	document.FileName = nil
+	document.FileContentType = nil
+	document.FileSize = nil
+
+	if err := s.documentRepo.Update(document); err != nil {
+		return fmt.Errorf("failed to update document: %w", err)
+	}
+
+	if err := s.blobs.Delete(key); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+// GetExpiring gets expired documents and documents expiring within the given days across the user's fleet.
+// A non-positive window uses the configured expiry window.
+func (s *vehicleDocumentService) GetExpiring(userID uint, withinDays int) ([]dto.VehicleDocumentResponse, error) {
+	if withinDays <= 0 {
+		withinDays = s.cfg.ExpiryWindowDays
+	}
+
+	organizationIDs, err := s.organizationService.GetOrganizationIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	documents, err := s.documentRepo.GetExpiringByOrganizationIDs(organizationIDs, now.AddDate(0, 0, withinDays))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring documents: %w", err)
+	}
+
+	responses := make([]dto.VehicleDocumentResponse, len(documents))
+	for i, document := range documents {
+		responses[i] = *s.entityToResponse(&document, now)
+	}
+
+	return responses, nil
+}
+
+// CheckExpiring writes a WARNING system log for every document entering the expiry window, once per expiry date
+func (s *vehicleDocumentService) CheckExpiring(ctx context.Context) error {
+	now := time.Now()
+	documents, err := s.documentRepo.GetExpiringUnnotified(now.AddDate(0, 0, s.cfg.ExpiryWindowDays))
+	if err != nil {
+		return fmt.Errorf("failed to get expiring documents: %w", err)
+	}
+
+	for _, document := range documents {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		state := fmt.Sprintf("expires in %d day(s) on %s", document.DaysUntilExpiry(now), document.ExpiresAt.Format("2006-01-02"))
+		if document.DaysUntilExpiry(now) < 0 {
+			state = "expired on " + document.ExpiresAt.Format("2006-01-02")
+		}
+
+		vehicleID := document.VehicleID
+		systemLog := &entity.SystemLog{
+			VehicleID: &vehicleID,
+			LogType:   entity.LogTypeWarning,
+			Message:   fmt.Sprintf("[DOCUMENT] %s %s of vehicle %s %s", document.Type, document.Number, document.Vehicle.PlateNumber, state),
+		}
+		if err := s.systemLogRepo.Create(systemLog); err != nil {
+			return fmt.Errorf("failed to write document warning: %w", err)
+		}
+		if err := s.documentRepo.MarkExpiryNotified(document.ID, now); err != nil {
+			return fmt.Errorf("failed to mark document notified: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// authorizeDocument checks vehicle access and loads a document of that vehicle
+func (s *vehicleDocumentService) authorizeDocument(userID, vehicleID, documentID uint, action entity.OrgAction) (*entity.VehicleDocument, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, action); err != nil {
+		return nil, err
+	}
+
+	document, err := s.documentRepo.GetByID(documentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("document not found")
+		}
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if document.VehicleID != vehicleID {
+		return nil, errors.New("document not found")
+	}
+
+	return document, nil
+}
+
+// entityToResponse converts entity to response DTO
+func (s *vehicleDocumentService) entityToResponse(document *entity.VehicleDocument, now time.Time) *dto.VehicleDocumentResponse {
+	days := document.DaysUntilExpiry(now)
+	status := "valid"
+	switch {
+	case days < 0:
+		status = "expired"
+	case days <= s.cfg.ExpiryWindowDays:
+		status = "expiring"
+	}
+
+	return &dto.VehicleDocumentResponse{
+		ID:              document.ID,
+		VehicleID:       document.VehicleID,
+		PlateNumber:     document.Vehicle.PlateNumber,
+		Type:            string(document.Type),
+		Number:          document.Number,
+		Issuer:          document.Issuer,
+		IssuedAt:        document.IssuedAt,
+		ExpiresAt:       document.ExpiresAt,
+		DaysUntilExpiry: days,
+		Status:          status,
+		Notes:           document.Notes,
+		HasFile:         document.HasFile(),
+		FileName:        document.FileName,
+		FileContentType: document.FileContentType,
+		FileSize:        document.FileSize,
+		CreatedAt:       document.CreatedAt,
+		UpdatedAt:       document.UpdatedAt,
+	}
+}
+
+// validateDocumentDates checks the document is not issued after it expires
+func validateDocumentDates(document *entity.VehicleDocument) error {
+	if document.IssuedAt != nil && document.IssuedAt.After(document.ExpiresAt) {
+		return errors.New("issue date must be before the expiry date")
+	}
+	return nil
+}
+
+// newDocumentFileKey generates an unguessable blob key for a vehicle's document attachment
+func newDocumentFileKey(vehicleID uint, name string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate file key: %w", err)
+	}
+	return fmt.Sprintf("vehicle-documents/%d/%s%s", vehicleID, hex.EncodeToString(b), filepath.Ext(name)), nil
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/signedlink"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxShareDuration caps how long a single share may stay valid
+	maxShareDuration = 30 * 24 * time.Hour
+	shareLinkPurpose = "vehicle_share"
+)
+
+// ErrShareNotFound is returned for unknown, expired, revoked or inaccessible shares
+var ErrShareNotFound = errors.New("share not found or no longer active")
+
+// VehicleShareService defines vehicle share service interface
+type VehicleShareService interface {
+	Create(userID, vehicleID uint, req *dto.CreateVehicleShareRequest) (*dto.VehicleShareResponse, error)
+	GetByVehicleID(userID, vehicleID uint) ([]dto.VehicleShareResponse, error)
+	Revoke(userID, vehicleID, shareID uint) error
+	GetSharedWithMe(userID uint) ([]dto.SharedVehicleResponse, error)
+	GetSharedLocation(userID, shareID uint) (*dto.SharedLocationResponse, error)
+	GetPublicLocation(token string) (*dto.SharedLocationResponse, error)
+}
+
+// vehicleShareService implements VehicleShareService interface
+type vehicleShareService struct {
+	shareRepo           repository.VehicleShareRepository
+	vehicleRepo         repository.VehicleRepository
+	locationLogRepo     repository.LocationLogRepository
+	userRepo            repository.UserRepository
+	organizationService OrganizationService
+	signer              *signedlink.Signer
+	appURL              string
+}
+
+// NewVehicleShareService creates new vehicle share service instance
+func NewVehicleShareService(cfg *configs.Config, shareRepo repository.VehicleShareRepository, vehicleRepo repository.VehicleRepository, locationLogRepo repository.LocationLogRepository, userRepo repository.UserRepository, organizationService OrganizationService) VehicleShareService {
+	return &vehicleShareService{
+		shareRepo:           shareRepo,
+		vehicleRepo:         vehicleRepo,
+		locationLogRepo:     locationLogRepo,
+		userRepo:            userRepo,
+		organizationService: organizationService,
+		signer:              signedlink.NewSigner(cfg.JWT.SecretKey, shareLinkPurpose),
+		appURL:              strings.TrimRight(cfg.AppURL, "/"),
+	}
+}
+
+// Create grants a user or a signed link read access to a vehicle's location
+func (s *vehicleShareService) Create(userID, vehicleID uint, req *dto.CreateVehicleShareRequest) (*dto.VehicleShareResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var expiresAt time.Time
+	switch {
+	case req.ExpiresAt != nil:
+		expiresAt = *req.ExpiresAt
+	case req.ExpiresInMinutes > 0:
+		expiresAt = now.Add(time.Duration(req.ExpiresInMinutes) * time.Minute)
+	default:
+		return nil, errors.New("expires_at or expires_in_minutes is required")
+	}
+	if !expiresAt.After(now) {
+		return nil, errors.New("expiry must be in the future")
+	}
+	if expiresAt.Sub(now) > maxShareDuration {
+		return nil, fmt.Errorf("shares cannot last longer than %d days", int(maxShareDuration.Hours()/24))
+	}
+
+	share := &entity.VehicleShare{
+		VehicleID:       vehicleID,
+		CreatedByUserID: userID,
+		HistoryMinutes:  req.HistoryMinutes,
+		ExpiresAt:       expiresAt,
+	}
+	if req.Label != "" {
+		share.Label = &req.Label
+	}
+
+	if req.GranteeEmail != "" {
+		grantee, err := s.userRepo.GetByEmail(req.GranteeEmail)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("no registered user with this email")
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if grantee.ID == userID {
+			return nil, errors.New("you cannot share a vehicle with yourself")
+		}
+		share.GranteeUserID = &grantee.ID
+		share.GranteeUser = grantee
+	}
+
+	if err := s.shareRepo.Create(share); err != nil {
+		return nil, fmt.Errorf("failed to create share: %w", err)
+	}
+
+	return s.entityToResponse(share, now), nil
+}
+
+// GetByVehicleID gets all shares of a vehicle
+func (s *vehicleShareService) GetByVehicleID(userID, vehicleID uint) ([]dto.VehicleShareResponse, error) {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return nil, err
+	}
+
+	shares, err := s.shareRepo.GetByVehicleID(vehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shares: %w", err)
+	}
+
+	now := time.Now()
+	responses := make([]dto.VehicleShareResponse, len(shares))
+	for i, share := range shares {
+		responses[i] = *s.entityToResponse(&share, now)
+	}
+
+	return responses, nil
+}
+
+// Revoke ends a share immediately
+func (s *vehicleShareService) Revoke(userID, vehicleID, shareID uint) error {
+	if _, err := authorizeVehicle(s.vehicleRepo, s.organizationService, userID, vehicleID, entity.OrgActionManageVehicles); err != nil {
+		return err
+	}
+
+	share, err := s.shareRepo.GetByID(shareID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("share not found")
+		}
+		return fmt.Errorf("failed to get share: %w", err)
+	}
+	if share.VehicleID != vehicleID {
+		return errors.New("share not found")
+	}
+
+	if err := s.shareRepo.Revoke(share.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke share: %w", err)
+	}
+
+	return nil
+}
+
+// GetSharedWithMe gets active shares granted to the user
+func (s *vehicleShareService) GetSharedWithMe(userID uint) ([]dto.SharedVehicleResponse, error) {
+	shares, err := s.shareRepo.GetActiveByGranteeID(userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shares: %w", err)
+	}
+
+	responses := make([]dto.SharedVehicleResponse, len(shares))
+	for i, share := range shares {
+		responses[i] = dto.SharedVehicleResponse{
+			ShareID:        share.ID,
+			Label:          share.Label,
+			HistoryMinutes: share.HistoryMinutes,
+			ExpiresAt:      share.ExpiresAt,
+		}
+	}
+
+	return responses, nil
+}
+
+// GetSharedLocation gets the location exposed by a share granted to the user
+func (s *vehicleShareService) GetSharedLocation(userID, shareID uint) (*dto.SharedLocationResponse, error) {
+	share, err := s.getActiveShare(shareID)
+	if err != nil {
+		return nil, err
+	}
+	if share.GranteeUserID == nil || *share.GranteeUserID != userID {
+		return nil, ErrShareNotFound
+	}
+
+	return s.sharedLocation(share)
+}
+
+// GetPublicLocation gets the location exposed by an anonymous signed link
+func (s *vehicleShareService) GetPublicLocation(token string) (*dto.SharedLocationResponse, error) {
+	shareID, err := s.signer.Verify(token, time.Now())
+	if err != nil {
+		return nil, ErrShareNotFound
+	}
+
+	share, err := s.getActiveShare(shareID)
+	if err != nil {
+		return nil, err
+	}
+	if !share.IsLink() {
+		return nil, ErrShareNotFound
+	}
+
+	return s.sharedLocation(share)
+}
+
+// getActiveShare gets a share and checks it is neither expired nor revoked
+func (s *vehicleShareService) getActiveShare(shareID uint) (*entity.VehicleShare, error) {
+	share, err := s.shareRepo.GetByID(shareID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShareNotFound
+		}
+		return nil, fmt.Errorf("failed to get share: %w", err)
+	}
+
+	if !share.IsActive(time.Now()) {
+		return nil, ErrShareNotFound
+	}
+
+	return share, nil
+}
+
+// sharedLocation builds the position-only view of a shared vehicle
+func (s *vehicleShareService) sharedLocation(share *entity.VehicleShare) (*dto.SharedLocationResponse, error) {
+	now := time.Now()
+	if err := s.shareRepo.TouchAccessed(share.ID, now); err != nil {
+		// Log error but don't fail the request
+		log.Printf("Failed to record share access for share %d: %v", share.ID, err)
+	}
+
+	result := &dto.SharedLocationResponse{
+		Label:     share.Label,
+		ExpiresAt: share.ExpiresAt,
+	}
+
+	latest, err := s.locationLogRepo.GetLatestByVehicleID(share.VehicleID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get latest location: %w", err)
+	}
+	if latest != nil {
+		result.Latest = toSharedPosition(latest)
+	}
+
+	if share.HistoryMinutes > 0 {
+		history, err := s.locationLogRepo.GetLocationHistory(share.VehicleID, now.Add(-time.Duration(share.HistoryMinutes)*time.Minute), now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get location history: %w", err)
+		}
+		result.History = make([]dto.SharedPositionResponse, len(history))
+		for i, log := range history {
+			result.History[i] = *toSharedPosition(&log)
+		}
+	}
+
+	return result, nil
+}
+
+// entityToResponse converts entity to response DTO
+func (s *vehicleShareService) entityToResponse(share *entity.VehicleShare, now time.Time) *dto.VehicleShareResponse {
+	response := &dto.VehicleShareResponse{
+		ID:             share.ID,
+		VehicleID:      share.VehicleID,
+		Type:           "user",
+		GranteeUserID:  share.GranteeUserID,
+		Label:          share.Label,
+		HistoryMinutes: share.HistoryMinutes,
+		ExpiresAt:      share.ExpiresAt,
+		RevokedAt:      share.RevokedAt,
+		LastAccessedAt: share.LastAccessedAt,
+		Active:         share.IsActive(now),
+		CreatedAt:      share.CreatedAt,
+	}
+
+	if share.GranteeUser != nil {
+		response.GranteeEmail = &share.GranteeUser.Email
+	}
+
+	if share.IsLink() {
+		response.Type = "link"
+		if response.Active {
+			response.Token = s.signer.Sign(share.ID, share.ExpiresAt)
+			response.URL = s.appURL + "/shared/" + response.Token
+		}
+	}
+
+	return response
+}
+
+// toSharedPosition strips a location log down to what a share may expose
+func toSharedPosition(log *entity.LocationLog) *dto.SharedPositionResponse {
+	return &dto.SharedPositionResponse{
+		Latitude:  log.Latitude,
+		Longitude: log.Longitude,
+		Speed:     log.Speed,
+		Direction: log.Direction,
+		Timestamp: log.Timestamp,
+	}
+}
@@ -0,0 +1,497 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/internal/entity"
+	"github.com/cartrack/backend/internal/http/dto"
+	"github.com/cartrack/backend/internal/repository"
+	"github.com/cartrack/backend/pkg/safehttp"
+	"gorm.io/gorm"
+)
+
+// Headers sent with every webhook delivery. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the subscription secret, prefixed with "sha256=".
+const (
+	webhookHeaderEventID   = "X-Webhook-Id"
+	webhookHeaderEventType = "X-Webhook-Event"
+	webhookHeaderTimestamp = "X-Webhook-Timestamp"
+	webhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// webhookResolveTimeout bounds the DNS lookup that checks a webhook URL when it is saved
+const webhookResolveTimeout = 5 * time.Second
+
+// WebhookService defines webhook service interface
+type WebhookService interface {
+	Create(userID uint, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+	GetMyWebhooks(userID uint) ([]dto.WebhookResponse, error)
+	GetByID(userID, webhookID uint) (*dto.WebhookResponse, error)
+	Update(userID, webhookID uint, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error)
+	Delete(userID, webhookID uint) error
+	RotateSecret(userID, webhookID uint) (*dto.WebhookResponse, error)
+	GetDeliveries(userID, webhookID uint, status string, limit, offset int) ([]dto.WebhookDeliveryResponse, int64, error)
+	Redeliver(userID, webhookID, deliveryID uint) (*dto.WebhookDeliveryResponse, error)
+	Publish(organizationID uint, eventType string, data interface{})
+	Dispatch(ctx context.Context) error
+}
+
+// webhookService implements WebhookService interface
+type webhookService struct {
+	cfg                 configs.WebhooksConfig
+	subscriptionRepo    repository.WebhookSubscriptionRepository
+	deliveryRepo        repository.WebhookDeliveryRepository
+	organizationService OrganizationService
+	client              *http.Client
+}
+
+// NewWebhookService creates new webhook service instance
+func NewWebhookService(cfg configs.WebhooksConfig, subscriptionRepo repository.WebhookSubscriptionRepository, deliveryRepo repository.WebhookDeliveryRepository, organizationService OrganizationService) WebhookService {
+	return &webhookService{
+		cfg:                 cfg,
+		subscriptionRepo:    subscriptionRepo,
+		deliveryRepo:        deliveryRepo,
+		organizationService: organizationService,
+		client:              safehttp.NewClient(cfg.Timeout),
+	}
+}
+
+// generateWebhookSecret generates a random signing secret
+func generateWebhookSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// generateEventID generates a random ID shared by every delivery of one event
+func generateEventID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// Create creates a webhook subscription for an organization
+func (s *webhookService) Create(userID uint, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	organizationID, err := s.organizationService.ResolveOrganizationID(userID, req.OrganizationID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhookURL("url", req.URL); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	subscription := &entity.WebhookSubscription{
+		OrganizationID:  organizationID,
+		CreatedByUserID: userID,
+		Name:            req.Name,
+		URL:             req.URL,
+		Secret:          secret,
+		EventTypes:      strings.Join(req.EventTypes, ","),
+		Enabled:         true,
+	}
+	if req.Enabled != nil {
+		subscription.Enabled = *req.Enabled
+	}
+
+	if err := s.subscriptionRepo.Create(subscription); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	response := s.entityToResponse(subscription)
+	response.Secret = subscription.Secret
+	return response, nil
+}
+
+// GetMyWebhooks gets webhook subscriptions of the user's organizations
+func (s *webhookService) GetMyWebhooks(userID uint) ([]dto.WebhookResponse, error) {
+	orgIDs, err := s.organizationService.GetOrganizationIDsFor(userID, entity.OrgActionManageVehicles)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.subscriptionRepo.GetByOrganizationIDs(orgIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	responses := make([]dto.WebhookResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = *s.entityToResponse(&subscription)
+	}
+	return responses, nil
+}
+
+// GetByID gets a webhook subscription by ID
+func (s *webhookService) GetByID(userID, webhookID uint) (*dto.WebhookResponse, error) {
+	subscription, err := s.authorizeSubscription(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return s.entityToResponse(subscription), nil
+}
+
+// Update updates a webhook subscription
+func (s *webhookService) Update(userID, webhookID uint, req *dto.UpdateWebhookRequest) (*dto.WebhookResponse, error) {
+	subscription, err := s.authorizeSubscription(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		subscription.Name = req.Name
+	}
+	if req.URL != "" {
+		if err := validateWebhookURL("url", req.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = req.URL
+	}
+	if len(req.EventTypes) > 0 {
+		subscription.EventTypes = strings.Join(req.EventTypes, ",")
+	}
+	if req.Enabled != nil {
+		subscription.Enabled = *req.Enabled
+	}
+
+	if err := s.subscriptionRepo.Update(subscription); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return s.entityToResponse(subscription), nil
+}
+
+// Delete deletes a webhook subscription. Its pending deliveries fail on their next attempt.
+func (s *webhookService) Delete(userID, webhookID uint) error {
+	subscription, err := s.authorizeSubscription(userID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.subscriptionRepo.Delete(subscription.ID); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// RotateSecret replaces the signing secret of a webhook subscription and returns the new one
+func (s *webhookService) RotateSecret(userID, webhookID uint) (*dto.WebhookResponse, error) {
+	subscription, err := s.authorizeSubscription(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	subscription.Secret = secret
+
+	if err := s.subscriptionRepo.Update(subscription); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	response := s.entityToResponse(subscription)
+	response.Secret = subscription.Secret
+	return response, nil
+}
+
+// GetDeliveries gets the delivery log of a webhook subscription
+func (s *webhookService) GetDeliveries(userID, webhookID uint, status string, limit, offset int) ([]dto.WebhookDeliveryResponse, int64, error) {
+	subscription, err := s.authorizeSubscription(userID, webhookID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, total, err := s.deliveryRepo.GetBySubscriptionIDWithPagination(subscription.ID, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	responses := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = *s.deliveryToResponse(&delivery)
+	}
+	return responses, total, nil
+}
+
+// Redeliver queues a new delivery of a logged event to the subscription, keeping the original in the log
+func (s *webhookService) Redeliver(userID, webhookID, deliveryID uint) (*dto.WebhookDeliveryResponse, error) {
+	subscription, err := s.authorizeSubscription(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := s.deliveryRepo.GetByID(deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook delivery not found")
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if original.SubscriptionID != subscription.ID {
+		return nil, errors.New("webhook delivery not found")
+	}
+
+	deliveries := []entity.WebhookDelivery{{
+		SubscriptionID: subscription.ID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         entity.WebhookDeliveryPending,
+		NextAttemptAt:  time.Now(),
+	}}
+	if err := s.deliveryRepo.CreateBatch(deliveries); err != nil {
+		return nil, fmt.Errorf("failed to queue webhook delivery: %w", err)
+	}
+
+	return s.deliveryToResponse(&deliveries[0]), nil
+}
+
+// Publish queues an event for every enabled subscription of the organization that includes its type.
+// Failures are logged so the operation that raised the event is not affected.
+func (s *webhookService) Publish(organizationID uint, eventType string, data interface{}) {
+	subscriptions, err := s.subscriptionRepo.GetEnabledForEvent(organizationID, eventType)
+	if err != nil {
+		log.Printf("Failed to get webhooks for %s: %v", eventType, err)
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	eventID, err := generateEventID()
+	if err != nil {
+		log.Printf("Failed to generate webhook event ID: %v", err)
+		return
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(dto.WebhookEvent{
+		ID:             eventID,
+		Type:           eventType,
+		OrganizationID: organizationID,
+		CreatedAt:      now,
+		Data:           data,
+	})
+	if err != nil {
+		log.Printf("Failed to encode %s webhook payload: %v", eventType, err)
+		return
+	}
+
+	deliveries := make([]entity.WebhookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = entity.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        string(payload),
+			Status:         entity.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+		}
+	}
+
+	if err := s.deliveryRepo.CreateBatch(deliveries); err != nil {
+		log.Printf("Failed to queue %s webhook deliveries: %v", eventType, err)
+	}
+}
+
+// Dispatch attempts every due delivery, batch by batch, rescheduling failures with exponential backoff
+func (s *webhookService) Dispatch(ctx context.Context) error {
+	// A claimed delivery is retried if its attempt has not been recorded by then
+	lease := s.cfg.Timeout + time.Minute
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		deliveries, err := s.deliveryRepo.ClaimDue(time.Now(), lease, s.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+		}
+
+		for i := range deliveries {
+			s.attempt(ctx, &deliveries[i])
+		}
+
+		if len(deliveries) < s.cfg.BatchSize {
+			return nil
+		}
+	}
+}
+
+// attempt sends a delivery once and records the outcome
+func (s *webhookService) attempt(ctx context.Context, delivery *entity.WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = nil
+
+	subscription := delivery.Subscription
+	var err error
+	if subscription.DeletedAt.Valid || !subscription.Enabled {
+		err = errors.New("webhook is disabled or deleted")
+		delivery.Attempts = s.cfg.MaxAttempts
+	} else {
+		err = s.send(ctx, delivery)
+	}
+
+	if err == nil {
+		delivery.Status = entity.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.LastError = nil
+	} else {
+		message := err.Error()
+		delivery.LastError = &message
+		if delivery.Attempts >= s.cfg.MaxAttempts {
+			delivery.Status = entity.WebhookDeliveryFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(s.backoff(delivery.Attempts))
+		}
+	}
+
+	if err := s.deliveryRepo.Update(delivery); err != nil {
+		log.Printf("Failed to record webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// send posts the signed payload and treats any non-2xx response as a failure. Only the status of a failed
+// response is recorded; its body could expose whatever the URL points at.
+func (s *webhookService) send(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscription.URL, bytes.NewReader([]byte(delivery.Payload)))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookHeaderEventID, delivery.EventID)
+	req.Header.Set(webhookHeaderEventType, delivery.EventType)
+	req.Header.Set(webhookHeaderTimestamp, timestamp)
+	req.Header.Set(webhookHeaderSignature, signWebhook(delivery.Subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	delivery.ResponseStatus = &status
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("responded with status %d", status)
+	}
+	return nil
+}
+
+// validateWebhookURL checks a user-supplied webhook URL is http or https and does not point at a loopback,
+// private or link-local address
+func validateWebhookURL(field, raw string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookResolveTimeout)
+	defer cancel()
+
+	if err := safehttp.ValidateURL(ctx, raw); err != nil {
+		return fmt.Errorf("%s %s", field, err.Error())
+	}
+	return nil
+}
+
+// backoff returns the wait before the next attempt after the given number of failed attempts
+func (s *webhookService) backoff(attempts int) time.Duration {
+	delay := s.cfg.BaseBackoff
+	for i := 1; i < attempts && delay < s.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.cfg.MaxBackoff {
+		delay = s.cfg.MaxBackoff
+	}
+	return delay
+}
+
+// signWebhook computes the signature header value of a payload
+func signWebhook(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// authorizeSubscription loads a webhook subscription the user may manage
+func (s *webhookService) authorizeSubscription(userID, webhookID uint) (*entity.WebhookSubscription, error) {
+	subscription, err := s.subscriptionRepo.GetByID(webhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	if _, err := s.organizationService.Authorize(userID, subscription.OrganizationID, entity.OrgActionManageVehicles); err != nil {
+		if errors.Is(err, ErrOrganizationForbidden) {
+			return nil, err
+		}
+		return nil, errors.New("webhook not found")
+	}
+
+	return subscription, nil
+}
+
+// entityToResponse converts entity to response DTO
+func (s *webhookService) entityToResponse(subscription *entity.WebhookSubscription) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:             subscription.ID,
+		OrganizationID: subscription.OrganizationID,
+		Name:           subscription.Name,
+		URL:            subscription.URL,
+		EventTypes:     subscription.EventTypeList(),
+		Enabled:        subscription.Enabled,
+		CreatedAt:      subscription.CreatedAt,
+		UpdatedAt:      subscription.UpdatedAt,
+	}
+}
+
+// deliveryToResponse converts a delivery entity to response DTO
+func (s *webhookService) deliveryToResponse(delivery *entity.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	response := &dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+		Payload:        json.RawMessage(delivery.Payload),
+	}
+	if delivery.Status == entity.WebhookDeliveryPending {
+		next := delivery.NextAttemptAt
+		response.NextAttemptAt = &next
+	}
+	return response
+}
@@ -0,0 +1,53 @@
+package geo
+
+import "math"
+
+// EarthRadiusMeters is the mean Earth radius used for great-circle calculations
+const EarthRadiusMeters = 6371000.0
+
+// Point is a coordinate in degrees
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// DistanceMeters returns the great-circle (haversine) distance between two coordinates
+func DistanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return EarthRadiusMeters * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// BearingDegrees returns the initial bearing from the first coordinate to the second, clockwise from north in [0, 360)
+func BearingDegrees(lat1, lng1, lat2, lng2 float64) float64 {
+	dLng := radians(lng2 - lng1)
+	y := math.Sin(dLng) * math.Cos(radians(lat2))
+	x := math.Cos(radians(lat1))*math.Sin(radians(lat2)) - math.Sin(radians(lat1))*math.Cos(radians(lat2))*math.Cos(dLng)
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// Interpolate returns the coordinate a fraction of the way from the first coordinate to the second.
+// Positions are interpolated linearly, which is accurate for the short distances between consecutive fixes.
+func Interpolate(lat1, lng1, lat2, lng2, fraction float64) (float64, float64) {
+	return lat1 + (lat2-lat1)*fraction, lng1 + (lng2-lng1)*fraction
+}
+
+// radians converts degrees to radians
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// BoundingBox returns the latitude and longitude bounds of the square around a coordinate that contains every
+// point within radius meters of it. Longitudes are not wrapped at the antimeridian.
+func BoundingBox(lat, lng, radius float64) (minLat, maxLat, minLng, maxLng float64) {
+	dLat := radius / EarthRadiusMeters * 180 / math.Pi
+	// Meridians converge towards the poles; near them the box spans every longitude
+	cos := math.Cos(radians(lat))
+	dLng := 180.0
+	if cos > 1e-6 {
+		dLng = math.Min(180, dLat/cos)
+	}
+	return lat - dLat, lat + dLat, lng - dLng, lng + dLng
+}
@@ -0,0 +1,78 @@
+package geo
+
+// Shape is an area spatial queries can be restricted to
+type Shape interface {
+	// Bounds returns the smallest bounding box containing the shape
+	Bounds() Bounds
+	// Contains reports whether a coordinate lies inside the shape
+	Contains(p Point) bool
+}
+
+// Bounds is a bounding box in degrees. Longitudes are not wrapped at the antimeridian.
+type Bounds struct {
+	MinLat float64
+	MaxLat float64
+	MinLng float64
+	MaxLng float64
+}
+
+// Bounds returns the box itself
+func (b Bounds) Bounds() Bounds {
+	return b
+}
+
+// Contains reports whether a coordinate lies inside the box, edges included
+func (b Bounds) Contains(p Point) bool {
+	return p.Latitude >= b.MinLat && p.Latitude <= b.MaxLat && p.Longitude >= b.MinLng && p.Longitude <= b.MaxLng
+}
+
+// Circle is the area within Radius meters of Center
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Bounds returns the box around the circle
+func (c Circle) Bounds() Bounds {
+	minLat, maxLat, minLng, maxLng := BoundingBox(c.Center.Latitude, c.Center.Longitude, c.Radius)
+	return Bounds{MinLat: minLat, MaxLat: maxLat, MinLng: minLng, MaxLng: maxLng}
+}
+
+// Contains reports whether a coordinate is at most Radius meters from the center
+func (c Circle) Contains(p Point) bool {
+	return DistanceMeters(c.Center.Latitude, c.Center.Longitude, p.Latitude, p.Longitude) <= c.Radius
+}
+
+// Polygon is a ring of at least three vertices; the last vertex connects back to the first. Edges are straight
+// in degrees, which for areas up to a few tens of kilometers matches the great-circle edges PostGIS uses.
+type Polygon []Point
+
+// Bounds returns the box around the vertices
+func (p Polygon) Bounds() Bounds {
+	if len(p) == 0 {
+		return Bounds{}
+	}
+	b := Bounds{MinLat: p[0].Latitude, MaxLat: p[0].Latitude, MinLng: p[0].Longitude, MaxLng: p[0].Longitude}
+	for _, vertex := range p[1:] {
+		b.MinLat, b.MaxLat = min(b.MinLat, vertex.Latitude), max(b.MaxLat, vertex.Latitude)
+		b.MinLng, b.MaxLng = min(b.MinLng, vertex.Longitude), max(b.MaxLng, vertex.Longitude)
+	}
+	return b
+}
+
+// Contains reports whether a coordinate lies inside the polygon by counting the edges a ray from it crosses.
+// Coordinates exactly on an edge may fall either way.
+func (p Polygon) Contains(point Point) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if (a.Latitude > point.Latitude) == (b.Latitude > point.Latitude) {
+			continue
+		}
+		crossing := a.Longitude + (point.Latitude-a.Latitude)/(b.Latitude-a.Latitude)*(b.Longitude-a.Longitude)
+		if point.Longitude < crossing {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,173 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// metersPerDegree is the length of one degree of latitude on the sphere used by DistanceMeters
+const metersPerDegree = 111194.93
+
+func TestBoundsContains(t *testing.T) {
+	box := Bounds{MinLat: -6.3, MaxLat: -6.1, MinLng: 106.7, MaxLng: 106.9}
+
+	tests := []struct {
+		name  string
+		point Point
+		want  bool
+	}{
+		{"inside", Point{-6.2, 106.8}, true},
+		{"on the south edge", Point{-6.3, 106.8}, true},
+		{"on a corner", Point{-6.1, 106.9}, true},
+		{"north of the box", Point{-6.09, 106.8}, false},
+		{"east of the box", Point{-6.2, 106.91}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := box.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircleContains(t *testing.T) {
+	const radius = 100.0
+	center := Point{Latitude: -6.2, Longitude: 106.8}
+	north := func(meters float64) Point {
+		return Point{Latitude: center.Latitude + meters/metersPerDegree, Longitude: center.Longitude}
+	}
+
+	tests := []struct {
+		name   string
+		circle Circle
+		point  Point
+		want   bool
+	}{
+		{"center", Circle{center, radius}, center, true},
+		{"just inside the radius", Circle{center, radius}, north(99.9), true},
+		{"just outside the radius", Circle{center, radius}, north(100.1), false},
+		{"zero radius", Circle{center, 0}, north(1), false},
+		// About 111 m apart across the antimeridian
+		{"across the antimeridian", Circle{Point{0, 179.9995}, radius * 2}, Point{0, -179.9995}, true},
+		{"far side of the globe", Circle{Point{0, 179.9995}, radius * 2}, Point{0, 0}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.circle.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircleBounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		circle Circle
+	}{
+		{"equator", Circle{Point{0, 106.8}, 1000}},
+		{"southern latitude", Circle{Point{-60, 106.8}, 1000}},
+		{"next to the antimeridian", Circle{Point{0, 179.999}, 1000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.circle.Bounds()
+			if !b.Contains(tt.circle.Center) {
+				t.Fatalf("Bounds() = %+v does not contain the center", b)
+			}
+			// Every point on the circle lies inside the box
+			for _, bearing := range []float64{0, 45, 90, 135, 180, 225, 270, 315} {
+				point := destination(tt.circle.Center, bearing, tt.circle.Radius*0.999)
+				if !tt.circle.Contains(point) {
+					t.Fatalf("destination(%v) = %v is not within the circle", bearing, point)
+				}
+				if point.Longitude < 0 && b.MaxLng > 180 {
+					// Longitudes are not wrapped, so points past the antimeridian are compared on the same side
+					point.Longitude += 360
+				}
+				if !b.Contains(point) {
+					t.Errorf("Bounds() = %+v does not contain %v at bearing %v", b, point, bearing)
+				}
+			}
+		})
+	}
+
+	if b := (Circle{Point{0, 179.999}, 1000}).Bounds(); b.MaxLng <= 180 {
+		t.Errorf("MaxLng = %v, want past 180 as longitudes are not wrapped", b.MaxLng)
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	square := Polygon{{-6.3, 106.7}, {-6.3, 106.9}, {-6.1, 106.9}, {-6.1, 106.7}}
+	clockwise := Polygon{{-6.3, 106.7}, {-6.1, 106.7}, {-6.1, 106.9}, {-6.3, 106.9}}
+	// A U open to the north: arms at 106.7-106.75 and 106.85-106.9, joined below -6.25
+	concave := Polygon{
+		{-6.3, 106.7}, {-6.3, 106.9}, {-6.1, 106.9}, {-6.1, 106.85},
+		{-6.25, 106.85}, {-6.25, 106.75}, {-6.1, 106.75}, {-6.1, 106.7},
+	}
+	// Longitudes continue past 180 rather than wrapping to -180
+	antimeridian := Polygon{{-17, 179.5}, {-17, 180.5}, {-16, 180.5}, {-16, 179.5}}
+	const epsilon = 1e-9
+
+	tests := []struct {
+		name    string
+		polygon Polygon
+		point   Point
+		want    bool
+	}{
+		{"inside", square, Point{-6.2, 106.8}, true},
+		{"outside", square, Point{-6.2, 107}, false},
+		{"in line with an edge but outside", square, Point{-6.3, 107}, false},
+		{"just inside the east edge", square, Point{-6.2, 106.9 - epsilon}, true},
+		{"just outside the east edge", square, Point{-6.2, 106.9 + epsilon}, false},
+		{"just inside the north edge", square, Point{-6.1 - epsilon, 106.8}, true},
+		{"just outside the north edge", square, Point{-6.1 + epsilon, 106.8}, false},
+		{"clockwise vertices", clockwise, Point{-6.2, 106.8}, true},
+		{"concave west arm", concave, Point{-6.15, 106.72}, true},
+		{"concave east arm", concave, Point{-6.15, 106.88}, true},
+		{"concave notch", concave, Point{-6.15, 106.8}, false},
+		{"concave base", concave, Point{-6.28, 106.8}, true},
+		{"across the antimeridian, east of 180", antimeridian, Point{-16.5, 180.2}, true},
+		{"across the antimeridian, west of 180", antimeridian, Point{-16.5, 179.8}, true},
+		{"beyond the antimeridian polygon", antimeridian, Point{-16.5, 180.7}, false},
+		{"empty polygon", Polygon{}, Point{-6.2, 106.8}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.polygon.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygonBounds(t *testing.T) {
+	concave := Polygon{{-6.3, 106.7}, {-6.3, 106.9}, {-6.1, 106.85}, {-6.25, 106.75}, {-6.1, 106.7}}
+	want := Bounds{MinLat: -6.3, MaxLat: -6.1, MinLng: 106.7, MaxLng: 106.9}
+	if got := concave.Bounds(); got != want {
+		t.Errorf("Bounds() = %+v, want %+v", got, want)
+	}
+	if got := (Polygon{}).Bounds(); got != (Bounds{}) {
+		t.Errorf("Bounds() of an empty polygon = %+v, want zero", got)
+	}
+}
+
+// destination returns the point the given distance from p along the initial bearing, for small distances
+func destination(p Point, bearing, meters float64) Point {
+	lat, lng := radians(p.Latitude), radians(p.Longitude)
+	angle := meters / EarthRadiusMeters
+	b := radians(bearing)
+
+	lat2 := math.Asin(math.Sin(lat)*math.Cos(angle) + math.Cos(lat)*math.Sin(angle)*math.Cos(b))
+	lng2 := lng + math.Atan2(math.Sin(b)*math.Sin(angle)*math.Cos(lat), math.Cos(angle)-math.Sin(lat)*math.Sin(lat2))
+	lng2Degrees := lng2 * 180 / math.Pi
+	if lng2Degrees > 180 {
+		lng2Degrees -= 360
+	}
+	return Point{Latitude: lat2 * 180 / math.Pi, Longitude: lng2Degrees}
+}
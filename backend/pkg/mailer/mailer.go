@@ -0,0 +1,87 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cartrack/backend/configs"
+)
+
+// Message represents a plain-text email message with optional file attachments
+type Message struct {
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []Attachment
+}
+
+// Attachment is a file sent along with a message
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Mailer sends email messages
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// NewMailer creates the mailer selected by cfg.Driver
+func NewMailer(cfg configs.MailConfig) Mailer {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg)
+	default:
+		return NewLogMailer(cfg.From, cfg.LogPath)
+	}
+}
+
+// logMailer writes messages to a file instead of sending them
+type logMailer struct {
+	from string
+	path string
+	mu   sync.Mutex
+}
+
+// NewLogMailer creates a mailer that appends every message to path.
+// When path is empty messages are written to stdout.
+func NewLogMailer(from, path string) Mailer {
+	return &logMailer{from: from, path: path}
+}
+
+// Send appends the message to the log file
+func (m *logMailer) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := fmt.Sprintf("==== %s ====\nFrom: %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC3339), m.from, strings.Join(msg.To, ", "), msg.Subject, msg.Body)
+	for _, attachment := range msg.Attachments {
+		entry += fmt.Sprintf("Attachment: %s (%s, %d bytes)\n\n", attachment.Name, attachment.ContentType, len(attachment.Data))
+	}
+
+	if m.path == "" {
+		fmt.Print(entry)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create mail log directory: %w", err)
+	}
+
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open mail log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(entry); err != nil {
+		return fmt.Errorf("failed to write mail log: %w", err)
+	}
+	return nil
+}
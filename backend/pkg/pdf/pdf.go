@@ -0,0 +1,239 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// A4 landscape page layout in points
+const (
+	pageWidth     = 842.0
+	pageHeight    = 595.0
+	margin        = 36.0
+	titleSize     = 14.0
+	subtitleSize  = 9.0
+	fontSize      = 8.0
+	rowHeight     = 13.0
+	cellPadding   = 3.0
+	averageGlyph  = 0.52 // average Helvetica glyph width relative to the font size
+	minColumnText = 4
+	maxColumnText = 48
+)
+
+// Table is a titled table rendered as a PDF document on A4 landscape pages using the built-in Helvetica
+// fonts, so no font files are needed. The header row is repeated on every page and cells too wide for
+// their column are shortened with "...". Text outside Latin-1 is replaced with "?".
+type Table struct {
+	Title    string
+	Subtitle []string
+	Headers  []string
+	Rows     [][]string
+}
+
+// Render writes the table as a PDF document
+func (t *Table) Render(w io.Writer) error {
+	widths := t.columnWidths()
+	pages := t.paginate()
+
+	doc := &document{w: w}
+	doc.writeHeader()
+
+	// Objects 1-4 are the catalog, page tree and fonts; each page adds a page and a content object
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	doc.writeObject(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	doc.writeObject(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	doc.writeObject(3, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	doc.writeObject(4, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, rows := range pages {
+		content := t.pageContent(i, len(pages), rows, widths)
+		doc.writeObject(5+2*i, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 6+2*i))
+		doc.writeStream(6+2*i, content)
+	}
+
+	doc.writeTrailer()
+	return doc.err
+}
+
+// paginate splits the rows into pages. The first page leaves room for the title and subtitle.
+func (t *Table) paginate() [][][]string {
+	usable := pageHeight - 2*margin - 2*rowHeight
+	perPage := int(math.Floor(usable / rowHeight))
+	heading := titleSize + 6 + float64(len(t.Subtitle))*(subtitleSize+3) + 8
+	firstPage := max(perPage-int(math.Ceil(heading/rowHeight)), 1)
+
+	pages := [][][]string{}
+	rows := t.Rows
+	capacity := firstPage
+	for {
+		if len(rows) <= capacity {
+			return append(pages, rows)
+		}
+		pages = append(pages, rows[:capacity])
+		rows = rows[capacity:]
+		capacity = perPage
+	}
+}
+
+// columnWidths shares the usable width between columns in proportion to their longest text
+func (t *Table) columnWidths() []float64 {
+	weights := make([]int, len(t.Headers))
+	total := 0
+	for i, header := range t.Headers {
+		weight := len([]rune(header))
+		for _, row := range t.Rows {
+			if i < len(row) && len([]rune(row[i])) > weight {
+				weight = len([]rune(row[i]))
+			}
+		}
+		weight = min(max(weight, minColumnText), maxColumnText)
+		weights[i] = weight
+		total += weight
+	}
+
+	widths := make([]float64, len(weights))
+	for i, weight := range weights {
+		widths[i] = (pageWidth - 2*margin) * float64(weight) / float64(total)
+	}
+	return widths
+}
+
+// pageContent draws one page: the title on the first page, the header row, the rows and a page number
+func (t *Table) pageContent(index, count int, rows [][]string, widths []float64) []byte {
+	var b bytes.Buffer
+	y := pageHeight - margin
+
+	if index == 0 {
+		y -= titleSize
+		text(&b, "F2", titleSize, margin, y, t.Title)
+		y -= 6
+		for _, line := range t.Subtitle {
+			y -= subtitleSize + 3
+			text(&b, "F1", subtitleSize, margin, y, line)
+		}
+		y -= 8
+	}
+
+	// Header row on a grey band
+	y -= rowHeight
+	fmt.Fprintf(&b, "0.88 g %.2f %.2f %.2f %.2f re f 0 g\n", margin, y, pageWidth-2*margin, rowHeight)
+	t.row(&b, "F2", y, t.Headers, widths)
+
+	for i, row := range rows {
+		y -= rowHeight
+		if i%2 == 1 {
+			fmt.Fprintf(&b, "0.96 g %.2f %.2f %.2f %.2f re f 0 g\n", margin, y, pageWidth-2*margin, rowHeight)
+		}
+		t.row(&b, "F1", y, row, widths)
+	}
+
+	if count > 1 {
+		text(&b, "F1", fontSize, pageWidth-margin-60, margin/2, fmt.Sprintf("Page %d of %d", index+1, count))
+	}
+	return b.Bytes()
+}
+
+// row draws the cells of a row, shortening text that does not fit its column
+func (t *Table) row(b *bytes.Buffer, font string, y float64, cells []string, widths []float64) {
+	x := margin
+	for i, width := range widths {
+		if i < len(cells) {
+			text(b, font, fontSize, x+cellPadding, y+4, truncate(cells[i], width-2*cellPadding))
+		}
+		x += width
+	}
+}
+
+// truncate shortens text to the number of average glyphs fitting the width
+func truncate(value string, width float64) string {
+	limit := int(width / (fontSize * averageGlyph))
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	if limit <= 3 {
+		return string(runes[:max(limit, 0)])
+	}
+	return string(runes[:limit-3]) + "..."
+}
+
+// text draws a single line of text
+func text(b *bytes.Buffer, font string, size, x, y float64, value string) {
+	fmt.Fprintf(b, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(value))
+}
+
+// escape encodes text as a PDF string literal body in WinAnsi, keeping the stream ASCII
+func escape(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// document writes numbered objects and records their offsets for the cross-reference table
+type document struct {
+	w       io.Writer
+	offset  int
+	offsets map[int]int
+	err     error
+}
+
+// write writes raw bytes, keeping the first error
+func (d *document) write(value string) {
+	if d.err != nil {
+		return
+	}
+	n, err := io.WriteString(d.w, value)
+	d.offset += n
+	d.err = err
+}
+
+// writeHeader writes the PDF version and a binary marker comment
+func (d *document) writeHeader() {
+	d.offsets = map[int]int{}
+	d.write("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+}
+
+// writeObject writes an indirect object
+func (d *document) writeObject(number int, body string) {
+	d.offsets[number] = d.offset
+	d.write(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", number, body))
+}
+
+// writeStream writes an indirect stream object
+func (d *document) writeStream(number int, content []byte) {
+	d.offsets[number] = d.offset
+	d.write(fmt.Sprintf("%d 0 obj\n<< /Length %d >>\nstream\n", number, len(content)))
+	d.write(string(content))
+	d.write("\nendstream\nendobj\n")
+}
+
+// writeTrailer writes the cross-reference table and trailer
+func (d *document) writeTrailer() {
+	start := d.offset
+	size := len(d.offsets) + 1
+
+	d.write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", size))
+	for number := 1; number < size; number++ {
+		d.write(fmt.Sprintf("%010d 00000 n \n", d.offsets[number]))
+	}
+	d.write(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, start))
+}
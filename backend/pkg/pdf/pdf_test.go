@@ -0,0 +1,149 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// render renders a table with the given number of rows
+func render(t *testing.T, rows int) []byte {
+	t.Helper()
+
+	table := &Table{
+		Title:    "Trip report (B 1234 XYZ)",
+		Subtitle: []string{"1-7 January 2025", "Généré le 8 janvier"},
+		Headers:  []string{"Time", "Location", "Speed"},
+	}
+	for i := 0; i < rows; i++ {
+		table.Rows = append(table.Rows, []string{fmt.Sprintf("08:%02d", i%60), `Jl. Sudirman \ Thamrin`, strconv.Itoa(i)})
+	}
+
+	var buf bytes.Buffer
+	if err := table.Render(&buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+var (
+	startxrefPattern = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+	xrefPattern      = regexp.MustCompile(`^xref\n0 (\d+)\n`)
+	streamPattern    = regexp.MustCompile(`<< /Length (\d+) >>\nstream\n`)
+	countPattern     = regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`)
+)
+
+func TestRenderStructure(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      int
+		wantPages int
+	}{
+		{"no rows", 0, 1},
+		{"one page", 10, 1},
+		{"several pages", 200, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := render(t, tt.rows)
+
+			if !bytes.HasPrefix(doc, []byte("%PDF-")) {
+				t.Fatalf("document starts with %q, want %%PDF-", doc[:min(len(doc), 8)])
+			}
+
+			// startxref points at the cross-reference table
+			match := startxrefPattern.FindSubmatch(doc)
+			if match == nil {
+				t.Fatalf("document does not end with startxref and %%%%EOF")
+			}
+			xrefStart, _ := strconv.Atoi(string(match[1]))
+			xref := xrefPattern.FindSubmatch(doc[xrefStart:])
+			if xref == nil {
+				t.Fatalf("startxref %d does not point at the xref table", xrefStart)
+			}
+
+			// Every in-use entry points at the start of its object
+			size, _ := strconv.Atoi(string(xref[1]))
+			entries := strings.Split(string(doc[xrefStart+len(xref[0]):]), "\n")
+			if entries[0] != "0000000000 65535 f " {
+				t.Errorf("free entry = %q", entries[0])
+			}
+			for number := 1; number < size; number++ {
+				entry := entries[number]
+				if len(entry) != 19 || !strings.HasSuffix(entry, " 00000 n ") {
+					t.Fatalf("xref entry %d = %q, want 20-byte in-use entry", number, entry)
+				}
+				offset, err := strconv.Atoi(entry[:10])
+				if err != nil {
+					t.Fatalf("xref entry %d offset: %v", number, err)
+				}
+				if want := fmt.Sprintf("%d 0 obj\n", number); !bytes.HasPrefix(doc[offset:], []byte(want)) {
+					t.Errorf("xref entry %d points at %q, want %q", number, doc[offset:min(offset+12, len(doc))], want)
+				}
+			}
+			if want := 4 + 2*tt.wantPages + 1; size != want {
+				t.Errorf("xref size = %d, want %d", size, want)
+			}
+
+			// Stream lengths match their content
+			for _, loc := range streamPattern.FindAllSubmatchIndex(doc, -1) {
+				length, _ := strconv.Atoi(string(doc[loc[2]:loc[3]]))
+				if !bytes.HasPrefix(doc[loc[1]+length:], []byte("\nendstream")) {
+					t.Errorf("stream at %d is not %d bytes long", loc[1], length)
+				}
+			}
+
+			count := countPattern.FindSubmatch(doc)
+			if count == nil || string(count[1]) != strconv.Itoa(tt.wantPages) {
+				t.Errorf("page count = %s, want %d", count, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{`a (b) \ c`, `a \(b\) \\ c`},
+		{"Généré", `G\351n\351r\351`},
+		{"日本", "??"},
+		{"tab\tnewline\n", "tab?newline?"},
+	}
+
+	for _, tt := range tests {
+		if got := escape(tt.in); got != tt.want {
+			t.Errorf("escape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	glyph := fontSize * averageGlyph
+
+	tests := []struct {
+		name  string
+		value string
+		width float64
+		want  string
+	}{
+		{"fits", "Jakarta", 7 * glyph, "Jakarta"},
+		{"shortened", "Jakarta Selatan", 10 * glyph, "Jakarta..."},
+		{"too narrow for an ellipsis", "Jakarta", 3 * glyph, "Jak"},
+		{"no room", "Jakarta", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncate(tt.value, tt.width); got != tt.want {
+				t.Errorf("truncate(%q, %.1f) = %q, want %q", tt.value, tt.width, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package permission
+
+import "errors"
+
+// Permission names granted to roles and required by routes.
+// Names follow the "<resource>:<action>" convention.
+const (
+	ProfileManage      = "profile:manage"
+	OrganizationsRead  = "organizations:read"
+	OrganizationsWrite = "organizations:write"
+	VehiclesRead       = "vehicles:read"
+	VehiclesWrite      = "vehicles:write"
+	VehiclesReadAll    = "vehicles:read_all"
+	DriversRead        = "drivers:read"
+	DriversWrite       = "drivers:write"
+	POIsRead           = "pois:read"
+	POIsWrite          = "pois:write"
+	MaintenanceRead    = "maintenance:read"
+	MaintenanceWrite   = "maintenance:write"
+	DocumentsRead      = "documents:read"
+	DocumentsWrite     = "documents:write"
+	AlertsRead         = "alerts:read"
+	AlertsWrite        = "alerts:write"
+	NotificationsRead  = "notifications:read"
+	CommandsRead       = "commands:read"
+	CommandsWrite      = "commands:write"
+	FirmwareRead       = "firmware:read"
+	FirmwareManage     = "firmware:manage"
+	DeviceConfigRead   = "device_config:read"
+	DeviceConfigManage = "device_config:manage"
+	LogsRead           = "logs:read"
+	LogsWrite          = "logs:write"
+	ImportsManage      = "imports:manage"
+	APIKeysManage      = "api_keys:manage"
+	WebhooksManage     = "webhooks:manage"
+	ReportsRead        = "reports:read"
+	ReportsReadAll     = "reports:read_all"
+	ReportsManage      = "reports:manage"
+	UsersManage        = "users:manage"
+	RolesManage        = "roles:manage"
+)
+
+// descriptions documents every known permission
+var descriptions = map[string]string{
+	ProfileManage:      "View and update own profile, password and two-factor settings",
+	OrganizationsRead:  "View organizations the user belongs to",
+	OrganizationsWrite: "Create organizations and manage their members",
+	VehiclesRead:       "View vehicles of the user's organizations",
+	VehiclesWrite:      "Create, update and delete vehicles of the user's organizations",
+	VehiclesReadAll:    "View every vehicle in the system",
+	DriversRead:        "View drivers and their vehicle assignments",
+	DriversWrite:       "Manage drivers and assign them to vehicles",
+	POIsRead:           "View points of interest and vehicles near them",
+	POIsWrite:          "Manage and import points of interest",
+	MaintenanceRead:    "View maintenance plans, service records and due items",
+	MaintenanceWrite:   "Manage maintenance plans and record services",
+	DocumentsRead:      "View vehicle documents and expiring items",
+	DocumentsWrite:     "Manage vehicle documents and their attachments",
+	AlertsRead:         "View alert rules and triggered alerts",
+	AlertsWrite:        "Manage alert rules and their notification channels",
+	NotificationsRead:  "View own notifications and mark them read",
+	CommandsRead:       "View the command history of vehicle trackers",
+	CommandsWrite:      "Send commands to vehicle trackers",
+	FirmwareRead:       "View firmware releases, rollouts and install reports",
+	FirmwareManage:     "Upload firmware releases and manage their rollouts",
+	DeviceConfigRead:   "View tracker configuration profiles and what each device applied",
+	DeviceConfigManage: "Manage tracker configuration profiles and assign them to vehicles",
+	LogsRead:           "View location and fuel logs",
+	LogsWrite:          "Submit location and fuel logs",
+	ImportsManage:      "Import location history files and follow their import jobs",
+	APIKeysManage:      "Manage device API keys",
+	WebhooksManage:     "Manage outgoing webhooks and inspect their deliveries",
+	ReportsRead:        "View dashboards and reports of the user's organizations",
+	ReportsReadAll:     "View system-wide dashboards and reports",
+	ReportsManage:      "Create and schedule emailed fleet reports",
+	UsersManage:        "List, inspect, unlock, delete users and assign their roles",
+	RolesManage:        "Create and edit roles and their permissions",
+}
+
+// Definition describes a permission
+type Definition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// All returns every known permission in a stable order
+func All() []Definition {
+	names := []string{
+		ProfileManage, OrganizationsRead, OrganizationsWrite,
+		VehiclesRead, VehiclesWrite, VehiclesReadAll,
+		DriversRead, DriversWrite, POIsRead, POIsWrite,
+		MaintenanceRead, MaintenanceWrite,
+		DocumentsRead, DocumentsWrite,
+		AlertsRead, AlertsWrite, NotificationsRead,
+		CommandsRead, CommandsWrite, FirmwareRead, FirmwareManage,
+		DeviceConfigRead, DeviceConfigManage,
+		LogsRead, LogsWrite, ImportsManage, APIKeysManage, WebhooksManage,
+		ReportsRead, ReportsReadAll, ReportsManage,
+		UsersManage, RolesManage,
+	}
+
+	definitions := make([]Definition, len(names))
+	for i, name := range names {
+		definitions[i] = Definition{Name: name, Description: descriptions[name]}
+	}
+	return definitions
+}
+
+// IsValid checks if the name is a known permission
+func IsValid(name string) bool {
+	_, ok := descriptions[name]
+	return ok
+}
+
+// ErrUnknownUser is returned by Resolver.UserRole when the user does not exist
+var ErrUnknownUser = errors.New("user not found")
+
+// Resolver looks up the current role of a user and checks whether a role grants a set of permissions
+type Resolver interface {
+	UserRole(userID uint) (string, error)
+	RoleHasPermissions(role string, required []string) (bool, error)
+}
+
+// Privileged lists the permissions that let a role change who can do what.
+// Roles holding any of them must use two-factor authentication when it is required for admins.
+var Privileged = []string{UsersManage, RolesManage}
+
+// IsPrivileged checks if the role holds any of the Privileged permissions
+func IsPrivileged(resolver Resolver, role string) (bool, error) {
+	for _, name := range Privileged {
+		ok, err := resolver.RoleHasPermissions(role, []string{name})
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
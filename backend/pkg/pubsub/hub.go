@@ -0,0 +1,56 @@
+package pubsub
+
+import "sync"
+
+// subscriberBuffer is how many messages a subscriber may lag behind before messages to it are dropped
+const subscriberBuffer = 16
+
+// Hub fans out messages published on a topic to every current subscriber of that topic.
+// Delivery is best effort and in-process only: slow subscribers miss messages rather than block publishers.
+type Hub[T any] struct {
+	mu          sync.RWMutex
+	subscribers map[uint]map[chan T]struct{}
+}
+
+// NewHub creates an empty hub
+func NewHub[T any]() *Hub[T] {
+	return &Hub[T]{subscribers: make(map[uint]map[chan T]struct{})}
+}
+
+// Subscribe registers a subscriber of the topic. The returned function unsubscribes and closes the channel.
+func (h *Hub[T]) Subscribe(topic uint) (<-chan T, func()) {
+	ch := make(chan T, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[topic] == nil {
+		h.subscribers[topic] = make(map[chan T]struct{})
+	}
+	h.subscribers[topic][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[topic], ch)
+			if len(h.subscribers[topic]) == 0 {
+				delete(h.subscribers, topic)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish sends the message to every subscriber of the topic without blocking
+func (h *Hub[T]) Publish(topic uint, message T) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[topic] {
+		select {
+		case ch <- message:
+		default:
+		}
+	}
+}
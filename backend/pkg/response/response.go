@@ -117,3 +117,11 @@ func Created(c echo.Context, message string, data interface{}) error {
 		Data: data,
 	})
 }
+
+// TooManyRequests returns a too many requests response
+func TooManyRequests(c echo.Context, message string, data interface{}) error {
+	return c.JSON(http.StatusTooManyRequests, Response{
+		Meta: Meta{Code: http.StatusTooManyRequests, Message: message},
+		Data: data,
+	})
+}
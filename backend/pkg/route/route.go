@@ -6,5 +6,7 @@ type Route struct {
 	Method  string
 	Path    string
 	Handler echo.HandlerFunc
-	Roles   []string
+	// Permissions lists every permission the caller's role must grant.
+	// Private routes with no permissions only require a valid login.
+	Permissions []string
 }
@@ -0,0 +1,113 @@
+package safehttp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsAllowed(t *testing.T) {
+	tests := []struct {
+		ip      string
+		allowed bool
+	}{
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsAllowed(net.ParseIP(tt.ip)); got != tt.allowed {
+			t.Errorf("IsAllowed(%s) = %v, want %v", tt.ip, got, tt.allowed)
+		}
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		forbidden bool
+		invalid   bool
+	}{
+		{url: "https://8.8.8.8/hook"},
+		{url: "http://[2606:4700:4700::1111]:8080/hook"},
+		{url: "ftp://8.8.8.8/hook", invalid: true},
+		{url: "file:///etc/passwd", invalid: true},
+		{url: "/relative/path", invalid: true},
+		{url: "http://127.0.0.1:8003/api", forbidden: true},
+		{url: "http://169.254.169.254/latest/meta-data/", forbidden: true},
+		{url: "http://[::1]/", forbidden: true},
+		{url: "https://10.0.0.5/hook", forbidden: true},
+		{url: "http://localhost:5432/", forbidden: true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateURL(context.Background(), tt.url)
+		switch {
+		case tt.forbidden:
+			if !errors.Is(err, ErrForbiddenAddress) {
+				t.Errorf("ValidateURL(%q) = %v, want ErrForbiddenAddress", tt.url, err)
+			}
+		case tt.invalid:
+			if err == nil || errors.Is(err, ErrForbiddenAddress) {
+				t.Errorf("ValidateURL(%q) = %v, want an invalid URL error", tt.url, err)
+			}
+		default:
+			if err != nil {
+				t.Errorf("ValidateURL(%q) = %v, want nil", tt.url, err)
+			}
+		}
+	}
+}
+
+func TestClientRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("internal secret"))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(time.Second).Get(server.URL)
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("Get(%s) error = %v, want ErrForbiddenAddress", server.URL, err)
+	}
+}
+
+func TestClientDoesNotFollowRedirects(t *testing.T) {
+	client := NewClient(time.Second)
+	// Dial through a plain transport so the loopback test server is reachable; only redirect handling is tested
+	client.Transport = http.DefaultTransport
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("redirect was followed")
+	}))
+	defer target.Close()
+	redirector := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer redirector.Close()
+
+	resp, err := client.Get(redirector.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusFound)
+	}
+}
@@ -1,9 +1,14 @@
 package server
 
 import (
+	"errors"
+	"log"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/pkg/permission"
 	"github.com/cartrack/backend/pkg/response"
 	"github.com/cartrack/backend/pkg/route"
 	"github.com/cartrack/backend/pkg/token"
@@ -29,10 +34,11 @@ type Server struct {
 	*echo.Echo
 }
 
-func NewServer(cfg *configs.Config, publicRoutes, privateRoutes []route.Route) *Server {
+func NewServer(cfg *configs.Config, permissions permission.Resolver, publicRoutes, privateRoutes []route.Route) *Server {
 	e := echo.New()
 	e.HideBanner = true
 	e.Validator = &CustomValidator{validator: validator.New()}
+	e.IPExtractor = IPExtractor(cfg.TrustedProxies)
 
 	// Add CORS middleware
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
@@ -55,12 +61,35 @@ func NewServer(cfg *configs.Config, publicRoutes, privateRoutes []route.Route) *
 
 	if len(privateRoutes) > 0 {
 		for _, route := range privateRoutes {
-			v1.Add(route.Method, route.Path, route.Handler, JWTMiddleware(cfg.JWT.SecretKey), RBACMiddleware(route.Roles))
+			v1.Add(route.Method, route.Path, route.Handler, JWTMiddleware(cfg.JWT.SecretKey), MFAMiddleware(cfg.MFA, permissions), PermissionMiddleware(permissions, route.Permissions))
 		}
 	}
 	return &Server{e}
 }
 
+// IPExtractor returns how the client IP is read for c.RealIP(). Without trusted proxies the peer address
+// is used as is; X-Forwarded-For is only honoured when the request comes through one of the given CIDR ranges.
+func IPExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range trustedProxies {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			log.Printf("Ignoring invalid trusted proxy range %q: %v", cidr, err)
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
 func JWTMiddleware(secretKey string) echo.MiddlewareFunc {
 	return echojwt.WithConfig(echojwt.Config{
 		NewClaimsFunc: func(c echo.Context) jwt.Claims {
@@ -73,18 +102,67 @@ func JWTMiddleware(secretKey string) echo.MiddlewareFunc {
 	})
 }
 
-func RBACMiddleware(roles []string) echo.MiddlewareFunc {
+// mfaEnrollmentPath is the route prefix privileged users may still use before enrolling in two-factor authentication
+const mfaEnrollmentPath = "/api/v1/user/2fa"
+
+// MFAMiddleware rejects MFA challenge tokens and, when two-factor is required for admins, limits sessions
+// without a verified second factor to the enrollment routes for roles that can manage users or roles
+func MFAMiddleware(cfg configs.MFAConfig, resolver permission.Resolver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			user := ctx.Get("user").(*jwt.Token)
+			claims := user.Claims.(*token.Claims)
+
+			if claims.IsMFAChallenge() {
+				return ctx.JSON(http.StatusUnauthorized, response.ErrorResponse(http.StatusUnauthorized, "anda harus menyelesaikan verifikasi dua langkah."))
+			}
+
+			if !cfg.RequireForAdmin || claims.MFAVerified || strings.HasPrefix(ctx.Path(), mfaEnrollmentPath) {
+				return next(ctx)
+			}
+
+			role, err := resolver.UserRole(uint(claims.UserID))
+			if errors.Is(err, permission.ErrUnknownUser) {
+				return ctx.JSON(http.StatusUnauthorized, response.ErrorResponse(http.StatusUnauthorized, "pengguna tidak ditemukan."))
+			}
+			if err != nil {
+				return ctx.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "gagal memeriksa hak akses."))
+			}
+			privileged, err := permission.IsPrivileged(resolver, role)
+			if err != nil {
+				return ctx.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "gagal memeriksa hak akses."))
+			}
+			if privileged {
+				return ctx.JSON(http.StatusForbidden, response.ErrorResponse(http.StatusForbidden, "admin wajib mengaktifkan dan menggunakan verifikasi dua langkah."))
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// PermissionMiddleware rejects requests whose user's role does not grant every required permission.
+// The role is looked up by user ID instead of read from the token, so role and permission changes
+// apply without a new login.
+func PermissionMiddleware(resolver permission.Resolver, required []string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
+			if len(required) == 0 {
+				return next(ctx)
+			}
+
 			user := ctx.Get("user").(*jwt.Token)
 			claims := user.Claims.(*token.Claims)
 
-			allowed := false
-			for _, role := range roles {
-				if role == claims.Role {
-					allowed = true
-					break
-				}
+			role, err := resolver.UserRole(uint(claims.UserID))
+			if errors.Is(err, permission.ErrUnknownUser) {
+				return ctx.JSON(http.StatusUnauthorized, response.ErrorResponse(http.StatusUnauthorized, "pengguna tidak ditemukan."))
+			}
+			if err != nil {
+				return ctx.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "gagal memeriksa hak akses."))
+			}
+			allowed, err := resolver.RoleHasPermissions(role, required)
+			if err != nil {
+				return ctx.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "gagal memeriksa hak akses."))
 			}
 
 			if !allowed {
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/cartrack/backend/configs"
+	"github.com/cartrack/backend/pkg/permission"
+	"github.com/cartrack/backend/pkg/token"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+func TestIPExtractor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		want           string
+	}{
+		{
+			name:         "no trusted proxies ignores forwarded header",
+			remoteAddr:   "203.0.113.7:52000",
+			forwardedFor: "198.51.100.1",
+			want:         "203.0.113.7",
+		},
+		{
+			name:           "untrusted peer cannot spoof forwarded header",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "203.0.113.7:52000",
+			forwardedFor:   "198.51.100.1",
+			want:           "203.0.113.7",
+		},
+		{
+			name:           "trusted proxy forwards client address",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.1.2.3:52000",
+			forwardedFor:   "198.51.100.1",
+			want:           "198.51.100.1",
+		},
+		{
+			name:           "private network is not trusted implicitly",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "192.168.1.10:52000",
+			forwardedFor:   "198.51.100.1",
+			want:           "192.168.1.10",
+		},
+		{
+			name:           "invalid ranges are skipped",
+			trustedProxies: []string{"not-a-cidr", " 10.0.0.0/8 "},
+			remoteAddr:     "10.1.2.3:52000",
+			forwardedFor:   "198.51.100.1",
+			want:           "198.51.100.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.IPExtractor = IPExtractor(tt.trustedProxies)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, tt.forwardedFor)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			if got := c.RealIP(); got != tt.want {
+				t.Errorf("RealIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// roleResolver knows the current role of each user and grants each role a fixed set of permissions
+type roleResolver struct {
+	users       map[uint]string
+	permissions map[string][]string
+}
+
+func (r roleResolver) UserRole(userID uint) (string, error) {
+	role, ok := r.users[userID]
+	if !ok {
+		return "", permission.ErrUnknownUser
+	}
+	return role, nil
+}
+
+func (r roleResolver) RoleHasPermissions(role string, required []string) (bool, error) {
+	for _, name := range required {
+		if !slices.Contains(r.permissions[role], name) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// testResolver has an admin (1), a custom role managing roles (2), a user (3) and an admin
+// demoted to user after their token was issued (4)
+var testResolver = roleResolver{
+	users: map[uint]string{1: "admin", 2: "fleet_manager", 3: "user", 4: "user"},
+	permissions: map[string][]string{
+		"admin":         {permission.UsersManage, permission.RolesManage, permission.VehiclesRead},
+		"fleet_manager": {permission.RolesManage},
+		"user":          {permission.VehiclesRead},
+	},
+}
+
+// serveWithClaims runs middleware for a request to path carrying claims and returns the status code
+func serveWithClaims(t *testing.T, mw echo.MiddlewareFunc, claims token.Claims, path string) int {
+	t.Helper()
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, path, nil), rec)
+	c.SetPath(path)
+	c.Set("user", &jwt.Token{Claims: &claims})
+
+	handler := mw(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	if err := handler(c); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	return rec.Code
+}
+
+func TestMFAMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims token.Claims
+		path   string
+		want   int
+	}{
+		{name: "challenge token", claims: token.Claims{UserID: 3, Role: "user", Purpose: token.PurposeMFAChallenge}, path: "/api/v1/vehicles", want: http.StatusUnauthorized},
+		{name: "unprivileged role", claims: token.Claims{UserID: 3, Role: "user"}, path: "/api/v1/vehicles", want: http.StatusOK},
+		{name: "admin without second factor", claims: token.Claims{UserID: 1, Role: "admin"}, path: "/api/v1/vehicles", want: http.StatusForbidden},
+		{name: "custom role managing roles", claims: token.Claims{UserID: 2, Role: "fleet_manager"}, path: "/api/v1/vehicles", want: http.StatusForbidden},
+		{name: "admin enrolling", claims: token.Claims{UserID: 1, Role: "admin"}, path: "/api/v1/user/2fa/setup", want: http.StatusOK},
+		{name: "admin with second factor", claims: token.Claims{UserID: 1, Role: "admin", MFAVerified: true}, path: "/api/v1/vehicles", want: http.StatusOK},
+		{name: "promoted after the token was issued", claims: token.Claims{UserID: 1, Role: "user"}, path: "/api/v1/vehicles", want: http.StatusForbidden},
+		{name: "demoted after the token was issued", claims: token.Claims{UserID: 4, Role: "admin"}, path: "/api/v1/vehicles", want: http.StatusOK},
+		{name: "deleted user", claims: token.Claims{UserID: 9, Role: "admin"}, path: "/api/v1/vehicles", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := MFAMiddleware(configs.MFAConfig{RequireForAdmin: true}, testResolver)
+			if got := serveWithClaims(t, mw, tt.claims, tt.path); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   token.Claims
+		required []string
+		want     int
+	}{
+		{name: "no permission required", claims: token.Claims{UserID: 9}, want: http.StatusOK},
+		{name: "role grants permission", claims: token.Claims{UserID: 3, Role: "user"}, required: []string{permission.VehiclesRead}, want: http.StatusOK},
+		{name: "role lacks permission", claims: token.Claims{UserID: 3, Role: "user"}, required: []string{permission.UsersManage}, want: http.StatusForbidden},
+		{name: "demoted admin with an old token", claims: token.Claims{UserID: 4, Role: "admin"}, required: []string{permission.UsersManage}, want: http.StatusForbidden},
+		{name: "deleted user", claims: token.Claims{UserID: 9, Role: "admin"}, required: []string{permission.UsersManage}, want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := PermissionMiddleware(testResolver, tt.required)
+			if got := serveWithClaims(t, mw, tt.claims, "/api/v1/users"); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package signedlink
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "test-secret"
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	signer := NewSigner(testSecret, "share")
+	now := time.Unix(1700000000, 0)
+
+	for _, id := range []uint{0, 1, 42, 4294967295} {
+		token := signer.Sign(id, now.Add(time.Hour))
+		got, err := signer.Verify(token, now)
+		if err != nil {
+			t.Fatalf("Verify(Sign(%d)) error: %v", id, err)
+		}
+		if got != id {
+			t.Errorf("Verify(Sign(%d)) = %d", id, got)
+		}
+	}
+}
+
+func TestVerifyExpiry(t *testing.T) {
+	signer := NewSigner(testSecret, "share")
+	expiresAt := time.Unix(1700000000, 0)
+	token := signer.Sign(7, expiresAt)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		wantErr error
+	}{
+		{"one second before expiry", expiresAt.Add(-time.Second), nil},
+		{"at expiry", expiresAt, ErrExpiredLink},
+		{"after expiry", expiresAt.Add(time.Hour), ErrExpiredLink},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signer.Verify(token, tt.now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsForgedLinks(t *testing.T) {
+	signer := NewSigner(testSecret, "share")
+	now := time.Unix(1700000000, 0)
+	token := signer.Sign(7, now.Add(time.Hour))
+	parts := strings.Split(token, ".")
+
+	tests := []struct {
+		name   string
+		signer *Signer
+		token  string
+	}{
+		{"tampered ID", signer, "8." + parts[1] + "." + parts[2]},
+		{"extended expiry", signer, parts[0] + ".9999999999." + parts[2]},
+		{"tampered signature", signer, parts[0] + "." + parts[1] + "." + strings.ToUpper(parts[2])},
+		{"missing signature", signer, parts[0] + "." + parts[1] + "."},
+		{"wrong purpose", NewSigner(testSecret, "firmware"), token},
+		{"wrong secret", NewSigner("other-secret", "share"), token},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.signer.Verify(tt.token, now); !errors.Is(err, ErrInvalidLink) {
+				t.Errorf("Verify(%q) error = %v, want %v", tt.token, err, ErrInvalidLink)
+			}
+		})
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	signer := NewSigner(testSecret, "share")
+	now := time.Unix(1700000000, 0)
+
+	// signed returns a correctly signed token for an arbitrary payload
+	signed := func(payload string) string {
+		return payload + "." + signer.signature(payload)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"one part", "7"},
+		{"two parts", "7.1700003600"},
+		{"four parts", signer.Sign(7, now.Add(time.Hour)) + ".extra"},
+		{"ID not a number", signed("abc.1700003600")},
+		{"negative ID", signed("-7.1700003600")},
+		{"ID above 32 bits", signed("4294967296.1700003600")},
+		{"expiry not a number", signed("7.soon")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signer.Verify(tt.token, now); !errors.Is(err, ErrInvalidLink) {
+				t.Errorf("Verify(%q) error = %v, want %v", tt.token, err, ErrInvalidLink)
+			}
+		})
+	}
+}
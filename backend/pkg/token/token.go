@@ -8,15 +8,25 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// PurposeMFAChallenge marks a token that only proves the password step of a two-step login
+const PurposeMFAChallenge = "mfa_challenge"
+
 // Claims represents the JWT claims structure
 type Claims struct {
-	UserID   int    `json:"user_id"`
-	Email    string `json:"email"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	UserID      int    `json:"user_id"`
+	Email       string `json:"email"`
+	Username    string `json:"username"`
+	Role        string `json:"role"`
+	MFAVerified bool   `json:"mfa,omitempty"`
+	Purpose     string `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsMFAChallenge checks if the token is an MFA challenge token rather than an access token
+func (c *Claims) IsMFAChallenge() bool {
+	return c.Purpose == PurposeMFAChallenge
+}
+
 // JwtCustomClaims for compatibility with echo-jwt middleware (deprecated, use Claims instead)
 // type JwtCustomClaims struct {
 // 	UserID   int    `json:"user_id"`
@@ -40,22 +50,25 @@ func NewTokenManager(secretKey string) *TokenManager {
 
 // GenerateToken creates a new JWT token for a user
 func (tm *TokenManager) GenerateToken(userID int, email, username, role string, expirationHours int) (string, error) {
-	// Set expiration time
-	expirationTime := time.Now().Add(time.Duration(expirationHours) * time.Hour)
-
-	// Create claims
-	claims := &Claims{
+	return tm.generateWithClaims(&Claims{
 		UserID:   userID,
 		Email:    email,
 		Username: username,
 		Role:     role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-			Issuer:    "cartrack-backend",
-			Subject:   fmt.Sprintf("%d", userID),
-		},
+	}, time.Duration(expirationHours)*time.Hour)
+}
+
+// generateWithClaims fills in the registered claims and signs the token
+func (tm *TokenManager) generateWithClaims(claims *Claims, ttl time.Duration) (string, error) {
+	// Set expiration time
+	expirationTime := time.Now().Add(ttl)
+
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(expirationTime),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		NotBefore: jwt.NewNumericDate(time.Now()),
+		Issuer:    "cartrack-backend",
+		Subject:   fmt.Sprintf("%d", claims.UserID),
 	}
 
 	// Create token
@@ -189,3 +202,45 @@ func (tm *TokenManager) GenerateTokenPair(userID int, email, username, role stri
 
 	return accessToken, refreshToken, nil
 }
+
+// GenerateVerifiedTokenPair creates access and refresh tokens for a user who passed the second factor
+func (tm *TokenManager) GenerateVerifiedTokenPair(userID int, email, username, role string) (accessToken, refreshToken string, err error) {
+	accessToken, err = tm.GenerateVerifiedAccessToken(userID, email, username, role)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err = tm.generateWithClaims(&Claims{
+		UserID:      userID,
+		Email:       email,
+		Username:    username,
+		Role:        role,
+		MFAVerified: true,
+	}, 168*time.Hour) // 7 days expiration
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// GenerateVerifiedAccessToken creates a short-lived access token that records a passed second factor
+func (tm *TokenManager) GenerateVerifiedAccessToken(userID int, email, username, role string) (string, error) {
+	return tm.generateWithClaims(&Claims{
+		UserID:      userID,
+		Email:       email,
+		Username:    username,
+		Role:        role,
+		MFAVerified: true,
+	}, time.Hour) // 1 hour expiration
+}
+
+// GenerateMFAChallengeToken creates a short-lived token that can only be exchanged for tokens with a valid second factor.
+// It carries no role, so RBAC rejects it on every private route.
+func (tm *TokenManager) GenerateMFAChallengeToken(userID int, email string, ttl time.Duration) (string, error) {
+	return tm.generateWithClaims(&Claims{
+		UserID:  userID,
+		Email:   email,
+		Purpose: PurposeMFAChallenge,
+	}, ttl)
+}
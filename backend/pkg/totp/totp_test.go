@@ -0,0 +1,94 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the RFC 6238 SHA-1 test key "12345678901234567890" in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// rfcVectors are the RFC 6238 appendix B SHA-1 vectors, truncated to the last six digits
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestGenerateCode(t *testing.T) {
+	for _, tt := range rfcVectors {
+		got, err := GenerateCode(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("GenerateCode(%d) error: %v", tt.unix, err)
+		}
+		if got != tt.code {
+			t.Errorf("GenerateCode(%d) = %s, want %s", tt.unix, got, tt.code)
+		}
+	}
+}
+
+func TestGenerateCodeInvalidSecret(t *testing.T) {
+	if _, err := GenerateCode("not base32!", time.Unix(59, 0)); err == nil {
+		t.Error("GenerateCode with an invalid secret should fail")
+	}
+}
+
+func TestValidateStep(t *testing.T) {
+	const unix = 1111111111
+	step := int64(unix / Period)
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		at       int64
+		skew     int
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", rfcSecret, "050471", unix, 0, step, true},
+		{"lowercase secret and padded code", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", " 050471 ", unix, 0, step, true},
+		{"one step late within skew", rfcSecret, "050471", unix + Period, 1, step, true},
+		{"one step early within skew", rfcSecret, "050471", unix - Period, 1, step, true},
+		{"one step late without skew", rfcSecret, "050471", unix + Period, 0, 0, false},
+		{"two steps late with skew 1", rfcSecret, "050471", unix + 2*Period, 1, 0, false},
+		{"two steps late with skew 2", rfcSecret, "050471", unix + 2*Period, 2, step, true},
+		{"wrong code", rfcSecret, "050472", unix, 1, 0, false},
+		{"short code", rfcSecret, "50471", unix, 1, 0, false},
+		{"invalid secret", "not base32!", "050471", unix, 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, gotOK := ValidateStep(tt.secret, tt.code, time.Unix(tt.at, 0), tt.skew)
+			if gotOK != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("ValidateStep() = (%d, %v), want (%d, %v)", gotStep, gotOK, tt.wantStep, tt.wantOK)
+			}
+			if ok := Validate(tt.secret, tt.code, time.Unix(tt.at, 0), tt.skew); ok != tt.wantOK {
+				t.Errorf("Validate() = %v, want %v", ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateSecretRoundTrip(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret error: %v", err)
+	}
+
+	now := time.Now()
+	code, err := GenerateCode(secret, now)
+	if err != nil {
+		t.Fatalf("GenerateCode error: %v", err)
+	}
+	if !Validate(secret, code, now, 0) {
+		t.Errorf("code %s generated for secret %s does not validate", code, secret)
+	}
+}
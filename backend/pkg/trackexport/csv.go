@@ -0,0 +1,56 @@
+package trackexport
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// csvWriter writes one row per point, numbering segments from 1
+type csvWriter struct {
+	w       *csv.Writer
+	segment int
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	c := &csvWriter{w: csv.NewWriter(w)}
+	err := c.w.Write([]string{"segment", "timestamp", "latitude", "longitude", "speed_kmh", "direction", "ignition"})
+	return c, err
+}
+
+// StartSegment starts numbering points with the next segment number
+func (c *csvWriter) StartSegment() error {
+	c.segment++
+	return nil
+}
+
+// WritePoint writes a point row. Unknown values are left empty.
+func (c *csvWriter) WritePoint(point Point) error {
+	if c.segment == 0 {
+		c.segment = 1
+	}
+
+	record := []string{
+		strconv.Itoa(c.segment),
+		formatTime(point.Time),
+		strconv.FormatFloat(point.Latitude, 'f', 6, 64),
+		strconv.FormatFloat(point.Longitude, 'f', 6, 64),
+		"", "", "",
+	}
+	if point.Speed != nil {
+		record[4] = strconv.FormatFloat(*point.Speed, 'f', 2, 64)
+	}
+	if point.Direction != nil {
+		record[5] = strconv.Itoa(int(*point.Direction))
+	}
+	if point.Ignition != nil {
+		record[6] = strconv.FormatBool(*point.Ignition)
+	}
+	return c.w.Write(record)
+}
+
+// Close flushes buffered rows
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
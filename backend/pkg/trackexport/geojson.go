@@ -0,0 +1,105 @@
+package trackexport
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// geoJSONWriter writes a FeatureCollection with one feature per segment. Times, speeds and directions are
+// listed in the coordTimes, speeds and directions properties, parallel to the coordinates, so the points of
+// the open segment are held until it ends.
+type geoJSONWriter struct {
+	w        *bufio.Writer
+	segment  []Point
+	segments int
+}
+
+func newGeoJSONWriter(w io.Writer, name string) (*geoJSONWriter, error) {
+	g := &geoJSONWriter{w: bufio.NewWriter(w)}
+	encodedName, err := json.Marshal(name)
+	if err != nil {
+		return nil, err
+	}
+	_, err = fmt.Fprintf(g.w, `{"type":"FeatureCollection","name":%s,"features":[`, encodedName)
+	return g, err
+}
+
+// StartSegment writes the open segment and starts a new one
+func (g *geoJSONWriter) StartSegment() error {
+	return g.flushSegment()
+}
+
+// WritePoint adds a point to the open segment
+func (g *geoJSONWriter) WritePoint(point Point) error {
+	g.segment = append(g.segment, point)
+	return nil
+}
+
+// Close writes the open segment, finishes the document and flushes it
+func (g *geoJSONWriter) Close() error {
+	if err := g.flushSegment(); err != nil {
+		return err
+	}
+	g.w.WriteString("\n]}\n")
+	return g.w.Flush()
+}
+
+// flushSegment writes the open segment as a feature. A single point is a Point, as a LineString needs two.
+func (g *geoJSONWriter) flushSegment() error {
+	if len(g.segment) == 0 {
+		return nil
+	}
+	if g.segments > 0 {
+		g.w.WriteString(",")
+	}
+	g.segments++
+
+	fmt.Fprintf(g.w, "\n"+`{"type":"Feature","properties":{"segment":%d,"coordTimes":[`, g.segments)
+	for i, point := range g.segment {
+		g.separator(i)
+		fmt.Fprintf(g.w, `"%s"`, formatTime(point.Time))
+	}
+	g.w.WriteString(`],"speeds":[`)
+	for i, point := range g.segment {
+		g.separator(i)
+		if point.Speed != nil {
+			fmt.Fprintf(g.w, "%.2f", *point.Speed)
+		} else {
+			g.w.WriteString("null")
+		}
+	}
+	g.w.WriteString(`],"directions":[`)
+	for i, point := range g.segment {
+		g.separator(i)
+		if point.Direction != nil {
+			fmt.Fprintf(g.w, "%d", *point.Direction)
+		} else {
+			g.w.WriteString("null")
+		}
+	}
+
+	var err error
+	if len(g.segment) == 1 {
+		point := g.segment[0]
+		_, err = fmt.Fprintf(g.w, `]},"geometry":{"type":"Point","coordinates":[%.6f,%.6f]}}`, point.Longitude, point.Latitude)
+	} else {
+		g.w.WriteString(`]},"geometry":{"type":"LineString","coordinates":[`)
+		for i, point := range g.segment {
+			g.separator(i)
+			fmt.Fprintf(g.w, "[%.6f,%.6f]", point.Longitude, point.Latitude)
+		}
+		_, err = g.w.WriteString("]}}")
+	}
+
+	g.segment = g.segment[:0]
+	return err
+}
+
+// separator writes the comma before every array element but the first
+func (g *geoJSONWriter) separator(i int) {
+	if i > 0 {
+		g.w.WriteString(",")
+	}
+}
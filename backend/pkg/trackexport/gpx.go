@@ -0,0 +1,67 @@
+package trackexport
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// gpxWriter writes GPX 1.1 with speed and course in Garmin TrackPointExtension v2 elements
+type gpxWriter struct {
+	w         *bufio.Writer
+	inSegment bool
+}
+
+func newGPXWriter(w io.Writer, name string) (*gpxWriter, error) {
+	g := &gpxWriter{w: bufio.NewWriter(w)}
+	g.w.WriteString(xml.Header)
+	g.w.WriteString(`<gpx version="1.1" creator="cartrack" xmlns="http://www.topografix.com/GPX/1/1"` +
+		` xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v2">` + "\n")
+	g.w.WriteString("<trk><name>")
+	xml.EscapeText(g.w, []byte(name))
+	_, err := g.w.WriteString("</name>\n")
+	return g, err
+}
+
+// StartSegment closes the current track segment and opens a new one
+func (g *gpxWriter) StartSegment() error {
+	if g.inSegment {
+		g.w.WriteString("</trkseg>\n")
+	}
+	g.inSegment = true
+	_, err := g.w.WriteString("<trkseg>\n")
+	return err
+}
+
+// WritePoint writes a track point. GPX speeds are in metres per second.
+func (g *gpxWriter) WritePoint(point Point) error {
+	if !g.inSegment {
+		if err := g.StartSegment(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(g.w, `<trkpt lat="%.6f" lon="%.6f"><time>%s</time>`, point.Latitude, point.Longitude, formatTime(point.Time))
+	if point.Speed != nil || point.Direction != nil {
+		g.w.WriteString("<extensions><gpxtpx:TrackPointExtension>")
+		if point.Speed != nil {
+			fmt.Fprintf(g.w, "<gpxtpx:speed>%.2f</gpxtpx:speed>", *point.Speed/3.6)
+		}
+		if point.Direction != nil {
+			fmt.Fprintf(g.w, "<gpxtpx:course>%d</gpxtpx:course>", *point.Direction)
+		}
+		g.w.WriteString("</gpxtpx:TrackPointExtension></extensions>")
+	}
+	_, err := g.w.WriteString("</trkpt>\n")
+	return err
+}
+
+// Close finishes the document and flushes it
+func (g *gpxWriter) Close() error {
+	if g.inSegment {
+		g.w.WriteString("</trkseg>\n")
+	}
+	g.w.WriteString("</trk>\n</gpx>\n")
+	return g.w.Flush()
+}
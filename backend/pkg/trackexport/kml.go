@@ -0,0 +1,87 @@
+package trackexport
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// kmlWriter writes KML 2.2 with one gx:Track placemark per segment. A gx:Track lists all its timestamps,
+// then all coordinates, then the extended data arrays, so the points of the open segment are held until it ends.
+type kmlWriter struct {
+	w        *bufio.Writer
+	segment  []Point
+	segments int
+}
+
+func newKMLWriter(w io.Writer, name string) (*kmlWriter, error) {
+	k := &kmlWriter{w: bufio.NewWriter(w)}
+	k.w.WriteString(xml.Header)
+	k.w.WriteString(`<kml xmlns="http://www.opengis.net/kml/2.2" xmlns:gx="http://www.google.com/kml/ext/2.2">` + "\n")
+	k.w.WriteString("<Document><name>")
+	xml.EscapeText(k.w, []byte(name))
+	k.w.WriteString("</name>\n")
+	_, err := k.w.WriteString(`<Schema id="trackpoint">` +
+		`<gx:SimpleArrayField name="speed" type="float"><displayName>Speed (km/h)</displayName></gx:SimpleArrayField>` +
+		`<gx:SimpleArrayField name="direction" type="int"><displayName>Direction</displayName></gx:SimpleArrayField>` +
+		"</Schema>\n")
+	return k, err
+}
+
+// StartSegment writes the open segment and starts a new one
+func (k *kmlWriter) StartSegment() error {
+	return k.flushSegment()
+}
+
+// WritePoint adds a point to the open segment
+func (k *kmlWriter) WritePoint(point Point) error {
+	k.segment = append(k.segment, point)
+	return nil
+}
+
+// Close writes the open segment, finishes the document and flushes it
+func (k *kmlWriter) Close() error {
+	if err := k.flushSegment(); err != nil {
+		return err
+	}
+	k.w.WriteString("</Document>\n</kml>\n")
+	return k.w.Flush()
+}
+
+// flushSegment writes the open segment as a placemark
+func (k *kmlWriter) flushSegment() error {
+	if len(k.segment) == 0 {
+		return nil
+	}
+	k.segments++
+
+	fmt.Fprintf(k.w, "<Placemark><name>Segment %d</name><gx:Track>\n", k.segments)
+	for _, point := range k.segment {
+		fmt.Fprintf(k.w, "<when>%s</when>\n", formatTime(point.Time))
+	}
+	for _, point := range k.segment {
+		fmt.Fprintf(k.w, "<gx:coord>%.6f %.6f 0</gx:coord>\n", point.Longitude, point.Latitude)
+	}
+
+	k.w.WriteString(`<ExtendedData><SchemaData schemaUrl="#trackpoint"><gx:SimpleArrayData name="speed">`)
+	for _, point := range k.segment {
+		if point.Speed != nil {
+			fmt.Fprintf(k.w, "<gx:value>%.2f</gx:value>", *point.Speed)
+		} else {
+			k.w.WriteString("<gx:value/>")
+		}
+	}
+	k.w.WriteString(`</gx:SimpleArrayData><gx:SimpleArrayData name="direction">`)
+	for _, point := range k.segment {
+		if point.Direction != nil {
+			fmt.Fprintf(k.w, "<gx:value>%d</gx:value>", *point.Direction)
+		} else {
+			k.w.WriteString("<gx:value/>")
+		}
+	}
+	_, err := k.w.WriteString("</gx:SimpleArrayData></SchemaData></ExtendedData>\n</gx:Track></Placemark>\n")
+
+	k.segment = k.segment[:0]
+	return err
+}
@@ -0,0 +1,87 @@
+package trackexport
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format is a track export file format
+type Format string
+
+// Supported export formats
+const (
+	FormatGPX     Format = "gpx"
+	FormatKML     Format = "kml"
+	FormatGeoJSON Format = "geojson"
+	FormatCSV     Format = "csv"
+)
+
+// ParseFormat parses a format name case-insensitively
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case FormatGPX, FormatKML, FormatGeoJSON, FormatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q, use gpx, kml, geojson or csv", name)
+	}
+}
+
+// ContentType returns the MIME type of files in the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatGPX:
+		return "application/gpx+xml"
+	case FormatKML:
+		return "application/vnd.google-earth.kml+xml"
+	case FormatGeoJSON:
+		return "application/geo+json"
+	default:
+		return "text/csv"
+	}
+}
+
+// Extension returns the file extension of the format without the dot
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Point is a recorded position of a track. Speed is in km/h and Direction in degrees from north.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+	Time      time.Time
+	Speed     *float64
+	Direction *int16
+	Ignition  *bool
+}
+
+// Writer streams a track as a sequence of segments. A new segment starts after a gap in the recording.
+// Close must be called to finish the document; it does not close the underlying writer.
+type Writer interface {
+	StartSegment() error
+	WritePoint(point Point) error
+	Close() error
+}
+
+// NewWriter writes the document header of a track named name to w and returns a writer for its points
+func NewWriter(format Format, w io.Writer, name string) (Writer, error) {
+	switch format {
+	case FormatGPX:
+		return newGPXWriter(w, name)
+	case FormatKML:
+		return newKMLWriter(w, name)
+	case FormatGeoJSON:
+		return newGeoJSONWriter(w, name)
+	case FormatCSV:
+		return newCSVWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// formatTime formats a point time as UTC RFC 3339, which all formats accept
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
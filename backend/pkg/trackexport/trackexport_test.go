@@ -0,0 +1,118 @@
+package trackexport
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenName needs escaping in every format that embeds it
+const goldenName = `B 1234 "XY" <Fleet & Co>`
+
+// writeTrack writes two segments: two fully populated points, then a lone point without optional values
+func writeTrack(t *testing.T, format Format) []byte {
+	t.Helper()
+
+	speed, slower := 36.0, 18.5
+	direction, ignition := int16(90), true
+	start := time.Date(2025, 1, 6, 8, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(format, &buf, goldenName)
+	if err != nil {
+		t.Fatalf("NewWriter(%s): %v", format, err)
+	}
+
+	segments := [][]Point{
+		{
+			{Latitude: -6.2, Longitude: 106.816666, Time: start, Speed: &speed, Direction: &direction, Ignition: &ignition},
+			{Latitude: -6.201, Longitude: 106.817, Time: start.Add(time.Minute), Speed: &slower, Direction: &direction, Ignition: &ignition},
+		},
+		{
+			{Latitude: -6.3, Longitude: 106.9, Time: start.Add(time.Hour)},
+		},
+	}
+	for _, segment := range segments {
+		if err := writer.StartSegment(); err != nil {
+			t.Fatalf("StartSegment: %v", err)
+		}
+		for _, point := range segment {
+			if err := writer.WritePoint(point); err != nil {
+				t.Fatalf("WritePoint: %v", err)
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestWriterGolden(t *testing.T) {
+	for _, format := range []Format{FormatGPX, FormatKML, FormatGeoJSON, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			got := writeTrack(t, format)
+
+			path := filepath.Join("testdata", "track."+format.Extension())
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading golden file: %v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s output differs from %s\ngot:\n%s\nwant:\n%s", format, path, got, want)
+			}
+		})
+	}
+}
+
+func TestWriterWithoutStartSegment(t *testing.T) {
+	// Writers open the first segment themselves when points come before StartSegment
+	for _, format := range []Format{FormatGPX, FormatKML, FormatGeoJSON, FormatCSV} {
+		var buf bytes.Buffer
+		writer, err := NewWriter(format, &buf, "track")
+		if err != nil {
+			t.Fatalf("NewWriter(%s): %v", format, err)
+		}
+		if err := writer.WritePoint(Point{Latitude: 1, Longitude: 2, Time: time.Unix(0, 0)}); err != nil {
+			t.Fatalf("%s WritePoint: %v", format, err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("%s Close: %v", format, err)
+		}
+		if !bytes.Contains(buf.Bytes(), []byte("1970-01-01T00:00:00Z")) {
+			t.Errorf("%s output lacks the point:\n%s", format, buf.Bytes())
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr bool
+	}{
+		{"gpx", FormatGPX, false},
+		{"KML", FormatKML, false},
+		{"GeoJSON", FormatGeoJSON, false},
+		{"csv", FormatCSV, false},
+		{"shp", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = (%q, %v), want %q, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
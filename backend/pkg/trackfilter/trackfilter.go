@@ -0,0 +1,179 @@
+package trackfilter
+
+import (
+	"math"
+	"time"
+
+	"github.com/cartrack/backend/pkg/geo"
+)
+
+// jitterMinPoints is the smallest run of positions snapped together. Shorter runs are
+// indistinguishable from slow driving with dense fixes.
+const jitterMinPoints = 3
+
+// Point is a position of a track ordered by time. Index refers back to the caller's data.
+// Suspect marks a position already flagged as implausible when it was recorded.
+type Point struct {
+	Index     int
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+	Suspect   bool
+}
+
+// Options selects the cleaning steps applied to a track. A zero value disables a step.
+// MaxSpeed (km/h) drops positions that could only be reached faster than physically possible,
+// JitterRadius (meters) collapses a vehicle standing still into one position and Tolerance (meters)
+// is the Douglas-Peucker simplification tolerance.
+type Options struct {
+	MaxSpeed     float64
+	JitterRadius float64
+	Tolerance    float64
+}
+
+// Enabled reports whether any step is selected
+func (o Options) Enabled() bool {
+	return o.MaxSpeed > 0 || o.JitterRadius > 0 || o.Tolerance > 0
+}
+
+// Apply runs the selected steps in order: outliers are dropped first so they cannot distort
+// the stationary clusters, and the cleaned track is simplified last
+func Apply(points []Point, opts Options) []Point {
+	if opts.MaxSpeed > 0 {
+		points = DropOutliers(points, opts.MaxSpeed)
+	}
+	if opts.JitterRadius > 0 {
+		points = SnapJitter(points, opts.JitterRadius)
+	}
+	if opts.Tolerance > 0 {
+		points = Simplify(points, opts.Tolerance)
+	}
+	return points
+}
+
+// ImpliedSpeed returns the speed in km/h needed to travel between two positions
+func ImpliedSpeed(from, to Point) float64 {
+	meters := geo.DistanceMeters(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+	seconds := to.Time.Sub(from.Time).Seconds()
+	if seconds <= 0 {
+		if meters == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return meters / seconds * 3.6
+}
+
+// IsImpossibleJump reports whether reaching to from from needs a speed above maxSpeed km/h
+func IsImpossibleJump(from, to Point, maxSpeed float64) bool {
+	return ImpliedSpeed(from, to) > maxSpeed
+}
+
+// DropOutliers removes suspect positions and positions reached from the previous kept one faster than maxSpeed.
+// The first position is dropped instead when it is the one out of line with the two following it.
+func DropOutliers(points []Point, maxSpeed float64) []Point {
+	kept := make([]Point, 0, len(points))
+	for i, point := range points {
+		if point.Suspect {
+			continue
+		}
+		if len(kept) == 0 {
+			kept = append(kept, point)
+			continue
+		}
+
+		if !IsImpossibleJump(kept[len(kept)-1], point, maxSpeed) {
+			kept = append(kept, point)
+			continue
+		}
+
+		// A bad first position would otherwise reject the whole track
+		if len(kept) == 1 && i+1 < len(points) && !IsImpossibleJump(point, points[i+1], maxSpeed) {
+			kept[0] = point
+		}
+	}
+	return kept
+}
+
+// SnapJitter collapses runs of positions staying within radius meters of the run's first one.
+// The run is reduced to its first and last position, both at the first one's coordinates, so the
+// time spent standing is kept while the jitter disappears.
+func SnapJitter(points []Point, radius float64) []Point {
+	kept := make([]Point, 0, len(points))
+	for i := 0; i < len(points); {
+		anchor := points[i]
+		j := i
+		for j+1 < len(points) && geo.DistanceMeters(anchor.Latitude, anchor.Longitude, points[j+1].Latitude, points[j+1].Longitude) <= radius {
+			j++
+		}
+
+		if j-i+1 < jitterMinPoints {
+			kept = append(kept, anchor)
+			i++
+			continue
+		}
+
+		last := points[j]
+		last.Latitude, last.Longitude = anchor.Latitude, anchor.Longitude
+		kept = append(kept, anchor, last)
+		i = j + 1
+	}
+	return kept
+}
+
+// Simplify reduces a track with the Douglas-Peucker algorithm, keeping every position that lies more
+// than tolerance meters from the line between the positions kept around it
+func Simplify(points []Point, tolerance float64) []Point {
+	if len(points) < 3 {
+		return points
+	}
+
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+
+	// Iterative to keep long tracks from growing the call stack
+	stack := [][2]int{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		first, last := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		farthest, maxDistance := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			if distance := distanceToSegment(points[i], points[first], points[last]); distance > maxDistance {
+				farthest, maxDistance = i, distance
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+
+		keep[farthest] = true
+		stack = append(stack, [2]int{first, farthest}, [2]int{farthest, last})
+	}
+
+	kept := make([]Point, 0, len(points))
+	for i, point := range points {
+		if keep[i] {
+			kept = append(kept, point)
+		}
+	}
+	return kept
+}
+
+// distanceToSegment returns the distance in meters from p to the segment between a and b, on a plane
+// tangent at a. The error is negligible over the few kilometers between simplified positions.
+func distanceToSegment(p, a, b Point) float64 {
+	scale := math.Cos(a.Latitude * math.Pi / 180)
+	toMeters := geo.EarthRadiusMeters * math.Pi / 180
+
+	px, py := (p.Longitude-a.Longitude)*scale*toMeters, (p.Latitude-a.Latitude)*toMeters
+	bx, by := (b.Longitude-a.Longitude)*scale*toMeters, (b.Latitude-a.Latitude)*toMeters
+
+	length := bx*bx + by*by
+	if length == 0 {
+		return math.Hypot(px, py)
+	}
+
+	t := math.Max(0, math.Min(1, (px*bx+py*by)/length))
+	return math.Hypot(px-t*bx, py-t*by)
+}
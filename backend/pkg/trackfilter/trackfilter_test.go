@@ -0,0 +1,173 @@
+package trackfilter
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC)
+
+// metersPerDegree is the length of one degree of latitude on the sphere used by geo
+const metersPerDegree = 111194.93
+
+// track builds points one minute apart from (latitude, longitude) pairs
+func track(coords ...[2]float64) []Point {
+	points := make([]Point, len(coords))
+	for i, c := range coords {
+		points[i] = Point{Index: i, Time: start.Add(time.Duration(i) * time.Minute), Latitude: c[0], Longitude: c[1]}
+	}
+	return points
+}
+
+func indexes(points []Point) []int {
+	result := make([]int, len(points))
+	for i, point := range points {
+		result[i] = point.Index
+	}
+	return result
+}
+
+func equalIndexes(got []Point, want []int) bool {
+	indexes := indexes(got)
+	if len(indexes) != len(want) {
+		return false
+	}
+	for i := range want {
+		if indexes[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSimplifyTolerance(t *testing.T) {
+	// A straight road north with a 100 m detour east at the middle position
+	detour := 100 / metersPerDegree
+	points := track(
+		[2]float64{0, 0},
+		[2]float64{0.001, 0},
+		[2]float64{0.002, detour},
+		[2]float64{0.003, 0},
+		[2]float64{0.004, 0},
+	)
+
+	tests := []struct {
+		name      string
+		tolerance float64
+		want      []int
+	}{
+		{"tolerance below the detour keeps it", 50, []int{0, 2, 4}},
+		{"tolerance above the detour drops it", 150, []int{0, 4}},
+		// The positions around the detour lie about 46 m from the lines through it
+		{"tolerance below every offset keeps everything", 40, []int{0, 1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Simplify(points, tt.tolerance); !equalIndexes(got, tt.want) {
+				t.Errorf("Simplify(%v) kept %v, want %v", tt.tolerance, indexes(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSimplifyShortTrack(t *testing.T) {
+	points := track([2]float64{0, 0}, [2]float64{1, 1})
+	if got := Simplify(points, 1000); !equalIndexes(got, []int{0, 1}) {
+		t.Errorf("Simplify kept %v, want both positions", indexes(got))
+	}
+}
+
+func TestDropOutliers(t *testing.T) {
+	// 0.001 degree per minute is about 6.7 km/h; 0.5 degree per minute is well over 3000 km/h
+	tests := []struct {
+		name   string
+		points []Point
+		want   []int
+	}{
+		{
+			name:   "consistent track is kept",
+			points: track([2]float64{0, 0}, [2]float64{0.001, 0}, [2]float64{0.002, 0}),
+			want:   []int{0, 1, 2},
+		},
+		{
+			name:   "spike in the middle is dropped",
+			points: track([2]float64{0, 0}, [2]float64{0.5, 0}, [2]float64{0.002, 0}, [2]float64{0.003, 0}),
+			want:   []int{0, 2, 3},
+		},
+		{
+			name:   "bad first position is replaced",
+			points: track([2]float64{0.5, 0}, [2]float64{0, 0}, [2]float64{0.001, 0}, [2]float64{0.002, 0}),
+			want:   []int{1, 2, 3},
+		},
+		{
+			name:   "bad first position followed by a spike is kept",
+			points: track([2]float64{0.5, 0}, [2]float64{0, 0}, [2]float64{-0.5, 0}),
+			want:   []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DropOutliers(tt.points, 200); !equalIndexes(got, tt.want) {
+				t.Errorf("DropOutliers kept %v, want %v", indexes(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestDropOutliersSkipsSuspect(t *testing.T) {
+	points := track([2]float64{0, 0}, [2]float64{0.001, 0}, [2]float64{0.002, 0})
+	points[1].Suspect = true
+
+	if got := DropOutliers(points, 200); !equalIndexes(got, []int{0, 2}) {
+		t.Errorf("DropOutliers kept %v, want [0 2]", indexes(got))
+	}
+}
+
+func TestSnapJitter(t *testing.T) {
+	// Offsets of about 5 m around a parked position, then driving away
+	jitter := 5 / metersPerDegree
+	points := track(
+		[2]float64{0, 0},
+		[2]float64{jitter, 0},
+		[2]float64{0, jitter},
+		[2]float64{-jitter, 0},
+		[2]float64{0.01, 0},
+	)
+
+	got := SnapJitter(points, 10)
+	if !equalIndexes(got, []int{0, 3, 4}) {
+		t.Fatalf("SnapJitter kept %v, want [0 3 4]", indexes(got))
+	}
+	if got[1].Latitude != 0 || got[1].Longitude != 0 {
+		t.Errorf("end of the stay at (%v, %v), want it snapped to (0, 0)", got[1].Latitude, got[1].Longitude)
+	}
+	if !got[1].Time.Equal(points[3].Time) {
+		t.Errorf("end of the stay at %v, want the last position's time %v", got[1].Time, points[3].Time)
+	}
+}
+
+func TestSnapJitterShortRun(t *testing.T) {
+	// Two close positions are too few to tell standing from slow driving
+	jitter := 5 / metersPerDegree
+	points := track([2]float64{0, 0}, [2]float64{jitter, 0}, [2]float64{0.01, 0})
+
+	got := SnapJitter(points, 10)
+	if !equalIndexes(got, []int{0, 1, 2}) {
+		t.Fatalf("SnapJitter kept %v, want every position", indexes(got))
+	}
+	if got[1].Latitude != jitter {
+		t.Errorf("second position moved to %v, want it left at %v", got[1].Latitude, jitter)
+	}
+}
+
+func TestApplyDisabled(t *testing.T) {
+	points := track([2]float64{0, 0}, [2]float64{0.5, 0}, [2]float64{0.001, 0})
+	if (Options{}).Enabled() {
+		t.Error("zero Options should be disabled")
+	}
+	if got := Apply(points, Options{}); !equalIndexes(got, []int{0, 1, 2}) {
+		t.Errorf("Apply without steps kept %v, want every position", indexes(got))
+	}
+}
@@ -0,0 +1,175 @@
+package trackimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnMapping names the CSV header columns holding each field. Latitude, longitude and timestamp
+// are required; the others are read when set. TimeFormat is "rfc3339" (default), "unix", "unix_ms"
+// or a Go time layout interpreted in UTC. SpeedUnit is "kmh" (default), "mps", "mph" or "knots".
+type ColumnMapping struct {
+	Timestamp  string `json:"timestamp,omitempty"`
+	Latitude   string `json:"latitude,omitempty"`
+	Longitude  string `json:"longitude,omitempty"`
+	Speed      string `json:"speed,omitempty"`
+	Direction  string `json:"direction,omitempty"`
+	Ignition   string `json:"ignition,omitempty"`
+	TimeFormat string `json:"time_format,omitempty"`
+	SpeedUnit  string `json:"speed_unit,omitempty"`
+	Delimiter  string `json:"delimiter,omitempty"`
+}
+
+// DefaultColumnMapping maps the columns written by the CSV track export
+func DefaultColumnMapping() ColumnMapping {
+	return ColumnMapping{
+		Timestamp: "timestamp",
+		Latitude:  "latitude",
+		Longitude: "longitude",
+		Speed:     "speed_kmh",
+		Direction: "direction",
+		Ignition:  "ignition",
+	}
+}
+
+// Validate checks the mapping names the required columns and uses known units and a single-character delimiter
+func (m ColumnMapping) Validate() error {
+	if m.Timestamp == "" || m.Latitude == "" || m.Longitude == "" {
+		return errors.New("column mapping must name the timestamp, latitude and longitude columns")
+	}
+	if _, err := convertSpeed(0, m.SpeedUnit); err != nil {
+		return err
+	}
+	if len([]rune(m.Delimiter)) > 1 {
+		return errors.New("delimiter must be a single character")
+	}
+	return nil
+}
+
+// csvReader reads fixes from CSV rows using a header row and a column mapping
+type csvReader struct {
+	r       *csv.Reader
+	mapping ColumnMapping
+	columns map[string]int
+}
+
+func newCSVReader(r io.Reader, mapping ColumnMapping) (*csvReader, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+	if mapping.Delimiter != "" {
+		reader.Comma = []rune(mapping.Delimiter)[0]
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	for _, required := range []string{mapping.Timestamp, mapping.Latitude, mapping.Longitude} {
+		if _, ok := columns[strings.ToLower(required)]; !ok {
+			return nil, fmt.Errorf("CSV header has no %q column", required)
+		}
+	}
+
+	return &csvReader{r: reader, mapping: mapping, columns: columns}, nil
+}
+
+// Next reads the next row
+func (c *csvReader) Next() (*Fix, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &RowError{Row: parseErr.Line, Message: parseErr.Err.Error()}
+		}
+		return nil, err
+	}
+	row, _ := c.r.FieldPos(0)
+
+	fix := &Fix{Row: row}
+	if fix.Latitude, err = strconv.ParseFloat(c.value(record, c.mapping.Latitude), 64); err != nil {
+		return nil, &RowError{Row: row, Message: "invalid latitude"}
+	}
+	if fix.Longitude, err = strconv.ParseFloat(c.value(record, c.mapping.Longitude), 64); err != nil {
+		return nil, &RowError{Row: row, Message: "invalid longitude"}
+	}
+	if fix.Time, err = c.parseTime(c.value(record, c.mapping.Timestamp)); err != nil {
+		return nil, &RowError{Row: row, Message: "invalid timestamp"}
+	}
+
+	if value := c.value(record, c.mapping.Speed); value != "" {
+		speed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, &RowError{Row: row, Message: "invalid speed"}
+		}
+		speed, _ = convertSpeed(speed, c.mapping.SpeedUnit)
+		fix.Speed = &speed
+	}
+	if value := c.value(record, c.mapping.Direction); value != "" {
+		direction, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, &RowError{Row: row, Message: "invalid direction"}
+		}
+		normalized := normalizeDirection(direction)
+		fix.Direction = &normalized
+	}
+	if value := c.value(record, c.mapping.Ignition); value != "" {
+		ignition, err := strconv.ParseBool(strings.ToLower(value))
+		if err != nil {
+			return nil, &RowError{Row: row, Message: "invalid ignition"}
+		}
+		fix.Ignition = &ignition
+	}
+
+	return fix, nil
+}
+
+// value returns the trimmed value of a mapped column, or "" when the column is unmapped or missing
+func (c *csvReader) value(record []string, column string) string {
+	if column == "" {
+		return ""
+	}
+	i, ok := c.columns[strings.ToLower(column)]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// parseTime parses a timestamp in the mapping's time format
+func (c *csvReader) parseTime(value string) (time.Time, error) {
+	switch strings.ToLower(c.mapping.TimeFormat) {
+	case "", "rfc3339":
+		return time.Parse(time.RFC3339, value)
+	case "unix":
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(int64(seconds * 1000)).UTC(), nil
+	case "unix_ms":
+		milliseconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(milliseconds).UTC(), nil
+	default:
+		return time.ParseInLocation(c.mapping.TimeFormat, value, time.UTC)
+	}
+}
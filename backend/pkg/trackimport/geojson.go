@@ -0,0 +1,239 @@
+package trackimport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// geoJSONFeature is a feature of a FeatureCollection with its geometry left for decoding by type
+type geoJSONFeature struct {
+	Geometry *struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+// geoJSONReader reads positions from the features of a FeatureCollection one feature at a time.
+// Point features carry time, speed, direction and ignition properties. LineString and MultiLineString
+// features carry parallel coordTimes, speeds and directions arrays, as written by the track export.
+type geoJSONReader struct {
+	dec     *json.Decoder
+	opened  bool
+	feature int
+	row     int
+	pending []Fix
+}
+
+func newGeoJSONReader(r io.Reader) *geoJSONReader {
+	return &geoJSONReader{dec: json.NewDecoder(r)}
+}
+
+// Next returns the next position, decoding another feature when the current one is exhausted
+func (g *geoJSONReader) Next() (*Fix, error) {
+	if !g.opened {
+		if err := g.openFeatures(); err != nil {
+			return nil, err
+		}
+		g.opened = true
+	}
+
+	for len(g.pending) == 0 {
+		if !g.dec.More() {
+			return nil, io.EOF
+		}
+
+		var feature geoJSONFeature
+		if err := g.dec.Decode(&feature); err != nil {
+			return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+		}
+		g.feature++
+
+		if err := g.expand(&feature); err != nil {
+			return nil, &RowError{Row: g.row + 1, Message: fmt.Sprintf("feature %d: %s", g.feature, err.Error())}
+		}
+	}
+
+	fix := g.pending[0]
+	g.pending = g.pending[1:]
+	return &fix, nil
+}
+
+// openFeatures advances the decoder into the features array of the top-level object, skipping other members
+func (g *geoJSONReader) openFeatures() error {
+	if err := g.expectDelim('{'); err != nil {
+		return err
+	}
+
+	for g.dec.More() {
+		token, err := g.dec.Token()
+		if err != nil {
+			return fmt.Errorf("invalid GeoJSON: %w", err)
+		}
+		if key, _ := token.(string); key == "features" {
+			return g.expectDelim('[')
+		}
+
+		var skipped json.RawMessage
+		if err := g.dec.Decode(&skipped); err != nil {
+			return fmt.Errorf("invalid GeoJSON: %w", err)
+		}
+	}
+	return errors.New("GeoJSON must be a FeatureCollection with a features array")
+}
+
+// expectDelim reads a token and checks it is the delimiter
+func (g *geoJSONReader) expectDelim(delim json.Delim) error {
+	token, err := g.dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+	if token != delim {
+		return errors.New("GeoJSON must be a FeatureCollection with a features array")
+	}
+	return nil
+}
+
+// expand converts a feature to pending fixes
+func (g *geoJSONReader) expand(feature *geoJSONFeature) error {
+	if feature.Geometry == nil {
+		return errors.New("missing geometry")
+	}
+
+	switch feature.Geometry.Type {
+	case "Point":
+		var position []float64
+		if err := json.Unmarshal(feature.Geometry.Coordinates, &position); err != nil || len(position) < 2 {
+			return errors.New("invalid coordinates")
+		}
+		// A single-position segment of the track export keeps its one-element arrays
+		var times []json.RawMessage
+		var speeds []*float64
+		var directions []*float64
+		unmarshalOptional(firstProperty(feature.Properties, "coordTimes", "times"), &times)
+		if len(times) == 1 {
+			unmarshalOptional(feature.Properties["speeds"], &speeds)
+			unmarshalOptional(feature.Properties["directions"], &directions)
+			return g.appendLine([][]float64{position}, times, speeds, directions)
+		}
+
+		timestamp, err := parseJSONTime(firstProperty(feature.Properties, "time", "timestamp"))
+		if err != nil {
+			return errors.New("missing or invalid time property")
+		}
+
+		fix := Fix{Latitude: position[1], Longitude: position[0], Time: timestamp}
+		var speed *float64
+		var direction *float64
+		unmarshalOptional(feature.Properties["speed"], &speed)
+		unmarshalOptional(firstProperty(feature.Properties, "direction", "course", "heading"), &direction)
+		unmarshalOptional(feature.Properties["ignition"], &fix.Ignition)
+		g.appendFix(fix, speed, direction)
+
+	case "LineString":
+		var line [][]float64
+		if err := json.Unmarshal(feature.Geometry.Coordinates, &line); err != nil {
+			return errors.New("invalid coordinates")
+		}
+		var times []json.RawMessage
+		var speeds []*float64
+		var directions []*float64
+		unmarshalOptional(firstProperty(feature.Properties, "coordTimes", "times"), &times)
+		unmarshalOptional(feature.Properties["speeds"], &speeds)
+		unmarshalOptional(feature.Properties["directions"], &directions)
+		return g.appendLine(line, times, speeds, directions)
+
+	case "MultiLineString":
+		var lines [][][]float64
+		if err := json.Unmarshal(feature.Geometry.Coordinates, &lines); err != nil {
+			return errors.New("invalid coordinates")
+		}
+		var times [][]json.RawMessage
+		unmarshalOptional(firstProperty(feature.Properties, "coordTimes", "times"), &times)
+		if len(times) != len(lines) {
+			return errors.New("coordTimes must list the times of every line")
+		}
+		for i, line := range lines {
+			if err := g.appendLine(line, times[i], nil, nil); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("unsupported geometry type %q", feature.Geometry.Type)
+	}
+	return nil
+}
+
+// appendLine adds the positions of a line with their parallel times, speeds and directions
+func (g *geoJSONReader) appendLine(line [][]float64, times []json.RawMessage, speeds, directions []*float64) error {
+	if len(times) != len(line) {
+		return errors.New("coordTimes must list a time for every coordinate")
+	}
+	for i, position := range line {
+		if len(position) < 2 {
+			return fmt.Errorf("invalid coordinate %d", i+1)
+		}
+		timestamp, err := parseJSONTime(times[i])
+		if err != nil {
+			return fmt.Errorf("invalid time of coordinate %d", i+1)
+		}
+
+		var speed, direction *float64
+		if i < len(speeds) {
+			speed = speeds[i]
+		}
+		if i < len(directions) {
+			direction = directions[i]
+		}
+		g.appendFix(Fix{Latitude: position[1], Longitude: position[0], Time: timestamp}, speed, direction)
+	}
+	return nil
+}
+
+// appendFix numbers a fix, sets its optional speed (km/h) and direction and queues it
+func (g *geoJSONReader) appendFix(fix Fix, speed, direction *float64) {
+	g.row++
+	fix.Row = g.row
+	fix.Speed = speed
+	if direction != nil {
+		normalized := normalizeDirection(*direction)
+		fix.Direction = &normalized
+	}
+	g.pending = append(g.pending, fix)
+}
+
+// firstProperty returns the first present property of the names
+func firstProperty(properties map[string]json.RawMessage, names ...string) json.RawMessage {
+	for _, name := range names {
+		if value, ok := properties[name]; ok {
+			return value
+		}
+	}
+	return nil
+}
+
+// unmarshalOptional decodes a property when present, leaving the target unchanged otherwise
+func unmarshalOptional(raw json.RawMessage, target interface{}) {
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, target)
+	}
+}
+
+// parseJSONTime parses an RFC 3339 string or a number of Unix seconds
+func parseJSONTime(raw json.RawMessage) (time.Time, error) {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return time.Parse(time.RFC3339, text)
+	}
+
+	seconds, err := strconv.ParseFloat(string(raw), 64)
+	if err != nil {
+		return time.Time{}, errors.New("invalid time")
+	}
+	return time.UnixMilli(int64(seconds * 1000)).UTC(), nil
+}
@@ -0,0 +1,97 @@
+package trackimport
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// gpxPoint is a GPX track or route point. Speed and course are read from GPX 1.0 elements or
+// from a Garmin TrackPointExtension, both in metres per second and degrees.
+type gpxPoint struct {
+	Lat        string   `xml:"lat,attr"`
+	Lon        string   `xml:"lon,attr"`
+	Time       string   `xml:"time"`
+	Speed      *float64 `xml:"speed"`
+	Course     *float64 `xml:"course"`
+	Extensions struct {
+		Speed  *float64 `xml:"TrackPointExtension>speed"`
+		Course *float64 `xml:"TrackPointExtension>course"`
+	} `xml:"extensions"`
+}
+
+// gpxReader reads trkpt and rtept elements from a GPX document
+type gpxReader struct {
+	dec *xml.Decoder
+	row int
+}
+
+func newGPXReader(r io.Reader) *gpxReader {
+	return &gpxReader{dec: xml.NewDecoder(r)}
+}
+
+// Next reads the next track or route point
+func (g *gpxReader) Next() (*Fix, error) {
+	for {
+		token, err := g.dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("invalid GPX: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || (start.Name.Local != "trkpt" && start.Name.Local != "rtept") {
+			continue
+		}
+
+		g.row++
+		var point gpxPoint
+		if err := g.dec.DecodeElement(&point, &start); err != nil {
+			return nil, fmt.Errorf("invalid GPX at point %d: %w", g.row, err)
+		}
+		return g.toFix(&point)
+	}
+}
+
+// toFix converts a decoded point
+func (g *gpxReader) toFix(point *gpxPoint) (*Fix, error) {
+	fix := &Fix{Row: g.row}
+	var err error
+	if fix.Latitude, err = strconv.ParseFloat(point.Lat, 64); err != nil {
+		return nil, &RowError{Row: g.row, Message: "invalid latitude"}
+	}
+	if fix.Longitude, err = strconv.ParseFloat(point.Lon, 64); err != nil {
+		return nil, &RowError{Row: g.row, Message: "invalid longitude"}
+	}
+	if point.Time == "" {
+		return nil, &RowError{Row: g.row, Message: "missing time"}
+	}
+	if fix.Time, err = time.Parse(time.RFC3339, point.Time); err != nil {
+		return nil, &RowError{Row: g.row, Message: "invalid time"}
+	}
+
+	speed := point.Extensions.Speed
+	if speed == nil {
+		speed = point.Speed
+	}
+	if speed != nil {
+		kmh := *speed * 3.6
+		fix.Speed = &kmh
+	}
+
+	course := point.Extensions.Course
+	if course == nil {
+		course = point.Course
+	}
+	if course != nil {
+		direction := normalizeDirection(*course)
+		fix.Direction = &direction
+	}
+
+	return fix, nil
+}
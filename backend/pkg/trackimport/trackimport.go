@@ -0,0 +1,99 @@
+package trackimport
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// Format is a track import file format
+type Format string
+
+// Supported import formats
+const (
+	FormatCSV     Format = "csv"
+	FormatGPX     Format = "gpx"
+	FormatGeoJSON Format = "geojson"
+)
+
+// ParseFormat parses a format name case-insensitively. "json" is accepted for GeoJSON.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case FormatCSV, FormatGPX, FormatGeoJSON:
+		return format, nil
+	case "json":
+		return FormatGeoJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported import format %q, use csv, gpx or geojson", name)
+	}
+}
+
+// Fix is a recorded position read from an import file. Speed is in km/h and Direction in degrees from north.
+// Row identifies the fix in the file: the line for CSV and the position number for GPX and GeoJSON.
+type Fix struct {
+	Row       int
+	Latitude  float64
+	Longitude float64
+	Time      time.Time
+	Speed     *float64
+	Direction *int16
+	Ignition  *bool
+}
+
+// RowError reports a record that could not be read. Reading can continue after it.
+type RowError struct {
+	Row     int
+	Message string
+}
+
+// Error implements error
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+}
+
+// Reader reads fixes from an import file one at a time. Next returns io.EOF after the last fix and a
+// *RowError for a malformed record; any other error means the file cannot be read further.
+type Reader interface {
+	Next() (*Fix, error)
+}
+
+// NewReader creates a reader of the format over r. The column mapping is only used for CSV.
+func NewReader(format Format, r io.Reader, mapping ColumnMapping) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r, mapping)
+	case FormatGPX:
+		return newGPXReader(r), nil
+	case FormatGeoJSON:
+		return newGeoJSONReader(r), nil
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
+
+// convertSpeed converts a speed in the unit to km/h
+func convertSpeed(value float64, unit string) (float64, error) {
+	switch strings.ToLower(unit) {
+	case "", "kmh", "km/h":
+		return value, nil
+	case "mps", "m/s":
+		return value * 3.6, nil
+	case "mph":
+		return value * 1.609344, nil
+	case "knots", "kn":
+		return value * 1.852, nil
+	default:
+		return 0, fmt.Errorf("unknown speed unit %q", unit)
+	}
+}
+
+// normalizeDirection rounds a heading to whole degrees in [0, 360)
+func normalizeDirection(value float64) int16 {
+	degrees := int(math.Round(value)) % 360
+	if degrees < 0 {
+		degrees += 360
+	}
+	return int16(degrees)
+}
@@ -0,0 +1,321 @@
+package trackimport
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readAll reads every fix and row error, failing the test on any other error
+func readAll(t *testing.T, reader Reader) ([]*Fix, []*RowError) {
+	t.Helper()
+
+	var fixes []*Fix
+	var rowErrors []*RowError
+	for {
+		fix, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return fixes, rowErrors
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			rowErrors = append(rowErrors, rowErr)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		fixes = append(fixes, fix)
+	}
+}
+
+func derefDirection(direction *int16) interface{} {
+	if direction == nil {
+		return nil
+	}
+	return *direction
+}
+
+func newCSV(t *testing.T, data string, mapping ColumnMapping) Reader {
+	t.Helper()
+	reader, err := NewReader(FormatCSV, strings.NewReader(data), mapping)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	return reader
+}
+
+func TestCSVDefaultMapping(t *testing.T) {
+	// The header of the track export, with a byte order mark as spreadsheets save it
+	data := "\ufeffsegment,timestamp,latitude,longitude,speed_kmh,direction,ignition\n" +
+		"1,2025-01-06T01:00:00Z,-6.200000,106.816666,36.00,90,true\n" +
+		"1,2025-01-06T01:01:00Z,-6.201000,106.817000,,,\n"
+
+	fixes, rowErrors := readAll(t, newCSV(t, data, DefaultColumnMapping()))
+	if len(rowErrors) != 0 || len(fixes) != 2 {
+		t.Fatalf("got %d fixes and %v, want 2 fixes and no row errors", len(fixes), rowErrors)
+	}
+
+	first := fixes[0]
+	if first.Row != 2 || first.Latitude != -6.2 || first.Longitude != 106.816666 {
+		t.Errorf("first fix = row %d at (%v, %v), want row 2 at (-6.2, 106.816666)", first.Row, first.Latitude, first.Longitude)
+	}
+	if !first.Time.Equal(time.Date(2025, 1, 6, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("first fix time = %v", first.Time)
+	}
+	if first.Speed == nil || *first.Speed != 36 || first.Direction == nil || *first.Direction != 90 || first.Ignition == nil || !*first.Ignition {
+		t.Errorf("first fix optional values = %v, %v, %v", first.Speed, first.Direction, first.Ignition)
+	}
+
+	second := fixes[1]
+	if second.Speed != nil || second.Direction != nil || second.Ignition != nil {
+		t.Errorf("empty optional values read as %v, %v, %v, want nil", second.Speed, second.Direction, second.Ignition)
+	}
+}
+
+func TestCSVCustomMapping(t *testing.T) {
+	mapping := ColumnMapping{
+		Timestamp:  "Time",
+		Latitude:   "LAT",
+		Longitude:  "lng",
+		Speed:      "Speed",
+		Direction:  "Heading",
+		TimeFormat: "unix",
+		SpeedUnit:  "mph",
+		Delimiter:  ";",
+	}
+	data := "time;lat;LNG;speed;heading;unused\n" +
+		"1736125200.5;-6.2;106.8;10;-90;x\n"
+
+	fixes, rowErrors := readAll(t, newCSV(t, data, mapping))
+	if len(rowErrors) != 0 || len(fixes) != 1 {
+		t.Fatalf("got %d fixes and %v, want 1 fix and no row errors", len(fixes), rowErrors)
+	}
+
+	fix := fixes[0]
+	if want := time.UnixMilli(1736125200500).UTC(); !fix.Time.Equal(want) {
+		t.Errorf("time = %v, want %v", fix.Time, want)
+	}
+	if fix.Speed == nil || math.Abs(*fix.Speed-16.09344) > 1e-9 {
+		t.Errorf("speed = %v, want 16.09344 km/h", fix.Speed)
+	}
+	if fix.Direction == nil || *fix.Direction != 270 {
+		t.Errorf("direction = %v, want 270", derefDirection(fix.Direction))
+	}
+	if fix.Ignition != nil {
+		t.Errorf("unmapped ignition read as %v", *fix.Ignition)
+	}
+}
+
+func TestCSVTimeFormats(t *testing.T) {
+	want := time.Date(2025, 1, 6, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		format string
+		value  string
+	}{
+		{"", "2025-01-06T15:30:00+07:00"},
+		{"rfc3339", "2025-01-06T08:30:00Z"},
+		{"unix", "1736152200"},
+		{"unix_ms", "1736152200000"},
+		{"2006-01-02 15:04", "2025-01-06 08:30"},
+	}
+
+	for _, tt := range tests {
+		mapping := DefaultColumnMapping()
+		mapping.TimeFormat = tt.format
+		data := "timestamp,latitude,longitude\n" + tt.value + ",1,2\n"
+
+		fixes, rowErrors := readAll(t, newCSV(t, data, mapping))
+		if len(rowErrors) != 0 || len(fixes) != 1 {
+			t.Errorf("format %q: got %d fixes and %v", tt.format, len(fixes), rowErrors)
+			continue
+		}
+		if !fixes[0].Time.Equal(want) {
+			t.Errorf("format %q: time = %v, want %v", tt.format, fixes[0].Time, want)
+		}
+	}
+}
+
+func TestCSVRowErrors(t *testing.T) {
+	data := "timestamp,latitude,longitude,speed_kmh,direction,ignition\n" +
+		"2025-01-06T01:00:00Z,north,106.8,,,\n" +
+		"2025-01-06T01:00:00Z,-6.2,,,,\n" +
+		"yesterday,-6.2,106.8,,,\n" +
+		"2025-01-06T01:00:00Z,-6.2,106.8,fast,,\n" +
+		"2025-01-06T01:00:00Z,-6.2,106.8,,east,\n" +
+		"2025-01-06T01:00:00Z,-6.2,106.8,,,maybe\n" +
+		"2025-01-06T01:00:00Z,-6.2,\"106.8,,,\n"
+
+	fixes, rowErrors := readAll(t, newCSV(t, data, DefaultColumnMapping()))
+	if len(fixes) != 0 {
+		t.Errorf("got %d fixes from invalid rows", len(fixes))
+	}
+
+	want := []RowError{
+		{Row: 2, Message: "invalid latitude"},
+		{Row: 3, Message: "invalid longitude"},
+		{Row: 4, Message: "invalid timestamp"},
+		{Row: 5, Message: "invalid speed"},
+		{Row: 6, Message: "invalid direction"},
+		{Row: 7, Message: "invalid ignition"},
+	}
+	if len(rowErrors) != len(want)+1 {
+		t.Fatalf("got row errors %v, want %d", rowErrors, len(want)+1)
+	}
+	for i, w := range want {
+		if *rowErrors[i] != w {
+			t.Errorf("row error %d = %v, want %v", i, rowErrors[i], &w)
+		}
+	}
+	// The unterminated quote is reported by the CSV parser
+	if last := rowErrors[len(want)]; last.Row != 8 {
+		t.Errorf("quote error reported at row %d, want 8", last.Row)
+	}
+}
+
+func TestCSVRecoversAfterRowError(t *testing.T) {
+	data := "timestamp,latitude,longitude\n" +
+		"2025-01-06T01:00:00Z,bad,106.8\n" +
+		"2025-01-06T01:01:00Z,-6.2,106.8\n"
+
+	fixes, rowErrors := readAll(t, newCSV(t, data, DefaultColumnMapping()))
+	if len(rowErrors) != 1 || len(fixes) != 1 || fixes[0].Row != 3 {
+		t.Errorf("got fixes %v and row errors %v, want the row 3 fix after one row error", fixes, rowErrors)
+	}
+}
+
+func TestCSVMappingErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		mapping ColumnMapping
+	}{
+		{"missing required mapping", "timestamp,latitude,longitude", ColumnMapping{Timestamp: "timestamp", Latitude: "latitude"}},
+		{"unknown speed unit", "timestamp,latitude,longitude", ColumnMapping{Timestamp: "timestamp", Latitude: "latitude", Longitude: "longitude", SpeedUnit: "furlongs"}},
+		{"long delimiter", "timestamp,latitude,longitude", ColumnMapping{Timestamp: "timestamp", Latitude: "latitude", Longitude: "longitude", Delimiter: "||"}},
+		{"mapped column not in header", "time,latitude,longitude", DefaultColumnMapping()},
+		{"empty file", "", DefaultColumnMapping()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewReader(FormatCSV, strings.NewReader(tt.header), tt.mapping); err == nil {
+				t.Error("NewReader succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestGPX(t *testing.T) {
+	data := `<?xml version="1.0"?>
+<gpx version="1.1" xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v2">
+<trk><trkseg>
+<trkpt lat="-6.2" lon="106.8"><time>2025-01-06T01:00:00Z</time><extensions><gpxtpx:TrackPointExtension><gpxtpx:speed>10</gpxtpx:speed><gpxtpx:course>90</gpxtpx:course></gpxtpx:TrackPointExtension></extensions></trkpt>
+<trkpt lat="-6.3" lon="106.9"></trkpt>
+<trkpt lat="x" lon="106.9"><time>2025-01-06T01:02:00Z</time></trkpt>
+</trkseg></trk>
+<rte><rtept lat="-6.4" lon="107"><time>2025-01-06T01:03:00Z</time><speed>5</speed></rtept></rte>
+</gpx>`
+
+	reader, err := NewReader(FormatGPX, strings.NewReader(data), ColumnMapping{})
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	fixes, rowErrors := readAll(t, reader)
+
+	if len(fixes) != 2 || fixes[0].Row != 1 || fixes[1].Row != 4 {
+		t.Fatalf("got fixes %v, want points 1 and 4", fixes)
+	}
+	if fixes[0].Speed == nil || *fixes[0].Speed != 36 || fixes[0].Direction == nil || *fixes[0].Direction != 90 {
+		t.Errorf("extension speed and course read as %v, %v, want 36 km/h and 90", fixes[0].Speed, fixes[0].Direction)
+	}
+	if fixes[1].Speed == nil || *fixes[1].Speed != 18 {
+		t.Errorf("GPX 1.0 speed read as %v, want 18 km/h", fixes[1].Speed)
+	}
+
+	want := []RowError{{Row: 2, Message: "missing time"}, {Row: 3, Message: "invalid latitude"}}
+	if len(rowErrors) != len(want) || *rowErrors[0] != want[0] || *rowErrors[1] != want[1] {
+		t.Errorf("got row errors %v, want %v", rowErrors, want)
+	}
+}
+
+func TestGeoJSON(t *testing.T) {
+	data := `{"type":"FeatureCollection","name":"track","features":[
+{"type":"Feature","properties":{"coordTimes":["2025-01-06T01:00:00Z","2025-01-06T01:01:00Z"],"speeds":[36,null],"directions":[450,null]},"geometry":{"type":"LineString","coordinates":[[106.8,-6.2],[106.9,-6.3]]}},
+{"type":"Feature","properties":{"coordTimes":["2025-01-06T01:02:00Z"]},"geometry":{"type":"LineString","coordinates":[[106.8,-6.2],[106.9,-6.3]]}},
+{"type":"Feature","properties":{"time":1736125380,"ignition":false},"geometry":{"type":"Point","coordinates":[107,-6.4]}},
+{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[]}}
+]}`
+
+	reader, err := NewReader(FormatGeoJSON, strings.NewReader(data), ColumnMapping{})
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	fixes, rowErrors := readAll(t, reader)
+
+	if len(fixes) != 3 {
+		t.Fatalf("got %d fixes, want 3", len(fixes))
+	}
+	if fixes[0].Latitude != -6.2 || fixes[0].Longitude != 106.8 || fixes[0].Direction == nil || *fixes[0].Direction != 90 {
+		t.Errorf("first fix = %+v, want (-6.2, 106.8) heading 90", fixes[0])
+	}
+	if fixes[1].Speed != nil || fixes[1].Direction != nil {
+		t.Errorf("null speed and direction read as %v, %v", fixes[1].Speed, fixes[1].Direction)
+	}
+	if fixes[2].Row != 3 || fixes[2].Ignition == nil || *fixes[2].Ignition || !fixes[2].Time.Equal(time.Unix(1736125380, 0)) {
+		t.Errorf("point fix = %+v", fixes[2])
+	}
+
+	if len(rowErrors) != 2 ||
+		rowErrors[0].Message != "feature 2: coordTimes must list a time for every coordinate" ||
+		rowErrors[1].Message != `feature 4: unsupported geometry type "Polygon"` {
+		t.Errorf("got row errors %v", rowErrors)
+	}
+}
+
+func TestNormalizeDirection(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  int16
+	}{
+		{0, 0},
+		{89.4, 89},
+		{89.5, 90},
+		{359.6, 0},
+		{360, 0},
+		{450, 90},
+		{-90, 270},
+		{-0.4, 0},
+		{-720.6, 359},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeDirection(tt.value); got != tt.want {
+			t.Errorf("normalizeDirection(%v) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr bool
+	}{
+		{"CSV", FormatCSV, false},
+		{"gpx", FormatGPX, false},
+		{"geojson", FormatGeoJSON, false},
+		{"json", FormatGeoJSON, false},
+		{"kml", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = (%q, %v), want %q, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
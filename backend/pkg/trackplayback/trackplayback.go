@@ -0,0 +1,254 @@
+package trackplayback
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/pkg/geo"
+)
+
+// State is what a vehicle was doing at a playback frame
+type State string
+
+const (
+	StateMoving  State = "moving"  // between two fixes while driving; the position is interpolated
+	StateStopped State = "stopped" // within a stop; the position is held at the stop
+	StateGap     State = "gap"     // no fixes for longer than the gap threshold; the last known position is held
+)
+
+// Fix is a recorded position of a vehicle. Speed is in km/h.
+type Fix struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+	Speed     *float64
+	Ignition  *bool
+}
+
+// Options tunes how a track is resampled.
+// Step is the time between frames. Two fixes further apart than GapAfter are a gap, unless the vehicle
+// moved less than StopRadius meters meanwhile, in which case it was parked with its tracker silent.
+// The vehicle is stationary between fixes while its speed is at most StopSpeed km/h, and a stationary
+// stretch lasting StopMinDuration or longer is reported as a stop.
+type Options struct {
+	Step            time.Duration
+	GapAfter        time.Duration
+	StopSpeed       float64
+	StopRadius      float64
+	StopMinDuration time.Duration
+}
+
+// Frame is the interpolated state of a vehicle at one time step. DistanceKm is the distance driven
+// since the first fix; stationary jitter and stops add no distance.
+type Frame struct {
+	Time       time.Time
+	Latitude   float64
+	Longitude  float64
+	Speed      float64
+	Heading    float64
+	State      State
+	DistanceKm float64
+	Ignition   *bool
+}
+
+// Interval is a stretch of time in a track. Stops carry the position the vehicle stood at.
+type Interval struct {
+	Start     time.Time
+	End       time.Time
+	Latitude  float64
+	Longitude float64
+}
+
+// Playback is a track resampled to fixed time steps with its stops and gaps
+type Playback struct {
+	Frames     []Frame
+	Stops      []Interval
+	Gaps       []Interval
+	DistanceKm float64
+}
+
+// FrameCount returns how many frames a track from start to end has at the given step
+func FrameCount(start, end time.Time, step time.Duration) int {
+	if step <= 0 || end.Before(start) {
+		return 0
+	}
+	count := int(end.Sub(start)/step) + 1
+	if start.Add(time.Duration(count-1) * step).Before(end) {
+		count++
+	}
+	return count
+}
+
+// segment is the stretch between two consecutive fixes
+type segment struct {
+	kind     State
+	distance float64 // meters counted towards the driven distance
+	stop     int     // index of the stop the segment belongs to, -1 otherwise
+}
+
+// Build resamples fixes ordered by time into frames Step apart, from the first fix to the last.
+// Fixes sharing a timestamp with the previous one are ignored.
+func Build(fixes []Fix, opts Options) *Playback {
+	fixes = dedupe(fixes)
+	playback := &Playback{Frames: []Frame{}, Stops: []Interval{}, Gaps: []Interval{}}
+	if len(fixes) == 0 || opts.Step <= 0 {
+		return playback
+	}
+
+	segments := classify(fixes, opts, playback)
+
+	// Cumulative distance at every fix
+	cumulative := make([]float64, len(fixes))
+	for i := 1; i < len(fixes); i++ {
+		cumulative[i] = cumulative[i-1] + segments[i-1].distance
+	}
+	playback.DistanceKm = cumulative[len(cumulative)-1] / 1000
+
+	start, end := fixes[0].Time, fixes[len(fixes)-1].Time
+	count := FrameCount(start, end, opts.Step)
+	playback.Frames = make([]Frame, 0, count)
+
+	// Stopped and gap frames keep the heading of the last movement
+	i := 0
+	heading := 0.0
+	for n := 0; n < count; n++ {
+		t := start.Add(time.Duration(n) * opts.Step)
+		if t.After(end) {
+			t = end
+		}
+		for i+1 < len(fixes)-1 && !t.Before(fixes[i+1].Time) {
+			i++
+		}
+		frame := frameAt(t, fixes, segments, cumulative, playback.Stops, i)
+		if frame.State == StateMoving {
+			heading = frame.Heading
+		} else {
+			frame.Heading = heading
+		}
+		playback.Frames = append(playback.Frames, frame)
+	}
+	return playback
+}
+
+// classify labels every segment between consecutive fixes as moving, stationary or a gap and collects
+// the stops and gaps of the track
+func classify(fixes []Fix, opts Options, playback *Playback) []segment {
+	segments := make([]segment, len(fixes)-1)
+	stationary := make([]bool, len(segments))
+
+	for i := range segments {
+		from, to := fixes[i], fixes[i+1]
+		meters := geo.DistanceMeters(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+		elapsed := to.Time.Sub(from.Time)
+		segments[i] = segment{kind: StateMoving, distance: meters, stop: -1}
+
+		switch {
+		case elapsed > opts.GapAfter && meters >= opts.StopRadius:
+			segments[i].kind = StateGap
+			playback.Gaps = append(playback.Gaps, Interval{Start: from.Time, End: to.Time, Latitude: from.Latitude, Longitude: from.Longitude})
+		case elapsed > opts.GapAfter:
+			stationary[i] = true
+		default:
+			stationary[i] = isStationary(from, to, meters, elapsed, opts.StopSpeed)
+		}
+		if stationary[i] {
+			segments[i].distance = 0
+		}
+	}
+
+	// Runs of stationary segments lasting long enough are stops
+	for i := 0; i < len(segments); {
+		if !stationary[i] {
+			i++
+			continue
+		}
+		j := i
+		for j+1 < len(segments) && stationary[j+1] {
+			j++
+		}
+		if fixes[j+1].Time.Sub(fixes[i].Time) >= opts.StopMinDuration {
+			for k := i; k <= j; k++ {
+				segments[k].kind = StateStopped
+				segments[k].stop = len(playback.Stops)
+			}
+			playback.Stops = append(playback.Stops, Interval{
+				Start:     fixes[i].Time,
+				End:       fixes[j+1].Time,
+				Latitude:  fixes[i].Latitude,
+				Longitude: fixes[i].Longitude,
+			})
+		}
+		i = j + 1
+	}
+
+	return segments
+}
+
+// isStationary reports whether the vehicle stood still between two fixes. Reported speeds are preferred,
+// as GPS jitter makes the speed derived from positions unreliable at walking pace.
+func isStationary(from, to Fix, meters float64, elapsed time.Duration, stopSpeed float64) bool {
+	if from.Speed != nil && to.Speed != nil {
+		return *from.Speed <= stopSpeed && *to.Speed <= stopSpeed
+	}
+	if elapsed <= 0 {
+		return meters == 0
+	}
+	return meters/elapsed.Seconds()*3.6 <= stopSpeed
+}
+
+// frameAt computes the frame at time t, which lies between fix i and the next one
+func frameAt(t time.Time, fixes []Fix, segments []segment, cumulative []float64, stops []Interval, i int) Frame {
+	from := fixes[i]
+	frame := Frame{Time: t, Latitude: from.Latitude, Longitude: from.Longitude, State: StateStopped, DistanceKm: cumulative[i] / 1000, Ignition: from.Ignition}
+	if i+1 >= len(fixes) {
+		return frame
+	}
+
+	to := fixes[i+1]
+	seg := segments[i]
+	if !t.Before(to.Time) {
+		// Last frame of the track
+		frame.Latitude, frame.Longitude = to.Latitude, to.Longitude
+		frame.DistanceKm = cumulative[i+1] / 1000
+		frame.Ignition = to.Ignition
+		if to.Speed != nil {
+			frame.Speed = *to.Speed
+		}
+		if seg.kind == StateMoving {
+			frame.State = StateMoving
+			frame.Heading = geo.BearingDegrees(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+		}
+		return frame
+	}
+
+	switch seg.kind {
+	case StateGap:
+		frame.State = StateGap
+	case StateStopped:
+		stop := stops[seg.stop]
+		frame.Latitude, frame.Longitude = stop.Latitude, stop.Longitude
+	default:
+		fraction := t.Sub(from.Time).Seconds() / to.Time.Sub(from.Time).Seconds()
+		frame.State = StateMoving
+		frame.Latitude, frame.Longitude = geo.Interpolate(from.Latitude, from.Longitude, to.Latitude, to.Longitude, fraction)
+		frame.DistanceKm = (cumulative[i] + seg.distance*fraction) / 1000
+		frame.Heading = geo.BearingDegrees(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+		if from.Speed != nil && to.Speed != nil {
+			frame.Speed = *from.Speed + (*to.Speed-*from.Speed)*fraction
+		} else {
+			frame.Speed = seg.distance / to.Time.Sub(from.Time).Seconds() * 3.6
+		}
+	}
+	return frame
+}
+
+// dedupe drops fixes that do not advance in time
+func dedupe(fixes []Fix) []Fix {
+	kept := make([]Fix, 0, len(fixes))
+	for _, fix := range fixes {
+		if len(kept) > 0 && !fix.Time.After(kept[len(kept)-1].Time) {
+			continue
+		}
+		kept = append(kept, fix)
+	}
+	return kept
+}
@@ -0,0 +1,228 @@
+package trackplayback
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC)
+
+// kmPerHundredth is the length of 0.01 degrees of latitude on the sphere used by geo
+const kmPerHundredth = 1.1119493
+
+// at builds a fix the given offset after start, with an optional reported speed
+func at(offset time.Duration, latitude float64, speed ...float64) Fix {
+	fix := Fix{Time: start.Add(offset), Latitude: latitude, Longitude: 106.8}
+	if len(speed) > 0 {
+		fix.Speed = &speed[0]
+	}
+	return fix
+}
+
+func TestBuild(t *testing.T) {
+	opts := Options{
+		Step:            time.Minute,
+		GapAfter:        15 * time.Minute,
+		StopSpeed:       3,
+		StopRadius:      50,
+		StopMinDuration: 2 * time.Minute,
+	}
+	withStep := func(step time.Duration) Options {
+		o := opts
+		o.Step = step
+		return o
+	}
+
+	const (
+		m = StateMoving
+		s = StateStopped
+		g = StateGap
+	)
+
+	tests := []struct {
+		name       string
+		fixes      []Fix
+		opts       Options
+		wantStates []State
+		wantStops  int
+		wantGaps   int
+		wantKm     float64
+	}{
+		{
+			name: "empty input",
+			opts: opts,
+		},
+		{
+			name:       "single point",
+			fixes:      []Fix{at(0, -6.2)},
+			opts:       opts,
+			wantStates: []State{s},
+		},
+		{
+			name:  "no step",
+			fixes: []Fix{at(0, -6.2), at(time.Minute, -6.19)},
+			opts:  withStep(0),
+		},
+		{
+			name:       "driving",
+			fixes:      []Fix{at(0, -6.2), at(2*time.Minute, -6.19)},
+			opts:       opts,
+			wantStates: []State{m, m, m},
+			wantKm:     kmPerHundredth,
+		},
+		{
+			name:       "step that does not divide the range ends on the last fix",
+			fixes:      []Fix{at(0, -6.2), at(50*time.Second, -6.19)},
+			opts:       withStep(20 * time.Second),
+			wantStates: []State{m, m, m, m},
+			wantKm:     kmPerHundredth,
+		},
+		{
+			name:       "fixes sharing a timestamp are ignored",
+			fixes:      []Fix{at(0, -6.2), at(0, -6.0), at(time.Minute, -6.19)},
+			opts:       opts,
+			wantStates: []State{m, m},
+			wantKm:     kmPerHundredth,
+		},
+		{
+			name: "gap longer than the step",
+			fixes: []Fix{
+				at(0, -6.2),
+				at(10*time.Minute, -6.19),
+				at(40*time.Minute, -6.17),
+			},
+			opts:       withStep(10 * time.Minute),
+			wantStates: []State{m, g, g, g, s},
+			wantGaps:   1,
+			wantKm:     3 * kmPerHundredth,
+		},
+		{
+			name: "stop between drives",
+			fixes: []Fix{
+				at(0, -6.2, 40),
+				at(time.Minute, -6.19, 0),
+				at(6*time.Minute, -6.19, 0),
+				at(7*time.Minute, -6.18, 40),
+			},
+			opts:       opts,
+			wantStates: []State{m, s, s, s, s, s, m, m},
+			wantStops:  1,
+			wantKm:     2 * kmPerHundredth,
+		},
+		{
+			name: "stationary shorter than the minimum stop",
+			fixes: []Fix{
+				at(0, -6.2, 0),
+				at(time.Minute, -6.2, 0),
+				at(2*time.Minute, -6.19, 40),
+			},
+			opts:       opts,
+			wantStates: []State{m, m, m},
+			wantKm:     kmPerHundredth,
+		},
+		{
+			name: "silent tracker without moving is a stop, not a gap",
+			fixes: []Fix{
+				at(0, -6.2),
+				at(time.Minute, -6.19),
+				at(61*time.Minute, -6.1899),
+				at(62*time.Minute, -6.1799),
+			},
+			opts:       withStep(30 * time.Minute),
+			wantStates: []State{m, s, s, m},
+			wantStops:  1,
+			wantKm:     2 * kmPerHundredth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playback := Build(tt.fixes, tt.opts)
+
+			states := make([]State, len(playback.Frames))
+			for i, frame := range playback.Frames {
+				states[i] = frame.State
+			}
+			if !slices.Equal(states, tt.wantStates) {
+				t.Errorf("states = %v, want %v", states, tt.wantStates)
+			}
+			if len(playback.Stops) != tt.wantStops {
+				t.Errorf("stops = %d, want %d", len(playback.Stops), tt.wantStops)
+			}
+			if len(playback.Gaps) != tt.wantGaps {
+				t.Errorf("gaps = %d, want %d", len(playback.Gaps), tt.wantGaps)
+			}
+			if math.Abs(playback.DistanceKm-tt.wantKm) > 0.005 {
+				t.Errorf("DistanceKm = %.4f, want %.4f", playback.DistanceKm, tt.wantKm)
+			}
+			if len(playback.Frames) == 0 {
+				return
+			}
+
+			// Frames are Step apart from the first fix, and the last one is at the last fix
+			first, last := tt.fixes[0], tt.fixes[len(tt.fixes)-1]
+			for n, frame := range playback.Frames[:len(playback.Frames)-1] {
+				if want := first.Time.Add(time.Duration(n) * tt.opts.Step); !frame.Time.Equal(want) {
+					t.Errorf("frame %d at %s, want %s", n, frame.Time.Format(time.TimeOnly), want.Format(time.TimeOnly))
+				}
+			}
+			end := playback.Frames[len(playback.Frames)-1]
+			if !end.Time.Equal(last.Time) || end.Latitude != last.Latitude {
+				t.Errorf("last frame = %s at %.4f, want %s at %.4f", end.Time.Format(time.TimeOnly), end.Latitude, last.Time.Format(time.TimeOnly), last.Latitude)
+			}
+
+			// Gap and stop frames hold the position the vehicle was last seen at
+			for _, frame := range playback.Frames {
+				for _, interval := range append(slices.Clone(playback.Gaps), playback.Stops...) {
+					if frame.State != StateMoving && frame.Time.After(interval.Start) && frame.Time.Before(interval.End) && frame.Latitude != interval.Latitude {
+						t.Errorf("frame at %s in %s is at %.4f, want held at %.4f", frame.Time.Format(time.TimeOnly), frame.State, frame.Latitude, interval.Latitude)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBuildInterpolatesMovingFrames(t *testing.T) {
+	playback := Build([]Fix{at(0, -6.2), at(2*time.Minute, -6.18)}, Options{Step: time.Minute, GapAfter: time.Hour, StopMinDuration: time.Minute})
+
+	middle := playback.Frames[1]
+	if math.Abs(middle.Latitude+6.19) > 1e-6 {
+		t.Errorf("middle latitude = %.6f, want -6.19", middle.Latitude)
+	}
+	if math.Abs(middle.DistanceKm-kmPerHundredth) > 0.005 {
+		t.Errorf("middle DistanceKm = %.4f, want %.4f", middle.DistanceKm, kmPerHundredth)
+	}
+	// About 2.2 km in two minutes, heading north
+	if math.Abs(middle.Speed-66.7) > 0.5 {
+		t.Errorf("middle Speed = %.1f, want about 66.7", middle.Speed)
+	}
+	if middle.Heading > 0.01 && middle.Heading < 359.99 {
+		t.Errorf("middle Heading = %.2f, want north", middle.Heading)
+	}
+}
+
+func TestFrameCount(t *testing.T) {
+	tests := []struct {
+		name string
+		span time.Duration
+		step time.Duration
+		want int
+	}{
+		{"same instant", 0, time.Second, 1},
+		{"step divides the range", time.Minute, 10 * time.Second, 7},
+		{"step does not divide the range", 50 * time.Second, 20 * time.Second, 4},
+		{"end before start", -time.Minute, time.Second, 0},
+		{"no step", time.Minute, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FrameCount(start, start.Add(tt.span), tt.step); got != tt.want {
+				t.Errorf("FrameCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
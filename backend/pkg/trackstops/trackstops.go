@@ -0,0 +1,116 @@
+package trackstops
+
+import (
+	"time"
+
+	"github.com/cartrack/backend/pkg/geo"
+)
+
+// Kind tells whether the engine ran during a stop
+type Kind string
+
+const (
+	KindParked  Kind = "parked"  // the ignition was off for most of the stop
+	KindIdling  Kind = "idling"  // the ignition was on while standing for most of the stop
+	KindUnknown Kind = "unknown" // the tracker reported no ignition state during the stop
+)
+
+// Fix is a recorded position of a vehicle. Speed is in km/h.
+type Fix struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+	Speed     *float64
+	Ignition  *bool
+}
+
+// Options tunes stop detection. Consecutive fixes staying within Radius meters of their centroid for at
+// least MinDuration are a stop. Time with the ignition on counts as idling while the reported speed is
+// at most IdleSpeed km/h.
+type Options struct {
+	Radius      float64
+	MinDuration time.Duration
+	IdleSpeed   float64
+}
+
+// Stop is a stretch of time a vehicle stayed in one place. The position is the centroid of its fixes.
+type Stop struct {
+	Start     time.Time
+	End       time.Time
+	Latitude  float64
+	Longitude float64
+	Fixes     int
+	Idle      time.Duration
+	Kind      Kind
+}
+
+// Duration returns the dwell time of the stop
+func (s Stop) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Detect finds the stops in fixes ordered by time. A tracker falling silent while the vehicle is parked
+// does not end a stop, as long as the next fix is still within the radius.
+func Detect(fixes []Fix, opts Options) []Stop {
+	stops := []Stop{}
+	for i := 0; i < len(fixes); {
+		sumLatitude, sumLongitude := fixes[i].Latitude, fixes[i].Longitude
+		j := i
+		for j+1 < len(fixes) {
+			count := float64(j - i + 1)
+			next := fixes[j+1]
+			if geo.DistanceMeters(sumLatitude/count, sumLongitude/count, next.Latitude, next.Longitude) > opts.Radius {
+				break
+			}
+			sumLatitude += next.Latitude
+			sumLongitude += next.Longitude
+			j++
+		}
+
+		// Too short to be a stop; a later fix of the cluster may still start one
+		if fixes[j].Time.Sub(fixes[i].Time) < opts.MinDuration {
+			i++
+			continue
+		}
+
+		count := float64(j - i + 1)
+		stop := Stop{
+			Start:     fixes[i].Time,
+			End:       fixes[j].Time,
+			Latitude:  sumLatitude / count,
+			Longitude: sumLongitude / count,
+			Fixes:     j - i + 1,
+		}
+		stop.Idle, stop.Kind = idling(fixes[i:j+1], opts.IdleSpeed)
+		stops = append(stops, stop)
+		i = j + 1
+	}
+	return stops
+}
+
+// idling sums the time the engine ran while standing during a stop and labels the stop. Each fix's ignition
+// state and speed hold until the next fix.
+func idling(fixes []Fix, idleSpeed float64) (time.Duration, Kind) {
+	var idle time.Duration
+	known := false
+	for k := 0; k+1 < len(fixes); k++ {
+		fix := fixes[k]
+		if fix.Ignition == nil {
+			continue
+		}
+		known = true
+		if *fix.Ignition && (fix.Speed == nil || *fix.Speed <= idleSpeed) {
+			idle += fixes[k+1].Time.Sub(fix.Time)
+		}
+	}
+
+	dwell := fixes[len(fixes)-1].Time.Sub(fixes[0].Time)
+	switch {
+	case !known:
+		return idle, KindUnknown
+	case idle*2 > dwell:
+		return idle, KindIdling
+	default:
+		return idle, KindParked
+	}
+}
@@ -0,0 +1,181 @@
+package trackstops
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC)
+
+// metersPerDegree is the length of one degree of latitude on the sphere used by geo
+const metersPerDegree = 111194.93
+
+var opts = Options{Radius: 50, MinDuration: 3 * time.Minute, IdleSpeed: 3}
+
+// fix builds a fix the given number of minutes after start, the given meters north of the origin
+func fix(minutes float64, north float64) Fix {
+	return Fix{
+		Time:      start.Add(time.Duration(minutes * float64(time.Minute))),
+		Latitude:  -6.2 + north/metersPerDegree,
+		Longitude: 106.8,
+	}
+}
+
+// withEngine sets the ignition state and speed of a fix
+func withEngine(f Fix, ignition bool, speed float64) Fix {
+	f.Ignition = &ignition
+	f.Speed = &speed
+	return f
+}
+
+// span is the expected start and end of a stop in minutes after start
+type span struct {
+	start, end float64
+	fixes      int
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name  string
+		fixes []Fix
+		want  []span
+	}{
+		{
+			name: "empty track",
+		},
+		{
+			name:  "dwell of exactly the minimum duration",
+			fixes: []Fix{fix(0, 0), fix(1, 0), fix(2, 0), fix(3, 0), fix(4, 1000)},
+			want:  []span{{0, 3, 4}},
+		},
+		{
+			name:  "dwell just under the minimum duration",
+			fixes: []Fix{fix(0, 0), fix(1, 0), fix(2, 0), fix(2.99, 0), fix(4, 1000)},
+		},
+		{
+			name:  "fix just inside the radius",
+			fixes: []Fix{fix(0, 0), fix(3, 49), fix(4, 1000)},
+			want:  []span{{0, 3, 2}},
+		},
+		{
+			name:  "fix just outside the radius",
+			fixes: []Fix{fix(0, 0), fix(3, 51), fix(4, 1000)},
+		},
+		{
+			name:  "stop still open at the end of the track",
+			fixes: []Fix{fix(0, 0), fix(1, 500), fix(2, 1000), fix(3, 1000), fix(6, 1010)},
+			want:  []span{{2, 6, 3}},
+		},
+		{
+			name:  "short dwell before a stop starts later in the cluster",
+			fixes: []Fix{fix(0, 0), fix(1, 40), fix(2, 80), fix(5, 80), fix(6, 1000)},
+			want:  []span{{1, 5, 3}},
+		},
+		{
+			name:  "silent tracker within the radius keeps one stop",
+			fixes: []Fix{fix(0, 0), fix(1, 0), fix(60, 10), fix(61, 1000)},
+			want:  []span{{0, 60, 3}},
+		},
+		{
+			name: "gap with the vehicle moving splits the stops",
+			fixes: []Fix{
+				fix(0, 0), fix(5, 0),
+				fix(65, 2000), fix(70, 2000),
+			},
+			want: []span{{0, 5, 2}, {65, 70, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stops := Detect(tt.fixes, opts)
+			if len(stops) != len(tt.want) {
+				t.Fatalf("Detect() found %d stops, want %d: %+v", len(stops), len(tt.want), stops)
+			}
+			for i, want := range tt.want {
+				got := stops[i]
+				wantStart := start.Add(time.Duration(want.start * float64(time.Minute)))
+				wantEnd := start.Add(time.Duration(want.end * float64(time.Minute)))
+				if !got.Start.Equal(wantStart) || !got.End.Equal(wantEnd) || got.Fixes != want.fixes {
+					t.Errorf("stop %d = %s-%s with %d fixes, want %s-%s with %d fixes", i,
+						got.Start.Format(time.TimeOnly), got.End.Format(time.TimeOnly), got.Fixes,
+						wantStart.Format(time.TimeOnly), wantEnd.Format(time.TimeOnly), want.fixes)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectCentroid(t *testing.T) {
+	stops := Detect([]Fix{fix(0, 0), fix(2, 20), fix(4, 40)}, opts)
+	if len(stops) != 1 {
+		t.Fatalf("Detect() found %d stops, want 1", len(stops))
+	}
+	if want := fix(0, 20).Latitude; stops[0].Latitude-want > 1e-9 || want-stops[0].Latitude > 1e-9 {
+		t.Errorf("Latitude = %.7f, want centroid %.7f", stops[0].Latitude, want)
+	}
+	if stops[0].Duration() != 4*time.Minute {
+		t.Errorf("Duration() = %s, want 4m0s", stops[0].Duration())
+	}
+}
+
+func TestDetectKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixes    []Fix
+		wantIdle time.Duration
+		wantKind Kind
+	}{
+		{
+			name:     "no ignition reported",
+			fixes:    []Fix{fix(0, 0), fix(2, 0), fix(4, 0)},
+			wantKind: KindUnknown,
+		},
+		{
+			name:     "engine off",
+			fixes:    []Fix{withEngine(fix(0, 0), false, 0), withEngine(fix(2, 0), false, 0), withEngine(fix(4, 0), false, 0)},
+			wantKind: KindParked,
+		},
+		{
+			name:     "engine running while standing",
+			fixes:    []Fix{withEngine(fix(0, 0), true, 0), withEngine(fix(2, 0), true, 0), withEngine(fix(4, 0), true, 0)},
+			wantIdle: 4 * time.Minute,
+			wantKind: KindIdling,
+		},
+		{
+			name:     "idle for exactly half the stop",
+			fixes:    []Fix{withEngine(fix(0, 0), true, 0), withEngine(fix(2, 0), false, 0), withEngine(fix(4, 0), false, 0)},
+			wantIdle: 2 * time.Minute,
+			wantKind: KindParked,
+		},
+		{
+			name:     "idle for just over half the stop",
+			fixes:    []Fix{withEngine(fix(0, 0), true, 0), withEngine(fix(2.1, 0), false, 0), withEngine(fix(4, 0), false, 0)},
+			wantIdle: 2*time.Minute + 6*time.Second,
+			wantKind: KindIdling,
+		},
+		{
+			name:     "crawling above the idle speed",
+			fixes:    []Fix{withEngine(fix(0, 0), true, 5), withEngine(fix(2, 0), true, 3), withEngine(fix(4, 0), true, 3)},
+			wantIdle: 2 * time.Minute,
+			wantKind: KindParked,
+		},
+		{
+			name:     "the last fix does not count towards idling",
+			fixes:    []Fix{withEngine(fix(0, 0), false, 0), withEngine(fix(4, 0), true, 0)},
+			wantKind: KindParked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stops := Detect(tt.fixes, opts)
+			if len(stops) != 1 {
+				t.Fatalf("Detect() found %d stops, want 1", len(stops))
+			}
+			if stops[0].Idle != tt.wantIdle || stops[0].Kind != tt.wantKind {
+				t.Errorf("stop = %s idle, %s; want %s idle, %s", stops[0].Idle, stops[0].Kind, tt.wantIdle, tt.wantKind)
+			}
+		})
+	}
+}